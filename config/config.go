@@ -1,7 +1,214 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+	"inventory-management/database"
+	"inventory-management/logging"
+	"inventory-management/money"
+	"inventory-management/services/payments"
+	"inventory-management/tracing"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Config is read from a JSON or YAML file, then the INV_* environment and then
+// the command line, each overriding the one before. Fields marked secret can
+// also be read from a file and are redacted when the config is printed.
 type Config struct {
-	AppName    string `json:"app_name"`
-	ServerPort string `json:"server_port"`
-	DbUrl      string `json:"db_url"`
+	AppName            string                     `json:"app_name"`
+	ServerPort         string                     `json:"server_port"`
+	ReadTimeout        string                     `json:"read_timeout"`
+	WriteTimeout       string                     `json:"write_timeout"`
+	IdleTimeout        string                     `json:"idle_timeout"`
+	ShutdownDelay      string                     `json:"shutdown_delay"`
+	ShutdownTimeout    string                     `json:"shutdown_timeout"`
+	RequestTimeout     string                     `json:"request_timeout"`
+	BulkTimeout        string                     `json:"bulk_timeout"`
+	LogLevel           string                     `json:"log_level"`
+	LogFormat          string                     `json:"log_format"`
+	DbDriver           string                     `json:"db_driver"`
+	DbUrl              string                     `json:"db_url" secret:"true"`
+	DbMaxOpenConns     int                        `json:"db_max_open_conns"`
+	DbMaxIdleConns     int                        `json:"db_max_idle_conns"`
+	DbConnMaxLifetime  string                     `json:"db_conn_max_lifetime"`
+	DbConnMaxIdleTime  string                     `json:"db_conn_max_idle_time"`
+	BaseCurrency       string                     `json:"base_currency"`
+	ExchangeRates      map[string]decimal.Decimal `json:"exchange_rates"`
+	SellerId           string                     `json:"seller_id"`
+	PaymentProvider    string                     `json:"payment_provider"`
+	PaymentProviderUrl string                     `json:"payment_provider_url"`
+	PaymentProviderKey string                     `json:"payment_provider_key" secret:"true"`
+	IdempotencyTTL     string                     `json:"idempotency_ttl"`
+	MetricsStockLimit  int                        `json:"metrics_stock_limit"`
+	TraceExporter      string                     `json:"trace_exporter"`
+	TraceEndpoint      string                     `json:"trace_endpoint"`
+	TraceFile          string                     `json:"trace_file"`
+}
+
+var (
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+)
+
+const redacted = "[REDACTED]"
+
+func Default() *Config {
+	return &Config{
+		AppName:           "inventory-management",
+		ServerPort:        "8080",
+		ReadTimeout:       "15s",
+		WriteTimeout:      "30s",
+		IdleTimeout:       "120s",
+		ShutdownDelay:     "5s",
+		ShutdownTimeout:   "30s",
+		RequestTimeout:    "10s",
+		BulkTimeout:       "5m",
+		LogLevel:          LogLevelInfo,
+		LogFormat:         logging.FormatJSON,
+		DbDriver:          database.DriverMySQL,
+		DbMaxOpenConns:    25,
+		DbMaxIdleConns:    10,
+		DbConnMaxLifetime: "30m",
+		DbConnMaxIdleTime: "5m",
+		BaseCurrency:      "INR",
+		PaymentProvider:   payments.ProviderManual,
+		IdempotencyTTL:    "24h",
+		MetricsStockLimit: 100,
+		TraceExporter:     tracing.ExporterNone,
+	}
+}
+
+// Validate reports every field that is missing or malformed, not just the
+// first.
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(field string, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: "+format, append([]any{field}, args...)...))
+	}
+
+	if c.AppName == "" {
+		fail("app_name", "is required")
+	}
+
+	port, err := strconv.Atoi(c.ServerPort)
+	if err != nil || port < 1 || port > 65535 {
+		fail("server_port", "must be a port number, got %q", c.ServerPort)
+	}
+
+	durations := []struct {
+		field string
+		value string
+	}{
+		{"read_timeout", c.ReadTimeout},
+		{"write_timeout", c.WriteTimeout},
+		{"idle_timeout", c.IdleTimeout},
+		{"shutdown_delay", c.ShutdownDelay},
+		{"shutdown_timeout", c.ShutdownTimeout},
+		{"request_timeout", c.RequestTimeout},
+		{"bulk_timeout", c.BulkTimeout},
+		{"db_conn_max_lifetime", c.DbConnMaxLifetime},
+		{"db_conn_max_idle_time", c.DbConnMaxIdleTime},
+		{"idempotency_ttl", c.IdempotencyTTL},
+	}
+	for _, v := range durations {
+		d, err := time.ParseDuration(v.value)
+		if err != nil || d < 0 {
+			fail(v.field, "must be a duration such as 30s, got %q", v.value)
+		}
+	}
+
+	switch c.LogLevel {
+	case LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError:
+	default:
+		fail("log_level", "must be debug, info, warn or error, got %q", c.LogLevel)
+	}
+
+	switch c.LogFormat {
+	case logging.FormatJSON, logging.FormatText:
+	default:
+		fail("log_format", "must be json or text, got %q", c.LogFormat)
+	}
+
+	switch c.DbDriver {
+	case database.DriverMySQL, database.DriverPostgres, database.DriverSQLite:
+	default:
+		fail("db_driver", "must be mysql, postgres or sqlite, got %q", c.DbDriver)
+	}
+
+	if c.DbUrl == "" {
+		fail("db_url", "is required")
+	}
+
+	if c.DbMaxOpenConns < 0 {
+		fail("db_max_open_conns", "must not be negative")
+	}
+
+	if c.DbMaxIdleConns < 0 {
+		fail("db_max_idle_conns", "must not be negative")
+	}
+
+	if c.DbMaxOpenConns > 0 && c.DbMaxIdleConns > c.DbMaxOpenConns {
+		fail("db_max_idle_conns", "must not be more than db_max_open_conns")
+	}
+
+	if !money.ValidCurrency(money.NormalizeCurrency(c.BaseCurrency)) {
+		fail("base_currency", "must be an ISO 4217 code, got %q", c.BaseCurrency)
+	}
+
+	currencies := slices.Sorted(maps.Keys(c.ExchangeRates))
+	for _, currency := range currencies {
+		if !money.ValidCurrency(money.NormalizeCurrency(currency)) {
+			fail("exchange_rates", "%q is not an ISO 4217 code", currency)
+		}
+
+		if !c.ExchangeRates[currency].IsPositive() {
+			fail("exchange_rates", "rate for %s must be positive", currency)
+		}
+	}
+
+	switch c.PaymentProvider {
+	case payments.ProviderManual:
+	case payments.ProviderHTTP:
+		if c.PaymentProviderUrl == "" {
+			fail("payment_provider_url", "is required for the http provider")
+		}
+	default:
+		fail("payment_provider", "must be manual or http, got %q", c.PaymentProvider)
+	}
+
+	if c.MetricsStockLimit < 0 {
+		fail("metrics_stock_limit", "must not be negative")
+	}
+
+	switch c.TraceExporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	case tracing.ExporterFile:
+		if c.TraceFile == "" {
+			fail("trace_file", "is required for the file exporter")
+		}
+	default:
+		fail("trace_exporter", "must be none, stdout, file or otlp, got %q", c.TraceExporter)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy with the secrets blanked out, for printing.
+func (c *Config) Redacted() *Config {
+	result := *c
+
+	for _, f := range fields(&result) {
+		if f.secret && f.value.String() != "" {
+			f.value.SetString(redacted)
+		}
+	}
+
+	return &result
 }
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func environment(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, "config.json", `{"server_port": "9000", "log_level": "warn", "db_url": "from-file", "db_max_open_conns": 50}`)
+
+	config, args, err := Load([]string{"-config", path, "-log-level", "debug", "migrate", "up"}, environment(map[string]string{
+		"INV_LOG_LEVEL":         "error",
+		"INV_DB_MAX_OPEN_CONNS": "40",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"migrate", "up"}, args)
+
+	assert.Equal(t, "9000", config.ServerPort)
+	assert.Equal(t, "debug", config.LogLevel)
+	assert.Equal(t, 40, config.DbMaxOpenConns)
+	assert.Equal(t, "from-file", config.DbUrl)
+	assert.Equal(t, "inventory-management", config.AppName)
+	assert.Equal(t, "30m", config.DbConnMaxLifetime)
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := writeFile(t, "config.yaml", "db_driver: sqlite\ndb_url: inventory.db\ndb_max_idle_conns: 2\nexchange_rates:\n  USD: \"0.012\"\n")
+
+	config, _, err := Load(nil, environment(map[string]string{"INV_CONFIG": path}))
+	assert.NoError(t, err)
+	assert.Equal(t, "sqlite", config.DbDriver)
+	assert.Equal(t, 2, config.DbMaxIdleConns)
+	assert.True(t, decimal.RequireFromString("0.012").Equal(config.ExchangeRates["USD"]))
+	assert.NoError(t, config.Validate())
+}
+
+func TestLoadRejectsUnknownSettings(t *testing.T) {
+	path := writeFile(t, "config.json", `{"db_urll": "typo"}`)
+
+	_, _, err := Load([]string{"-config", path}, environment(nil))
+	assert.ErrorContains(t, err, "db_urll")
+
+	_, _, err = Load([]string{"-db-max-open-conns", "many"}, environment(nil))
+	assert.ErrorContains(t, err, "db_max_open_conns")
+}
+
+func TestLoadSecretFromFile(t *testing.T) {
+	secret := writeFile(t, "db_url", "postgres://inventory:secret@db/inventory\n")
+
+	config, _, err := Load(nil, environment(map[string]string{
+		"INV_DB_URL":      "ignored",
+		"INV_DB_URL_FILE": secret,
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "postgres://inventory:secret@db/inventory", config.DbUrl)
+
+	redacted := config.Redacted()
+	assert.Equal(t, "[REDACTED]", redacted.DbUrl)
+	assert.Equal(t, config.AppName, redacted.AppName)
+	assert.Equal(t, "postgres://inventory:secret@db/inventory", config.DbUrl)
+
+	_, _, err = Load(nil, environment(map[string]string{"INV_DB_URL_FILE": filepath.Join(t.TempDir(), "missing")}))
+	assert.ErrorContains(t, err, "INV_DB_URL_FILE")
+}
+
+func TestLoadMaps(t *testing.T) {
+	config, _, err := Load([]string{"-exchange-rates", "USD=0.012, EUR=0.011"}, environment(nil))
+	assert.NoError(t, err)
+	assert.Len(t, config.ExchangeRates, 2)
+	assert.True(t, decimal.RequireFromString("0.011").Equal(config.ExchangeRates["EUR"]))
+
+	_, _, err = Load([]string{"-exchange-rates", "USD"}, environment(nil))
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	config := Default()
+	config.DbUrl = "inventory.db"
+	assert.NoError(t, config.Validate())
+
+	config.PaymentProvider = "http"
+	assert.ErrorContains(t, config.Validate(), "payment_provider_url:")
+
+	config.AppName = ""
+	config.ServerPort = "80800"
+	config.ReadTimeout = "soon"
+	config.LogLevel = "verbose"
+	config.LogFormat = "xml"
+	config.DbDriver = "oracle"
+	config.DbUrl = ""
+	config.DbMaxIdleConns = 30
+	config.BaseCurrency = "RUPEE"
+	config.ExchangeRates = map[string]decimal.Decimal{"USD": decimal.Zero}
+	config.PaymentProvider = "fake"
+	config.TraceExporter = "file"
+
+	err := config.Validate()
+	for _, field := range []string{"app_name", "server_port", "read_timeout", "log_level", "log_format", "db_driver", "db_url",
+		"db_max_idle_conns", "base_currency", "exchange_rates", "payment_provider", "trace_file"} {
+		assert.ErrorContains(t, err, field+":")
+	}
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const envPrefix = "INV_"
+
+type field struct {
+	name   string
+	secret bool
+	value  reflect.Value
+}
+
+// fields lists the settings of c by their JSON name.
+func fields(c *Config) []field {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+
+	var result []field
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		result = append(result, field{
+			name:   name,
+			secret: t.Field(i).Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+
+	return result
+}
+
+// Load builds the config from the defaults, the config file, the environment
+// and the flags in args, and returns the arguments left after the flags.
+//
+// The file is the one named by -config or INV_CONFIG. Without either it is
+// <env>.json, .yaml or .yml, where env is the env variable and defaults to
+// "default", and it may be missing. Every setting can be overridden by
+// INV_<NAME> and -<name> with dashes, e.g. INV_DB_URL and -db-url. Secrets are
+// also read from the file named by INV_<NAME>_FILE. Maps are written as
+// key=value pairs separated by commas.
+func Load(args []string, getenv func(string) string) (*Config, []string, error) {
+	config := Default()
+	settings := fields(config)
+
+	flags := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ContinueOnError)
+	path := flags.String("config", getenv(envPrefix+"CONFIG"), "config file, JSON or YAML")
+
+	fromFlags := make(map[string]string)
+	for _, v := range settings {
+		name := v.name
+		flags.Func(strings.ReplaceAll(name, "_", "-"), "overrides "+name, func(value string) error {
+			fromFlags[name] = value
+			return nil
+		})
+	}
+
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = readFile(*path, getenv("env"), config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, v := range settings {
+		key := envPrefix + strings.ToUpper(v.name)
+
+		value, ok := getenv(key), getenv(key) != ""
+		if v.secret && getenv(key+"_FILE") != "" {
+			content, err := os.ReadFile(getenv(key + "_FILE"))
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s_FILE: %w", key, err)
+			}
+			value, ok = strings.TrimSpace(string(content)), true
+		}
+
+		if fromFlag, given := fromFlags[v.name]; given {
+			value, ok = fromFlag, true
+		}
+
+		if !ok {
+			continue
+		}
+
+		err = set(v.value, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", v.name, err)
+		}
+	}
+
+	return config, flags.Args(), nil
+}
+
+// readFile reads the config file into config. YAML is read through JSON so
+// both formats use the JSON names and unknown settings are rejected alike.
+func readFile(path string, env string, config *Config) error {
+	if path == "" {
+		if env == "" {
+			env = "default"
+		}
+
+		for _, ext := range []string{".json", ".yaml", ".yml"} {
+			if _, err := os.Stat(env + ext); err == nil {
+				path = env + ext
+				break
+			}
+		}
+
+		if path == "" {
+			return nil
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		var document any
+		err = yaml.Unmarshal(content, &document)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		content, err = json.Marshal(document)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+
+	err = decoder.Decode(config)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+// set parses value into a setting.
+func set(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		v.SetInt(int64(n))
+	case reflect.Map:
+		result := reflect.MakeMap(v.Type())
+		for _, pair := range strings.Split(value, ",") {
+			key, item, found := strings.Cut(strings.TrimSpace(pair), "=")
+			if !found {
+				return errors.New("must be key=value pairs separated by commas")
+			}
+
+			elem := reflect.New(v.Type().Elem())
+			err := elem.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(item))
+			if err != nil {
+				return err
+			}
+			result.SetMapIndex(reflect.ValueOf(key), elem.Elem())
+		}
+		v.Set(result)
+	default:
+		return fmt.Errorf("unsupported setting type %s", v.Kind())
+	}
+
+	return nil
+}
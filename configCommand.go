@@ -0,0 +1,29 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"inventory-management/config"
+	"os"
+)
+
+var errConfigUsage = errors.New("usage: config print")
+
+// runConfig runs the config subcommand. Print writes the effective config with
+// its secrets redacted and then fails if the config is invalid, so a broken
+// deployment can be inspected.
+func runConfig(config *config.Config, args []string) error {
+	if len(args) != 1 || args[0] != "print" {
+		return errConfigUsage
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(config.Redacted())
+	if err != nil {
+		return err
+	}
+
+	return config.Validate()
+}
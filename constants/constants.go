@@ -5,3 +5,83 @@ var (
 	RoleSupplier = "supplier"
 	RoleAdmin    = "admin"
 )
+
+var (
+	DiscountTypePercentage = "percentage"
+	DiscountTypeFixed      = "fixed"
+
+	CouponScopeOrder   = "order"
+	CouponScopeArticle = "article"
+)
+
+var (
+	TaxClassStandard = "standard"
+)
+
+var (
+	OrderStatusPending   = "pending"
+	OrderStatusConfirmed = "confirmed"
+	OrderStatusCancelled = "cancelled"
+)
+
+var (
+	InvoiceTypeInvoice    = "invoice"
+	InvoiceTypeCreditNote = "credit_note"
+
+	InvoiceFormatJSON = "json"
+	InvoiceFormatPDF  = "pdf"
+	InvoiceFormatUBL  = "ubl"
+)
+
+var (
+	ReturnStatusRequested = "requested"
+	ReturnStatusApproved  = "approved"
+	ReturnStatusReceived  = "received"
+	ReturnStatusInspected = "inspected"
+	ReturnStatusRefunded  = "refunded"
+	ReturnStatusRejected  = "rejected"
+)
+
+var (
+	FulfilmentStatusUnfulfilled = "unfulfilled"
+	FulfilmentStatusPartial     = "partially_fulfilled"
+	FulfilmentStatusFulfilled   = "fulfilled"
+)
+
+var (
+	BackorderPolicyAllow  = "allow"
+	BackorderPolicyReject = "reject"
+
+	BackorderStatusOpen      = "open"
+	BackorderStatusAllocated = "allocated"
+	BackorderStatusCancelled = "cancelled"
+)
+
+var (
+	PaymentTermsPrepaid   = "prepaid"
+	PaymentTermsOnAccount = "on_account"
+
+	PaymentTypePayment = "payment"
+	PaymentTypeRefund  = "refund"
+
+	PaymentStatusPending   = "pending"
+	PaymentStatusSucceeded = "succeeded"
+	PaymentStatusFailed    = "failed"
+)
+
+var (
+	ImportTypeArticles = "articles"
+
+	ImportStatusQueued    = "queued"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+
+	FileFormatCSV  = "csv"
+	FileFormatXLSX = "xlsx"
+)
+
+var (
+	HealthStatusOk      = "ok"
+	HealthStatusFailing = "failing"
+)
@@ -3,8 +3,56 @@ package constants
 import "errors"
 
 var (
-	ErrorNotFound       = errors.New("Error Record Not Found")
-	ErrorRecordExists   = errors.New("Error Record Already Exists")
-	ErrorOrderIdEmpty   = errors.New("Error Order Id Empty")
-	ErrorArticleIdEmpty = errors.New("Error Article Id Empty")
+	ErrorNotFound                 = errors.New("Error Record Not Found")
+	ErrorRecordExists             = errors.New("Error Record Already Exists")
+	ErrorOrderIdEmpty             = errors.New("Error Order Id Empty")
+	ErrorArticleIdEmpty           = errors.New("Error Article Id Empty")
+	ErrorInvalidCurrency          = errors.New("Error Invalid Currency")
+	ErrorCurrencyMismatch         = errors.New("Error Currency Mismatch")
+	ErrorExchangeRateNotFound     = errors.New("Error Exchange Rate Not Found")
+	ErrorCouponInvalid            = errors.New("Error Coupon Invalid")
+	ErrorCouponNotActive          = errors.New("Error Coupon Not Active")
+	ErrorCouponMinOrderValue      = errors.New("Error Coupon Minimum Order Value Not Met")
+	ErrorCouponNotApplicable      = errors.New("Error Coupon Not Applicable To Order")
+	ErrorCouponUsageLimit         = errors.New("Error Coupon Usage Limit Reached")
+	ErrorShippingAddressEmpty     = errors.New("Error Shipping Address Empty")
+	ErrorInvalidOrderStatus       = errors.New("Error Invalid Order Status Transition")
+	ErrorOrderNotEditable         = errors.New("Error Order Can Only Be Changed While Pending")
+	ErrorSellerNotConfigured      = errors.New("Error Seller Not Configured")
+	ErrorInvalidInvoiceFormat     = errors.New("Error Invalid Invoice Format")
+	ErrorCreditExceedsInvoice     = errors.New("Error Credit Exceeds Invoiced Quantity")
+	ErrorReturnItemsEmpty         = errors.New("Error Return Items Empty")
+	ErrorInvalidQuantity          = errors.New("Error Quantity Must Be Positive")
+	ErrorReturnExceedsShipped     = errors.New("Error Return Exceeds Shipped Quantity")
+	ErrorInvalidReturnStatus      = errors.New("Error Invalid Return Status Transition")
+	ErrorInspectionMismatch       = errors.New("Error Inspected Quantities Do Not Match Returned Quantity")
+	ErrorOrderNotConfirmed        = errors.New("Error Order Not Confirmed")
+	ErrorShipmentItemsEmpty       = errors.New("Error Shipment Items Empty")
+	ErrorShipmentExceedsAllocated = errors.New("Error Shipment Exceeds Allocated Quantity")
+	ErrorInsufficientStock        = errors.New("Error Insufficient Stock")
+	ErrorOrderFullyShipped        = errors.New("Error Order Already Fully Shipped")
+	ErrorPaymentMethodEmpty       = errors.New("Error Payment Method Empty")
+	ErrorInvalidAmount            = errors.New("Error Amount Must Be Positive")
+	ErrorOrderNotPaid             = errors.New("Error Prepaid Order Not Paid")
+	ErrorPaymentExceedsBalance    = errors.New("Error Payment Exceeds Order Balance")
+	ErrorPaymentFailed            = errors.New("Error Payment Failed")
+	ErrorUnknownPaymentProvider   = errors.New("Error Unknown Payment Provider")
+	ErrorVersionMismatch          = errors.New("Error Version Mismatch")
+	ErrorIfMatchRequired          = errors.New("Error If-Match Header Required")
+	ErrorIdempotencyKeyTooLong    = errors.New("Error Idempotency Key Too Long")
+	ErrorIdempotencyKeyReused     = errors.New("Error Idempotency Key Reused With A Different Request")
+	ErrorIdempotencyKeyInProgress = errors.New("Error Request With This Idempotency Key Is Still In Progress")
+	ErrorInvalidMergePatch        = errors.New("Error Invalid Merge Patch")
+	ErrorUnsupportedMediaType     = errors.New("Error Unsupported Media Type")
+	ErrorValidationFailed         = errors.New("Error Validation Failed")
+	ErrorUnsupportedFileFormat    = errors.New("Error Unsupported File Format")
+	ErrorImportFileEmpty          = errors.New("Error Import File Has No Rows")
+	ErrorImportColumnMissing      = errors.New("Error Mapped Column Missing From Import File")
+	ErrorInvalidColumnMapping     = errors.New("Error Invalid Column Mapping")
+	ErrorInvalidMigration         = errors.New("Error Invalid Migration")
+	ErrorUnknownMigration         = errors.New("Error Database Has A Migration Unknown To This Build")
+	ErrorSchemaOutdated           = errors.New("Error Database Schema Is Older Than This Build Expects")
+	ErrorUnknownDbDriver          = errors.New("Error Unknown Database Driver")
+	ErrorShuttingDown             = errors.New("Error Server Is Shutting Down")
+	ErrorWorkerStopped            = errors.New("Error Background Worker Stopped")
 )
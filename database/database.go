@@ -0,0 +1,118 @@
+package database
+
+import (
+	"inventory-management/constants"
+	"strings"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// sqliteDefaults are added to a SQLite url unless it sets them. SQLite has no
+// SELECT ... FOR UPDATE, so every transaction takes the write lock when it
+// begins instead, and waits for a running one rather than failing at once.
+// WAL lets requests keep reading while a transaction writes.
+var sqliteDefaults = []string{"_txlock=immediate", "_busy_timeout=5000", "_journal_mode=WAL"}
+
+// Open connects to the database at url with the driver, which defaults to
+// MySQL.
+func Open(driver string, url string) (*gorm.DB, error) {
+	dialector, err := Dialector(driver, url)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Every connection to :memory: is a separate database.
+	if driver == DriverSQLite && url == ":memory:" {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	return db, nil
+}
+
+func Dialector(driver string, url string) (gorm.Dialector, error) {
+	switch driver {
+	case "", DriverMySQL:
+		return mysql.Open(url), nil
+	case DriverPostgres:
+		return postgres.Open(url), nil
+	case DriverSQLite:
+		return sqlite.Open(sqliteURL(url)), nil
+	}
+
+	return nil, constants.ErrorUnknownDbDriver
+}
+
+func sqliteURL(url string) string {
+	if url == ":memory:" {
+		return url
+	}
+
+	for _, v := range sqliteDefaults {
+		name, _, _ := strings.Cut(v, "=")
+		if strings.Contains(url, name+"=") {
+			continue
+		}
+
+		if strings.Contains(url, "?") {
+			url += "&" + v
+		} else {
+			url += "?" + v
+		}
+	}
+
+	return url
+}
+
+type Pool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// SetPool sizes the connection pool. Zero values leave the database/sql
+// defaults, and an in-memory SQLite database keeps its single connection.
+func SetPool(db *gorm.DB, pool Pool) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	if dialector, ok := db.Dialector.(*sqlite.Dialector); ok && dialector.DSN == ":memory:" {
+		pool.MaxOpenConns = 0
+	}
+
+	if pool.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	if pool.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+	if pool.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"inventory-management/constants"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteURL(t *testing.T) {
+	assert.Equal(t, ":memory:", sqliteURL(":memory:"))
+	assert.Equal(t, "inventory.db?_txlock=immediate&_busy_timeout=5000&_journal_mode=WAL", sqliteURL("inventory.db"))
+	assert.Equal(t, "file:inventory.db?_busy_timeout=100&_txlock=immediate&_journal_mode=WAL", sqliteURL("file:inventory.db?_busy_timeout=100"))
+}
+
+func TestOpenSQLite(t *testing.T) {
+	db, err := Open(DriverSQLite, filepath.Join(t.TempDir(), "inventory.db"))
+	assert.NoError(t, err)
+	assert.Equal(t, "sqlite", db.Dialector.Name())
+
+	var mode string
+	err = db.Raw("PRAGMA journal_mode").Scan(&mode).Error
+	assert.NoError(t, err)
+	assert.Equal(t, "wal", mode)
+
+	sqlDB, _ := db.DB()
+	sqlDB.Close()
+}
+
+func TestDialector(t *testing.T) {
+	dialector, err := Dialector("", "root@tcp(localhost:3306)/inventory")
+	assert.NoError(t, err)
+	assert.Equal(t, "mysql", dialector.Name())
+
+	dialector, err = Dialector(DriverPostgres, "postgres://localhost/inventory")
+	assert.NoError(t, err)
+	assert.Equal(t, "postgres", dialector.Name())
+
+	_, err = Dialector("oracle", "")
+	assert.Equal(t, constants.ErrorUnknownDbDriver, err)
+}
@@ -1,12 +1,19 @@
 package dtos
 
+import "inventory-management/money"
+
 type Article struct {
-	ArticleId   string  `json:"article_id"`
-	ArticleName string  `json:"article_name"`
-	Price       float64 `json:"price"`
-	Stock       int64   `json:"stock"`
+	ArticleId    string        `json:"article_id" binding:"required"`
+	ArticleName  string        `json:"article_name" binding:"required"`
+	Price        money.Money   `json:"price" binding:"gte=0"`
+	Prices       []money.Money `json:"prices,omitempty" binding:"dive,gte=0"`
+	Stock        int64         `json:"stock" binding:"gte=0"`
+	DamagedStock int64         `json:"damaged_stock"`
+	TaxClass     string        `json:"tax_class"`
+	Version      int64         `json:"version"`
 }
 
 type UpdateStock struct {
-	NewStock int64 `json:"new_stock"`
+	NewStock int64 `json:"new_stock" binding:"gte=0"`
+	Version  int64 `json:"version"`
 }
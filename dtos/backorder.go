@@ -0,0 +1,27 @@
+package dtos
+
+import "time"
+
+type Backorder struct {
+	BackorderId string    `json:"backorder_id"`
+	OrderId     string    `json:"order_id"`
+	OrderItemId string    `json:"order_item_id"`
+	ArticleId   string    `json:"article_id"`
+	Quantity    int       `json:"quantity"`
+	Allocated   int       `json:"allocated_quantity"`
+	Status      string    `json:"status"`
+	OrderedAt   time.Time `json:"ordered_at"`
+}
+
+// BackorderReport is what is still owed to customers for one article, oldest
+// order first.
+type BackorderReport struct {
+	ArticleId   string       `json:"article_id"`
+	Stock       int64        `json:"stock"`
+	Backordered int          `json:"backordered"`
+	Backorders  []*Backorder `json:"backorders"`
+}
+
+type ReceiveStock struct {
+	Quantity int64 `json:"quantity"`
+}
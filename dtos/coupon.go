@@ -0,0 +1,22 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Coupon struct {
+	Code               string          `json:"code"`
+	DiscountType       string          `json:"discount_type"`
+	Scope              string          `json:"scope"`
+	ArticleId          string          `json:"article_id"`
+	Value              decimal.Decimal `json:"value"`
+	Currency           string          `json:"currency"`
+	MinOrderValue      decimal.Decimal `json:"min_order_value"`
+	MaxUses            int             `json:"max_uses"`
+	MaxUsesPerCustomer int             `json:"max_uses_per_customer"`
+	UsedCount          int             `json:"used_count"`
+	StartsAt           *time.Time      `json:"starts_at"`
+	EndsAt             *time.Time      `json:"ends_at"`
+}
@@ -0,0 +1,60 @@
+package dtos
+
+import "time"
+
+// ArticleImport describes an uploaded article file. Mapping maps article
+// fields to the column headers used in the file; unmapped fields are read from
+// columns named after the field.
+type ArticleImport struct {
+	Format  string            `json:"format"`
+	DryRun  bool              `json:"dry_run"`
+	Mapping map[string]string `json:"mapping"`
+}
+
+type ImportJob struct {
+	JobId     string            `json:"job_id"`
+	Type      string            `json:"type"`
+	Format    string            `json:"format"`
+	DryRun    bool              `json:"dry_run"`
+	Status    string            `json:"status"`
+	TotalRows int               `json:"total_rows"`
+	Processed int               `json:"processed"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Error     string            `json:"error,omitempty"`
+	Errors    []*ImportRowError `json:"errors"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ImportRowError is a problem with a line of an imported file. Field and Rule
+// name the failing validation; errors that only show up when the record is
+// saved have a Message instead.
+type ImportRowError struct {
+	Line      int    `json:"line"`
+	Reference string `json:"reference,omitempty"`
+	Field     string `json:"field,omitempty"`
+	Rule      string `json:"rule,omitempty"`
+	Param     string `json:"param,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// OrderImport describes an uploaded order file, one item per row. Rows with
+// the same order reference make up one order.
+type OrderImport struct {
+	Format  string            `json:"format"`
+	Mapping map[string]string `json:"mapping"`
+}
+
+type OrderImportReport struct {
+	TotalRows int               `json:"total_rows"`
+	Created   []*ImportedOrder  `json:"created"`
+	Failed    []string          `json:"failed"`
+	Errors    []*ImportRowError `json:"errors"`
+}
+
+type ImportedOrder struct {
+	Reference string `json:"reference"`
+	OrderId   string `json:"order_id"`
+	Lines     []int  `json:"lines"`
+}
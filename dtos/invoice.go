@@ -0,0 +1,60 @@
+package dtos
+
+import (
+	"inventory-management/money"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Invoice struct {
+	InvoiceNumber  string          `json:"invoice_number"`
+	Type           string          `json:"type"`
+	OrderId        string          `json:"order_id"`
+	InvoiceRef     string          `json:"invoice_ref,omitempty"`
+	IssuedAt       time.Time       `json:"issued_at"`
+	Currency       string          `json:"currency"`
+	Seller         Party           `json:"seller"`
+	Buyer          Party           `json:"buyer"`
+	Lines          []*InvoiceLines `json:"lines"`
+	Taxes          []*InvoiceTaxes `json:"taxes"`
+	Subtotal       money.Money     `json:"subtotal"`
+	DiscountAmount money.Money     `json:"discount_amount"`
+	NetAmount      money.Money     `json:"net_amount"`
+	TaxAmount      money.Money     `json:"tax_amount"`
+	TotalAmount    money.Money     `json:"total_amount"`
+}
+
+type Party struct {
+	Id      string  `json:"id"`
+	Name    string  `json:"name"`
+	Email   string  `json:"email"`
+	Mobile  string  `json:"mobile"`
+	Address Address `json:"address"`
+}
+
+// InvoiceLines carry the amount each line was taxed on. NetAmount excludes tax
+// whether or not the order prices included it.
+type InvoiceLines struct {
+	LineNumber     int             `json:"line_number"`
+	OrderItemId    string          `json:"order_item_id"`
+	ArticleId      string          `json:"article_id"`
+	Description    string          `json:"description"`
+	Quantity       int             `json:"quantity"`
+	UnitPrice      money.Money     `json:"unit_price"`
+	LineAmount     money.Money     `json:"line_amount"`
+	DiscountAmount money.Money     `json:"discount_amount"`
+	NetAmount      money.Money     `json:"net_amount"`
+	TaxRate        decimal.Decimal `json:"tax_rate"`
+	TaxAmount      money.Money     `json:"tax_amount"`
+}
+
+type InvoiceTaxes struct {
+	TaxRate       decimal.Decimal `json:"tax_rate"`
+	TaxableAmount money.Money     `json:"taxable_amount"`
+	TaxAmount     money.Money     `json:"tax_amount"`
+}
+
+type UpdateOrderStatus struct {
+	Status string `json:"status"`
+}
@@ -1,19 +1,51 @@
 package dtos
 
-import "time"
+import (
+	"inventory-management/money"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type Order struct {
-	OrderId     string        `json:"order_id"`
-	CustomerId  string        `json:"customer_id"`
-	OrderedAt   time.Time     `json:"ordered_at"`
-	TotalAmount float64       `json:"total_amount"`
-	NoOfItems   int           `json:"no_of_items"`
-	Items       []*OrderItems `json:"items"`
+	OrderId           string            `json:"order_id"`
+	CustomerId        string            `json:"customer_id" binding:"required"`
+	OrderedAt         time.Time         `json:"ordered_at"`
+	Currency          string            `json:"currency"`
+	Status            string            `json:"status"`
+	CouponCode        string            `json:"coupon_code"`
+	ShippingAddressId string            `json:"shipping_address_id"`
+	PricesIncludeTax  bool              `json:"prices_include_tax"`
+	BackorderPolicy   string            `json:"backorder_policy" binding:"omitempty,oneof=allow reject"`
+	PaymentTerms      string            `json:"payment_terms" binding:"omitempty,oneof=prepaid on_account"`
+	Subtotal          money.Money       `json:"subtotal"`
+	DiscountAmount    money.Money       `json:"discount_amount"`
+	TaxAmount         money.Money       `json:"tax_amount"`
+	TotalAmount       money.Money       `json:"total_amount"`
+	NoOfItems         int               `json:"no_of_items"`
+	Version           int64             `json:"version"`
+	Items             []*OrderItems     `json:"items" binding:"required,min=1,unique=ArticleId,dive"`
+	Discounts         []*OrderDiscounts `json:"discounts"`
 }
 
 type OrderItems struct {
-	OrderItemId string `json:"order_item_id"`
-	OrderId     string `json:"order_id"`
-	ArticleId   string `json:"article_id"`
-	Quantity    int    `json:"quantity"`
+	OrderItemId string          `json:"order_item_id"`
+	OrderId     string          `json:"order_id"`
+	ArticleId   string          `json:"article_id" binding:"required"`
+	Quantity    int             `json:"quantity" binding:"gt=0"`
+	UnitPrice   money.Money     `json:"unit_price"`
+	PriceListId string          `json:"price_list_id"`
+	Allocated   int             `json:"allocated_quantity"`
+	TaxClass    string          `json:"tax_class"`
+	TaxRuleId   string          `json:"tax_rule_id"`
+	TaxRate     decimal.Decimal `json:"tax_rate"`
+	TaxAmount   money.Money     `json:"tax_amount"`
+}
+
+type OrderDiscounts struct {
+	OrderDiscountId string      `json:"order_discount_id"`
+	OrderItemId     string      `json:"order_item_id"`
+	CouponCode      string      `json:"coupon_code"`
+	Description     string      `json:"description"`
+	Amount          money.Money `json:"amount"`
 }
@@ -0,0 +1,32 @@
+package dtos
+
+import (
+	"inventory-management/money"
+	"time"
+)
+
+type Payment struct {
+	PaymentId   string      `json:"payment_id"`
+	OrderId     string      `json:"order_id"`
+	Type        string      `json:"type"`
+	Method      string      `json:"method"`
+	Amount      money.Money `json:"amount"`
+	Status      string      `json:"status"`
+	ExternalRef string      `json:"external_ref"`
+	RefundOf    string      `json:"refund_of"`
+	Reason      string      `json:"reason"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// OrderBalance is what the customer still owes on an order: the total less
+// credit notes and payments, plus whatever was refunded.
+type OrderBalance struct {
+	OrderId      string      `json:"order_id"`
+	PaymentTerms string      `json:"payment_terms"`
+	TotalAmount  money.Money `json:"total_amount"`
+	Credited     money.Money `json:"credited"`
+	Paid         money.Money `json:"paid"`
+	Refunded     money.Money `json:"refunded"`
+	Balance      money.Money `json:"balance"`
+	Payments     []*Payment  `json:"payments"`
+}
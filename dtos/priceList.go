@@ -0,0 +1,26 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type PriceList struct {
+	PriceListId   string            `json:"price_list_id"`
+	Name          string            `json:"name"`
+	Currency      string            `json:"currency"`
+	CustomerId    string            `json:"customer_id"`
+	CustomerGroup string            `json:"customer_group"`
+	ValidFrom     *time.Time        `json:"valid_from"`
+	ValidTo       *time.Time        `json:"valid_to"`
+	Items         []*PriceListItems `json:"items"`
+}
+
+type PriceListItems struct {
+	PriceListItemId string              `json:"price_list_item_id"`
+	ArticleId       string              `json:"article_id"`
+	MinQuantity     int                 `json:"min_quantity"`
+	UnitPrice       decimal.NullDecimal `json:"unit_price"`
+	DiscountPercent decimal.NullDecimal `json:"discount_percent"`
+}
@@ -0,0 +1,33 @@
+package dtos
+
+import (
+	"inventory-management/money"
+	"time"
+)
+
+type Return struct {
+	ReturnId         string         `json:"return_id"`
+	OrderId          string         `json:"order_id"`
+	Status           string         `json:"status"`
+	Reason           string         `json:"reason"`
+	RequestedAt      time.Time      `json:"requested_at"`
+	RefundAmount     money.Money    `json:"refund_amount"`
+	CreditNoteNumber string         `json:"credit_note_number"`
+	Items            []*ReturnItems `json:"items"`
+}
+
+type ReturnItems struct {
+	ReturnItemId      string `json:"return_item_id"`
+	OrderItemId       string `json:"order_item_id"`
+	ArticleId         string `json:"article_id"`
+	Quantity          int    `json:"quantity"`
+	RestockedQuantity int    `json:"restocked_quantity"`
+	DamagedQuantity   int    `json:"damaged_quantity"`
+}
+
+// UpdateReturnStatus moves a return along. Items is only read when the status
+// is inspected and holds the outcome for every returned item.
+type UpdateReturnStatus struct {
+	Status string         `json:"status"`
+	Items  []*ReturnItems `json:"items"`
+}
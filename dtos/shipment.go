@@ -0,0 +1,37 @@
+package dtos
+
+import "time"
+
+type Shipment struct {
+	ShipmentId     string           `json:"shipment_id"`
+	OrderId        string           `json:"order_id"`
+	Carrier        string           `json:"carrier"`
+	TrackingNumber string           `json:"tracking_number"`
+	ShippedAt      time.Time        `json:"shipped_at"`
+	Items          []*ShipmentItems `json:"items"`
+}
+
+type ShipmentItems struct {
+	ShipmentItemId string `json:"shipment_item_id"`
+	OrderItemId    string `json:"order_item_id"`
+	ArticleId      string `json:"article_id"`
+	Quantity       int    `json:"quantity"`
+}
+
+// OrderFulfilment is the shipping state of an order, worked out from its
+// shipments.
+type OrderFulfilment struct {
+	OrderId          string             `json:"order_id"`
+	FulfilmentStatus string             `json:"fulfilment_status"`
+	Items            []*FulfilmentItems `json:"items"`
+	Shipments        []*Shipment        `json:"shipments"`
+}
+
+type FulfilmentItems struct {
+	OrderItemId string `json:"order_item_id"`
+	ArticleId   string `json:"article_id"`
+	Ordered     int    `json:"ordered"`
+	Allocated   int    `json:"allocated"`
+	Shipped     int    `json:"shipped"`
+	Backordered int    `json:"backordered"`
+}
@@ -0,0 +1,19 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type TaxRule struct {
+	TaxRuleId string          `json:"tax_rule_id"`
+	Name      string          `json:"name"`
+	Country   string          `json:"country"`
+	State     string          `json:"state"`
+	ZipPrefix string          `json:"zip_prefix"`
+	TaxClass  string          `json:"tax_class"`
+	Rate      decimal.Decimal `json:"rate"`
+	ValidFrom *time.Time      `json:"valid_from"`
+	ValidTo   *time.Time      `json:"valid_to"`
+}
@@ -1,12 +1,17 @@
 package dtos
 
 type User struct {
-	Id      string  `json:"id"`
-	Name    string  `json:"name"`
-	Email   string  `json:"email"`
-	Mobile  string  `json:"mobile"`
-	Address Address `json:"address"`
-	Role    string  `json:"role"`
+	Id            string  `json:"id"`
+	Name          string  `json:"name" binding:"required"`
+	Email         string  `json:"email" binding:"required,email"`
+	Mobile        string  `json:"mobile"`
+	Address       Address `json:"address"`
+	Role          string  `json:"role" binding:"omitempty,oneof=customer supplier admin"`
+	CustomerGroup string  `json:"customer_group"`
+
+	BackorderPolicy string `json:"backorder_policy" binding:"omitempty,oneof=allow reject"`
+
+	Version int64 `json:"version"`
 }
 
 type Address struct {
@@ -15,6 +20,6 @@ type Address struct {
 	Line2     string `json:"line2"`
 	City      string `json:"city"`
 	State     string `json:"state"`
-	Country   string `json:"country"`
+	Country   string `json:"country" binding:"required"`
 	ZipCode   string `json:"zip_code"`
 }
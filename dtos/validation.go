@@ -0,0 +1,12 @@
+package dtos
+
+type ValidationErrors struct {
+	Message string        `json:"message"`
+	Errors  []*FieldError `json:"errors"`
+}
+
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
+}
@@ -21,25 +21,27 @@ func NewArticleHandler(articleService articles.ArticleService) *articleHandler {
 func (a *articleHandler) GetArticle(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	article, err := a.articleService.GetArticle(id)
+	article, err := a.articleService.GetArticle(ctx.Request.Context(), id)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	if notModified(ctx, article.Version) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, article)
 }
 
 func (a *articleHandler) CreateArticle(ctx *gin.Context) {
 	var req *dtos.Article
 
-	err := ctx.ShouldBindJSON(&req)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, err.Error())
+	if !bindJSON(ctx, &req) {
 		return
 	}
 
-	err = a.articleService.CreateArticle(req)
+	err := a.articleService.CreateArticle(ctx.Request.Context(), req)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
@@ -51,7 +53,7 @@ func (a *articleHandler) CreateArticle(ctx *gin.Context) {
 func (a *articleHandler) DeleteArticle(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	err := a.articleService.DeleteArticle(id)
+	err := a.articleService.DeleteArticle(ctx.Request.Context(), id)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
@@ -63,24 +65,53 @@ func (a *articleHandler) DeleteArticle(ctx *gin.Context) {
 func (a *articleHandler) UpdateArticle(ctx *gin.Context) {
 	id := ctx.Param("id")
 
+	version, ok := ifMatch(ctx)
+	if !ok {
+		return
+	}
+
 	var req dtos.Article
-	err := ctx.ShouldBindJSON(&req)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, err.Error())
+	if !bindJSON(ctx, &req) {
 		return
 	}
 
-	err = a.articleService.UpdateArticle(id, &req)
+	req.Version = version
+	err := a.articleService.UpdateArticle(ctx.Request.Context(), id, &req)
+	updated(ctx, err, version, "Updated article successfully")
+}
+
+// PatchArticle applies a JSON merge patch to the article.
+func (a *articleHandler) PatchArticle(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	version, ok := ifMatch(ctx)
+	if !ok {
+		return
+	}
+
+	current, err := a.articleService.GetArticle(ctx.Request.Context(), id)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Updated article successfully"})
+	if stale(ctx, current.Version, version) {
+		return
+	}
+
+	var req dtos.Article
+	if !mergePatch(ctx, current, &req) {
+		return
+	}
+
+	req.ArticleId = id
+	req.Version = version
+	err = a.articleService.UpdateArticle(ctx.Request.Context(), id, &req)
+	updated(ctx, err, version, "Updated article successfully")
 }
 
 func (a *articleHandler) ListArticles(ctx *gin.Context) {
-	articles, err := a.articleService.ListArticle()
+	articles, err := a.articleService.ListArticle(ctx.Request.Context())
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
@@ -91,19 +122,18 @@ func (a *articleHandler) ListArticles(ctx *gin.Context) {
 
 func (a *articleHandler) UpdateArticleStock(ctx *gin.Context) {
 	id := ctx.Param("id")
-	var req *dtos.UpdateStock
 
-	err := ctx.ShouldBindJSON(&req)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, err.Error())
+	version, ok := ifMatch(ctx)
+	if !ok {
 		return
 	}
 
-	err = a.articleService.UpdateArticleStock(id, req)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+	var req *dtos.UpdateStock
+	if !bindJSON(ctx, &req) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Article stock updated successfully"})
+	req.Version = version
+	err := a.articleService.UpdateArticleStock(ctx.Request.Context(), id, req)
+	updated(ctx, err, version, "Article stock updated successfully")
 }
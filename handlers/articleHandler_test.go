@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"inventory-management/constants"
 	"inventory-management/dtos"
+	"inventory-management/money"
 	"inventory-management/services/mocks"
 	"net/http"
 	"net/http/httptest"
@@ -45,11 +47,11 @@ func (suite *articleHandlerTestSuite) TestGetArticle() {
 	expected := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
-	suite.mockArticleService.EXPECT().GetArticle("123").Return(expected, nil).Times(1)
+	suite.mockArticleService.EXPECT().GetArticle(gomock.Any(), "123").Return(expected, nil).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -69,8 +71,29 @@ func (suite *articleHandlerTestSuite) TestGetArticle() {
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
 }
 
+func (suite *articleHandlerTestSuite) TestGetArticleNotModified() {
+	expected := &dtos.Article{
+		ArticleId: "123",
+		Version:   4,
+	}
+
+	suite.mockArticleService.EXPECT().GetArticle(gomock.Any(), "123").Return(expected, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/articles/123", nil)
+	c.Request.Header.Set("If-None-Match", `"3", W/"4"`)
+
+	suite.articleHandler.GetArticle(c)
+	assert.Equal(suite.T(), http.StatusNotModified, w.Code)
+	assert.Equal(suite.T(), `"4"`, w.Header().Get("ETag"))
+}
+
 func (suite *articleHandlerTestSuite) TestGetArticleError() {
-	suite.mockArticleService.EXPECT().GetArticle("123").Return(nil, constants.ErrorNotFound).Times(1)
+	suite.mockArticleService.EXPECT().GetArticle(gomock.Any(), "123").Return(nil, constants.ErrorNotFound).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -93,7 +116,7 @@ func (suite *articleHandlerTestSuite) TestCreateArticle() {
 	req := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
@@ -104,7 +127,7 @@ func (suite *articleHandlerTestSuite) TestCreateArticle() {
 	c.Request = httptest.NewRequest(http.MethodPost, "/articles", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 
-	suite.mockArticleService.EXPECT().CreateArticle(req).Return(nil).Times(1)
+	suite.mockArticleService.EXPECT().CreateArticle(gomock.Any(), req).Return(nil).Times(1)
 
 	suite.articleHandler.CreateArticle(c)
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
@@ -114,7 +137,7 @@ func (suite *articleHandlerTestSuite) TestCreateArticleError() {
 	req := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
@@ -125,7 +148,7 @@ func (suite *articleHandlerTestSuite) TestCreateArticleError() {
 	c.Request = httptest.NewRequest(http.MethodPost, "/articles", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 
-	suite.mockArticleService.EXPECT().CreateArticle(req).Return(constants.ErrorRecordExists).Times(1)
+	suite.mockArticleService.EXPECT().CreateArticle(gomock.Any(), req).Return(constants.ErrorRecordExists).Times(1)
 
 	suite.articleHandler.CreateArticle(c)
 	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
@@ -145,7 +168,7 @@ func (suite *articleHandlerTestSuite) TestCreateArticle_BadRequest() {
 }
 
 func (suite *articleHandlerTestSuite) TestDeleteArticle() {
-	suite.mockArticleService.EXPECT().DeleteArticle("123").Return(nil).Times(1)
+	suite.mockArticleService.EXPECT().DeleteArticle(gomock.Any(), "123").Return(nil).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -159,7 +182,7 @@ func (suite *articleHandlerTestSuite) TestDeleteArticle() {
 }
 
 func (suite *articleHandlerTestSuite) TestDeleteArticleError() {
-	suite.mockArticleService.EXPECT().DeleteArticle("123").Return(constants.ErrorNotFound).Times(1)
+	suite.mockArticleService.EXPECT().DeleteArticle(gomock.Any(), "123").Return(constants.ErrorNotFound).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -176,7 +199,7 @@ func (suite *articleHandlerTestSuite) TestUpdateArticle() {
 	req := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       200,
+		Price:       money.MustParse("200", "INR"),
 		Stock:       50,
 	}
 
@@ -189,18 +212,21 @@ func (suite *articleHandlerTestSuite) TestUpdateArticle() {
 	}
 	c.Request = httptest.NewRequest(http.MethodPut, "/articles/123", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
-	suite.mockArticleService.EXPECT().UpdateArticle("123", req).Return(nil).Times(1)
+	req.Version = 3
+	suite.mockArticleService.EXPECT().UpdateArticle(gomock.Any(), "123", req).Return(nil).Times(1)
 
 	suite.articleHandler.UpdateArticle(c)
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), `"4"`, w.Header().Get("ETag"))
 }
 
 func (suite *articleHandlerTestSuite) TestUpdateArticleError() {
 	req := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       200,
+		Price:       money.MustParse("200", "INR"),
 		Stock:       50,
 	}
 
@@ -213,13 +239,44 @@ func (suite *articleHandlerTestSuite) TestUpdateArticleError() {
 	}
 	c.Request = httptest.NewRequest(http.MethodPut, "/articles/123", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
-	suite.mockArticleService.EXPECT().UpdateArticle("123", req).Return(constants.ErrorNotFound).Times(1)
+	req.Version = 3
+	suite.mockArticleService.EXPECT().UpdateArticle(gomock.Any(), "123", req).Return(constants.ErrorNotFound).Times(1)
 
 	suite.articleHandler.UpdateArticle(c)
 	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
 }
 
+func (suite *articleHandlerTestSuite) TestUpdateArticleIfMatchRequired() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPut, "/articles/123", bytes.NewReader([]byte(`{"article_id":"123","article_name":"Test Article"}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.articleHandler.UpdateArticle(c)
+	assert.Equal(suite.T(), http.StatusPreconditionRequired, w.Code)
+}
+
+func (suite *articleHandlerTestSuite) TestUpdateArticleStaleVersion() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPut, "/articles/123", bytes.NewReader([]byte(`{"article_id":"123","article_name":"Test Article"}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"2"`)
+
+	suite.mockArticleService.EXPECT().UpdateArticle(gomock.Any(), "123", gomock.Any()).Return(constants.ErrorVersionMismatch).Times(1)
+
+	suite.articleHandler.UpdateArticle(c)
+	assert.Equal(suite.T(), http.StatusPreconditionFailed, w.Code)
+}
+
 func (suite *articleHandlerTestSuite) TestUpdateArticleBadRequest() {
 	invalidJSON := `{"articleId": "123", "articleName": "Test Article", "price": "not_a_number", "stock": "50"}`
 
@@ -230,6 +287,7 @@ func (suite *articleHandlerTestSuite) TestUpdateArticleBadRequest() {
 	}
 	c.Request = httptest.NewRequest(http.MethodPut, "/articles/123", bytes.NewReader([]byte(invalidJSON)))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
 	suite.articleHandler.UpdateArticle(c)
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
@@ -240,18 +298,18 @@ func (suite *articleHandlerTestSuite) TestListArticles() {
 		{
 			ArticleId:   "123",
 			ArticleName: "Test Article",
-			Price:       100,
+			Price:       money.MustParse("100", "INR"),
 			Stock:       50,
 		},
 		{
 			ArticleId:   "321",
 			ArticleName: "Test 2",
-			Price:       200,
+			Price:       money.MustParse("200", "INR"),
 			Stock:       10,
 		},
 	}
 
-	suite.mockArticleService.EXPECT().ListArticle().Return(expected, nil).Times(1)
+	suite.mockArticleService.EXPECT().ListArticle(gomock.Any()).Return(expected, nil).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -262,7 +320,7 @@ func (suite *articleHandlerTestSuite) TestListArticles() {
 }
 
 func (suite *articleHandlerTestSuite) TestListArticlesError() {
-	suite.mockArticleService.EXPECT().ListArticle().Return(nil, errors.New("repo error")).Times(1)
+	suite.mockArticleService.EXPECT().ListArticle(gomock.Any()).Return(nil, errors.New("repo error")).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -284,10 +342,12 @@ func (suite *articleHandlerTestSuite) TestUpdateArticleStock() {
 	c.Params = []gin.Param{
 		{Key: "id", Value: "123"},
 	}
-	c.Request = httptest.NewRequest(http.MethodPatch, "/articles/123", bytes.NewReader(body))
+	c.Request = httptest.NewRequest(http.MethodPut, "/articles/123/stock", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
-	suite.mockArticleService.EXPECT().UpdateArticleStock("123", req).Return(nil).Times(1)
+	req.Version = 3
+	suite.mockArticleService.EXPECT().UpdateArticleStock(gomock.Any(), "123", req).Return(nil).Times(1)
 
 	suite.articleHandler.UpdateArticleStock(c)
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
@@ -305,10 +365,12 @@ func (suite *articleHandlerTestSuite) TestUpdateArticleStockError() {
 	c.Params = []gin.Param{
 		{Key: "id", Value: "123"},
 	}
-	c.Request = httptest.NewRequest(http.MethodPatch, "/articles/123", bytes.NewReader(body))
+	c.Request = httptest.NewRequest(http.MethodPut, "/articles/123/stock", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
-	suite.mockArticleService.EXPECT().UpdateArticleStock("123", req).Return(constants.ErrorNotFound).Times(1)
+	req.Version = 3
+	suite.mockArticleService.EXPECT().UpdateArticleStock(gomock.Any(), "123", req).Return(constants.ErrorNotFound).Times(1)
 
 	suite.articleHandler.UpdateArticleStock(c)
 	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
@@ -322,9 +384,139 @@ func (suite *articleHandlerTestSuite) TestUpdateArticleStockBadRequest() {
 	c.Params = []gin.Param{
 		{Key: "id", Value: "123"},
 	}
-	c.Request = httptest.NewRequest(http.MethodPatch, "/articles/123", bytes.NewReader([]byte(invalidJSON)))
+	c.Request = httptest.NewRequest(http.MethodPut, "/articles/123/stock", bytes.NewReader([]byte(invalidJSON)))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
 	suite.articleHandler.UpdateArticleStock(c)
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
 }
+
+func (suite *articleHandlerTestSuite) TestPatchArticle() {
+	current := &dtos.Article{
+		ArticleId:   "123",
+		ArticleName: "Test Article",
+		Price:       money.MustParse("100", "INR"),
+		Stock:       50,
+		TaxClass:    "standard",
+		Version:     3,
+	}
+
+	expected := &dtos.Article{
+		ArticleId:   "123",
+		ArticleName: "Test Article",
+		Price:       money.MustParse("0", "INR"),
+		Stock:       0,
+		Version:     3,
+	}
+
+	suite.mockArticleService.EXPECT().GetArticle(gomock.Any(), "123").Return(current, nil).Times(1)
+	suite.mockArticleService.EXPECT().UpdateArticle(gomock.Any(), "123", gomock.Any()).DoAndReturn(func(_ context.Context, id string, req *dtos.Article) error {
+		assert.Equal(suite.T(), expected.ArticleName, req.ArticleName)
+		assert.True(suite.T(), req.Price.Equal(expected.Price))
+		assert.Equal(suite.T(), expected.Stock, req.Stock)
+		assert.Equal(suite.T(), "", req.TaxClass)
+		assert.Equal(suite.T(), expected.Version, req.Version)
+		return nil
+	}).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	body := `{"price":{"amount":"0"},"stock":0,"tax_class":null}`
+	c.Request = httptest.NewRequest(http.MethodPatch, "/articles/123", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/merge-patch+json")
+	c.Request.Header.Set("If-Match", `"3"`)
+
+	suite.articleHandler.PatchArticle(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), `"4"`, w.Header().Get("ETag"))
+}
+
+func (suite *articleHandlerTestSuite) TestPatchArticleStaleVersion() {
+	suite.mockArticleService.EXPECT().GetArticle(gomock.Any(), "123").Return(&dtos.Article{ArticleId: "123", Version: 4}, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPatch, "/articles/123", bytes.NewReader([]byte(`{"stock":0}`)))
+	c.Request.Header.Set("Content-Type", "application/merge-patch+json")
+	c.Request.Header.Set("If-Match", `"3"`)
+
+	suite.articleHandler.PatchArticle(c)
+	assert.Equal(suite.T(), http.StatusPreconditionFailed, w.Code)
+}
+
+func (suite *articleHandlerTestSuite) TestPatchArticleUnsupportedMediaType() {
+	suite.mockArticleService.EXPECT().GetArticle(gomock.Any(), "123").Return(&dtos.Article{ArticleId: "123", Version: 3}, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPatch, "/articles/123", bytes.NewReader([]byte(`[{"op":"remove","path":"/stock"}]`)))
+	c.Request.Header.Set("Content-Type", "application/json-patch+json")
+	c.Request.Header.Set("If-Match", `"3"`)
+
+	suite.articleHandler.PatchArticle(c)
+	assert.Equal(suite.T(), http.StatusUnsupportedMediaType, w.Code)
+}
+
+func (suite *articleHandlerTestSuite) TestPatchArticleBadRequest() {
+	suite.mockArticleService.EXPECT().GetArticle(gomock.Any(), "123").Return(&dtos.Article{ArticleId: "123", Version: 3}, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPatch, "/articles/123", bytes.NewReader([]byte(`{"stock":"many"}`)))
+	c.Request.Header.Set("Content-Type", "application/merge-patch+json")
+	c.Request.Header.Set("If-Match", `"3"`)
+
+	suite.articleHandler.PatchArticle(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *articleHandlerTestSuite) TestCreateArticleValidationFailed() {
+	body := `{"article_id":"123","article_name":"","price":{"amount":"-1","currency":"INR"},"stock":-5}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/articles", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.articleHandler.CreateArticle(c)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, w.Code)
+
+	var result dtos.ValidationErrors
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []*dtos.FieldError{
+		{Field: "article_name", Rule: "required"},
+		{Field: "price", Rule: "gte", Param: "0"},
+		{Field: "stock", Rule: "gte", Param: "0"},
+	}, result.Errors)
+}
+
+func (suite *articleHandlerTestSuite) TestPatchArticleValidationFailed() {
+	suite.mockArticleService.EXPECT().GetArticle(gomock.Any(), "123").Return(&dtos.Article{ArticleId: "123", ArticleName: "Test Article", Version: 3}, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPatch, "/articles/123", bytes.NewReader([]byte(`{"article_name":null}`)))
+	c.Request.Header.Set("Content-Type", "application/merge-patch+json")
+	c.Request.Header.Set("If-Match", `"3"`)
+
+	suite.articleHandler.PatchArticle(c)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `{"field":"article_name","rule":"required"}`)
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"inventory-management/dtos"
+	"inventory-management/services/backorders"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type backorderHandler struct {
+	backorderService backorders.BackorderService
+}
+
+func NewBackorderHandler(backorderService backorders.BackorderService) *backorderHandler {
+	return &backorderHandler{
+		backorderService: backorderService,
+	}
+}
+
+func (b *backorderHandler) ReceiveStock(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req dtos.ReceiveStock
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = b.backorderService.ReceiveStock(ctx.Request.Context(), id, &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Stock received successfully"})
+}
+
+func (b *backorderHandler) GetArticleBackorders(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	report, err := b.backorderService.GetArticleBackorders(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type backorderHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl             *gomock.Controller
+	mockBackorderService *mocks.MockBackorderService
+	backorderHandler     *backorderHandler
+}
+
+func TestBackorderHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(backorderHandlerTestSuite))
+}
+
+func (suite *backorderHandlerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockBackorderService = mocks.NewMockBackorderService(suite.mockCtrl)
+
+	suite.backorderHandler = NewBackorderHandler(suite.mockBackorderService)
+}
+
+func (suite *backorderHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *backorderHandlerTestSuite) TestReceiveStock() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "a1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/articles/a1/receipts", bytes.NewReader([]byte(`{"quantity":10}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockBackorderService.EXPECT().ReceiveStock(gomock.Any(), "a1", &dtos.ReceiveStock{Quantity: 10}).Return(nil).Times(1)
+
+	suite.backorderHandler.ReceiveStock(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *backorderHandlerTestSuite) TestReceiveStockError() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "a1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/articles/a1/receipts", bytes.NewReader([]byte(`{"quantity":0}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockBackorderService.EXPECT().ReceiveStock(gomock.Any(), "a1", gomock.Any()).Return(constants.ErrorInvalidQuantity).Times(1)
+
+	suite.backorderHandler.ReceiveStock(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *backorderHandlerTestSuite) TestReceiveStockBadRequest() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "a1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/articles/a1/receipts", bytes.NewReader([]byte(`{"quantity":"ten"}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.backorderHandler.ReceiveStock(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *backorderHandlerTestSuite) TestGetArticleBackorders() {
+	expected := &dtos.BackorderReport{
+		ArticleId:   "a1",
+		Backordered: 3,
+		Backorders:  []*dtos.Backorder{{BackorderId: "b1", OrderId: "o1", ArticleId: "a1", Quantity: 3}},
+	}
+
+	suite.mockBackorderService.EXPECT().GetArticleBackorders(gomock.Any(), "a1").Return(expected, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "a1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/articles/a1/backorders", nil)
+
+	suite.backorderHandler.GetArticleBackorders(c)
+
+	var result *dtos.BackorderReport
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, result.Backordered)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/catalog"
+	"inventory-management/tabular"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type catalogHandler struct {
+	catalogService catalog.CatalogService
+}
+
+func NewCatalogHandler(catalogService catalog.CatalogService) *catalogHandler {
+	return &catalogHandler{
+		catalogService: catalogService,
+	}
+}
+
+// ImportArticles takes the file as an upload. Setting "dry_run" only
+// validates the rows.
+func (c *catalogHandler) ImportArticles(ctx *gin.Context) {
+	format, mapping, data, ok := upload(ctx)
+	if !ok {
+		return
+	}
+
+	req := &dtos.ArticleImport{
+		Format:  format,
+		Mapping: mapping,
+	}
+
+	if dryRun := ctx.PostForm("dry_run"); dryRun != "" {
+		var err error
+		req.DryRun, err = strconv.ParseBool(dryRun)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	job, err := c.catalogService.ImportArticles(ctx.Request.Context(), req, data)
+	if err != nil {
+		if badUpload(err) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, constants.ErrorShuttingDown) {
+			ctx.JSON(http.StatusServiceUnavailable, err.Error())
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.Header("Location", "/articles/import/"+job.JobId)
+	ctx.JSON(http.StatusAccepted, job)
+}
+
+func (c *catalogHandler) GetImportJob(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	job, err := c.catalogService.GetImportJob(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, job)
+}
+
+// ExportArticles streams the catalog in the format asked for by the format
+// query parameter, which defaults to csv.
+func (c *catalogHandler) ExportArticles(ctx *gin.Context) {
+	format := ctx.DefaultQuery("format", constants.FileFormatCSV)
+
+	contentType, ok := tabular.ContentTypes[format]
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, constants.ErrorUnsupportedFileFormat.Error())
+		return
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", `attachment; filename="articles.`+format+`"`)
+	ctx.Status(http.StatusOK)
+
+	err := c.catalogService.ExportArticles(ctx.Request.Context(), format, ctx.Writer)
+	if err != nil {
+		// The status has gone out with the first rows, so the client only
+		// sees a truncated file.
+		_ = ctx.Error(err)
+	}
+}
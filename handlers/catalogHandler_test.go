@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/mocks"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type catalogHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl           *gomock.Controller
+	mockCatalogService *mocks.MockCatalogService
+	catalogHandler     *catalogHandler
+}
+
+func TestCatalogHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(catalogHandlerTestSuite))
+}
+
+func (suite *catalogHandlerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockCatalogService = mocks.NewMockCatalogService(suite.mockCtrl)
+
+	suite.catalogHandler = NewCatalogHandler(suite.mockCatalogService)
+}
+
+func (suite *catalogHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func uploadBody(filename string, content string, fields map[string]string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, _ := writer.CreateFormFile("file", filename)
+	part.Write([]byte(content))
+
+	for k, v := range fields {
+		writer.WriteField(k, v)
+	}
+	writer.Close()
+
+	return body, writer.FormDataContentType()
+}
+
+func (suite *catalogHandlerTestSuite) TestImportArticles() {
+	body, contentType := uploadBody("suppliers.csv", "sku,article_name,price,stock\n", map[string]string{
+		"mapping": `{"article_id":"sku"}`,
+		"dry_run": "true",
+	})
+
+	expected := &dtos.ImportJob{JobId: "j1", Status: constants.ImportStatusQueued}
+	suite.mockCatalogService.EXPECT().ImportArticles(gomock.Any(), &dtos.ArticleImport{
+		Format:  "csv",
+		DryRun:  true,
+		Mapping: map[string]string{"article_id": "sku"},
+	}, []byte("sku,article_name,price,stock\n")).Return(expected, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/articles/import", body)
+	c.Request.Header.Set("Content-Type", contentType)
+
+	suite.catalogHandler.ImportArticles(c)
+	assert.Equal(suite.T(), http.StatusAccepted, w.Code)
+	assert.Equal(suite.T(), "/articles/import/j1", w.Header().Get("Location"))
+
+	var result dtos.ImportJob
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "j1", result.JobId)
+}
+
+func (suite *catalogHandlerTestSuite) TestImportArticlesUnsupportedFormat() {
+	body, contentType := uploadBody("suppliers.ods", "", nil)
+
+	suite.mockCatalogService.EXPECT().ImportArticles(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, constants.ErrorUnsupportedFileFormat).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/articles/import", body)
+	c.Request.Header.Set("Content-Type", contentType)
+
+	suite.catalogHandler.ImportArticles(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *catalogHandlerTestSuite) TestImportArticlesShuttingDown() {
+	body, contentType := uploadBody("suppliers.csv", "article_id\na1\n", nil)
+
+	suite.mockCatalogService.EXPECT().ImportArticles(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, constants.ErrorShuttingDown).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/articles/import", body)
+	c.Request.Header.Set("Content-Type", contentType)
+
+	suite.catalogHandler.ImportArticles(c)
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+}
+
+func (suite *catalogHandlerTestSuite) TestImportArticlesInvalidMapping() {
+	body, contentType := uploadBody("suppliers.csv", "", map[string]string{"mapping": `["sku"]`})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/articles/import", body)
+	c.Request.Header.Set("Content-Type", contentType)
+
+	suite.catalogHandler.ImportArticles(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *catalogHandlerTestSuite) TestImportArticlesWithoutFile() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/articles/import", nil)
+
+	suite.catalogHandler.ImportArticles(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *catalogHandlerTestSuite) TestGetImportJob() {
+	expected := &dtos.ImportJob{JobId: "j1", Status: constants.ImportStatusCompleted, Succeeded: 3}
+	suite.mockCatalogService.EXPECT().GetImportJob(gomock.Any(), "j1").Return(expected, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "j1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/articles/import/j1", nil)
+
+	suite.catalogHandler.GetImportJob(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var result dtos.ImportJob
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, result.Succeeded)
+}
+
+func (suite *catalogHandlerTestSuite) TestExportArticles() {
+	suite.mockCatalogService.EXPECT().ExportArticles(gomock.Any(), constants.FileFormatCSV, gomock.Any()).DoAndReturn(func(_ context.Context, format string, w io.Writer) error {
+		_, err := w.Write([]byte("article_id\na1\n"))
+		return err
+	}).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/articles/export", nil)
+
+	suite.catalogHandler.ExportArticles(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(suite.T(), "article_id\na1\n", w.Body.String())
+}
+
+func (suite *catalogHandlerTestSuite) TestExportArticlesUnsupportedFormat() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/articles/export?format=ods", nil)
+
+	suite.catalogHandler.ExportArticles(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
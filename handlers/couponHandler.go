@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"inventory-management/dtos"
+	"inventory-management/services/coupons"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type couponHandler struct {
+	couponService coupons.CouponService
+}
+
+func NewCouponHandler(couponService coupons.CouponService) *couponHandler {
+	return &couponHandler{
+		couponService: couponService,
+	}
+}
+
+func (c *couponHandler) GetCoupon(ctx *gin.Context) {
+	code := ctx.Param("code")
+
+	coupon, err := c.couponService.GetCoupon(ctx.Request.Context(), code)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, coupon)
+}
+
+func (c *couponHandler) CreateCoupon(ctx *gin.Context) {
+	var req *dtos.Coupon
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.couponService.CreateCoupon(ctx.Request.Context(), req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Coupon created successfully", "code": req.Code})
+}
+
+func (c *couponHandler) DeleteCoupon(ctx *gin.Context) {
+	code := ctx.Param("code")
+
+	err := c.couponService.DeleteCoupon(ctx.Request.Context(), code)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Coupon deleted successfully"})
+}
+
+func (c *couponHandler) UpdateCoupon(ctx *gin.Context) {
+	code := ctx.Param("code")
+
+	var req dtos.Coupon
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.couponService.UpdateCoupon(ctx.Request.Context(), code, &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Updated coupon successfully"})
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type couponHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl          *gomock.Controller
+	mockCouponService *mocks.MockCouponService
+	couponHandler     *couponHandler
+}
+
+func TestCouponHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(couponHandlerTestSuite))
+}
+
+func (suite *couponHandlerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockCouponService = mocks.NewMockCouponService(suite.mockCtrl)
+
+	suite.couponHandler = NewCouponHandler(suite.mockCouponService)
+}
+
+func (suite *couponHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *couponHandlerTestSuite) TestGetCoupon() {
+	expected := &dtos.Coupon{
+		Code:         "SAVE10",
+		DiscountType: constants.DiscountTypePercentage,
+		Scope:        constants.CouponScopeOrder,
+		Value:        decimal.NewFromInt(10),
+	}
+
+	suite.mockCouponService.EXPECT().GetCoupon(gomock.Any(), "SAVE10").Return(expected, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "code", Value: "SAVE10"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/coupons/SAVE10", nil)
+
+	suite.couponHandler.GetCoupon(c)
+
+	var result *dtos.Coupon
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected.Code, result.Code)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *couponHandlerTestSuite) TestGetCouponError() {
+	suite.mockCouponService.EXPECT().GetCoupon(gomock.Any(), "SAVE10").Return(nil, constants.ErrorNotFound).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "code", Value: "SAVE10"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/coupons/SAVE10", nil)
+
+	suite.couponHandler.GetCoupon(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *couponHandlerTestSuite) TestCreateCoupon() {
+	req := &dtos.Coupon{
+		Code:         "SAVE10",
+		DiscountType: constants.DiscountTypePercentage,
+		Scope:        constants.CouponScopeOrder,
+		Value:        decimal.NewFromInt(10),
+	}
+
+	body, _ := json.Marshal(req)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/coupons", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockCouponService.EXPECT().CreateCoupon(gomock.Any(), gomock.AssignableToTypeOf(&dtos.Coupon{})).Return(nil).Times(1)
+
+	suite.couponHandler.CreateCoupon(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *couponHandlerTestSuite) TestCreateCouponBadRequest() {
+	invalidJSON := `{"code": 10, "value": "ten"}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/coupons", bytes.NewReader([]byte(invalidJSON)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.couponHandler.CreateCoupon(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *couponHandlerTestSuite) TestUpdateCoupon() {
+	body, _ := json.Marshal(&dtos.Coupon{Value: decimal.NewFromInt(5)})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "code", Value: "SAVE10"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPut, "/coupons/SAVE10", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockCouponService.EXPECT().UpdateCoupon(gomock.Any(), "SAVE10", gomock.Any()).Return(constants.ErrorNotFound).Times(1)
+
+	suite.couponHandler.UpdateCoupon(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *couponHandlerTestSuite) TestDeleteCoupon() {
+	suite.mockCouponService.EXPECT().DeleteCoupon(gomock.Any(), "SAVE10").Return(nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "code", Value: "SAVE10"},
+	}
+	c.Request = httptest.NewRequest(http.MethodDelete, "/coupons/SAVE10", nil)
+
+	suite.couponHandler.DeleteCoupon(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"inventory-management/openapi"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+type docsHandler struct {
+	document []byte
+}
+
+func NewDocsHandler(document []byte) *docsHandler {
+	return &docsHandler{
+		document: document,
+	}
+}
+
+// OpenAPI sends the OpenAPI document, which is written once at startup.
+func (d *docsHandler) OpenAPI(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "application/json", d.document)
+}
+
+func (d *docsHandler) Docs(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", openapi.DocsPage)
+}
+
+// SwaggerUI sends one of the embedded Swagger UI files the docs page loads.
+func (d *docsHandler) SwaggerUI(ctx *gin.Context) {
+	file := ctx.Param("file")
+
+	data, err := fs.ReadFile(openapi.SwaggerUI, file)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, "file not found")
+		return
+	}
+
+	ctx.Data(http.StatusOK, mime.TypeByExtension(path.Ext(file)), data)
+}
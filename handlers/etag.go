@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"inventory-management/constants"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// notModified sends the version as the ETag and answers 304 when If-None-Match
+// already lists it. Weak tags match too, as GET only needs weak comparison.
+func notModified(ctx *gin.Context, version int64) bool {
+	tag := etag(version)
+	ctx.Header("ETag", tag)
+
+	for _, v := range strings.Split(ctx.GetHeader("If-None-Match"), ",") {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+		if v == tag || v == "*" {
+			ctx.AbortWithStatus(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+// ifMatch reads the version a PUT or PATCH was based on from If-Match. It
+// answers 428 when the header is missing and 412 when it does not hold a
+// single version handed out as an ETag.
+func ifMatch(ctx *gin.Context) (int64, bool) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" {
+		ctx.JSON(http.StatusPreconditionRequired, constants.ErrorIfMatchRequired.Error())
+		return 0, false
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(header, `"`), 10, 64)
+	if err != nil || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		ctx.JSON(http.StatusPreconditionFailed, constants.ErrorVersionMismatch.Error())
+		return 0, false
+	}
+
+	return version, true
+}
+
+// updated answers a versioned write: 412 when the version was stale, 409 when
+// it would overwrite a record it doesn't own, 500 for any other error and
+// otherwise the message along with the new ETag.
+func updated(ctx *gin.Context, err error, version int64, message string) {
+	if errors.Is(err, constants.ErrorVersionMismatch) {
+		ctx.JSON(http.StatusPreconditionFailed, err.Error())
+		return
+	}
+
+	if errors.Is(err, constants.ErrorRecordExists) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.Header("ETag", etag(version+1))
+	ctx.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+// stale answers 412 when the resource has already moved past the version the
+// request was based on.
+func stale(ctx *gin.Context, current int64, version int64) bool {
+	if current == version {
+		return false
+	}
+
+	ctx.JSON(http.StatusPreconditionFailed, constants.ErrorVersionMismatch.Error())
+	return true
+}
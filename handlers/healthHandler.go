@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"inventory-management/constants"
+	"inventory-management/services/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type healthHandler struct {
+	healthService health.HealthService
+}
+
+func NewHealthHandler(healthService health.HealthService) *healthHandler {
+	return &healthHandler{
+		healthService: healthService,
+	}
+}
+
+// Healthz answers as long as the process can serve requests at all.
+func (h *healthHandler) Healthz(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": constants.HealthStatusOk})
+}
+
+func (h *healthHandler) Readyz(ctx *gin.Context) {
+	readiness := h.healthService.Ready(ctx.Request.Context())
+	if readiness.Status != constants.HealthStatusOk {
+		ctx.JSON(http.StatusServiceUnavailable, readiness)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, readiness)
+}
+
+func (h *healthHandler) Version(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, h.healthService.Version())
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type healthHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl          *gomock.Controller
+	mockHealthService *mocks.MockHealthService
+	healthHandler     *healthHandler
+}
+
+func TestHealthHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(healthHandlerTestSuite))
+}
+
+func (suite *healthHandlerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockHealthService = mocks.NewMockHealthService(suite.mockCtrl)
+
+	suite.healthHandler = NewHealthHandler(suite.mockHealthService)
+}
+
+func (suite *healthHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *healthHandlerTestSuite) TestHealthz() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/healthz", nil)
+
+	suite.healthHandler.Healthz(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *healthHandlerTestSuite) TestReadyz() {
+	suite.mockHealthService.EXPECT().Ready(gomock.Any()).Return(&dtos.Readiness{
+		Status: constants.HealthStatusOk,
+		Checks: map[string]string{"database": constants.HealthStatusOk},
+	}).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	suite.healthHandler.Readyz(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *healthHandlerTestSuite) TestReadyzFailing() {
+	suite.mockHealthService.EXPECT().Ready(gomock.Any()).Return(&dtos.Readiness{
+		Status: constants.HealthStatusFailing,
+		Checks: map[string]string{"shutdown": constants.ErrorShuttingDown.Error()},
+	}).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	suite.healthHandler.Readyz(c)
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+
+	var readiness dtos.Readiness
+	json.Unmarshal(w.Body.Bytes(), &readiness)
+	assert.Equal(suite.T(), constants.HealthStatusFailing, readiness.Status)
+}
+
+func (suite *healthHandlerTestSuite) TestVersion() {
+	suite.mockHealthService.EXPECT().Version().Return(&dtos.Version{AppName: "inventory-management", Commit: "0210c54"}).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/version", nil)
+
+	suite.healthHandler.Version(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "0210c54")
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/invoices"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type invoiceHandler struct {
+	invoiceService invoices.InvoiceService
+}
+
+func NewInvoiceHandler(invoiceService invoices.InvoiceService) *invoiceHandler {
+	return &invoiceHandler{
+		invoiceService: invoiceService,
+	}
+}
+
+func (i *invoiceHandler) GetOrderInvoice(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	invoice, err := i.invoiceService.GetOrderInvoice(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	i.render(ctx, invoice)
+}
+
+func (i *invoiceHandler) GetOrderCreditNotes(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	creditNotes, err := i.invoiceService.GetCreditNotes(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, creditNotes)
+}
+
+func (i *invoiceHandler) GetInvoice(ctx *gin.Context) {
+	number := ctx.Param("number")
+
+	invoice, err := i.invoiceService.GetInvoice(ctx.Request.Context(), number)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	i.render(ctx, invoice)
+}
+
+// render sends the document in the format asked for by the format query
+// parameter, which defaults to json.
+func (i *invoiceHandler) render(ctx *gin.Context, invoice *dtos.Invoice) {
+	format := ctx.DefaultQuery("format", constants.InvoiceFormatJSON)
+
+	var body []byte
+	var contentType string
+	var err error
+
+	switch format {
+	case constants.InvoiceFormatJSON:
+		ctx.JSON(http.StatusOK, invoice)
+		return
+	case constants.InvoiceFormatPDF:
+		body, err = invoices.RenderPDF(invoice)
+		contentType = "application/pdf"
+	case constants.InvoiceFormatUBL:
+		body, err = invoices.RenderUBL(invoice)
+		contentType = "application/xml"
+	default:
+		ctx.JSON(http.StatusBadRequest, constants.ErrorInvalidInvoiceFormat.Error())
+		return
+	}
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="`+invoice.InvoiceNumber+"."+format+`"`)
+	ctx.Data(http.StatusOK, contentType, body)
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/money"
+	"inventory-management/services/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type invoiceHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl           *gomock.Controller
+	mockInvoiceService *mocks.MockInvoiceService
+	invoiceHandler     *invoiceHandler
+}
+
+func TestInvoiceHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(invoiceHandlerTestSuite))
+}
+
+func (suite *invoiceHandlerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockInvoiceService = mocks.NewMockInvoiceService(suite.mockCtrl)
+
+	suite.invoiceHandler = NewInvoiceHandler(suite.mockInvoiceService)
+}
+
+func (suite *invoiceHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func testInvoice() *dtos.Invoice {
+	return &dtos.Invoice{
+		InvoiceNumber: "INV-000001",
+		Type:          constants.InvoiceTypeInvoice,
+		OrderId:       "123",
+		IssuedAt:      time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+		Currency:      "INR",
+		Seller:        dtos.Party{Id: "seller", Name: "Acme Traders"},
+		Buyer:         dtos.Party{Id: "c1", Name: "John"},
+		Lines: []*dtos.InvoiceLines{
+			{LineNumber: 1, ArticleId: "a1", Description: "Widget", Quantity: 2,
+				UnitPrice: money.MustParse("50", "INR"), LineAmount: money.MustParse("100", "INR"),
+				DiscountAmount: money.Zero("INR"), NetAmount: money.MustParse("100", "INR"), TaxAmount: money.Zero("INR")},
+		},
+		Subtotal:       money.MustParse("100", "INR"),
+		DiscountAmount: money.Zero("INR"),
+		NetAmount:      money.MustParse("100", "INR"),
+		TaxAmount:      money.Zero("INR"),
+		TotalAmount:    money.MustParse("100", "INR"),
+	}
+}
+
+func (suite *invoiceHandlerTestSuite) getOrderInvoice(format string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/orders/123/invoice?format="+format, nil)
+
+	suite.invoiceHandler.GetOrderInvoice(c)
+
+	return w
+}
+
+func (suite *invoiceHandlerTestSuite) TestGetOrderInvoiceJSON() {
+	suite.mockInvoiceService.EXPECT().GetOrderInvoice(gomock.Any(), "123").Return(testInvoice(), nil).Times(1)
+
+	w := suite.getOrderInvoice("json")
+
+	var result *dtos.Invoice
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "INV-000001", result.InvoiceNumber)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *invoiceHandlerTestSuite) TestGetOrderInvoicePDF() {
+	suite.mockInvoiceService.EXPECT().GetOrderInvoice(gomock.Any(), "123").Return(testInvoice(), nil).Times(1)
+
+	w := suite.getOrderInvoice("pdf")
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(suite.T(), `attachment; filename="INV-000001.pdf"`, w.Header().Get("Content-Disposition"))
+	assert.True(suite.T(), bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")))
+}
+
+func (suite *invoiceHandlerTestSuite) TestGetOrderInvoiceUBL() {
+	suite.mockInvoiceService.EXPECT().GetOrderInvoice(gomock.Any(), "123").Return(testInvoice(), nil).Times(1)
+
+	w := suite.getOrderInvoice("ubl")
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "application/xml", w.Header().Get("Content-Type"))
+	assert.Contains(suite.T(), w.Body.String(), "<cbc:ID>INV-000001</cbc:ID>")
+}
+
+func (suite *invoiceHandlerTestSuite) TestGetOrderInvoiceBadFormat() {
+	suite.mockInvoiceService.EXPECT().GetOrderInvoice(gomock.Any(), "123").Return(testInvoice(), nil).Times(1)
+
+	w := suite.getOrderInvoice("docx")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *invoiceHandlerTestSuite) TestGetOrderInvoiceError() {
+	suite.mockInvoiceService.EXPECT().GetOrderInvoice(gomock.Any(), "123").Return(nil, constants.ErrorNotFound).Times(1)
+
+	w := suite.getOrderInvoice("json")
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *invoiceHandlerTestSuite) TestGetOrderCreditNotes() {
+	creditNote := testInvoice()
+	creditNote.InvoiceNumber = "CN-000001"
+	creditNote.Type = constants.InvoiceTypeCreditNote
+	creditNote.InvoiceRef = "INV-000001"
+
+	suite.mockInvoiceService.EXPECT().GetCreditNotes(gomock.Any(), "123").Return([]*dtos.Invoice{creditNote}, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/orders/123/credit-notes", nil)
+
+	suite.invoiceHandler.GetOrderCreditNotes(c)
+
+	var result []*dtos.Invoice
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), "INV-000001", result[0].InvoiceRef)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *invoiceHandlerTestSuite) TestGetInvoice() {
+	suite.mockInvoiceService.EXPECT().GetInvoice(gomock.Any(), "INV-000001").Return(testInvoice(), nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "number", Value: "INV-000001"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/invoices/INV-000001", nil)
+
+	suite.invoiceHandler.GetInvoice(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"inventory-management/constants"
 	"inventory-management/dtos"
 	"inventory-management/services/orders"
 	"net/http"
@@ -21,37 +23,44 @@ func NewOrderHandler(orderService orders.OrderService) *orderHandler {
 func (o *orderHandler) GetOrder(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	order, err := o.orderService.GetOrder(id)
+	order, err := o.orderService.GetOrder(ctx.Request.Context(), id)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	if notModified(ctx, order.Version) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, order)
 }
 
 func (o *orderHandler) CreateOrder(ctx *gin.Context) {
 	var req *dtos.Order
 
-	err := ctx.ShouldBindJSON(&req)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, err.Error())
+	if !bindJSON(ctx, &req) {
+		return
+	}
+
+	err := o.orderService.CreateOrder(ctx.Request.Context(), req)
+	if errors.Is(err, constants.ErrorRecordExists) {
+		ctx.JSON(http.StatusConflict, err.Error())
 		return
 	}
 
-	err = o.orderService.CreateOrder(req)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Order created successfully"})
+	ctx.JSON(http.StatusOK, gin.H{"message": "Order created successfully", "order_id": req.OrderId})
 }
 
 func (o *orderHandler) DeleteOrder(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	err := o.orderService.DeleteOrder(id)
+	err := o.orderService.DeleteOrder(ctx.Request.Context(), id)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
@@ -63,18 +72,65 @@ func (o *orderHandler) DeleteOrder(ctx *gin.Context) {
 func (o *orderHandler) UpdateOrder(ctx *gin.Context) {
 	id := ctx.Param("id")
 
+	version, ok := ifMatch(ctx)
+	if !ok {
+		return
+	}
+
 	var req dtos.Order
-	err := ctx.ShouldBindJSON(&req)
+	if !bindJSON(ctx, &req) {
+		return
+	}
+
+	req.Version = version
+	err := o.orderService.UpdateOrder(ctx.Request.Context(), id, &req)
+	updated(ctx, err, version, "Updated order successfully")
+}
+
+// PatchOrder applies a JSON merge patch to the order. Items are replaced as a
+// whole when the patch has them, as merge patches don't merge arrays.
+func (o *orderHandler) PatchOrder(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	version, ok := ifMatch(ctx)
+	if !ok {
+		return
+	}
+
+	current, err := o.orderService.GetOrder(ctx.Request.Context(), id)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, err.Error())
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if stale(ctx, current.Version, version) {
+		return
+	}
+
+	var req dtos.Order
+	if !mergePatch(ctx, current, &req) {
+		return
+	}
+
+	req.OrderId = id
+	req.Version = version
+	err = o.orderService.UpdateOrder(ctx.Request.Context(), id, &req)
+	updated(ctx, err, version, "Updated order successfully")
+}
+
+func (o *orderHandler) UpdateOrderStatus(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req dtos.UpdateOrderStatus
+	if !bindJSON(ctx, &req) {
 		return
 	}
 
-	err = o.orderService.UpdateOrder(id, &req)
+	err := o.orderService.UpdateOrderStatus(ctx.Request.Context(), id, &req)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Updated order successfully"})
+	ctx.JSON(http.StatusOK, gin.H{"message": "Updated order status successfully"})
 }
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"inventory-management/constants"
 	"inventory-management/dtos"
+	"inventory-management/money"
 	"inventory-management/services/mocks"
 	"net/http"
 	"net/http/httptest"
@@ -47,7 +50,7 @@ func (suite *orderHandlerTestSuite) TestGetOrder() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -65,7 +68,7 @@ func (suite *orderHandlerTestSuite) TestGetOrder() {
 		},
 	}
 
-	suite.mockOrderService.EXPECT().GetOrder("123").Return(expected, nil).Times(1)
+	suite.mockOrderService.EXPECT().GetOrder(gomock.Any(), "123").Return(expected, nil).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -87,7 +90,7 @@ func (suite *orderHandlerTestSuite) TestGetOrder() {
 }
 
 func (suite *orderHandlerTestSuite) TestGetOrderError() {
-	suite.mockOrderService.EXPECT().GetOrder("123").Return(nil, constants.ErrorNotFound).Times(1)
+	suite.mockOrderService.EXPECT().GetOrder(gomock.Any(), "123").Return(nil, constants.ErrorNotFound).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -113,7 +116,7 @@ func (suite *orderHandlerTestSuite) TestCreateOrder() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -138,10 +141,11 @@ func (suite *orderHandlerTestSuite) TestCreateOrder() {
 	c.Request = httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 
-	suite.mockOrderService.EXPECT().CreateOrder(gomock.AssignableToTypeOf(&dtos.Order{})).Return(nil).Times(1)
+	suite.mockOrderService.EXPECT().CreateOrder(gomock.Any(), gomock.AssignableToTypeOf(&dtos.Order{})).Return(nil).Times(1)
 
 	suite.orderHandler.CreateOrder(c)
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"order_id":"123"`)
 }
 
 func (suite *orderHandlerTestSuite) TestCreateOrderError() {
@@ -151,7 +155,7 @@ func (suite *orderHandlerTestSuite) TestCreateOrderError() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -176,12 +180,50 @@ func (suite *orderHandlerTestSuite) TestCreateOrderError() {
 	c.Request = httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 
-	suite.mockOrderService.EXPECT().CreateOrder(gomock.AssignableToTypeOf(&dtos.Order{})).Return(constants.ErrorRecordExists).Times(1)
+	suite.mockOrderService.EXPECT().CreateOrder(gomock.Any(), gomock.AssignableToTypeOf(&dtos.Order{})).Return(errors.New("db error")).Times(1)
 
 	suite.orderHandler.CreateOrder(c)
 	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
 }
 
+func (suite *orderHandlerTestSuite) TestCreateOrderExists() {
+	now := time.Now()
+
+	req := &dtos.Order{
+		OrderId:     "123",
+		CustomerId:  "234",
+		OrderedAt:   now,
+		TotalAmount: money.MustParse("200", "INR"),
+		NoOfItems:   2,
+		Items: []*dtos.OrderItems{
+			{
+				OrderItemId: "",
+				OrderId:     "123",
+				ArticleId:   "1",
+				Quantity:    1,
+			},
+			{
+				OrderItemId: "",
+				OrderId:     "123",
+				ArticleId:   "2",
+				Quantity:    1,
+			},
+		},
+	}
+
+	body, _ := json.Marshal(req)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockOrderService.EXPECT().CreateOrder(gomock.Any(), gomock.AssignableToTypeOf(&dtos.Order{})).Return(constants.ErrorRecordExists).Times(1)
+
+	suite.orderHandler.CreateOrder(c)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
 func (suite *orderHandlerTestSuite) TestCreateOrder_BadRequest() {
 	invalidJSON := `{"order_id": 123, "orderName": "Test Order", "price": "not_a_number", "stock": "50"}`
 
@@ -196,7 +238,7 @@ func (suite *orderHandlerTestSuite) TestCreateOrder_BadRequest() {
 }
 
 func (suite *orderHandlerTestSuite) TestDeleteOrder() {
-	suite.mockOrderService.EXPECT().DeleteOrder("123").Return(nil).Times(1)
+	suite.mockOrderService.EXPECT().DeleteOrder(gomock.Any(), "123").Return(nil).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -210,7 +252,7 @@ func (suite *orderHandlerTestSuite) TestDeleteOrder() {
 }
 
 func (suite *orderHandlerTestSuite) TestDeleteOrderError() {
-	suite.mockOrderService.EXPECT().DeleteOrder("123").Return(constants.ErrorNotFound).Times(1)
+	suite.mockOrderService.EXPECT().DeleteOrder(gomock.Any(), "123").Return(constants.ErrorNotFound).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -230,7 +272,7 @@ func (suite *orderHandlerTestSuite) TestUpdateOrder() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -257,13 +299,48 @@ func (suite *orderHandlerTestSuite) TestUpdateOrder() {
 	}
 	c.Request = httptest.NewRequest(http.MethodPut, "/orders/123", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
-	suite.mockOrderService.EXPECT().UpdateOrder("123", gomock.Any()).Return(nil).Times(1)
+	suite.mockOrderService.EXPECT().UpdateOrder(gomock.Any(), "123", gomock.Any()).Return(nil).Times(1)
 
 	suite.orderHandler.UpdateOrder(c)
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
 }
 
+func (suite *orderHandlerTestSuite) TestUpdateOrderItemOfAnotherOrder() {
+	req := &dtos.Order{
+		OrderId:     "123",
+		CustomerId:  "234",
+		OrderedAt:   time.Now(),
+		TotalAmount: money.MustParse("100", "INR"),
+		NoOfItems:   1,
+		Items: []*dtos.OrderItems{
+			{
+				OrderItemId: "i9",
+				OrderId:     "123",
+				ArticleId:   "1",
+				Quantity:    1,
+			},
+		},
+	}
+
+	body, _ := json.Marshal(req)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPut, "/orders/123", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
+
+	suite.mockOrderService.EXPECT().UpdateOrder(gomock.Any(), "123", gomock.Any()).Return(constants.ErrorRecordExists).Times(1)
+
+	suite.orderHandler.UpdateOrder(c)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
 func (suite *orderHandlerTestSuite) TestUpdateOrderError() {
 	now := time.Now()
 
@@ -271,7 +348,7 @@ func (suite *orderHandlerTestSuite) TestUpdateOrderError() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -298,8 +375,9 @@ func (suite *orderHandlerTestSuite) TestUpdateOrderError() {
 	}
 	c.Request = httptest.NewRequest(http.MethodPut, "/orders/123", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
-	suite.mockOrderService.EXPECT().UpdateOrder("123", gomock.Any()).Return(constants.ErrorNotFound).Times(1)
+	suite.mockOrderService.EXPECT().UpdateOrder(gomock.Any(), "123", gomock.Any()).Return(constants.ErrorNotFound).Times(1)
 
 	suite.orderHandler.UpdateOrder(c)
 	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
@@ -315,7 +393,125 @@ func (suite *orderHandlerTestSuite) TestUpdateOrderBadRequest() {
 	}
 	c.Request = httptest.NewRequest(http.MethodPut, "/orders/123", bytes.NewReader([]byte(invalidJSON)))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
 	suite.orderHandler.UpdateOrder(c)
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
 }
+
+func (suite *orderHandlerTestSuite) TestUpdateOrderStatus() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPut, "/orders/123/status", bytes.NewReader([]byte(`{"status":"confirmed"}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockOrderService.EXPECT().UpdateOrderStatus(gomock.Any(), "123", &dtos.UpdateOrderStatus{Status: "confirmed"}).Return(nil).Times(1)
+
+	suite.orderHandler.UpdateOrderStatus(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *orderHandlerTestSuite) TestUpdateOrderStatusError() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPut, "/orders/123/status", bytes.NewReader([]byte(`{"status":"shipped"}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockOrderService.EXPECT().UpdateOrderStatus(gomock.Any(), "123", gomock.Any()).Return(constants.ErrorInvalidOrderStatus).Times(1)
+
+	suite.orderHandler.UpdateOrderStatus(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *orderHandlerTestSuite) TestPatchOrder() {
+	current := &dtos.Order{
+		OrderId:    "123",
+		CustomerId: "234",
+		CouponCode: "SAVE10",
+		Version:    1,
+		Items: []*dtos.OrderItems{
+			{OrderItemId: "1", OrderId: "123", ArticleId: "1", Quantity: 1},
+			{OrderItemId: "2", OrderId: "123", ArticleId: "2", Quantity: 1},
+		},
+	}
+
+	suite.mockOrderService.EXPECT().GetOrder(gomock.Any(), "123").Return(current, nil).Times(1)
+	suite.mockOrderService.EXPECT().UpdateOrder(gomock.Any(), "123", gomock.Any()).DoAndReturn(func(_ context.Context, id string, req *dtos.Order) error {
+		assert.Equal(suite.T(), "123", req.OrderId)
+		assert.Equal(suite.T(), "234", req.CustomerId)
+		assert.Equal(suite.T(), "", req.CouponCode)
+		assert.Len(suite.T(), req.Items, 1)
+		assert.Equal(suite.T(), 3, req.Items[0].Quantity)
+		return nil
+	}).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	body := `{"coupon_code":"","items":[{"order_item_id":"1","article_id":"1","quantity":3}]}`
+	c.Request = httptest.NewRequest(http.MethodPatch, "/orders/123", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/merge-patch+json")
+	c.Request.Header.Set("If-Match", `"1"`)
+
+	suite.orderHandler.PatchOrder(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *orderHandlerTestSuite) TestCreateOrderValidationFailed() {
+	body := `{"items":[{"article_id":"1","quantity":0},{"article_id":"1","quantity":2}]}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.orderHandler.CreateOrder(c)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, w.Code)
+
+	var result dtos.ValidationErrors
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.ErrorValidationFailed.Error(), result.Message)
+	assert.ElementsMatch(suite.T(), []*dtos.FieldError{
+		{Field: "customer_id", Rule: "required"},
+		{Field: "items", Rule: "unique", Param: "ArticleId"},
+	}, result.Errors)
+}
+
+func (suite *orderHandlerTestSuite) TestCreateOrderInvalidItem() {
+	body := `{"customer_id":"234","items":[{"article_id":"1","quantity":-1}]}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.orderHandler.CreateOrder(c)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, w.Code)
+
+	var result dtos.ValidationErrors
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []*dtos.FieldError{
+		{Field: "items[0].quantity", Rule: "gt", Param: "0"},
+	}, result.Errors)
+}
+
+func (suite *orderHandlerTestSuite) TestCreateOrderWithoutItems() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(`{"customer_id":"234","items":[]}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.orderHandler.CreateOrder(c)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `{"field":"items","rule":"min","param":"1"}`)
+}
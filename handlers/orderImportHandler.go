@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"inventory-management/dtos"
+	"inventory-management/services/orders"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type orderImportHandler struct {
+	orderImportService orders.OrderImportService
+}
+
+func NewOrderImportHandler(orderImportService orders.OrderImportService) *orderImportHandler {
+	return &orderImportHandler{
+		orderImportService: orderImportService,
+	}
+}
+
+// ImportOrders takes the file as an upload and answers with the report once
+// every order in it has been created or rejected.
+func (o *orderImportHandler) ImportOrders(ctx *gin.Context) {
+	format, mapping, data, ok := upload(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := o.orderImportService.ImportOrders(ctx.Request.Context(), &dtos.OrderImport{Format: format, Mapping: mapping}, data)
+	if err != nil {
+		if badUpload(err) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type orderImportHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl               *gomock.Controller
+	mockOrderImportService *mocks.MockOrderImportService
+	orderImportHandler     *orderImportHandler
+}
+
+func TestOrderImportHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(orderImportHandlerTestSuite))
+}
+
+func (suite *orderImportHandlerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockOrderImportService = mocks.NewMockOrderImportService(suite.mockCtrl)
+
+	suite.orderImportHandler = NewOrderImportHandler(suite.mockOrderImportService)
+}
+
+func (suite *orderImportHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *orderImportHandlerTestSuite) TestImportOrders() {
+	body, contentType := uploadBody("orders.csv", "po,customer_id,article_id,quantity\n", map[string]string{
+		"mapping": `{"order_reference":"po"}`,
+	})
+
+	expected := &dtos.OrderImportReport{
+		TotalRows: 1,
+		Created:   []*dtos.ImportedOrder{{Reference: "po-1", OrderId: "o1", Lines: []int{2}}},
+	}
+	suite.mockOrderImportService.EXPECT().ImportOrders(gomock.Any(), &dtos.OrderImport{
+		Format:  "csv",
+		Mapping: map[string]string{"order_reference": "po"},
+	}, []byte("po,customer_id,article_id,quantity\n")).Return(expected, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/import", body)
+	c.Request.Header.Set("Content-Type", contentType)
+
+	suite.orderImportHandler.ImportOrders(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var result dtos.OrderImportReport
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "o1", result.Created[0].OrderId)
+}
+
+func (suite *orderImportHandlerTestSuite) TestImportOrdersMissingColumn() {
+	body, contentType := uploadBody("orders.csv", "customer_id\n", nil)
+
+	suite.mockOrderImportService.EXPECT().ImportOrders(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, constants.ErrorImportColumnMissing).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/import", body)
+	c.Request.Header.Set("Content-Type", contentType)
+
+	suite.orderImportHandler.ImportOrders(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *orderImportHandlerTestSuite) TestImportOrdersError() {
+	body, contentType := uploadBody("orders.csv", "order_reference\n", nil)
+
+	suite.mockOrderImportService.EXPECT().ImportOrders(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("service error")).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/import", body)
+	c.Request.Header.Set("Content-Type", contentType)
+
+	suite.orderImportHandler.ImportOrders(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
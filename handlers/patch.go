@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"inventory-management/constants"
+	"inventory-management/mergepatch"
+	"inventory-management/validation"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+// mergePatch applies the request body as an RFC 7396 merge patch to current and
+// binds the result into target, validating it like a full PUT body. Members
+// left out of the patch keep their current value while explicit nulls and
+// zeros are applied. It answers the request itself and returns false when the
+// patch can't be applied.
+func mergePatch(ctx *gin.Context, current any, target any) bool {
+	contentType := ctx.ContentType()
+	if contentType != mergePatchContentType && contentType != binding.MIMEJSON {
+		ctx.JSON(http.StatusUnsupportedMediaType, constants.ErrorUnsupportedMediaType.Error())
+		return false
+	}
+
+	patch, err := ctx.GetRawData()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	document, err := json.Marshal(current)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	merged, err := mergepatch.Apply(document, patch)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	err = json.Unmarshal(merged, target)
+	if err == nil {
+		err = validation.Struct(target)
+	}
+	if err != nil {
+		invalid(ctx, err)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/payments"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type paymentHandler struct {
+	paymentService payments.PaymentService
+}
+
+func NewPaymentHandler(paymentService payments.PaymentService) *paymentHandler {
+	return &paymentHandler{
+		paymentService: paymentService,
+	}
+}
+
+func (p *paymentHandler) RecordPayment(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req *dtos.Payment
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = p.paymentService.RecordPayment(ctx.Request.Context(), id, req)
+	if errors.Is(err, constants.ErrorRecordExists) {
+		ctx.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Payment recorded successfully", "payment_id": req.PaymentId})
+}
+
+func (p *paymentHandler) GetOrderPayments(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	balance, err := p.paymentService.GetOrderPayments(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, balance)
+}
+
+func (p *paymentHandler) SettleRefunds(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	err := p.paymentService.SettleRefunds(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Refunds settled successfully"})
+}
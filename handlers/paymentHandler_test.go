@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/money"
+	"inventory-management/services/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type paymentHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl           *gomock.Controller
+	mockPaymentService *mocks.MockPaymentService
+	paymentHandler     *paymentHandler
+}
+
+func TestPaymentHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(paymentHandlerTestSuite))
+}
+
+func (suite *paymentHandlerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockPaymentService = mocks.NewMockPaymentService(suite.mockCtrl)
+
+	suite.paymentHandler = NewPaymentHandler(suite.mockPaymentService)
+}
+
+func (suite *paymentHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *paymentHandlerTestSuite) TestRecordPayment() {
+	body := `{"method":"card","amount":{"amount":"100","currency":"INR"}}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/o1/payments", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockPaymentService.EXPECT().RecordPayment(gomock.Any(), "o1", gomock.Any()).DoAndReturn(func(_ context.Context, orderId string, req *dtos.Payment) error {
+		assert.Equal(suite.T(), "100.00 INR", req.Amount.String())
+		return nil
+	}).Times(1)
+
+	suite.paymentHandler.RecordPayment(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *paymentHandlerTestSuite) TestRecordPaymentError() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/o1/payments", bytes.NewReader([]byte(`{"method":"card","amount":100}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockPaymentService.EXPECT().RecordPayment(gomock.Any(), "o1", gomock.Any()).Return(constants.ErrorPaymentFailed).Times(1)
+
+	suite.paymentHandler.RecordPayment(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *paymentHandlerTestSuite) TestRecordPaymentBadRequest() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/o1/payments", bytes.NewReader([]byte(`{"method": 12}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.paymentHandler.RecordPayment(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *paymentHandlerTestSuite) TestGetOrderPayments() {
+	expected := &dtos.OrderBalance{
+		OrderId:     "o1",
+		TotalAmount: money.MustParse("100", "INR"),
+		Paid:        money.MustParse("40", "INR"),
+		Balance:     money.MustParse("60", "INR"),
+		Payments:    []*dtos.Payment{},
+	}
+
+	suite.mockPaymentService.EXPECT().GetOrderPayments(gomock.Any(), "o1").Return(expected, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/orders/o1/payments", nil)
+
+	suite.paymentHandler.GetOrderPayments(c)
+
+	var result *dtos.OrderBalance
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "60.00 INR", result.Balance.String())
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *paymentHandlerTestSuite) TestRecordPaymentSettled() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/o1/payments", bytes.NewReader([]byte(`{"payment_id":"p1","method":"card","amount":100}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockPaymentService.EXPECT().RecordPayment(gomock.Any(), "o1", gomock.Any()).Return(constants.ErrorRecordExists).Times(1)
+
+	suite.paymentHandler.RecordPayment(c)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *paymentHandlerTestSuite) TestSettleRefunds() {
+	suite.mockPaymentService.EXPECT().SettleRefunds(gomock.Any(), "o1").Return(nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/o1/refunds", nil)
+
+	suite.paymentHandler.SettleRefunds(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"inventory-management/dtos"
+	"inventory-management/services/pricelists"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type priceListHandler struct {
+	priceListService pricelists.PriceListService
+}
+
+func NewPriceListHandler(priceListService pricelists.PriceListService) *priceListHandler {
+	return &priceListHandler{
+		priceListService: priceListService,
+	}
+}
+
+func (p *priceListHandler) GetPriceList(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	priceList, err := p.priceListService.GetPriceList(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, priceList)
+}
+
+func (p *priceListHandler) CreatePriceList(ctx *gin.Context) {
+	var req *dtos.PriceList
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = p.priceListService.CreatePriceList(ctx.Request.Context(), req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Price list created successfully", "price_list_id": req.PriceListId})
+}
+
+func (p *priceListHandler) DeletePriceList(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	err := p.priceListService.DeletePriceList(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Price list deleted successfully"})
+}
+
+func (p *priceListHandler) UpdatePriceList(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req dtos.PriceList
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = p.priceListService.UpdatePriceList(ctx.Request.Context(), id, &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Updated price list successfully"})
+}
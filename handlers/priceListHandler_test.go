@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type priceListHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl             *gomock.Controller
+	mockPriceListService *mocks.MockPriceListService
+	priceListHandler     *priceListHandler
+}
+
+func TestPriceListHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(priceListHandlerTestSuite))
+}
+
+func (suite *priceListHandlerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockPriceListService = mocks.NewMockPriceListService(suite.mockCtrl)
+
+	suite.priceListHandler = NewPriceListHandler(suite.mockPriceListService)
+}
+
+func (suite *priceListHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *priceListHandlerTestSuite) TestGetPriceList() {
+	expected := &dtos.PriceList{
+		PriceListId: "pl1",
+		Name:        "Wholesale",
+		Currency:    "INR",
+		CustomerId:  "c1",
+		Items:       []*dtos.PriceListItems{},
+	}
+
+	suite.mockPriceListService.EXPECT().GetPriceList(gomock.Any(), "pl1").Return(expected, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "pl1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/price-lists/pl1", nil)
+
+	suite.priceListHandler.GetPriceList(c)
+
+	var result *dtos.PriceList
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected.Name, result.Name)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *priceListHandlerTestSuite) TestGetPriceListError() {
+	suite.mockPriceListService.EXPECT().GetPriceList(gomock.Any(), "pl1").Return(nil, constants.ErrorNotFound).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "pl1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/price-lists/pl1", nil)
+
+	suite.priceListHandler.GetPriceList(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *priceListHandlerTestSuite) TestCreatePriceList() {
+	body := `{"name":"Wholesale","currency":"INR","customer_group":"wholesale","items":[{"article_id":"a1","min_quantity":10,"discount_percent":"5"}]}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/price-lists", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockPriceListService.EXPECT().CreatePriceList(gomock.Any(), gomock.AssignableToTypeOf(&dtos.PriceList{})).Return(nil).Times(1)
+
+	suite.priceListHandler.CreatePriceList(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *priceListHandlerTestSuite) TestCreatePriceListBadRequest() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/price-lists", bytes.NewReader([]byte(`{"name": 12}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.priceListHandler.CreatePriceList(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *priceListHandlerTestSuite) TestDeletePriceList() {
+	suite.mockPriceListService.EXPECT().DeletePriceList(gomock.Any(), "pl1").Return(nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "pl1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodDelete, "/price-lists/pl1", nil)
+
+	suite.priceListHandler.DeletePriceList(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *priceListHandlerTestSuite) TestUpdatePriceListError() {
+	body := `{"name":"Wholesale","currency":"INR","customer_id":"c1"}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "pl1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPut, "/price-lists/pl1", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockPriceListService.EXPECT().UpdatePriceList(gomock.Any(), "pl1", gomock.Any()).Return(constants.ErrorNotFound).Times(1)
+
+	suite.priceListHandler.UpdatePriceList(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
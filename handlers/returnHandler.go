@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"inventory-management/dtos"
+	"inventory-management/services/returns"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type returnHandler struct {
+	returnService returns.ReturnService
+}
+
+func NewReturnHandler(returnService returns.ReturnService) *returnHandler {
+	return &returnHandler{
+		returnService: returnService,
+	}
+}
+
+func (r *returnHandler) CreateReturn(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req *dtos.Return
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = r.returnService.CreateReturn(ctx.Request.Context(), id, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Return requested successfully", "return_id": req.ReturnId})
+}
+
+func (r *returnHandler) GetOrderReturns(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	rets, err := r.returnService.GetOrderReturns(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rets)
+}
+
+func (r *returnHandler) GetReturn(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	ret, err := r.returnService.GetReturn(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ret)
+}
+
+func (r *returnHandler) UpdateReturnStatus(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req dtos.UpdateReturnStatus
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = r.returnService.UpdateReturnStatus(ctx.Request.Context(), id, &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Updated return status successfully"})
+}
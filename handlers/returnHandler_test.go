@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type returnHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl          *gomock.Controller
+	mockReturnService *mocks.MockReturnService
+	returnHandler     *returnHandler
+}
+
+func TestReturnHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(returnHandlerTestSuite))
+}
+
+func (suite *returnHandlerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockReturnService = mocks.NewMockReturnService(suite.mockCtrl)
+
+	suite.returnHandler = NewReturnHandler(suite.mockReturnService)
+}
+
+func (suite *returnHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *returnHandlerTestSuite) TestCreateReturn() {
+	body := `{"reason":"wrong size","items":[{"order_item_id":"i1","quantity":1}]}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/o1/returns", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockReturnService.EXPECT().CreateReturn(gomock.Any(), "o1", gomock.Any()).DoAndReturn(func(_ context.Context, orderId string, req *dtos.Return) error {
+		req.ReturnId = "r1"
+		return nil
+	}).Times(1)
+
+	suite.returnHandler.CreateReturn(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"return_id":"r1"`)
+}
+
+func (suite *returnHandlerTestSuite) TestCreateReturnError() {
+	body := `{"items":[{"order_item_id":"i1","quantity":5}]}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/o1/returns", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockReturnService.EXPECT().CreateReturn(gomock.Any(), "o1", gomock.Any()).Return(constants.ErrorReturnExceedsShipped).Times(1)
+
+	suite.returnHandler.CreateReturn(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *returnHandlerTestSuite) TestCreateReturnBadRequest() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/o1/returns", bytes.NewReader([]byte(`{"items": "i1"}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.returnHandler.CreateReturn(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *returnHandlerTestSuite) TestGetOrderReturns() {
+	suite.mockReturnService.EXPECT().GetOrderReturns(gomock.Any(), "o1").Return([]*dtos.Return{{ReturnId: "r1", OrderId: "o1"}}, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/orders/o1/returns", nil)
+
+	suite.returnHandler.GetOrderReturns(c)
+
+	var result []*dtos.Return
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *returnHandlerTestSuite) TestGetReturnError() {
+	suite.mockReturnService.EXPECT().GetReturn(gomock.Any(), "r1").Return(nil, constants.ErrorNotFound).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "r1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/returns/r1", nil)
+
+	suite.returnHandler.GetReturn(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *returnHandlerTestSuite) TestUpdateReturnStatus() {
+	body := `{"status":"inspected","items":[{"return_item_id":"ri1","restocked_quantity":1}]}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "r1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPut, "/returns/r1/status", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockReturnService.EXPECT().UpdateReturnStatus(gomock.Any(), "r1", &dtos.UpdateReturnStatus{
+		Status: "inspected",
+		Items:  []*dtos.ReturnItems{{ReturnItemId: "ri1", RestockedQuantity: 1}},
+	}).Return(nil).Times(1)
+
+	suite.returnHandler.UpdateReturnStatus(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"inventory-management/dtos"
+	"inventory-management/services/shipments"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type shipmentHandler struct {
+	shipmentService shipments.ShipmentService
+}
+
+func NewShipmentHandler(shipmentService shipments.ShipmentService) *shipmentHandler {
+	return &shipmentHandler{
+		shipmentService: shipmentService,
+	}
+}
+
+func (s *shipmentHandler) CreateShipment(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req *dtos.Shipment
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = s.shipmentService.CreateShipment(ctx.Request.Context(), id, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Shipment created successfully", "shipment_id": req.ShipmentId})
+}
+
+func (s *shipmentHandler) GetOrderShipments(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	fulfilment, err := s.shipmentService.GetOrderShipments(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fulfilment)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type shipmentHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl            *gomock.Controller
+	mockShipmentService *mocks.MockShipmentService
+	shipmentHandler     *shipmentHandler
+}
+
+func TestShipmentHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(shipmentHandlerTestSuite))
+}
+
+func (suite *shipmentHandlerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockShipmentService = mocks.NewMockShipmentService(suite.mockCtrl)
+
+	suite.shipmentHandler = NewShipmentHandler(suite.mockShipmentService)
+}
+
+func (suite *shipmentHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *shipmentHandlerTestSuite) TestCreateShipment() {
+	body := `{"carrier":"UPS","tracking_number":"1Z999","items":[{"order_item_id":"i1","quantity":1}]}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/o1/shipments", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockShipmentService.EXPECT().CreateShipment(gomock.Any(), "o1", gomock.Any()).Return(nil).Times(1)
+
+	suite.shipmentHandler.CreateShipment(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *shipmentHandlerTestSuite) TestCreateShipmentError() {
+	body := `{"carrier":"UPS","items":[{"order_item_id":"i1","quantity":10}]}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/o1/shipments", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockShipmentService.EXPECT().CreateShipment(gomock.Any(), "o1", gomock.Any()).Return(constants.ErrorShipmentExceedsAllocated).Times(1)
+
+	suite.shipmentHandler.CreateShipment(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *shipmentHandlerTestSuite) TestCreateShipmentBadRequest() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/o1/shipments", bytes.NewReader([]byte(`{"shipped_at": "yesterday"}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.shipmentHandler.CreateShipment(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *shipmentHandlerTestSuite) TestGetOrderShipments() {
+	expected := &dtos.OrderFulfilment{
+		OrderId:          "o1",
+		FulfilmentStatus: constants.FulfilmentStatusPartial,
+		Shipments:        []*dtos.Shipment{{ShipmentId: "s1", OrderId: "o1"}},
+	}
+
+	suite.mockShipmentService.EXPECT().GetOrderShipments(gomock.Any(), "o1").Return(expected, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "o1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/orders/o1/shipments", nil)
+
+	suite.shipmentHandler.GetOrderShipments(c)
+
+	var result *dtos.OrderFulfilment
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.FulfilmentStatusPartial, result.FulfilmentStatus)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
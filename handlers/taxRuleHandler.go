@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"inventory-management/dtos"
+	"inventory-management/services/taxes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type taxRuleHandler struct {
+	taxService taxes.TaxService
+}
+
+func NewTaxRuleHandler(taxService taxes.TaxService) *taxRuleHandler {
+	return &taxRuleHandler{
+		taxService: taxService,
+	}
+}
+
+func (t *taxRuleHandler) GetTaxRule(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	taxRule, err := t.taxService.GetTaxRule(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, taxRule)
+}
+
+func (t *taxRuleHandler) CreateTaxRule(ctx *gin.Context) {
+	var req *dtos.TaxRule
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = t.taxService.CreateTaxRule(ctx.Request.Context(), req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Tax rule created successfully", "tax_rule_id": req.TaxRuleId})
+}
+
+func (t *taxRuleHandler) DeleteTaxRule(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	err := t.taxService.DeleteTaxRule(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Tax rule deleted successfully"})
+}
+
+func (t *taxRuleHandler) UpdateTaxRule(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req dtos.TaxRule
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = t.taxService.UpdateTaxRule(ctx.Request.Context(), id, &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Updated tax rule successfully"})
+}
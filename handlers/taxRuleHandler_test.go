@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/services/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type taxRuleHandlerTestSuite struct {
+	suite.Suite
+	mockCtrl       *gomock.Controller
+	mockTaxService *mocks.MockTaxService
+	taxRuleHandler *taxRuleHandler
+}
+
+func TestTaxRuleHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(taxRuleHandlerTestSuite))
+}
+
+func (suite *taxRuleHandlerTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockTaxService = mocks.NewMockTaxService(suite.mockCtrl)
+
+	suite.taxRuleHandler = NewTaxRuleHandler(suite.mockTaxService)
+}
+
+func (suite *taxRuleHandlerTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *taxRuleHandlerTestSuite) TestGetTaxRule() {
+	expected := &dtos.TaxRule{
+		TaxRuleId: "t1",
+		Name:      "GST",
+		Country:   "IN",
+		TaxClass:  "standard",
+		Rate:      decimal.NewFromInt(18),
+	}
+
+	suite.mockTaxService.EXPECT().GetTaxRule(gomock.Any(), "t1").Return(expected, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "t1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/tax-rules/t1", nil)
+
+	suite.taxRuleHandler.GetTaxRule(c)
+
+	var result *dtos.TaxRule
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected.Name, result.Name)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *taxRuleHandlerTestSuite) TestGetPriceListError() {
+	suite.mockTaxService.EXPECT().GetTaxRule(gomock.Any(), "t1").Return(nil, constants.ErrorNotFound).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "t1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodGet, "/tax-rules/t1", nil)
+
+	suite.taxRuleHandler.GetTaxRule(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *taxRuleHandlerTestSuite) TestCreateTaxRule() {
+	body := `{"name":"GST","country":"IN","state":"KA","tax_class":"standard","rate":"18"}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/tax-rules", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockTaxService.EXPECT().CreateTaxRule(gomock.Any(), gomock.AssignableToTypeOf(&dtos.TaxRule{})).Return(nil).Times(1)
+
+	suite.taxRuleHandler.CreateTaxRule(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *taxRuleHandlerTestSuite) TestCreatePriceListBadRequest() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/tax-rules", bytes.NewReader([]byte(`{"name": 12}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.taxRuleHandler.CreateTaxRule(c)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *taxRuleHandlerTestSuite) TestDeleteTaxRule() {
+	suite.mockTaxService.EXPECT().DeleteTaxRule(gomock.Any(), "t1").Return(nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "t1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodDelete, "/tax-rules/t1", nil)
+
+	suite.taxRuleHandler.DeleteTaxRule(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *taxRuleHandlerTestSuite) TestUpdatePriceListError() {
+	body := `{"name":"GST","country":"IN","tax_class":"standard","rate":"12"}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "t1"},
+	}
+	c.Request = httptest.NewRequest(http.MethodPut, "/tax-rules/t1", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockTaxService.EXPECT().UpdateTaxRule(gomock.Any(), "t1", gomock.Any()).Return(constants.ErrorNotFound).Times(1)
+
+	suite.taxRuleHandler.UpdateTaxRule(c)
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"inventory-management/constants"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// upload reads an import file sent as a multipart form with the file in
+// "file". The format defaults to the file extension and "mapping" is a JSON
+// object of field to column header. It answers the request itself and returns
+// false when the upload can't be read.
+func upload(ctx *gin.Context) (format string, mapping map[string]string, data []byte, ok bool) {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return "", nil, nil, false
+	}
+
+	format = ctx.PostForm("format")
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(header.Filename), ".")
+	}
+
+	if value := ctx.PostForm("mapping"); value != "" {
+		err = json.Unmarshal([]byte(value), &mapping)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, constants.ErrorInvalidColumnMapping.Error())
+			return "", nil, nil, false
+		}
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return "", nil, nil, false
+	}
+	defer file.Close()
+
+	data, err = io.ReadAll(file)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return "", nil, nil, false
+	}
+
+	return format, mapping, data, true
+}
+
+// badUpload reports whether err means the file itself can't be imported.
+func badUpload(err error) bool {
+	return errors.Is(err, constants.ErrorUnsupportedFileFormat) || errors.Is(err, constants.ErrorImportFileEmpty) ||
+		errors.Is(err, constants.ErrorImportColumnMissing) || errors.Is(err, constants.ErrorInvalidColumnMapping)
+}
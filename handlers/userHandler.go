@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"inventory-management/constants"
 	"inventory-management/dtos"
 	"inventory-management/services/users"
 	"net/http"
@@ -21,25 +23,32 @@ func NewUserHandler(userService users.UserService) *userHandler {
 func (c *userHandler) GetUser(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	user, err := c.userService.GetUser(id)
+	user, err := c.userService.GetUser(ctx.Request.Context(), id)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	if notModified(ctx, user.Version) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, user)
 }
 
 func (c *userHandler) CreateUser(ctx *gin.Context) {
 	var req *dtos.User
 
-	err := ctx.ShouldBindJSON(&req)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, err.Error())
+	if !bindJSON(ctx, &req) {
+		return
+	}
+
+	err := c.userService.CreateUser(ctx.Request.Context(), req)
+	if errors.Is(err, constants.ErrorRecordExists) {
+		ctx.JSON(http.StatusConflict, err.Error())
 		return
 	}
 
-	err = c.userService.CreateUser(req)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
@@ -51,7 +60,7 @@ func (c *userHandler) CreateUser(ctx *gin.Context) {
 func (c *userHandler) DeleteUser(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	err := c.userService.DeleteUser(id)
+	err := c.userService.DeleteUser(ctx.Request.Context(), id)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
@@ -63,18 +72,47 @@ func (c *userHandler) DeleteUser(ctx *gin.Context) {
 func (c *userHandler) UpdateUser(ctx *gin.Context) {
 	id := ctx.Param("id")
 
+	version, ok := ifMatch(ctx)
+	if !ok {
+		return
+	}
+
 	var req dtos.User
-	err := ctx.ShouldBindJSON(&req)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, err.Error())
+	if !bindJSON(ctx, &req) {
 		return
 	}
 
-	err = c.userService.UpdateUser(id, &req)
+	req.Version = version
+	err := c.userService.UpdateUser(ctx.Request.Context(), id, &req)
+	updated(ctx, err, version, "Updated user successfully")
+}
+
+// PatchUser applies a JSON merge patch to the user and their address.
+func (c *userHandler) PatchUser(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	version, ok := ifMatch(ctx)
+	if !ok {
+		return
+	}
+
+	current, err := c.userService.GetUser(ctx.Request.Context(), id)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Updated user successfully"})
+	if stale(ctx, current.Version, version) {
+		return
+	}
+
+	var req dtos.User
+	if !mergePatch(ctx, current, &req) {
+		return
+	}
+
+	req.Address.AddressId = current.Address.AddressId
+	req.Version = version
+	err = c.userService.UpdateUser(ctx.Request.Context(), id, &req)
+	updated(ctx, err, version, "Updated user successfully")
 }
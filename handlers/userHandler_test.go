@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"inventory-management/constants"
 	"inventory-management/dtos"
 	"inventory-management/services/mocks"
@@ -57,7 +59,7 @@ func (suite *userHandlerTestSuite) TestGetUser() {
 		Role: constants.RoleCustomer,
 	}
 
-	suite.mockUserService.EXPECT().GetUser("123").Return(expected, nil).Times(1)
+	suite.mockUserService.EXPECT().GetUser(gomock.Any(), "123").Return(expected, nil).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -78,7 +80,7 @@ func (suite *userHandlerTestSuite) TestGetUser() {
 }
 
 func (suite *userHandlerTestSuite) TestGetUserError() {
-	suite.mockUserService.EXPECT().GetUser("123").Return(nil, constants.ErrorNotFound).Times(1)
+	suite.mockUserService.EXPECT().GetUser(gomock.Any(), "123").Return(nil, constants.ErrorNotFound).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -122,7 +124,7 @@ func (suite *userHandlerTestSuite) TestCreateUser() {
 	c.Request = httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 
-	suite.mockUserService.EXPECT().CreateUser(gomock.AssignableToTypeOf(&dtos.User{})).Return(nil).Times(1)
+	suite.mockUserService.EXPECT().CreateUser(gomock.Any(), gomock.AssignableToTypeOf(&dtos.User{})).Return(nil).Times(1)
 
 	suite.userHandler.CreateUser(c)
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
@@ -153,12 +155,43 @@ func (suite *userHandlerTestSuite) TestCreateUserError() {
 	c.Request = httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 
-	suite.mockUserService.EXPECT().CreateUser(gomock.AssignableToTypeOf(&dtos.User{})).Return(constants.ErrorRecordExists).Times(1)
+	suite.mockUserService.EXPECT().CreateUser(gomock.Any(), gomock.AssignableToTypeOf(&dtos.User{})).Return(errors.New("db error")).Times(1)
 
 	suite.userHandler.CreateUser(c)
 	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
 }
 
+func (suite *userHandlerTestSuite) TestCreateUserExists() {
+	req := &dtos.User{
+		Id:     "123",
+		Name:   "John",
+		Email:  "john@abc.com",
+		Mobile: "12345",
+		Address: dtos.Address{
+			AddressId: "5",
+			Line1:     "12",
+			Line2:     "park street",
+			City:      "chennai",
+			State:     "tn",
+			Country:   "in",
+			ZipCode:   "600001",
+		},
+		Role: constants.RoleCustomer,
+	}
+
+	body, _ := json.Marshal(req)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.mockUserService.EXPECT().CreateUser(gomock.Any(), gomock.AssignableToTypeOf(&dtos.User{})).Return(constants.ErrorRecordExists).Times(1)
+
+	suite.userHandler.CreateUser(c)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
 func (suite *userHandlerTestSuite) TestCreateUser_BadRequest() {
 	invalidJSON := `{"id": 123, "userName": "Test User", "price": "not_a_number", "stock": "50"}`
 
@@ -173,7 +206,7 @@ func (suite *userHandlerTestSuite) TestCreateUser_BadRequest() {
 }
 
 func (suite *userHandlerTestSuite) TestDeleteUser() {
-	suite.mockUserService.EXPECT().DeleteUser("123").Return(nil).Times(1)
+	suite.mockUserService.EXPECT().DeleteUser(gomock.Any(), "123").Return(nil).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -187,7 +220,7 @@ func (suite *userHandlerTestSuite) TestDeleteUser() {
 }
 
 func (suite *userHandlerTestSuite) TestDeleteUserError() {
-	suite.mockUserService.EXPECT().DeleteUser("123").Return(constants.ErrorNotFound).Times(1)
+	suite.mockUserService.EXPECT().DeleteUser(gomock.Any(), "123").Return(constants.ErrorNotFound).Times(1)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -227,8 +260,9 @@ func (suite *userHandlerTestSuite) TestUpdateUser() {
 	}
 	c.Request = httptest.NewRequest(http.MethodPut, "/users/123", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
-	suite.mockUserService.EXPECT().UpdateUser("123", gomock.Any()).Return(nil).Times(1)
+	suite.mockUserService.EXPECT().UpdateUser(gomock.Any(), "123", gomock.Any()).Return(nil).Times(1)
 
 	suite.userHandler.UpdateUser(c)
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
@@ -261,8 +295,9 @@ func (suite *userHandlerTestSuite) TestUpdateUserError() {
 	}
 	c.Request = httptest.NewRequest(http.MethodPut, "/users/123", bytes.NewReader(body))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
-	suite.mockUserService.EXPECT().UpdateUser("123", gomock.Any()).Return(constants.ErrorNotFound).Times(1)
+	suite.mockUserService.EXPECT().UpdateUser(gomock.Any(), "123", gomock.Any()).Return(constants.ErrorNotFound).Times(1)
 
 	suite.userHandler.UpdateUser(c)
 	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
@@ -278,7 +313,69 @@ func (suite *userHandlerTestSuite) TestUpdateUserBadRequest() {
 	}
 	c.Request = httptest.NewRequest(http.MethodPut, "/users/123", bytes.NewReader([]byte(invalidJSON)))
 	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"3"`)
 
 	suite.userHandler.UpdateUser(c)
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
 }
+
+func (suite *userHandlerTestSuite) TestPatchUser() {
+	current := &dtos.User{
+		Id:     "123",
+		Name:   "John",
+		Email:  "john@abc.com",
+		Mobile: "12345",
+		Address: dtos.Address{
+			AddressId: "5",
+			City:      "chennai",
+			Country:   "in",
+		},
+		Role:    constants.RoleCustomer,
+		Version: 2,
+	}
+
+	suite.mockUserService.EXPECT().GetUser(gomock.Any(), "123").Return(current, nil).Times(1)
+	suite.mockUserService.EXPECT().UpdateUser(gomock.Any(), "123", gomock.Any()).DoAndReturn(func(_ context.Context, id string, req *dtos.User) error {
+		assert.Equal(suite.T(), "John", req.Name)
+		assert.Equal(suite.T(), "", req.Mobile)
+		assert.Equal(suite.T(), "5", req.Address.AddressId)
+		assert.Equal(suite.T(), "madurai", req.Address.City)
+		assert.Equal(suite.T(), "in", req.Address.Country)
+		assert.Equal(suite.T(), int64(2), req.Version)
+		return nil
+	}).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{
+		{Key: "id", Value: "123"},
+	}
+	body := `{"mobile":null,"address":{"address_id":"9","city":"madurai"}}`
+	c.Request = httptest.NewRequest(http.MethodPatch, "/users/123", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/merge-patch+json")
+	c.Request.Header.Set("If-Match", `"2"`)
+
+	suite.userHandler.PatchUser(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *userHandlerTestSuite) TestCreateUserValidationFailed() {
+	body := `{"name":"John","email":"john.abc.com","role":"owner","address":{"city":"chennai"}}`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.userHandler.CreateUser(c)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, w.Code)
+
+	var result dtos.ValidationErrors
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []*dtos.FieldError{
+		{Field: "email", Rule: "email"},
+		{Field: "address.country", Rule: "required"},
+		{Field: "role", Rule: "oneof", Param: "customer supplier admin"},
+	}, result.Errors)
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/validation"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindJSON binds the request body into req. Malformed bodies get a 400 and
+// bodies that fail validation a 422 listing every failing field.
+func bindJSON(ctx *gin.Context, req any) bool {
+	err := ctx.ShouldBindJSON(req)
+	if err != nil {
+		invalid(ctx, err)
+		return false
+	}
+
+	return true
+}
+
+func invalid(ctx *gin.Context, err error) {
+	fields, ok := validation.Fields(err)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusUnprocessableEntity, &dtos.ValidationErrors{
+		Message: constants.ErrorValidationFailed.Error(),
+		Errors:  fields,
+	})
+}
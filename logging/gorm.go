@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// GormLogger writes gorm's logs to slog with the context of the query, so SQL
+// errors carry the id of the request that ran them. Failed queries are logged
+// at error, slow ones at warn and the rest at debug.
+type GormLogger struct {
+	logger        *slog.Logger
+	slowThreshold time.Duration
+}
+
+func NewGormLogger(logger *slog.Logger, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{
+		logger:        logger,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// LogMode is a no-op, the level of the slog logger decides what is logged.
+func (g *GormLogger) LogMode(logger.LogLevel) logger.Interface {
+	return g
+}
+
+func (g *GormLogger) Info(ctx context.Context, format string, args ...any) {
+	g.logger.InfoContext(ctx, fmt.Sprintf(format, args...))
+}
+
+func (g *GormLogger) Warn(ctx context.Context, format string, args ...any) {
+	g.logger.WarnContext(ctx, fmt.Sprintf(format, args...))
+}
+
+func (g *GormLogger) Error(ctx context.Context, format string, args ...any) {
+	g.logger.ErrorContext(ctx, fmt.Sprintf(format, args...))
+}
+
+func (g *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		g.logger.ErrorContext(ctx, "query failed", "sql", sql, "rows", rows, "elapsed", elapsed, "error", err)
+	case g.slowThreshold > 0 && elapsed > g.slowThreshold:
+		sql, rows := fc()
+		g.logger.WarnContext(ctx, "slow query", "sql", sql, "rows", rows, "elapsed", elapsed)
+	case g.logger.Enabled(ctx, slog.LevelDebug):
+		sql, rows := fc()
+		g.logger.DebugContext(ctx, "query", "sql", sql, "rows", rows, "elapsed", elapsed)
+	}
+}
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// New returns a logger writing records at level and above to w, as JSON
+// unless format is text. Records logged with a context carrying a request id
+// get a request_id attribute, and ones inside a span its trace_id and span_id.
+func New(w io.Writer, level string, format string) *slog.Logger {
+	options := &slog.HandlerOptions{Level: ParseLevel(level)}
+
+	var handler slog.Handler = slog.NewJSONHandler(w, options)
+	if format == FormatText {
+		handler = slog.NewTextHandler(w, options)
+	}
+
+	return slog.New(&contextHandler{handler})
+}
+
+// ParseLevel maps the configured log level to slog's, defaulting to info.
+func ParseLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+type requestIdKey struct{}
+
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// RequestId returns the id of the request ctx belongs to, or "" outside of
+// one.
+func RequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (c *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestId := RequestId(ctx); requestId != "" {
+		record.AddAttrs(slog.String("request_id", requestId))
+	}
+
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+
+	return c.Handler.Handle(ctx, record)
+}
+
+func (c *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{c.Handler.WithAttrs(attrs)}
+}
+
+func (c *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{c.Handler.WithGroup(name)}
+}
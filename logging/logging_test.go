@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+func records(buf *bytes.Buffer) []map[string]any {
+	var result []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var record map[string]any
+		json.Unmarshal([]byte(line), &record)
+		result = append(result, record)
+	}
+
+	return result
+}
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, "warn", FormatJSON).With("component", "test")
+
+	traceId, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanId, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	span := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceId, SpanID: spanId})
+
+	ctx := trace.ContextWithSpanContext(WithRequestId(context.Background(), "req-1"), span)
+	logger.InfoContext(ctx, "hidden")
+	logger.WarnContext(ctx, "shown", "article_id", "a1")
+	logger.Error("without request")
+
+	logged := records(&buf)
+	assert.Len(t, logged, 2)
+	assert.Equal(t, "shown", logged[0]["msg"])
+	assert.Equal(t, "req-1", logged[0]["request_id"])
+	assert.Equal(t, "a1", logged[0]["article_id"])
+	assert.Equal(t, "test", logged[0]["component"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", logged[0]["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", logged[0]["span_id"])
+	assert.NotContains(t, logged[1], "request_id")
+
+	buf.Reset()
+	New(&buf, "debug", FormatText).DebugContext(ctx, "text")
+	assert.Contains(t, buf.String(), "level=DEBUG msg=text request_id=req-1")
+}
+
+func TestGormLogger(t *testing.T) {
+	var buf bytes.Buffer
+	gormLogger := NewGormLogger(New(&buf, "info", FormatJSON), 100*time.Millisecond)
+
+	ctx := WithRequestId(context.Background(), "req-2")
+	query := func() (string, int64) {
+		return "SELECT * FROM articles", 1
+	}
+
+	gormLogger.Trace(ctx, time.Now(), query, nil)
+	gormLogger.Trace(ctx, time.Now(), query, gorm.ErrRecordNotFound)
+	gormLogger.Trace(ctx, time.Now(), query, errors.New("no such table: articles"))
+	gormLogger.Trace(ctx, time.Now().Add(-time.Second), query, nil)
+
+	logged := records(&buf)
+	assert.Len(t, logged, 2)
+	assert.Equal(t, "ERROR", logged[0]["level"])
+	assert.Equal(t, "no such table: articles", logged[0]["error"])
+	assert.Equal(t, "SELECT * FROM articles", logged[0]["sql"])
+	assert.Equal(t, "req-2", logged[0]["request_id"])
+	assert.Equal(t, "WARN", logged[1]["level"])
+	assert.Equal(t, "slow query", logged[1]["msg"])
+}
@@ -1,58 +1,165 @@
 package main
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"errors"
 	"inventory-management/config"
+	"inventory-management/database"
+	"inventory-management/logging"
+	"inventory-management/metrics"
 	"inventory-management/routes"
-	"log"
+	"inventory-management/services/payments"
+	"inventory-management/tracing"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
-func GetConfig(filePath string) (*config.Config, error) {
-	file, err := os.ReadFile(filePath)
+// slowQueryThreshold is how long a query may take before it's logged as slow.
+const slowQueryThreshold = 200 * time.Millisecond
+
+func main() {
+	config, args, err := config.Load(os.Args[1:], os.Getenv)
+	if err != nil {
+		fatal("Error loading config", err)
+	}
+
+	slog.SetDefault(logging.New(os.Stderr, config.LogLevel, config.LogFormat))
+
+	if len(args) > 0 && args[0] == "config" {
+		err = runConfig(config, args[1:])
+		if err != nil {
+			fatal("Error in config", err)
+		}
+		return
+	}
+
+	err = config.Validate()
+	if err != nil {
+		fatal("Error in config", err)
+	}
+
+	db, err := database.Open(config.DbDriver, config.DbUrl)
+	if err != nil {
+		fatal("failed to connect to the database", err)
+	}
+
+	err = configureDb(db, config)
+	if err != nil {
+		fatal("failed to configure the database", err)
+	}
+
+	if len(args) > 0 && args[0] == "migrate" {
+		err = runMigrate(db, args[1:])
+		if err != nil {
+			fatal("Error migrating database", err)
+		}
+		return
+	}
+
+	err = checkSchema(db)
+	if err != nil {
+		fatal("Error checking database schema", err)
+	}
+
+	provider, err := payments.NewProvider(config.PaymentProvider, config.PaymentProviderUrl, config.PaymentProviderKey)
 	if err != nil {
-		return nil, err
+		fatal("Error setting up the payment provider", err)
 	}
 
-	var config config.Config
-	err = json.Unmarshal(file, &config)
+	if config.LogLevel != "debug" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	stopTracing, err := tracing.Setup(context.Background(), config.AppName, config.TraceExporter, traceTarget(config))
+	if err != nil {
+		fatal("Error setting up tracing", err)
+	}
+
+	r := gin.New()
+
+	lifecycle, err := routes.Router(r, db, config, provider)
+	if err != nil {
+		fatal("Error setting up routes", err)
+	}
+
+	server := &http.Server{
+		Addr:    ":" + config.ServerPort,
+		Handler: r,
+	}
+	server.ReadTimeout, _ = time.ParseDuration(config.ReadTimeout)
+	server.WriteTimeout, _ = time.ParseDuration(config.WriteTimeout)
+	server.IdleTimeout, _ = time.ParseDuration(config.IdleTimeout)
+	shutdownDelay, _ := time.ParseDuration(config.ShutdownDelay)
+	shutdownTimeout, _ := time.ParseDuration(config.ShutdownTimeout)
+
+	listener, err := net.Listen("tcp", server.Addr)
 	if err != nil {
-		return nil, err
+		fatal("Error starting server", err)
 	}
 
-	return &config, nil
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err = serve(ctx, server, listener, lifecycle, db, shutdownDelay, shutdownTimeout)
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err = errors.Join(err, stopTracing(flushCtx))
+	if err != nil {
+		fatal("Error shutting down", err)
+	}
 }
 
-func main() {
-	env := os.Getenv("env")
-	if env == "" {
-		env = "default"
+// traceTarget is where the configured exporter sends spans.
+func traceTarget(config *config.Config) string {
+	if config.TraceExporter == tracing.ExporterFile {
+		return config.TraceFile
 	}
 
-	config, err := GetConfig(fmt.Sprintf("%s.json", env))
+	return config.TraceEndpoint
+}
+
+// configureDb sizes the pool, exports query and pool metrics, traces queries
+// and sends gorm's logs to slog.
+func configureDb(db *gorm.DB, config *config.Config) error {
+	lifetime, _ := time.ParseDuration(config.DbConnMaxLifetime)
+	idleTime, _ := time.ParseDuration(config.DbConnMaxIdleTime)
+
+	err := database.SetPool(db, database.Pool{
+		MaxOpenConns:    config.DbMaxOpenConns,
+		MaxIdleConns:    config.DbMaxIdleConns,
+		ConnMaxLifetime: lifetime,
+		ConnMaxIdleTime: idleTime,
+	})
 	if err != nil {
-		log.Fatalf("Error loading config: %v", err)
-		os.Exit(1)
+		return err
 	}
 
-	db, err := gorm.Open(mysql.Open(config.DbUrl), &gorm.Config{})
+	err = db.Use(metrics.NewGormPlugin(metrics.Registry))
 	if err != nil {
-		log.Fatalf("failed to connect to the database: %v", err)
-		os.Exit(0)
+		return err
 	}
 
-	if config.ServerPort == "" {
-		config.ServerPort = "8080"
+	err = db.Use(tracing.NewGormPlugin())
+	if err != nil {
+		return err
 	}
 
-	r := gin.Default()
+	db.Logger = logging.NewGormLogger(slog.Default(), slowQueryThreshold)
 
-	routes.Router(r, db)
+	return nil
+}
 
-	r.Run(":" + config.ServerPort)
+func fatal(message string, err error) {
+	slog.Error(message, "error", err)
+	os.Exit(1)
 }
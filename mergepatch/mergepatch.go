@@ -0,0 +1,69 @@
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"inventory-management/constants"
+)
+
+// Apply applies an RFC 7396 merge patch to the target document. Members set to
+// null are removed, objects are merged recursively and any other value,
+// arrays included, replaces the target member as a whole.
+func Apply(target []byte, patch []byte) ([]byte, error) {
+	p, err := decode(patch)
+	if err != nil {
+		return nil, constants.ErrorInvalidMergePatch
+	}
+
+	var t any
+	if len(target) > 0 {
+		t, err = decode(target)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(merge(t, p))
+}
+
+func merge(target any, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any)
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+
+		t[k] = merge(t[k], v)
+	}
+
+	return t
+}
+
+// decode keeps numbers as json.Number so amounts and large counts survive the
+// round trip unchanged.
+func decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v any
+	err := decoder.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, constants.ErrorInvalidMergePatch
+	}
+
+	return v, nil
+}
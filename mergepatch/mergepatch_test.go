@@ -0,0 +1,58 @@
+package mergepatch
+
+import (
+	"inventory-management/constants"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The cases are the examples from RFC 7396 appendix A.
+func TestApply(t *testing.T) {
+	cases := []struct {
+		target string
+		patch  string
+		result string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, v := range cases {
+		result, err := Apply([]byte(v.target), []byte(v.patch))
+		assert.NoError(t, err)
+		assert.JSONEq(t, v.result, string(result), v.patch)
+	}
+}
+
+func TestApplyZeroValues(t *testing.T) {
+	result, err := Apply([]byte(`{"name":"box","stock":5,"price":{"amount":"10","currency":"INR"}}`),
+		[]byte(`{"name":"","stock":0,"price":{"amount":"0"}}`))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"","stock":0,"price":{"amount":"0","currency":"INR"}}`, string(result))
+}
+
+func TestApplyInvalidPatch(t *testing.T) {
+	_, err := Apply([]byte(`{"a":"b"}`), []byte(`{"a":`))
+	assert.Equal(t, constants.ErrorInvalidMergePatch, err)
+}
+
+func TestApplyKeepsNumbers(t *testing.T) {
+	result, err := Apply([]byte(`{"stock":9007199254740993}`), []byte(`{"price":0.1}`))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"stock":9007199254740993,"price":0.1}`, string(result))
+	assert.Contains(t, string(result), "9007199254740993")
+}
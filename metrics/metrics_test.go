@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"errors"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestObserveRequest(t *testing.T) {
+	ObserveRequest(http.MethodGet, "/articles/:id", http.StatusOK, 20*time.Millisecond)
+	ObserveRequest(http.MethodGet, "/articles/:id", http.StatusOK, 30*time.Millisecond)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/articles/:id", "200")))
+
+	response := httptest.NewRecorder()
+	Handler().ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, response.Body.String(), `inventory_http_request_duration_seconds_count{method="GET",route="/articles/:id"} 2`)
+}
+
+func TestOrderCreated(t *testing.T) {
+	OrderCreated(money.Money{Amount: decimal.RequireFromString("12.50"), Currency: "EUR"})
+	OrderCreated(money.Money{Amount: decimal.RequireFromString("7.50"), Currency: "EUR"})
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(ordersCreated.WithLabelValues("EUR")))
+	assert.Equal(t, float64(20), testutil.ToFloat64(orderValue.WithLabelValues("EUR")))
+}
+
+func TestGormPlugin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, db.Use(NewGormPlugin(registry)))
+
+	assert.NoError(t, db.Exec("CREATE TABLE gorm_plugin_items (id TEXT PRIMARY KEY)").Error)
+	assert.NoError(t, db.Table("gorm_plugin_items").Create(map[string]any{"id": "1"}).Error)
+
+	var ids []string
+	assert.NoError(t, db.Table("gorm_plugin_items").Pluck("id", &ids).Error)
+	assert.Error(t, db.Table("missing").Pluck("id", &ids).Error)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(dbQueryDuration.WithLabelValues("create", "gorm_plugin_items").(prometheus.Histogram)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(dbQueryErrors.WithLabelValues("query", "missing")))
+
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, families)
+	assert.True(t, strings.HasPrefix(families[0].GetName(), "go_sql_"))
+}
+
+func TestStockCollector(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockArticleRepo := mocks.NewMockArticleRepo(mockCtrl)
+	mockArticleRepo.EXPECT().CountOutOfStock(gomock.Any()).Return(int64(1), nil).Times(1)
+	mockArticleRepo.EXPECT().LowestStock(gomock.Any(), 2).Return([]*models.Article{
+		{ArticleId: "a1", Stock: 0},
+		{ArticleId: "a2", Stock: 3},
+	}, nil).Times(1)
+
+	expected := `
+# HELP inventory_article_stock Current stock of the articles with the least stock.
+# TYPE inventory_article_stock gauge
+inventory_article_stock{article_id="a1"} 0
+inventory_article_stock{article_id="a2"} 3
+# HELP inventory_articles_out_of_stock Articles with no stock left.
+# TYPE inventory_articles_out_of_stock gauge
+inventory_articles_out_of_stock 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(NewStockCollector(mockArticleRepo, 2), strings.NewReader(expected)))
+
+	// Without a limit only the count is exported.
+	mockArticleRepo.EXPECT().CountOutOfStock(gomock.Any()).Return(int64(0), nil).Times(1)
+	assert.Equal(t, 1, testutil.CollectAndCount(NewStockCollector(mockArticleRepo, 0)))
+
+	mockArticleRepo.EXPECT().CountOutOfStock(gomock.Any()).Return(int64(0), errors.New("database is down")).Times(1)
+	assert.Error(t, testutil.CollectAndCompare(NewStockCollector(mockArticleRepo, 0), strings.NewReader("")))
+}
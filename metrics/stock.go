@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+	"inventory-management/repository"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	articleStockDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "article_stock"),
+		"Current stock of the articles with the least stock.", []string{"article_id"}, nil)
+
+	outOfStockDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "articles_out_of_stock"),
+		"Articles with no stock left.", nil, nil)
+)
+
+const collectTimeout = 5 * time.Second
+
+// stockCollector reads the stock from the database on every scrape. Only the
+// limit articles with the least stock get a series of their own, which keeps
+// the number of series bounded however big the catalog grows while still
+// showing the articles about to run out.
+type stockCollector struct {
+	articleRepo repository.ArticleRepo
+	limit       int
+}
+
+func NewStockCollector(articleRepo repository.ArticleRepo, limit int) prometheus.Collector {
+	return &stockCollector{
+		articleRepo: articleRepo,
+		limit:       limit,
+	}
+}
+
+func (s *stockCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- articleStockDesc
+	ch <- outOfStockDesc
+}
+
+// Collect gives up on the database after collectTimeout, so a slow database
+// can't stall the scrape.
+func (s *stockCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	count, err := s.articleRepo.CountOutOfStock(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to count articles out of stock", "error", err)
+		ch <- prometheus.NewInvalidMetric(outOfStockDesc, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(outOfStockDesc, prometheus.GaugeValue, float64(count))
+
+	if s.limit <= 0 {
+		return
+	}
+
+	articles, err := s.articleRepo.LowestStock(ctx, s.limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to read article stock", "error", err)
+		ch <- prometheus.NewInvalidMetric(articleStockDesc, err)
+		return
+	}
+
+	for _, v := range articles {
+		ch <- prometheus.MustNewConstMetric(articleStockDesc, prometheus.GaugeValue, float64(v.Stock), v.ArticleId)
+	}
+}
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deadline cancels the request context after timeout, or after the timeout in
+// overrides for routes keyed by method and route template, such as
+// "GET /articles/export". Services and queries run under the request context
+// so they stop once the deadline passes.
+//
+// Overridden routes also get their write deadline moved, as the server's
+// write timeout would otherwise cut off long downloads first.
+func Deadline(timeout time.Duration, overrides map[string]time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		limit := timeout
+		if override, ok := overrides[ctx.Request.Method+" "+ctx.FullPath()]; ok {
+			limit = override
+
+			// Not every writer supports it, e.g. in tests, and the server's
+			// timeout then stays.
+			_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Now().Add(limit))
+		}
+
+		if limit <= 0 {
+			ctx.Next()
+			return
+		}
+
+		requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), limit)
+		defer cancel()
+
+		ctx.Request = ctx.Request.WithContext(requestCtx)
+		ctx.Next()
+	}
+}
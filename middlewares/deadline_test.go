@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadline(t *testing.T) {
+	r := gin.New()
+	r.Use(Deadline(time.Second, map[string]time.Duration{"GET /articles/export": time.Hour}))
+
+	remaining := func(ctx *gin.Context) {
+		deadline, ok := ctx.Request.Context().Deadline()
+		assert.True(t, ok)
+		ctx.JSON(http.StatusOK, time.Until(deadline).Round(time.Minute).String())
+	}
+	r.GET("/articles/:id", remaining)
+	r.GET("/articles/export", remaining)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/articles/a1", nil))
+	assert.Equal(t, `"0s"`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/articles/export", nil))
+	assert.Equal(t, `"1h0m0s"`, w.Body.String())
+}
@@ -0,0 +1,134 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/models"
+	"inventory-management/repository"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 200
+	idempotentResponseContent = "application/json; charset=utf-8"
+)
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+// Idempotency makes a route safe to retry. The first request sent with an
+// Idempotency-Key header claims the key and its response is stored together
+// with a hash of the request body. Later requests with the same key get the
+// stored response back, or 422 when their body differs. Server errors are not
+// stored so the request can be retried. Requests without the header are
+// handled as usual.
+func Idempotency(idempotencyKeyRepo repository.IdempotencyKeyRepo, ttl time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := ctx.GetHeader(IdempotencyKeyHeader)
+		if header == "" {
+			ctx.Next()
+			return
+		}
+
+		if len(header) > maxIdempotencyKeyLength {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, constants.ErrorIdempotencyKeyTooLong.Error())
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(body)
+		now := time.Now().UTC()
+		key := &models.IdempotencyKey{
+			Key:         ctx.Request.Method + " " + ctx.FullPath() + " " + header,
+			RequestHash: hex.EncodeToString(sum[:]),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		}
+
+		err = idempotencyKeyRepo.Claim(ctx.Request.Context(), key)
+		if errors.Is(err, constants.ErrorRecordExists) {
+			replay(ctx, idempotencyKeyRepo, key)
+			return
+		}
+
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+
+		ctx.Next()
+
+		// The request may have timed out or the client gone away by now, and
+		// the key has to be given up or stored regardless.
+		writeCtx := context.WithoutCancel(ctx.Request.Context())
+		if recorder.Status() >= http.StatusInternalServerError {
+			err = idempotencyKeyRepo.Delete(writeCtx, key.Key)
+		} else {
+			err = idempotencyKeyRepo.SaveResponse(writeCtx, key.Key, recorder.Status(), recorder.body.String())
+		}
+
+		if err != nil {
+			ctx.Error(err)
+		}
+	}
+}
+
+func replay(ctx *gin.Context, idempotencyKeyRepo repository.IdempotencyKeyRepo, key *models.IdempotencyKey) {
+	stored, err := idempotencyKeyRepo.Get(ctx.Request.Context(), key.Key)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The first request failed and gave the key up in the meantime.
+		ctx.AbortWithStatusJSON(http.StatusConflict, constants.ErrorIdempotencyKeyInProgress.Error())
+		return
+	}
+
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if stored.RequestHash != key.RequestHash {
+		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, constants.ErrorIdempotencyKeyReused.Error())
+		return
+	}
+
+	if stored.StatusCode == 0 {
+		ctx.AbortWithStatusJSON(http.StatusConflict, constants.ErrorIdempotencyKeyInProgress.Error())
+		return
+	}
+
+	ctx.Header(IdempotentReplayedHeader, "true")
+	ctx.Data(stored.StatusCode, idempotentResponseContent, []byte(stored.ResponseBody))
+	ctx.Abort()
+}
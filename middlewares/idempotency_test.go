@@ -0,0 +1,177 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"inventory-management/models"
+	"inventory-management/repository"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type idempotencyTestSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	router *gin.Engine
+	calls  int
+	status int
+}
+
+func TestIdempotencyTestSuite(t *testing.T) {
+	suite.Run(t, new(idempotencyTestSuite))
+}
+
+// SetupTest serves POST /orders with a handler that counts its calls and
+// answers with the call number, so replays are easy to tell apart.
+func (suite *idempotencyTestSuite) SetupTest() {
+	var err error
+	suite.db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		suite.T().Fatal("failed to connect to database")
+	}
+
+	err = suite.db.AutoMigrate(&models.IdempotencyKey{})
+	if err != nil {
+		suite.T().Fatal("failed to migrate database")
+	}
+
+	suite.calls = 0
+	suite.status = http.StatusOK
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.router.POST("/orders", Idempotency(repository.NewIdempotencyKeyRepo(suite.db), time.Hour), func(ctx *gin.Context) {
+		suite.calls++
+		ctx.JSON(suite.status, gin.H{"call": suite.calls})
+	})
+}
+
+func (suite *idempotencyTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *idempotencyTestSuite) post(key string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+
+	suite.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func (suite *idempotencyTestSuite) TestReplay() {
+	first := suite.post("k1", `{"customer_id":"c1"}`)
+	assert.Equal(suite.T(), http.StatusOK, first.Code)
+	assert.JSONEq(suite.T(), `{"call":1}`, first.Body.String())
+
+	second := suite.post("k1", `{"customer_id":"c1"}`)
+	assert.Equal(suite.T(), http.StatusOK, second.Code)
+	assert.JSONEq(suite.T(), `{"call":1}`, second.Body.String())
+	assert.Equal(suite.T(), "true", second.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(suite.T(), 1, suite.calls)
+}
+
+func (suite *idempotencyTestSuite) TestDifferentBody() {
+	suite.post("k1", `{"customer_id":"c1"}`)
+
+	w := suite.post("k1", `{"customer_id":"c2"}`)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(suite.T(), 1, suite.calls)
+}
+
+func (suite *idempotencyTestSuite) TestDifferentKeys() {
+	suite.post("k1", `{}`)
+	suite.post("k2", `{}`)
+
+	assert.Equal(suite.T(), 2, suite.calls)
+}
+
+func (suite *idempotencyTestSuite) TestWithoutKey() {
+	suite.post("", `{}`)
+	suite.post("", `{}`)
+
+	assert.Equal(suite.T(), 2, suite.calls)
+}
+
+func (suite *idempotencyTestSuite) TestClientErrorIsStored() {
+	suite.status = http.StatusBadRequest
+
+	suite.post("k1", `{}`)
+	w := suite.post("k1", `{}`)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(suite.T(), 1, suite.calls)
+}
+
+func (suite *idempotencyTestSuite) TestServerErrorIsRetried() {
+	suite.status = http.StatusInternalServerError
+	suite.post("k1", `{}`)
+
+	suite.status = http.StatusOK
+	w := suite.post("k1", `{}`)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"call":2}`, w.Body.String())
+}
+
+// TestTimedOutRequestIsRetried checks a request cut off by its deadline still
+// gives its key up, so a retry doesn't get 409 until the key expires.
+func (suite *idempotencyTestSuite) TestTimedOutRequestIsRetried() {
+	suite.router = gin.New()
+	suite.router.POST("/orders", Deadline(10*time.Millisecond, nil), Idempotency(repository.NewIdempotencyKeyRepo(suite.db), time.Hour),
+		func(ctx *gin.Context) {
+			suite.calls++
+			if suite.calls == 1 {
+				<-ctx.Request.Context().Done()
+				ctx.JSON(http.StatusInternalServerError, ctx.Request.Context().Err().Error())
+				return
+			}
+			ctx.JSON(http.StatusOK, gin.H{"call": suite.calls})
+		})
+
+	first := suite.post("k1", `{}`)
+	assert.Equal(suite.T(), http.StatusInternalServerError, first.Code)
+
+	second := suite.post("k1", `{}`)
+	assert.Equal(suite.T(), http.StatusOK, second.Code)
+	assert.JSONEq(suite.T(), `{"call":2}`, second.Body.String())
+}
+
+func (suite *idempotencyTestSuite) TestInProgress() {
+	err := repository.NewIdempotencyKeyRepo(suite.db).Claim(context.Background(), &models.IdempotencyKey{
+		Key:         "POST /orders k1",
+		RequestHash: "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+		CreatedAt:   time.Now().UTC(),
+		ExpiresAt:   time.Now().UTC().Add(time.Hour),
+	})
+	assert.NoError(suite.T(), err)
+
+	w := suite.post("k1", `{}`)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Equal(suite.T(), 0, suite.calls)
+}
+
+func (suite *idempotencyTestSuite) TestKeyTooLong() {
+	key := ""
+	for len(key) <= maxIdempotencyKeyLength {
+		key += strconv.Itoa(len(key))
+	}
+
+	w := suite.post(key, `{}`)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(suite.T(), 0, suite.calls)
+}
@@ -0,0 +1,28 @@
+package middlewares
+
+import (
+	"inventory-management/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests no route matched, which would otherwise add
+// a series for every path a scanner tries.
+const unmatchedRoute = "unmatched"
+
+// Metrics counts and times every request by its route template.
+func Metrics() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		metrics.ObserveRequest(ctx.Request.Method, route, ctx.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"inventory-management/metrics"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	r := gin.New()
+	r.Use(Metrics())
+	r.GET("/articles/:id", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusNotFound, "not found")
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/articles/a1", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/articles/a2", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/wp-login.php", nil))
+
+	response := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, response.Body.String(), `inventory_http_requests_total{method="GET",route="/articles/:id",status="404"} 2`)
+	assert.Contains(t, response.Body.String(), `inventory_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, response.Body.String(), "a1")
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"inventory-management/logging"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIdHeader = "X-Request-ID"
+
+// validRequestId limits the ids taken from clients to what is safe to log and
+// echo back.
+var validRequestId = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestId takes the request id from the X-Request-ID header, or generates
+// one, puts it in the request's context for logging and returns it in the
+// response.
+func RequestId() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestId := ctx.GetHeader(RequestIdHeader)
+		if !validRequestId.MatchString(requestId) {
+			requestId = uuid.NewString()
+		}
+
+		ctx.Request = ctx.Request.WithContext(logging.WithRequestId(ctx.Request.Context(), requestId))
+		ctx.Header(RequestIdHeader, requestId)
+
+		ctx.Next()
+	}
+}
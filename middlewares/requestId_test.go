@@ -0,0 +1,61 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"inventory-management/logging"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestId(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, "info", logging.FormatJSON)
+
+	r := gin.New()
+	r.Use(RequestId(), Logger(logger), Recovery(logger))
+	r.GET("/articles/:id", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, logging.RequestId(ctx.Request.Context()))
+	})
+	r.GET("/panic", func(ctx *gin.Context) {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/a1", nil)
+	req.Header.Set(RequestIdHeader, "client-id-1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "client-id-1", w.Header().Get(RequestIdHeader))
+	assert.Equal(t, `"client-id-1"`, w.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/articles/a1", nil)
+	req.Header.Set(RequestIdHeader, "not a valid\nid")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Len(t, w.Header().Get(RequestIdHeader), 36)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+
+	var first, panicked, last map[string]any
+	json.Unmarshal([]byte(lines[0]), &first)
+	json.Unmarshal([]byte(lines[2]), &panicked)
+	json.Unmarshal([]byte(lines[3]), &last)
+
+	assert.Equal(t, "request", first["msg"])
+	assert.Equal(t, "client-id-1", first["request_id"])
+	assert.Equal(t, "/articles/:id", first["route"])
+	assert.Equal(t, float64(http.StatusOK), first["status"])
+	assert.Equal(t, "panic", panicked["msg"])
+	assert.Equal(t, w.Header().Get(RequestIdHeader), panicked["request_id"])
+	assert.Equal(t, "ERROR", last["level"])
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"inventory-management/migrations"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"gorm.io/gorm"
+)
+
+var errMigrateUsage = errors.New("usage: migrate up | down [steps] | status")
+
+// runMigrate runs the migrate subcommand. Down reverts one migration unless
+// told how many.
+func runMigrate(db *gorm.DB, args []string) error {
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, v := range applied {
+			fmt.Printf("applied %d_%s\n", v.Version, v.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+		return nil
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return errMigrateUsage
+			}
+		}
+
+		reverted, err := migrator.Down(steps)
+		for _, v := range reverted {
+			fmt.Printf("reverted %d_%s\n", v.Version, v.Name)
+		}
+		return err
+
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, v := range status {
+			appliedAt := "pending"
+			if v.Applied {
+				appliedAt = v.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", v.Version, v.Name, appliedAt)
+		}
+		return w.Flush()
+	}
+
+	return errMigrateUsage
+}
+
+// checkSchema refuses to start the server on a database that is missing
+// migrations this build needs.
+func checkSchema(db *gorm.DB) error {
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	return migrator.Check()
+}
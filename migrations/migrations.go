@@ -0,0 +1,378 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"inventory-management/constants"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// files holds a directory of migrations for each database the app runs on,
+// named after the gorm dialect. They create the same schema and are changed
+// together.
+//
+//go:embed mysql/*.sql postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// fileName matches migration files such as 0002_add_returns.up.sql.
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// schemaMigration is a row of schema_migrations, one for every migration
+// applied to the database.
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"size:191"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator interface {
+	Up() ([]*Migration, error)
+	Down(steps int) ([]*Migration, error)
+	Status() ([]*MigrationStatus, error)
+	Version() (int64, error)
+	Latest() int64
+	Check() error
+}
+
+type migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+}
+
+// NewMigrator returns a migrator for the migrations built into the binary for
+// the database db is connected to.
+func NewMigrator(db *gorm.DB) (Migrator, error) {
+	source, err := Source(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return newMigrator(db, source)
+}
+
+// Source returns the built in migrations for a gorm dialect.
+func Source(dialect string) (fs.FS, error) {
+	if _, err := fs.Stat(files, dialect); err != nil {
+		return nil, constants.ErrorUnknownDbDriver
+	}
+
+	return fs.Sub(files, dialect)
+}
+
+func newMigrator(db *gorm.DB, source fs.FS) (*migrator, error) {
+	migrations, err := Load(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Load reads the up and down scripts in source, ordered by version. Every
+// version needs both scripts.
+func Load(source fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, v := range entries {
+		if v.IsDir() || path.Ext(v.Name()) != ".sql" {
+			continue
+		}
+
+		match := fileName.FindStringSubmatch(v.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: %s", constants.ErrorInvalidMigration, v.Name())
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d has two names", constants.ErrorInvalidMigration, version)
+		}
+
+		content, err := fs.ReadFile(source, v.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	var migrations []*Migration
+	for _, v := range byVersion {
+		if strings.TrimSpace(v.Up) == "" || strings.TrimSpace(v.Down) == "" {
+			return nil, fmt.Errorf("%w: version %d needs an up and a down script", constants.ErrorInvalidMigration, v.Version)
+		}
+
+		migrations = append(migrations, v)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every migration the database doesn't have yet, oldest first, and
+// returns the ones it applied.
+func (m *migrator) Up() ([]*Migration, error) {
+	if !m.db.Migrator().HasTable(&schemaMigration{}) {
+		err := m.db.Migrator().CreateTable(&schemaMigration{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []*Migration
+	for _, v := range m.migrations {
+		if _, exists := applied[v.Version]; exists {
+			continue
+		}
+
+		err = m.run(v, v.Up, func(tx *gorm.DB) error {
+			return tx.Create(&schemaMigration{Version: v.Version, Name: v.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, err
+		}
+
+		done = append(done, v)
+	}
+
+	return done, nil
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// the ones it reverted.
+func (m *migrator) Down(steps int) ([]*Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []int64
+	for k := range applied {
+		versions = append(versions, k)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] > versions[j]
+	})
+
+	var done []*Migration
+	for n, version := range versions {
+		if n == steps {
+			break
+		}
+
+		v := m.find(version)
+		if v == nil {
+			return done, fmt.Errorf("%w: version %d", constants.ErrorUnknownMigration, version)
+		}
+
+		err = m.run(v, v.Down, func(tx *gorm.DB) error {
+			return tx.Delete(&schemaMigration{}, version).Error
+		})
+		if err != nil {
+			return done, err
+		}
+
+		done = append(done, v)
+	}
+
+	return done, nil
+}
+
+// run executes a script and records it in one transaction. MySQL commits
+// schema changes as they run, so a failing script there can leave part of its
+// changes behind and has to be cleaned up by hand.
+func (m *migrator) run(migration *Migration, script string, record func(tx *gorm.DB) error) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		for _, v := range Statements(script) {
+			err := tx.Exec(v).Error
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+
+		return record(tx)
+	})
+}
+
+// Status lists every migration with whether it has been applied. Versions
+// applied by a newer build are listed too.
+func (m *migrator) Status() ([]*MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*MigrationStatus
+	for _, v := range m.migrations {
+		status := &MigrationStatus{Version: v.Version, Name: v.Name}
+		if row, exists := applied[v.Version]; exists {
+			status.Applied = true
+			status.AppliedAt = &row.AppliedAt
+			delete(applied, v.Version)
+		}
+
+		result = append(result, status)
+	}
+
+	for _, v := range applied {
+		result = append(result, &MigrationStatus{Version: v.Version, Name: v.Name, Applied: true, AppliedAt: &v.AppliedAt})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+
+	return result, nil
+}
+
+// Version is the newest migration applied to the database, or 0 when none is.
+func (m *migrator) Version() (int64, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	for k := range applied {
+		version = max(version, k)
+	}
+
+	return version, nil
+}
+
+// Latest is the newest migration built into the binary.
+func (m *migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Check fails when the database is missing migrations this build needs. A
+// database migrated by a newer build is accepted so an older build can keep
+// serving during a rollout.
+func (m *migrator) Check() error {
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	for _, v := range status {
+		if !v.Applied {
+			return fmt.Errorf("%w: migration %d_%s has not been applied, run migrate up", constants.ErrorSchemaOutdated, v.Version, v.Name)
+		}
+	}
+
+	return nil
+}
+
+func (m *migrator) find(version int64) *Migration {
+	for _, v := range m.migrations {
+		if v.Version == version {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// applied returns the schema_migrations rows by version. A database without
+// the table has nothing applied.
+func (m *migrator) applied() (map[int64]*schemaMigration, error) {
+	result := make(map[int64]*schemaMigration)
+	if !m.db.Migrator().HasTable(&schemaMigration{}) {
+		return result, nil
+	}
+
+	var rows []*schemaMigration
+	err := m.db.Order("version").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range rows {
+		result[v.Version] = v
+	}
+
+	return result, nil
+}
+
+// Statements splits a script into statements on the semicolons that end a
+// line, dropping comment lines. Scripts must not put two statements on a line.
+func Statements(script string) []string {
+	var result []string
+	var current strings.Builder
+
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			result = append(result, strings.TrimSuffix(strings.TrimSpace(current.String()), ";"))
+			current.Reset()
+		}
+	}
+
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		result = append(result, rest)
+	}
+
+	return result
+}
@@ -0,0 +1,260 @@
+package migrations
+
+import (
+	"inventory-management/constants"
+	"inventory-management/models"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type MigrationsTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	migrator *migrator
+}
+
+func TestMigrationsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsTestSuite))
+}
+
+var testMigrations = fstest.MapFS{
+	"0001_create_articles.up.sql":   {Data: []byte("-- articles\nCREATE TABLE articles (\n    article_id TEXT PRIMARY KEY\n);\n")},
+	"0001_create_articles.down.sql": {Data: []byte("DROP TABLE articles;\n")},
+	"0002_add_stock.up.sql": {Data: []byte("ALTER TABLE articles ADD COLUMN stock INTEGER;\n" +
+		"CREATE INDEX idx_articles_stock ON articles (stock);\n")},
+	"0002_add_stock.down.sql": {Data: []byte("DROP INDEX idx_articles_stock;\nALTER TABLE articles DROP COLUMN stock;\n")},
+}
+
+func (suite *MigrationsTestSuite) SetupTest() {
+	var err error
+	suite.db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		suite.T().Fatal("failed to connect to database")
+	}
+
+	suite.migrator, err = newMigrator(suite.db, testMigrations)
+	if err != nil {
+		suite.T().Fatal("failed to load migrations")
+	}
+}
+
+func (suite *MigrationsTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *MigrationsTestSuite) TestUpAndDown() {
+	err := suite.migrator.Check()
+	assert.ErrorIs(suite.T(), err, constants.ErrorSchemaOutdated)
+
+	applied, err := suite.migrator.Up()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), applied, 2)
+	assert.True(suite.T(), suite.db.Migrator().HasColumn("articles", "stock"))
+	assert.NoError(suite.T(), suite.migrator.Check())
+
+	version, err := suite.migrator.Version()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), version)
+
+	applied, err = suite.migrator.Up()
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), applied)
+
+	reverted, err := suite.migrator.Down(1)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), reverted, 1)
+	assert.Equal(suite.T(), int64(2), reverted[0].Version)
+	assert.False(suite.T(), suite.db.Migrator().HasColumn("articles", "stock"))
+	assert.ErrorIs(suite.T(), suite.migrator.Check(), constants.ErrorSchemaOutdated)
+
+	status, err := suite.migrator.Status()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), status, 2)
+	assert.True(suite.T(), status[0].Applied)
+	assert.NotNil(suite.T(), status[0].AppliedAt)
+	assert.False(suite.T(), status[1].Applied)
+
+	reverted, err = suite.migrator.Down(5)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), reverted, 1)
+	assert.False(suite.T(), suite.db.Migrator().HasTable("articles"))
+}
+
+func (suite *MigrationsTestSuite) TestFailedMigrationIsNotRecorded() {
+	broken := fstest.MapFS{
+		"0001_create_articles.up.sql":   testMigrations["0001_create_articles.up.sql"],
+		"0001_create_articles.down.sql": testMigrations["0001_create_articles.down.sql"],
+		"0002_broken.up.sql":            {Data: []byte("ALTER TABLE missing ADD COLUMN stock INTEGER;\n")},
+		"0002_broken.down.sql":          {Data: []byte("SELECT 1;\n")},
+	}
+
+	migrator, err := newMigrator(suite.db, broken)
+	assert.NoError(suite.T(), err)
+
+	applied, err := migrator.Up()
+	assert.Error(suite.T(), err)
+	assert.Len(suite.T(), applied, 1)
+
+	version, err := migrator.Version()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), version)
+}
+
+func (suite *MigrationsTestSuite) TestNewerDatabaseIsAccepted() {
+	_, err := suite.migrator.Up()
+	assert.NoError(suite.T(), err)
+
+	err = suite.db.Create(&schemaMigration{Version: 3, Name: "from_newer_build"}).Error
+	assert.NoError(suite.T(), err)
+
+	assert.NoError(suite.T(), suite.migrator.Check())
+
+	status, err := suite.migrator.Status()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), status, 3)
+
+	_, err = suite.migrator.Down(1)
+	assert.ErrorIs(suite.T(), err, constants.ErrorUnknownMigration)
+}
+
+func TestLoadRejectsInvalidFiles(t *testing.T) {
+	_, err := Load(fstest.MapFS{"create_articles.sql": {Data: []byte("SELECT 1;")}})
+	assert.ErrorIs(t, err, constants.ErrorInvalidMigration)
+
+	_, err = Load(fstest.MapFS{"0001_create_articles.up.sql": {Data: []byte("SELECT 1;")}})
+	assert.ErrorIs(t, err, constants.ErrorInvalidMigration)
+
+	_, err = Load(fstest.MapFS{
+		"0001_create_articles.up.sql": {Data: []byte("SELECT 1;")},
+		"0001_create_users.down.sql":  {Data: []byte("SELECT 1;")},
+	})
+	assert.ErrorIs(t, err, constants.ErrorInvalidMigration)
+}
+
+func TestStatements(t *testing.T) {
+	script := "-- comment\nCREATE TABLE a (\n    id INT\n);\n\nDROP TABLE b;\nSELECT 1"
+
+	assert.Equal(t, []string{"CREATE TABLE a (\n    id INT\n)", "DROP TABLE b", "SELECT 1"}, Statements(script))
+}
+
+// schemaModels are the tables the repositories use and the models they read
+// and write, which the migrations have to keep in step with.
+var schemaModels = map[string]any{
+	"articles":           &models.Article{},
+	"article_prices":     &models.ArticlePrice{},
+	"addresses":          &models.Address{},
+	"users":              &models.User{},
+	"orders":             &models.Order{},
+	"order_items":        &models.OrderItem{},
+	"order_discounts":    &models.OrderDiscount{},
+	"coupons":            &models.Coupon{},
+	"coupon_redemptions": &models.CouponRedemption{},
+	"price_lists":        &models.PriceList{},
+	"price_list_items":   &models.PriceListItem{},
+	"tax_rules":          &models.TaxRule{},
+	"invoices":           &models.Invoice{},
+	"document_sequences": &models.DocumentSequence{},
+	"backorders":         &models.Backorder{},
+	"shipments":          &models.Shipment{},
+	"shipment_items":     &models.ShipmentItem{},
+	"returns":            &models.Return{},
+	"return_items":       &models.ReturnItem{},
+	"payments":           &models.Payment{},
+	"idempotency_keys":   &models.IdempotencyKey{},
+	"import_jobs":        &models.ImportJob{},
+	"import_errors":      &models.ImportError{},
+}
+
+var (
+	createTable = regexp.MustCompile(`(?s)CREATE TABLE (\w+) \((.*)\)`)
+	columnName  = regexp.MustCompile(`^\s*(\w+)\s`)
+	notAColumn  = map[string]bool{"PRIMARY": true, "INDEX": true, "UNIQUE": true, "KEY": true, "CONSTRAINT": true}
+)
+
+// TestMigrationsMatchModels checks the tables the built in migrations create
+// have exactly the columns of the models, for every database.
+func TestMigrationsMatchModels(t *testing.T) {
+	for _, dialect := range []string{"mysql", "postgres", "sqlite"} {
+		t.Run(dialect, func(t *testing.T) {
+			source, err := Source(dialect)
+			assert.NoError(t, err)
+
+			migrations, err := Load(source)
+			assert.NoError(t, err)
+
+			matchModels(t, migrations)
+		})
+	}
+}
+
+func matchModels(t *testing.T, migrations []*Migration) {
+	tables := make(map[string][]string)
+	for _, migration := range migrations {
+		for _, statement := range Statements(migration.Up) {
+			match := createTable.FindStringSubmatch(statement)
+			if match == nil {
+				continue
+			}
+
+			var columns []string
+			for _, line := range strings.Split(match[2], "\n") {
+				name := columnName.FindStringSubmatch(line)
+				if name != nil && !notAColumn[name[1]] {
+					columns = append(columns, name[1])
+				}
+			}
+			tables[match[1]] = columns
+		}
+	}
+
+	for table, model := range schemaModels {
+		parsed, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+		assert.NoError(t, err)
+
+		expected := append([]string{}, parsed.DBNames...)
+		sort.Strings(expected)
+
+		actual, exists := tables[table]
+		assert.True(t, exists, "no migration creates %s", table)
+		sort.Strings(actual)
+
+		assert.Equal(t, expected, actual, table)
+	}
+
+	assert.Len(t, tables, len(schemaModels))
+}
+
+// TestBuiltInSQLite runs the SQLite migrations up and back down.
+func TestBuiltInSQLite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+
+	migrator, err := NewMigrator(db)
+	assert.NoError(t, err)
+
+	_, err = migrator.Up()
+	assert.NoError(t, err)
+	assert.NoError(t, migrator.Check())
+
+	for table := range schemaModels {
+		assert.True(t, db.Migrator().HasTable(table), table)
+	}
+
+	_, err = migrator.Down(len(schemaModels))
+	assert.NoError(t, err)
+	assert.False(t, db.Migrator().HasTable("articles"))
+
+	_, err = Source("oracle")
+	assert.ErrorIs(t, err, constants.ErrorUnknownDbDriver)
+}
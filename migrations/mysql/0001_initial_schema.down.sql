@@ -0,0 +1,23 @@
+DROP TABLE IF EXISTS import_errors;
+DROP TABLE IF EXISTS import_jobs;
+DROP TABLE IF EXISTS idempotency_keys;
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS return_items;
+DROP TABLE IF EXISTS returns;
+DROP TABLE IF EXISTS shipment_items;
+DROP TABLE IF EXISTS shipments;
+DROP TABLE IF EXISTS backorders;
+DROP TABLE IF EXISTS document_sequences;
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS tax_rules;
+DROP TABLE IF EXISTS price_list_items;
+DROP TABLE IF EXISTS price_lists;
+DROP TABLE IF EXISTS coupon_redemptions;
+DROP TABLE IF EXISTS coupons;
+DROP TABLE IF EXISTS order_discounts;
+DROP TABLE IF EXISTS order_items;
+DROP TABLE IF EXISTS orders;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS addresses;
+DROP TABLE IF EXISTS article_prices;
+DROP TABLE IF EXISTS articles;
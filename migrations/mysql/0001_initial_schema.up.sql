@@ -0,0 +1,296 @@
+CREATE TABLE articles (
+    article_id     VARCHAR(191)  NOT NULL,
+    article_name   VARCHAR(191),
+    price_amount   DECIMAL(19,4),
+    price_currency CHAR(3),
+    stock          BIGINT,
+    damaged_stock  BIGINT,
+    tax_class      VARCHAR(191),
+    version        BIGINT        NOT NULL DEFAULT 1,
+    PRIMARY KEY (article_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE article_prices (
+    article_id VARCHAR(191)  NOT NULL,
+    currency   CHAR(3)       NOT NULL,
+    amount     DECIMAL(19,4),
+    PRIMARY KEY (article_id, currency)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE addresses (
+    address_id VARCHAR(191) NOT NULL,
+    line1      VARCHAR(191),
+    line2      VARCHAR(191),
+    city       VARCHAR(191),
+    state      VARCHAR(191),
+    country    VARCHAR(191),
+    zip_code   VARCHAR(191),
+    PRIMARY KEY (address_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE users (
+    id               VARCHAR(191) NOT NULL,
+    name             VARCHAR(191),
+    email            VARCHAR(191),
+    mobile           VARCHAR(191),
+    address_id       VARCHAR(191),
+    role             VARCHAR(191),
+    customer_group   VARCHAR(191),
+    backorder_policy VARCHAR(191),
+    version          BIGINT       NOT NULL DEFAULT 1,
+    PRIMARY KEY (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE orders (
+    order_id            VARCHAR(191)  NOT NULL,
+    customer_id         VARCHAR(191),
+    ordered_at          DATETIME(3)   NULL,
+    status              VARCHAR(191),
+    coupon_code         VARCHAR(191),
+    shipping_address_id VARCHAR(191),
+    prices_include_tax  BOOLEAN,
+    backorder_policy    VARCHAR(191),
+    payment_terms       VARCHAR(191),
+    subtotal_amount     DECIMAL(19,4),
+    subtotal_currency   CHAR(3),
+    discount_amount     DECIMAL(19,4),
+    discount_currency   CHAR(3),
+    tax_amount          DECIMAL(19,4),
+    tax_currency        CHAR(3),
+    total_amount        DECIMAL(19,4),
+    total_currency      CHAR(3),
+    no_of_items         BIGINT,
+    version             BIGINT        NOT NULL DEFAULT 1,
+    PRIMARY KEY (order_id),
+    INDEX idx_orders_customer_id (customer_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE order_items (
+    order_item_id       VARCHAR(191)  NOT NULL,
+    order_id            VARCHAR(191),
+    article_id          VARCHAR(191),
+    quantity            BIGINT,
+    unit_price_amount   DECIMAL(19,4),
+    unit_price_currency CHAR(3),
+    price_list_id       VARCHAR(191),
+    allocated_quantity  BIGINT,
+    tax_class           VARCHAR(191),
+    tax_rule_id         VARCHAR(191),
+    tax_rate            DECIMAL(7,4),
+    tax_amount          DECIMAL(19,4),
+    tax_currency        CHAR(3),
+    PRIMARY KEY (order_item_id),
+    INDEX idx_order_items_order_id (order_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE order_discounts (
+    order_discount_id VARCHAR(191)  NOT NULL,
+    order_id          VARCHAR(191),
+    order_item_id     VARCHAR(191),
+    coupon_code       VARCHAR(191),
+    description       VARCHAR(191),
+    discount_amount   DECIMAL(19,4),
+    discount_currency CHAR(3),
+    PRIMARY KEY (order_discount_id),
+    INDEX idx_order_discounts_order_id (order_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE coupons (
+    code                  VARCHAR(191)  NOT NULL,
+    discount_type         VARCHAR(191),
+    scope                 VARCHAR(191),
+    article_id            VARCHAR(191),
+    value                 DECIMAL(19,4),
+    currency              CHAR(3),
+    min_order_value       DECIMAL(19,4),
+    max_uses              BIGINT,
+    max_uses_per_customer BIGINT,
+    used_count            BIGINT,
+    starts_at             DATETIME(3)   NULL,
+    ends_at               DATETIME(3)   NULL,
+    PRIMARY KEY (code)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE coupon_redemptions (
+    redemption_id VARCHAR(191) NOT NULL,
+    code          VARCHAR(191),
+    customer_id   VARCHAR(191),
+    order_id      VARCHAR(191),
+    redeemed_at   DATETIME(3)  NULL,
+    PRIMARY KEY (redemption_id),
+    INDEX idx_coupon_redemptions_code (code, customer_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE price_lists (
+    price_list_id  VARCHAR(191) NOT NULL,
+    name           VARCHAR(191),
+    currency       CHAR(3),
+    customer_id    VARCHAR(191),
+    customer_group VARCHAR(191),
+    valid_from     DATETIME(3)  NULL,
+    valid_to       DATETIME(3)  NULL,
+    PRIMARY KEY (price_list_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE price_list_items (
+    price_list_item_id VARCHAR(191)  NOT NULL,
+    price_list_id      VARCHAR(191),
+    article_id         VARCHAR(191),
+    min_quantity       BIGINT,
+    unit_price         DECIMAL(19,4),
+    discount_percent   DECIMAL(7,4),
+    PRIMARY KEY (price_list_item_id),
+    INDEX idx_price_list_items_price_list_id (price_list_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE tax_rules (
+    tax_rule_id VARCHAR(191) NOT NULL,
+    name        VARCHAR(191),
+    country     VARCHAR(191),
+    state       VARCHAR(191),
+    zip_prefix  VARCHAR(191),
+    tax_class   VARCHAR(191),
+    rate        DECIMAL(7,4),
+    valid_from  DATETIME(3)  NULL,
+    valid_to    DATETIME(3)  NULL,
+    PRIMARY KEY (tax_rule_id),
+    INDEX idx_tax_rules_country (country)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE invoices (
+    invoice_id     VARCHAR(191)  NOT NULL,
+    invoice_number VARCHAR(32),
+    type           VARCHAR(191),
+    order_id       VARCHAR(191),
+    invoice_ref    VARCHAR(191),
+    issued_at      DATETIME(3)   NULL,
+    total_amount   DECIMAL(19,4),
+    total_currency CHAR(3),
+    document       TEXT,
+    PRIMARY KEY (invoice_id),
+    UNIQUE INDEX idx_invoices_invoice_number (invoice_number),
+    INDEX idx_invoices_order_id (order_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE document_sequences (
+    name       VARCHAR(191) NOT NULL,
+    next_value BIGINT,
+    PRIMARY KEY (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE backorders (
+    backorder_id       VARCHAR(191) NOT NULL,
+    order_id           VARCHAR(191),
+    order_item_id      VARCHAR(191),
+    article_id         VARCHAR(191),
+    quantity           BIGINT,
+    allocated_quantity BIGINT,
+    status             VARCHAR(191),
+    ordered_at         DATETIME(3)  NULL,
+    PRIMARY KEY (backorder_id),
+    INDEX idx_backorders_order_id (order_id),
+    INDEX idx_backorders_article_id (article_id),
+    INDEX idx_backorders_ordered_at (ordered_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE shipments (
+    shipment_id     VARCHAR(191) NOT NULL,
+    order_id        VARCHAR(191),
+    carrier         VARCHAR(191),
+    tracking_number VARCHAR(191),
+    shipped_at      DATETIME(3)  NULL,
+    PRIMARY KEY (shipment_id),
+    INDEX idx_shipments_order_id (order_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE shipment_items (
+    shipment_item_id VARCHAR(191) NOT NULL,
+    shipment_id      VARCHAR(191),
+    order_id         VARCHAR(191),
+    order_item_id    VARCHAR(191),
+    article_id       VARCHAR(191),
+    quantity         BIGINT,
+    PRIMARY KEY (shipment_item_id),
+    INDEX idx_shipment_items_shipment_id (shipment_id),
+    INDEX idx_shipment_items_order_id (order_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE returns (
+    return_id          VARCHAR(191)  NOT NULL,
+    order_id           VARCHAR(191),
+    status             VARCHAR(191),
+    reason             VARCHAR(191),
+    requested_at       DATETIME(3)   NULL,
+    refund_amount      DECIMAL(19,4),
+    refund_currency    CHAR(3),
+    credit_note_number VARCHAR(191),
+    PRIMARY KEY (return_id),
+    INDEX idx_returns_order_id (order_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE return_items (
+    return_item_id     VARCHAR(191) NOT NULL,
+    return_id          VARCHAR(191),
+    order_item_id      VARCHAR(191),
+    article_id         VARCHAR(191),
+    quantity           BIGINT,
+    restocked_quantity BIGINT,
+    damaged_quantity   BIGINT,
+    PRIMARY KEY (return_item_id),
+    INDEX idx_return_items_return_id (return_id),
+    INDEX idx_return_items_order_item_id (order_item_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE payments (
+    payment_id      VARCHAR(191)  NOT NULL,
+    order_id        VARCHAR(191),
+    type            VARCHAR(191),
+    method          VARCHAR(191),
+    amount_amount   DECIMAL(19,4),
+    amount_currency CHAR(3),
+    status          VARCHAR(191),
+    external_ref    VARCHAR(191),
+    refund_of       VARCHAR(191),
+    reason          VARCHAR(191),
+    created_at      DATETIME(3)   NULL,
+    PRIMARY KEY (payment_id),
+    INDEX idx_payments_order_id (order_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE idempotency_keys (
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash    VARCHAR(64),
+    status_code     BIGINT,
+    response_body   TEXT,
+    created_at      DATETIME(3)  NULL,
+    expires_at      DATETIME(3)  NULL,
+    PRIMARY KEY (idempotency_key),
+    INDEX idx_idempotency_keys_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE import_jobs (
+    job_id     VARCHAR(191) NOT NULL,
+    type       VARCHAR(191),
+    format     VARCHAR(191),
+    dry_run    BOOLEAN,
+    status     VARCHAR(191),
+    total_rows BIGINT,
+    processed  BIGINT,
+    succeeded  BIGINT,
+    failed     BIGINT,
+    error      TEXT,
+    created_at DATETIME(3)  NULL,
+    updated_at DATETIME(3)  NULL,
+    PRIMARY KEY (job_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE import_errors (
+    import_error_id VARCHAR(191) NOT NULL,
+    job_id          VARCHAR(191),
+    line            BIGINT,
+    field           VARCHAR(191),
+    rule            VARCHAR(191),
+    param           VARCHAR(191),
+    PRIMARY KEY (import_error_id),
+    INDEX idx_import_errors_job_id (job_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
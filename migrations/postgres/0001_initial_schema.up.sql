@@ -0,0 +1,296 @@
+CREATE TABLE articles (
+    article_id     TEXT          NOT NULL,
+    article_name   TEXT,
+    price_amount   NUMERIC(19,4),
+    price_currency VARCHAR(3),
+    stock          BIGINT,
+    damaged_stock  BIGINT,
+    tax_class      TEXT,
+    version        BIGINT        NOT NULL DEFAULT 1,
+    PRIMARY KEY (article_id)
+);
+
+CREATE TABLE article_prices (
+    article_id TEXT          NOT NULL,
+    currency   VARCHAR(3)    NOT NULL,
+    amount     NUMERIC(19,4),
+    PRIMARY KEY (article_id, currency)
+);
+
+CREATE TABLE addresses (
+    address_id TEXT NOT NULL,
+    line1      TEXT,
+    line2      TEXT,
+    city       TEXT,
+    state      TEXT,
+    country    TEXT,
+    zip_code   TEXT,
+    PRIMARY KEY (address_id)
+);
+
+CREATE TABLE users (
+    id               TEXT   NOT NULL,
+    name             TEXT,
+    email            TEXT,
+    mobile           TEXT,
+    address_id       TEXT,
+    role             TEXT,
+    customer_group   TEXT,
+    backorder_policy TEXT,
+    version          BIGINT NOT NULL DEFAULT 1,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE orders (
+    order_id            TEXT          NOT NULL,
+    customer_id         TEXT,
+    ordered_at          TIMESTAMPTZ,
+    status              TEXT,
+    coupon_code         TEXT,
+    shipping_address_id TEXT,
+    prices_include_tax  BOOLEAN,
+    backorder_policy    TEXT,
+    payment_terms       TEXT,
+    subtotal_amount     NUMERIC(19,4),
+    subtotal_currency   VARCHAR(3),
+    discount_amount     NUMERIC(19,4),
+    discount_currency   VARCHAR(3),
+    tax_amount          NUMERIC(19,4),
+    tax_currency        VARCHAR(3),
+    total_amount        NUMERIC(19,4),
+    total_currency      VARCHAR(3),
+    no_of_items         BIGINT,
+    version             BIGINT        NOT NULL DEFAULT 1,
+    PRIMARY KEY (order_id)
+);
+CREATE INDEX idx_orders_customer_id ON orders (customer_id);
+
+CREATE TABLE order_items (
+    order_item_id       TEXT          NOT NULL,
+    order_id            TEXT,
+    article_id          TEXT,
+    quantity            BIGINT,
+    unit_price_amount   NUMERIC(19,4),
+    unit_price_currency VARCHAR(3),
+    price_list_id       TEXT,
+    allocated_quantity  BIGINT,
+    tax_class           TEXT,
+    tax_rule_id         TEXT,
+    tax_rate            NUMERIC(7,4),
+    tax_amount          NUMERIC(19,4),
+    tax_currency        VARCHAR(3),
+    PRIMARY KEY (order_item_id)
+);
+CREATE INDEX idx_order_items_order_id ON order_items (order_id);
+
+CREATE TABLE order_discounts (
+    order_discount_id TEXT          NOT NULL,
+    order_id          TEXT,
+    order_item_id     TEXT,
+    coupon_code       TEXT,
+    description       TEXT,
+    discount_amount   NUMERIC(19,4),
+    discount_currency VARCHAR(3),
+    PRIMARY KEY (order_discount_id)
+);
+CREATE INDEX idx_order_discounts_order_id ON order_discounts (order_id);
+
+CREATE TABLE coupons (
+    code                  TEXT          NOT NULL,
+    discount_type         TEXT,
+    scope                 TEXT,
+    article_id            TEXT,
+    value                 NUMERIC(19,4),
+    currency              VARCHAR(3),
+    min_order_value       NUMERIC(19,4),
+    max_uses              BIGINT,
+    max_uses_per_customer BIGINT,
+    used_count            BIGINT,
+    starts_at             TIMESTAMPTZ,
+    ends_at               TIMESTAMPTZ,
+    PRIMARY KEY (code)
+);
+
+CREATE TABLE coupon_redemptions (
+    redemption_id TEXT        NOT NULL,
+    code          TEXT,
+    customer_id   TEXT,
+    order_id      TEXT,
+    redeemed_at   TIMESTAMPTZ,
+    PRIMARY KEY (redemption_id)
+);
+CREATE INDEX idx_coupon_redemptions_code ON coupon_redemptions (code, customer_id);
+
+CREATE TABLE price_lists (
+    price_list_id  TEXT        NOT NULL,
+    name           TEXT,
+    currency       VARCHAR(3),
+    customer_id    TEXT,
+    customer_group TEXT,
+    valid_from     TIMESTAMPTZ,
+    valid_to       TIMESTAMPTZ,
+    PRIMARY KEY (price_list_id)
+);
+
+CREATE TABLE price_list_items (
+    price_list_item_id TEXT          NOT NULL,
+    price_list_id      TEXT,
+    article_id         TEXT,
+    min_quantity       BIGINT,
+    unit_price         NUMERIC(19,4),
+    discount_percent   NUMERIC(7,4),
+    PRIMARY KEY (price_list_item_id)
+);
+CREATE INDEX idx_price_list_items_price_list_id ON price_list_items (price_list_id);
+
+CREATE TABLE tax_rules (
+    tax_rule_id TEXT         NOT NULL,
+    name        TEXT,
+    country     TEXT,
+    state       TEXT,
+    zip_prefix  TEXT,
+    tax_class   TEXT,
+    rate        NUMERIC(7,4),
+    valid_from  TIMESTAMPTZ,
+    valid_to    TIMESTAMPTZ,
+    PRIMARY KEY (tax_rule_id)
+);
+CREATE INDEX idx_tax_rules_country ON tax_rules (country);
+
+CREATE TABLE invoices (
+    invoice_id     TEXT          NOT NULL,
+    invoice_number VARCHAR(32),
+    type           TEXT,
+    order_id       TEXT,
+    invoice_ref    TEXT,
+    issued_at      TIMESTAMPTZ,
+    total_amount   NUMERIC(19,4),
+    total_currency VARCHAR(3),
+    document       TEXT,
+    PRIMARY KEY (invoice_id)
+);
+CREATE UNIQUE INDEX idx_invoices_invoice_number ON invoices (invoice_number);
+CREATE INDEX idx_invoices_order_id ON invoices (order_id);
+
+CREATE TABLE document_sequences (
+    name       TEXT   NOT NULL,
+    next_value BIGINT,
+    PRIMARY KEY (name)
+);
+
+CREATE TABLE backorders (
+    backorder_id       TEXT        NOT NULL,
+    order_id           TEXT,
+    order_item_id      TEXT,
+    article_id         TEXT,
+    quantity           BIGINT,
+    allocated_quantity BIGINT,
+    status             TEXT,
+    ordered_at         TIMESTAMPTZ,
+    PRIMARY KEY (backorder_id)
+);
+CREATE INDEX idx_backorders_order_id ON backorders (order_id);
+CREATE INDEX idx_backorders_article_id ON backorders (article_id);
+CREATE INDEX idx_backorders_ordered_at ON backorders (ordered_at);
+
+CREATE TABLE shipments (
+    shipment_id     TEXT        NOT NULL,
+    order_id        TEXT,
+    carrier         TEXT,
+    tracking_number TEXT,
+    shipped_at      TIMESTAMPTZ,
+    PRIMARY KEY (shipment_id)
+);
+CREATE INDEX idx_shipments_order_id ON shipments (order_id);
+
+CREATE TABLE shipment_items (
+    shipment_item_id TEXT   NOT NULL,
+    shipment_id      TEXT,
+    order_id         TEXT,
+    order_item_id    TEXT,
+    article_id       TEXT,
+    quantity         BIGINT,
+    PRIMARY KEY (shipment_item_id)
+);
+CREATE INDEX idx_shipment_items_shipment_id ON shipment_items (shipment_id);
+CREATE INDEX idx_shipment_items_order_id ON shipment_items (order_id);
+
+CREATE TABLE returns (
+    return_id          TEXT          NOT NULL,
+    order_id           TEXT,
+    status             TEXT,
+    reason             TEXT,
+    requested_at       TIMESTAMPTZ,
+    refund_amount      NUMERIC(19,4),
+    refund_currency    VARCHAR(3),
+    credit_note_number TEXT,
+    PRIMARY KEY (return_id)
+);
+CREATE INDEX idx_returns_order_id ON returns (order_id);
+
+CREATE TABLE return_items (
+    return_item_id     TEXT   NOT NULL,
+    return_id          TEXT,
+    order_item_id      TEXT,
+    article_id         TEXT,
+    quantity           BIGINT,
+    restocked_quantity BIGINT,
+    damaged_quantity   BIGINT,
+    PRIMARY KEY (return_item_id)
+);
+CREATE INDEX idx_return_items_return_id ON return_items (return_id);
+CREATE INDEX idx_return_items_order_item_id ON return_items (order_item_id);
+
+CREATE TABLE payments (
+    payment_id      TEXT          NOT NULL,
+    order_id        TEXT,
+    type            TEXT,
+    method          TEXT,
+    amount_amount   NUMERIC(19,4),
+    amount_currency VARCHAR(3),
+    status          TEXT,
+    external_ref    TEXT,
+    refund_of       TEXT,
+    reason          TEXT,
+    created_at      TIMESTAMPTZ,
+    PRIMARY KEY (payment_id)
+);
+CREATE INDEX idx_payments_order_id ON payments (order_id);
+
+CREATE TABLE idempotency_keys (
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash    VARCHAR(64),
+    status_code     BIGINT,
+    response_body   TEXT,
+    created_at      TIMESTAMPTZ,
+    expires_at      TIMESTAMPTZ,
+    PRIMARY KEY (idempotency_key)
+);
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+CREATE TABLE import_jobs (
+    job_id     TEXT        NOT NULL,
+    type       TEXT,
+    format     TEXT,
+    dry_run    BOOLEAN,
+    status     TEXT,
+    total_rows BIGINT,
+    processed  BIGINT,
+    succeeded  BIGINT,
+    failed     BIGINT,
+    error      TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    PRIMARY KEY (job_id)
+);
+
+CREATE TABLE import_errors (
+    import_error_id TEXT   NOT NULL,
+    job_id          TEXT,
+    line            BIGINT,
+    field           TEXT,
+    rule            TEXT,
+    param           TEXT,
+    PRIMARY KEY (import_error_id)
+);
+CREATE INDEX idx_import_errors_job_id ON import_errors (job_id);
@@ -1,8 +1,27 @@
 package models
 
+import (
+	"inventory-management/money"
+
+	"github.com/shopspring/decimal"
+)
+
 type Article struct {
-	ArticleId   string  `json:"article_id" gorm:"primaryKey"`
-	ArticleName string  `json:"article_name"`
-	Price       float64 `json:"price"`
-	Stock       int64   `json:"stock"`
+	ArticleId    string      `json:"article_id" gorm:"primaryKey"`
+	ArticleName  string      `json:"article_name"`
+	Price        money.Money `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	Stock        int64       `json:"stock"`
+	DamagedStock int64       `json:"damaged_stock"`
+	TaxClass     string      `json:"tax_class"`
+	Version      int64       `json:"version" gorm:"not null;default:1"`
+}
+
+type ArticlePrice struct {
+	ArticleId string          `json:"article_id" gorm:"primaryKey"`
+	Currency  string          `json:"currency" gorm:"primaryKey;type:char(3)"`
+	Amount    decimal.Decimal `json:"amount" gorm:"type:decimal(19,4)"`
+}
+
+func (ap *ArticlePrice) Money() money.Money {
+	return money.New(ap.Amount, ap.Currency)
 }
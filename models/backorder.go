@@ -0,0 +1,35 @@
+package models
+
+import (
+	"errors"
+	"inventory-management/constants"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Backorder is the part of an order item that could not be allocated from
+// stock. OrderedAt is copied from the order so open backorders are allocated
+// in the order they were placed.
+type Backorder struct {
+	BackorderId       string    `json:"backorder_id" gorm:"primaryKey"`
+	OrderId           string    `json:"order_id" gorm:"index"`
+	OrderItemId       string    `json:"order_item_id"`
+	ArticleId         string    `json:"article_id" gorm:"index"`
+	Quantity          int       `json:"quantity"`
+	AllocatedQuantity int       `json:"allocated_quantity"`
+	Status            string    `json:"status"`
+	OrderedAt         time.Time `json:"ordered_at" gorm:"index"`
+}
+
+func (b *Backorder) BeforeSave(tx *gorm.DB) error {
+	if b.Quantity <= 0 {
+		return errors.New("quantity must be positive")
+	}
+
+	return nil
+}
+
+func ValidBackorderPolicy(policy string) bool {
+	return policy == "" || policy == constants.BackorderPolicyAllow || policy == constants.BackorderPolicyReject
+}
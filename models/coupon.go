@@ -0,0 +1,62 @@
+package models
+
+import (
+	"errors"
+	"inventory-management/constants"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type Coupon struct {
+	Code               string          `json:"code" gorm:"primaryKey"`
+	DiscountType       string          `json:"discount_type"`
+	Scope              string          `json:"scope"`
+	ArticleId          string          `json:"article_id"`
+	Value              decimal.Decimal `json:"value" gorm:"type:decimal(19,4)"`
+	Currency           string          `json:"currency" gorm:"type:char(3)"`
+	MinOrderValue      decimal.Decimal `json:"min_order_value" gorm:"type:decimal(19,4)"`
+	MaxUses            int             `json:"max_uses"`
+	MaxUsesPerCustomer int             `json:"max_uses_per_customer"`
+	UsedCount          int             `json:"used_count"`
+	StartsAt           *time.Time      `json:"starts_at"`
+	EndsAt             *time.Time      `json:"ends_at"`
+}
+
+func (c *Coupon) BeforeSave(tx *gorm.DB) error {
+	if strings.TrimSpace(c.Code) == "" {
+		return errors.New("code is required")
+	}
+
+	if c.DiscountType != constants.DiscountTypePercentage && c.DiscountType != constants.DiscountTypeFixed {
+		return errors.New("discount type must be percentage or fixed")
+	}
+
+	if c.Scope != constants.CouponScopeOrder && c.Scope != constants.CouponScopeArticle {
+		return errors.New("scope must be order or article")
+	}
+
+	if c.Scope == constants.CouponScopeArticle && c.ArticleId == "" {
+		return errors.New("article id is required for article coupons")
+	}
+
+	if !c.Value.IsPositive() {
+		return errors.New("value must be positive")
+	}
+
+	if c.DiscountType == constants.DiscountTypePercentage && c.Value.GreaterThan(decimal.NewFromInt(100)) {
+		return errors.New("percentage must not exceed 100")
+	}
+
+	return nil
+}
+
+type CouponRedemption struct {
+	RedemptionId string    `json:"redemption_id" gorm:"primaryKey"`
+	Code         string    `json:"code"`
+	CustomerId   string    `json:"customer_id"`
+	OrderId      string    `json:"order_id"`
+	RedeemedAt   time.Time `json:"redeemed_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// IdempotencyKey remembers the response to a request sent with an
+// Idempotency-Key header. StatusCode stays zero while the first request is
+// still being handled.
+type IdempotencyKey struct {
+	Key          string    `json:"key" gorm:"column:idempotency_key;primaryKey;size:255"`
+	RequestHash  string    `json:"request_hash" gorm:"size:64"`
+	StatusCode   int       `json:"status_code"`
+	ResponseBody string    `json:"response_body" gorm:"type:text"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at" gorm:"index"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ImportJob tracks a bulk import that runs in the background. Rows that fail
+// are kept as ImportErrors so the whole file can be fixed in one go.
+type ImportJob struct {
+	JobId     string    `json:"job_id" gorm:"primaryKey"`
+	Type      string    `json:"type"`
+	Format    string    `json:"format"`
+	DryRun    bool      `json:"dry_run"`
+	Status    string    `json:"status"`
+	TotalRows int       `json:"total_rows"`
+	Processed int       `json:"processed"`
+	Succeeded int       `json:"succeeded"`
+	Failed    int       `json:"failed"`
+	Error     string    `json:"error"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (j *ImportJob) BeforeSave(tx *gorm.DB) error {
+	if j.Type == "" {
+		return errors.New("type is required")
+	}
+
+	return nil
+}
+
+// ImportError is a field of an imported row that failed validation. Line is the
+// line in the file, counting the header as line 1.
+type ImportError struct {
+	ImportErrorId string `json:"import_error_id" gorm:"primaryKey"`
+	JobId         string `json:"job_id" gorm:"index"`
+	Line          int    `json:"line"`
+	Field         string `json:"field"`
+	Rule          string `json:"rule"`
+	Param         string `json:"param"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"inventory-management/money"
+	"time"
+)
+
+// Invoice is an issued invoice or credit note. Document holds the complete
+// JSON snapshot it was issued with so it renders the same way forever.
+type Invoice struct {
+	InvoiceId     string      `json:"invoice_id" gorm:"primaryKey"`
+	InvoiceNumber string      `json:"invoice_number" gorm:"uniqueIndex;size:32"`
+	Type          string      `json:"type"`
+	OrderId       string      `json:"order_id" gorm:"index"`
+	InvoiceRef    string      `json:"invoice_ref"`
+	IssuedAt      time.Time   `json:"issued_at"`
+	TotalAmount   money.Money `json:"total_amount" gorm:"embedded;embeddedPrefix:total_"`
+	Document      string      `json:"document" gorm:"type:text"`
+}
+
+type DocumentSequence struct {
+	Name      string `json:"name" gorm:"primaryKey"`
+	NextValue int64  `json:"next_value"`
+}
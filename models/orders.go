@@ -2,17 +2,29 @@ package models
 
 import (
 	"errors"
+	"inventory-management/money"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
 type Order struct {
-	OrderId     string    `json:"order_id" gorm:"primaryKey"`
-	CustomerId  string    `json:"customer_id"`
-	OrderedAt   time.Time `json:"ordered_at"`
-	TotalAmount float64   `json:"total_amount"`
-	NoOfItems   int       `json:"no_of_items"`
+	OrderId           string      `json:"order_id" gorm:"primaryKey"`
+	CustomerId        string      `json:"customer_id"`
+	OrderedAt         time.Time   `json:"ordered_at"`
+	Status            string      `json:"status"`
+	CouponCode        string      `json:"coupon_code"`
+	ShippingAddressId string      `json:"shipping_address_id"`
+	PricesIncludeTax  bool        `json:"prices_include_tax"`
+	BackorderPolicy   string      `json:"backorder_policy"`
+	PaymentTerms      string      `json:"payment_terms"`
+	Subtotal          money.Money `json:"subtotal" gorm:"embedded;embeddedPrefix:subtotal_"`
+	DiscountAmount    money.Money `json:"discount_amount" gorm:"embedded;embeddedPrefix:discount_"`
+	TaxAmount         money.Money `json:"tax_amount" gorm:"embedded;embeddedPrefix:tax_"`
+	TotalAmount       money.Money `json:"total_amount" gorm:"embedded;embeddedPrefix:total_"`
+	NoOfItems         int         `json:"no_of_items"`
+	Version           int64       `json:"version" gorm:"not null;default:1"`
 }
 
 func (o *Order) BeforeSave(tx *gorm.DB) error {
@@ -20,14 +32,35 @@ func (o *Order) BeforeSave(tx *gorm.DB) error {
 		return errors.New("customer id is required")
 	}
 
+	if !ValidBackorderPolicy(o.BackorderPolicy) {
+		return errors.New("invalid backorder policy")
+	}
+
+	if !ValidPaymentTerms(o.PaymentTerms) {
+		return errors.New("invalid payment terms")
+	}
+
 	return nil
 }
 
 type OrderItem struct {
-	OrderItemId string `json:"order_item_id" gorm:"primaryKey"`
-	OrderId     string `json:"order_id"`
-	ArticleId   string `json:"article_id"`
-	Quantity    int    `json:"quantity"`
+	OrderItemId string      `json:"order_item_id" gorm:"primaryKey"`
+	OrderId     string      `json:"order_id"`
+	ArticleId   string      `json:"article_id"`
+	Quantity    int         `json:"quantity"`
+	UnitPrice   money.Money `json:"unit_price" gorm:"embedded;embeddedPrefix:unit_price_"`
+	PriceListId string      `json:"price_list_id"`
+
+	// AllocatedQuantity is the part of Quantity taken out of stock for the
+	// order. The rest is backordered.
+	AllocatedQuantity int `json:"allocated_quantity"`
+
+	// The tax rule and rate are copied onto the item so the order can be
+	// reproduced after the rule changes.
+	TaxClass  string          `json:"tax_class"`
+	TaxRuleId string          `json:"tax_rule_id"`
+	TaxRate   decimal.Decimal `json:"tax_rate" gorm:"type:decimal(7,4)"`
+	TaxAmount money.Money     `json:"tax_amount" gorm:"embedded;embeddedPrefix:tax_"`
 }
 
 func (oi *OrderItem) BeforeSave(tx *gorm.DB) error {
@@ -37,3 +70,12 @@ func (oi *OrderItem) BeforeSave(tx *gorm.DB) error {
 
 	return nil
 }
+
+type OrderDiscount struct {
+	OrderDiscountId string      `json:"order_discount_id" gorm:"primaryKey"`
+	OrderId         string      `json:"order_id"`
+	OrderItemId     string      `json:"order_item_id"`
+	CouponCode      string      `json:"coupon_code"`
+	Description     string      `json:"description"`
+	Amount          money.Money `json:"amount" gorm:"embedded;embeddedPrefix:discount_"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Payment is money received for an order or paid back to the customer. A
+// refund points at the payment it was paid back against through RefundOf.
+type Payment struct {
+	PaymentId   string      `json:"payment_id" gorm:"primaryKey"`
+	OrderId     string      `json:"order_id" gorm:"index"`
+	Type        string      `json:"type"`
+	Method      string      `json:"method"`
+	Amount      money.Money `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	Status      string      `json:"status"`
+	ExternalRef string      `json:"external_ref"`
+	RefundOf    string      `json:"refund_of"`
+	Reason      string      `json:"reason"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+func (p *Payment) BeforeSave(tx *gorm.DB) error {
+	if p.OrderId == "" {
+		return errors.New("order id is required")
+	}
+
+	if !p.Amount.Amount.IsPositive() {
+		return errors.New("amount must be positive")
+	}
+
+	if p.Type != constants.PaymentTypePayment && p.Type != constants.PaymentTypeRefund {
+		return errors.New("invalid payment type")
+	}
+
+	return nil
+}
+
+func ValidPaymentTerms(terms string) bool {
+	return terms == "" || terms == constants.PaymentTermsPrepaid || terms == constants.PaymentTermsOnAccount
+}
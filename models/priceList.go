@@ -0,0 +1,63 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type PriceList struct {
+	PriceListId   string     `json:"price_list_id" gorm:"primaryKey"`
+	Name          string     `json:"name"`
+	Currency      string     `json:"currency" gorm:"type:char(3)"`
+	CustomerId    string     `json:"customer_id"`
+	CustomerGroup string     `json:"customer_group"`
+	ValidFrom     *time.Time `json:"valid_from"`
+	ValidTo       *time.Time `json:"valid_to"`
+}
+
+func (p *PriceList) BeforeSave(tx *gorm.DB) error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if p.CustomerId == "" && p.CustomerGroup == "" {
+		return errors.New("customer id or customer group is required")
+	}
+
+	if p.ValidFrom != nil && p.ValidTo != nil && p.ValidTo.Before(*p.ValidFrom) {
+		return errors.New("valid to must not be before valid from")
+	}
+
+	return nil
+}
+
+// PriceListItem is one quantity break for an article. It either fixes the unit
+// price in the price list currency or takes a percentage off the standard price.
+type PriceListItem struct {
+	PriceListItemId string              `json:"price_list_item_id" gorm:"primaryKey"`
+	PriceListId     string              `json:"price_list_id"`
+	ArticleId       string              `json:"article_id"`
+	MinQuantity     int                 `json:"min_quantity"`
+	UnitPrice       decimal.NullDecimal `json:"unit_price" gorm:"type:decimal(19,4)"`
+	DiscountPercent decimal.NullDecimal `json:"discount_percent" gorm:"type:decimal(7,4)"`
+}
+
+func (pi *PriceListItem) BeforeSave(tx *gorm.DB) error {
+	if pi.ArticleId == "" {
+		return errors.New("article id is required")
+	}
+
+	if pi.MinQuantity < 1 {
+		return errors.New("min quantity must be at least 1")
+	}
+
+	if pi.UnitPrice.Valid == pi.DiscountPercent.Valid {
+		return errors.New("exactly one of unit price or discount percent is required")
+	}
+
+	return nil
+}
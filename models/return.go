@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"inventory-management/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Return is a return merchandise authorisation for part of an order.
+// CreditNoteNumber is set once the return is refunded.
+type Return struct {
+	ReturnId         string      `json:"return_id" gorm:"primaryKey"`
+	OrderId          string      `json:"order_id" gorm:"index"`
+	Status           string      `json:"status"`
+	Reason           string      `json:"reason"`
+	RequestedAt      time.Time   `json:"requested_at"`
+	RefundAmount     money.Money `json:"refund_amount" gorm:"embedded;embeddedPrefix:refund_"`
+	CreditNoteNumber string      `json:"credit_note_number"`
+}
+
+func (r *Return) BeforeSave(tx *gorm.DB) error {
+	if r.OrderId == "" {
+		return errors.New("order id is required")
+	}
+
+	return nil
+}
+
+// ReturnItem is a returned quantity of one order item. Inspection splits the
+// quantity into units put back into stock and units that are damaged.
+type ReturnItem struct {
+	ReturnItemId      string `json:"return_item_id" gorm:"primaryKey"`
+	ReturnId          string `json:"return_id" gorm:"index"`
+	OrderItemId       string `json:"order_item_id" gorm:"index"`
+	ArticleId         string `json:"article_id"`
+	Quantity          int    `json:"quantity"`
+	RestockedQuantity int    `json:"restocked_quantity"`
+	DamagedQuantity   int    `json:"damaged_quantity"`
+}
+
+func (ri *ReturnItem) BeforeSave(tx *gorm.DB) error {
+	if ri.Quantity <= 0 {
+		return errors.New("quantity must be positive")
+	}
+
+	if ri.RestockedQuantity < 0 || ri.DamagedQuantity < 0 || ri.RestockedQuantity+ri.DamagedQuantity > ri.Quantity {
+		return errors.New("inspected quantities must be between zero and the returned quantity")
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Shipment struct {
+	ShipmentId     string    `json:"shipment_id" gorm:"primaryKey"`
+	OrderId        string    `json:"order_id" gorm:"index"`
+	Carrier        string    `json:"carrier"`
+	TrackingNumber string    `json:"tracking_number"`
+	ShippedAt      time.Time `json:"shipped_at"`
+}
+
+func (s *Shipment) BeforeSave(tx *gorm.DB) error {
+	if s.OrderId == "" {
+		return errors.New("order id is required")
+	}
+
+	return nil
+}
+
+// ShipmentItem is the quantity of one order item sent in a shipment. OrderId
+// is copied from the shipment so an order's shipped quantities can be read
+// without a join.
+type ShipmentItem struct {
+	ShipmentItemId string `json:"shipment_item_id" gorm:"primaryKey"`
+	ShipmentId     string `json:"shipment_id" gorm:"index"`
+	OrderId        string `json:"order_id" gorm:"index"`
+	OrderItemId    string `json:"order_item_id"`
+	ArticleId      string `json:"article_id"`
+	Quantity       int    `json:"quantity"`
+}
+
+func (si *ShipmentItem) BeforeSave(tx *gorm.DB) error {
+	if si.Quantity <= 0 {
+		return errors.New("quantity must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// TaxRule is the rate for one tax class in a destination. State and ZipPrefix
+// are optional and narrow the rule; the most specific matching rule wins.
+type TaxRule struct {
+	TaxRuleId string          `json:"tax_rule_id" gorm:"primaryKey"`
+	Name      string          `json:"name"`
+	Country   string          `json:"country"`
+	State     string          `json:"state"`
+	ZipPrefix string          `json:"zip_prefix"`
+	TaxClass  string          `json:"tax_class"`
+	Rate      decimal.Decimal `json:"rate" gorm:"type:decimal(7,4)"`
+	ValidFrom *time.Time      `json:"valid_from"`
+	ValidTo   *time.Time      `json:"valid_to"`
+}
+
+func (t *TaxRule) BeforeSave(tx *gorm.DB) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if t.Country == "" {
+		return errors.New("country is required")
+	}
+
+	if t.TaxClass == "" {
+		return errors.New("tax class is required")
+	}
+
+	if t.Rate.IsNegative() || t.Rate.GreaterThan(decimal.NewFromInt(100)) {
+		return errors.New("rate must be between 0 and 100")
+	}
+
+	if t.ValidFrom != nil && t.ValidTo != nil && t.ValidTo.Before(*t.ValidFrom) {
+		return errors.New("valid to must not be before valid from")
+	}
+
+	return nil
+}
@@ -8,18 +8,28 @@ import (
 )
 
 type User struct {
-	Id        string `json:"id" gorm:"primaryKey"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	Mobile    string `json:"mobile"`
-	AddressId string `json:"address_id"`
-	Role      string `json:"role"`
+	Id            string `json:"id" gorm:"primaryKey"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Mobile        string `json:"mobile"`
+	AddressId     string `json:"address_id"`
+	Role          string `json:"role"`
+	CustomerGroup string `json:"customer_group"`
+
+	// BackorderPolicy is the customer's default for their orders. Empty means
+	// backorders are rejected.
+	BackorderPolicy string `json:"backorder_policy"`
+
+	Version int64 `json:"version" gorm:"not null;default:1"`
 }
 
 func (u *User) BeforeSave(tx *gorm.DB) error {
 	if strings.TrimSpace(u.Name) == "" {
 		return errors.New("name is required")
 	}
+	if !ValidBackorderPolicy(u.BackorderPolicy) {
+		return errors.New("invalid backorder policy")
+	}
 	return nil
 }
 
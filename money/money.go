@@ -0,0 +1,157 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"inventory-management/constants"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// minorUnits lists the ISO 4217 currencies whose minor unit is not the usual
+// two decimal places.
+var minorUnits = map[string]int32{
+	"BHD": 3,
+	"CLP": 0,
+	"IQD": 3,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"LYD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"UGX": 0,
+	"VND": 0,
+}
+
+// Money is an exact decimal amount in a single ISO 4217 currency.
+type Money struct {
+	Amount   decimal.Decimal `json:"amount" gorm:"type:decimal(19,4)"`
+	Currency string          `json:"currency" gorm:"type:char(3)"`
+}
+
+func New(amount decimal.Decimal, currency string) Money {
+	currency = NormalizeCurrency(currency)
+	return Money{Amount: amount.Round(MinorUnits(currency)), Currency: currency}
+}
+
+func Zero(currency string) Money {
+	return New(decimal.Zero, currency)
+}
+
+func MustParse(amount string, currency string) Money {
+	return New(decimal.RequireFromString(amount), currency)
+}
+
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// ValidCurrency reports whether currency looks like an ISO 4217 alphabetic code.
+func ValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func MinorUnits(currency string) int32 {
+	if units, ok := minorUnits[currency]; ok {
+		return units
+	}
+
+	return 2
+}
+
+func (m Money) Round() Money {
+	return New(m.Amount, m.Currency)
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount.IsNegative()
+}
+
+func (m Money) Equal(o Money) bool {
+	return m.Currency == o.Currency && m.Amount.Equal(o.Amount)
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, constants.ErrorCurrencyMismatch
+	}
+
+	return New(m.Amount.Add(o.Amount), m.Currency), nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, constants.ErrorCurrencyMismatch
+	}
+
+	return New(m.Amount.Sub(o.Amount), m.Currency), nil
+}
+
+func (m Money) Mul(quantity int64) Money {
+	return New(m.Amount.Mul(decimal.NewFromInt(quantity)), m.Currency)
+}
+
+func (m Money) String() string {
+	return m.Amount.StringFixed(MinorUnits(m.Currency)) + " " + m.Currency
+}
+
+// MarshalJSON always renders the amount with the currency's minor units so
+// that 100 INR is sent as "100.00".
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{
+		Amount:   m.Amount.StringFixed(MinorUnits(m.Currency)),
+		Currency: m.Currency,
+	})
+}
+
+// UnmarshalJSON accepts the {"amount": ..., "currency": ...} form as well as a
+// bare number or numeric string, which leaves the currency empty so callers can
+// fall back to the base currency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] != '{' {
+		var amount decimal.Decimal
+		if err := amount.UnmarshalJSON(data); err != nil {
+			return err
+		}
+
+		m.Amount = amount
+		m.Currency = ""
+		return nil
+	}
+
+	type plain Money
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+
+	m.Amount = p.Amount
+	m.Currency = NormalizeCurrency(p.Currency)
+	return nil
+}
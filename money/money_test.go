@@ -0,0 +1,60 @@
+package money
+
+import (
+	"encoding/json"
+	"inventory-management/constants"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRoundsToMinorUnits(t *testing.T) {
+	assert.Equal(t, "10.13 EUR", New(decimal.RequireFromString("10.125"), "eur").String())
+	assert.Equal(t, "1013 JPY", New(decimal.RequireFromString("1012.5"), "JPY").String())
+	assert.Equal(t, "1.235 KWD", New(decimal.RequireFromString("1.2345"), "KWD").String())
+}
+
+func TestAddIsExact(t *testing.T) {
+	total := Zero("USD")
+	for i := 0; i < 10; i++ {
+		total, _ = total.Add(MustParse("0.10", "USD"))
+	}
+
+	assert.True(t, total.Equal(MustParse("1", "USD")))
+}
+
+func TestAddCurrencyMismatch(t *testing.T) {
+	_, err := MustParse("1", "USD").Add(MustParse("1", "EUR"))
+	assert.Equal(t, constants.ErrorCurrencyMismatch, err)
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var m Money
+	err := json.Unmarshal([]byte(`{"amount":"12.50","currency":"usd"}`), &m)
+	assert.NoError(t, err)
+	assert.True(t, m.Equal(MustParse("12.5", "USD")))
+
+	err = json.Unmarshal([]byte(`99.99`), &m)
+	assert.NoError(t, err)
+	assert.Equal(t, "", m.Currency)
+	assert.True(t, decimal.RequireFromString("99.99").Equal(m.Amount))
+}
+
+func TestConvert(t *testing.T) {
+	rates := NewRates("INR", map[string]decimal.Decimal{
+		"usd": decimal.RequireFromString("0.012"),
+		"EUR": decimal.RequireFromString("0.011"),
+	})
+
+	usd, err := rates.Convert(MustParse("1000", "INR"), "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, "12.00 USD", usd.String())
+
+	eur, err := rates.Convert(MustParse("12", "USD"), "EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, "11.00 EUR", eur.String())
+
+	_, err = rates.Convert(MustParse("1", "USD"), "GBP")
+	assert.Equal(t, constants.ErrorExchangeRateNotFound, err)
+}
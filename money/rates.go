@@ -0,0 +1,58 @@
+package money
+
+import (
+	"inventory-management/constants"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rates holds exchange rates quoted against a base currency, i.e. one unit of
+// the base currency buys Rates[code] units of code.
+type Rates struct {
+	Base  string
+	Rates map[string]decimal.Decimal
+}
+
+func NewRates(base string, rates map[string]decimal.Decimal) *Rates {
+	normalized := make(map[string]decimal.Decimal, len(rates))
+	for code, rate := range rates {
+		normalized[NormalizeCurrency(code)] = rate
+	}
+
+	return &Rates{
+		Base:  NormalizeCurrency(base),
+		Rates: normalized,
+	}
+}
+
+func (r *Rates) rate(currency string) (decimal.Decimal, error) {
+	if currency == r.Base {
+		return decimal.NewFromInt(1), nil
+	}
+
+	rate, ok := r.Rates[currency]
+	if !ok || !rate.IsPositive() {
+		return decimal.Zero, constants.ErrorExchangeRateNotFound
+	}
+
+	return rate, nil
+}
+
+func (r *Rates) Convert(m Money, to string) (Money, error) {
+	to = NormalizeCurrency(to)
+	if m.Currency == to {
+		return m.Round(), nil
+	}
+
+	from, err := r.rate(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	target, err := r.rate(to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return New(m.Amount.Div(from).Mul(target), to), nil
+}
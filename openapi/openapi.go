@@ -0,0 +1,274 @@
+package openapi
+
+import (
+	_ "embed"
+	"inventory-management/dtos"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// Version is the OpenAPI version documents are written in.
+const Version = "3.1.0"
+
+// DocsPage is the interactive docs, a Swagger UI page reading /openapi.json.
+//
+//go:embed docs.html
+var DocsPage []byte
+
+// SwaggerUI holds the swagger-ui-dist files the docs page loads, Swagger UI
+// 5.18.2 as embedded by github.com/swaggo/files/v2, so the docs work offline
+// and load nothing that go.sum doesn't pin.
+var SwaggerUI = swaggerFiles.FS
+
+// Document is the part of OpenAPI the API is described with.
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Tags       []*Tag                `json:"tags,omitempty"`
+	Security   []map[string][]string `json:"security"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations on a path by lower case method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationId string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Route describes an endpoint. Request and Response are values of the DTOs
+// sent and returned, whose schemas are worked out from their types, or a
+// *Schema to use as it is.
+type Route struct {
+	Method     string
+	Path       string
+	Id         string
+	Tag        string
+	Summary    string
+	Parameters []*Parameter
+	Request    any
+	// Patch sends Request as a JSON merge patch.
+	Patch bool
+	// Upload sends an import file as a multipart form.
+	Upload   bool
+	Status   int
+	Response any
+	// Files lists the media types of a response sent as a file instead.
+	Files  []string
+	Errors []int
+}
+
+// The headers routes take.
+var (
+	IfMatch = &Parameter{
+		Name: "If-Match", In: "header", Required: true, Schema: &Schema{Type: "string"},
+		Description: "The ETag of the version the change is based on.",
+	}
+	IfNoneMatch = &Parameter{
+		Name: "If-None-Match", In: "header", Schema: &Schema{Type: "string"},
+		Description: "ETags the client already has; a match is answered with 304.",
+	}
+	IdempotencyKey = &Parameter{
+		Name: "Idempotency-Key", In: "header", Schema: &Schema{Type: "string"},
+		Description: "Replays the first response to requests retried with the same key.",
+	}
+)
+
+// Query returns an optional query parameter taking one of values.
+func Query(name string, description string, values ...string) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string", Enum: values}}
+}
+
+// Message is the schema of the {"message": ...} answers to writes, along
+// with the string fields in ids.
+func Message(ids ...string) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{"message": {Type: "string"}}, Required: []string{"message"}}
+	for _, v := range ids {
+		schema.Properties[v] = &Schema{Type: "string"}
+	}
+
+	return schema
+}
+
+// Build writes the document for routes. The API has no authentication of its
+// own, which the document states with an empty security requirement.
+func Build(info Info, tags []*Tag, routes []*Route) *Document {
+	document := &Document{
+		OpenAPI:  Version,
+		Info:     info,
+		Tags:     tags,
+		Security: []map[string][]string{},
+		Paths:    make(map[string]PathItem),
+		Components: Components{Schemas: map[string]*Schema{
+			"Error": {Type: "string", Description: "What went wrong."},
+		}},
+	}
+
+	schemas := newGenerator(document.Components.Schemas)
+	schemas.ref(reflect.TypeFor[dtos.ValidationErrors]())
+
+	for _, v := range routes {
+		path := Path(v.Path)
+		if document.Paths[path] == nil {
+			document.Paths[path] = make(PathItem)
+		}
+
+		document.Paths[path][strings.ToLower(v.Method)] = operation(v, schemas)
+	}
+
+	return document
+}
+
+// Path turns a gin path into an OpenAPI one, e.g. /orders/:id into
+// /orders/{id}.
+func Path(path string) string {
+	segments := strings.Split(path, "/")
+	for i, v := range segments {
+		if strings.HasPrefix(v, ":") || strings.HasPrefix(v, "*") {
+			segments[i] = "{" + v[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func operation(route *Route, schemas *generator) *Operation {
+	op := &Operation{
+		OperationId: route.Id,
+		Summary:     route.Summary,
+		Tags:        []string{route.Tag},
+		Responses:   make(map[string]*Response),
+	}
+
+	for _, v := range strings.Split(route.Path, "/") {
+		if strings.HasPrefix(v, ":") || strings.HasPrefix(v, "*") {
+			op.Parameters = append(op.Parameters, &Parameter{Name: v[1:], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+	op.Parameters = append(op.Parameters, route.Parameters...)
+
+	switch {
+	case route.Upload:
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{
+			"multipart/form-data": {Schema: &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"file":    {Type: "string", Format: "binary"},
+					"format":  {Type: "string", Enum: []string{"csv", "xlsx"}, Description: "Defaults to the file extension."},
+					"mapping": {Type: "string", Description: "A JSON object of field to column header."},
+				},
+				Required: []string{"file"},
+			}},
+		}}
+	case route.Request != nil:
+		contentType := "application/json"
+		if route.Patch {
+			contentType = "application/merge-patch+json"
+		}
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{
+			contentType: {Schema: schemas.of(route.Request)},
+		}}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	success := &Response{Description: http.StatusText(status), Content: make(map[string]*MediaType)}
+	if route.Response != nil {
+		success.Content["application/json"] = &MediaType{Schema: schemas.of(route.Response)}
+	}
+	for _, v := range route.Files {
+		success.Content[v] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+
+	for _, v := range route.Errors {
+		op.Responses[strconv.Itoa(v)] = errorResponse(v)
+	}
+
+	return op
+}
+
+// errorResponse describes an error status. Errors are sent as a JSON string
+// holding the message, except for failed validation which lists the fields.
+func errorResponse(status int) *Response {
+	response := &Response{Description: http.StatusText(status)}
+
+	switch status {
+	case http.StatusNotModified:
+	case http.StatusUnprocessableEntity:
+		response.Content = map[string]*MediaType{"application/json": {Schema: &Schema{Ref: ref("ValidationErrors")}}}
+	default:
+		response.Content = map[string]*MediaType{"application/json": {Schema: &Schema{Ref: ref("Error")}}}
+	}
+
+	return response
+}
+
+func ref(name string) string {
+	return "#/components/schemas/" + name
+}
@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"encoding/json"
+	"inventory-management/dtos"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, "/orders/{id}/invoice", Path("/orders/:id/invoice"))
+	assert.Equal(t, "/files/{path}", Path("/files/*path"))
+	assert.Equal(t, "/articles-list", Path("/articles-list"))
+}
+
+func TestBuild(t *testing.T) {
+	document := Build(Info{Title: "inventory", Version: "1.0.0"}, nil, []*Route{
+		{Method: http.MethodPut, Path: "/orders/:id", Id: "updateOrder", Tag: "orders", Summary: "Replace an order",
+			Parameters: []*Parameter{IfMatch}, Request: dtos.Order{}, Response: Message(),
+			Errors: []int{http.StatusBadRequest, http.StatusUnprocessableEntity}},
+		{Method: http.MethodPost, Path: "/orders/import", Id: "importOrders", Tag: "orders", Summary: "Import orders",
+			Upload: true, Response: dtos.OrderImportReport{}, Files: []string{"text/csv"}},
+	})
+
+	operation := document.Paths["/orders/{id}"]["put"]
+	assert.Equal(t, "updateOrder", operation.OperationId)
+	assert.Equal(t, "id", operation.Parameters[0].Name)
+	assert.Equal(t, "path", operation.Parameters[0].In)
+	assert.Equal(t, IfMatch, operation.Parameters[1])
+	assert.Equal(t, "#/components/schemas/Order", operation.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/ValidationErrors", operation.Responses["422"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/Error", operation.Responses["400"].Content["application/json"].Schema.Ref)
+
+	upload := document.Paths["/orders/import"]["post"]
+	assert.Contains(t, upload.RequestBody.Content, "multipart/form-data")
+	assert.Contains(t, upload.Responses["200"].Content, "text/csv")
+
+	order := document.Components.Schemas["Order"]
+	assert.ElementsMatch(t, []string{"customer_id", "items"}, order.Required)
+	assert.Equal(t, 1, *order.Properties["items"].MinItems)
+	assert.Equal(t, "#/components/schemas/OrderItems", order.Properties["items"].Items.Ref)
+	assert.Equal(t, []string{"allow", "reject"}, order.Properties["backorder_policy"].Enum)
+	assert.Equal(t, "date-time", order.Properties["ordered_at"].Format)
+	assert.Equal(t, "#/components/schemas/Money", order.Properties["subtotal"].Ref)
+
+	items := document.Components.Schemas["OrderItems"]
+	assert.Equal(t, float64(0), *items.Properties["quantity"].ExclusiveMinimum)
+	assert.Equal(t, "decimal", items.Properties["tax_rate"].Format)
+
+	money := document.Components.Schemas["Money"]
+	assert.Equal(t, "string", money.Properties["amount"].Type)
+	assert.Equal(t, "decimal", money.Properties["amount"].Format)
+}
+
+// TestReferencesResolve checks every $ref points at a schema in the
+// document.
+func TestReferencesResolve(t *testing.T) {
+	document := Build(Info{Title: "inventory", Version: "1.0.0"}, nil, []*Route{
+		{Method: http.MethodGet, Path: "/orders/:id/invoice", Id: "getOrderInvoice", Tag: "invoices", Summary: "Get an invoice",
+			Response: dtos.Invoice{}, Errors: []int{http.StatusInternalServerError}},
+		{Method: http.MethodPost, Path: "/orders/:id/returns", Id: "createReturn", Tag: "returns", Summary: "Request a return",
+			Request: dtos.Return{}, Response: Message("return_id")},
+	})
+
+	content, err := json.Marshal(document)
+	assert.NoError(t, err)
+
+	for _, match := range regexp.MustCompile(`"\$ref":"([^"]+)"`).FindAllStringSubmatch(string(content), -1) {
+		name := strings.TrimPrefix(match[1], "#/components/schemas/")
+		assert.Contains(t, document.Components.Schemas, name)
+	}
+	assert.Contains(t, document.Components.Schemas, "Party")
+	assert.Contains(t, document.Components.Schemas, "Address")
+}
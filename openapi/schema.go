@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	timeType        = reflect.TypeFor[time.Time]()
+	decimalType     = reflect.TypeFor[decimal.Decimal]()
+	nullDecimalType = reflect.TypeFor[decimal.NullDecimal]()
+)
+
+// generator works out schemas from Go types, the way encoding/json writes
+// them. Structs go into the components by name and are referred to.
+type generator struct {
+	schemas map[string]*Schema
+	types   map[string]reflect.Type
+}
+
+func newGenerator(schemas map[string]*Schema) *generator {
+	return &generator{
+		schemas: schemas,
+		types:   make(map[string]reflect.Type),
+	}
+}
+
+// of returns the schema for a value, or the value itself when it is one.
+func (g *generator) of(value any) *Schema {
+	if schema, ok := value.(*Schema); ok {
+		return schema
+	}
+
+	return g.schema(reflect.TypeOf(value))
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case decimalType:
+		return &Schema{Type: "string", Format: "decimal"}
+	case nullDecimalType:
+		return &Schema{Type: "string", Format: "decimal", Description: "Null when not set."}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return &Schema{Ref: g.ref(t)}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{}
+	}
+}
+
+// ref adds the struct to the components, unless it is there already, and
+// returns the reference to it.
+func (g *generator) ref(t reflect.Type) string {
+	name := t.Name()
+	if existing, ok := g.types[name]; ok {
+		if existing != t {
+			panic(fmt.Sprintf("openapi: %s and %s are both named %s", existing, t, name))
+		}
+		return ref(name)
+	}
+
+	g.types[name] = t
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.schemas[name] = schema
+	g.fields(t, schema)
+
+	return ref(name)
+}
+
+// fields adds the JSON fields of a struct to schema, including those of
+// embedded structs.
+func (g *generator) fields(t reflect.Type, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.fields(field.Type, schema)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schema(field.Type)
+		if rules(property, field.Tag.Get("binding")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// rules adds the binding rules that can be written in a schema to it, up to
+// the first dive, and reports whether the field is required.
+func rules(schema *Schema, binding string) bool {
+	required := false
+	for _, v := range strings.Split(binding, ",") {
+		rule, param, _ := strings.Cut(v, "=")
+		number, err := strconv.ParseFloat(param, 64)
+
+		switch {
+		case rule == "dive":
+			return required
+		case rule == "required":
+			required = true
+		case rule == "email":
+			schema.Format = "email"
+		case rule == "oneof":
+			schema.Enum = strings.Fields(param)
+		case rule == "min" && schema.Type == "array" && err == nil:
+			items := int(number)
+			schema.MinItems = &items
+		case rule == "gte" && (schema.Type == "integer" || schema.Type == "number") && err == nil:
+			schema.Minimum = &number
+		case rule == "gt" && (schema.Type == "integer" || schema.Type == "number") && err == nil:
+			schema.ExclusiveMinimum = &number
+		}
+	}
+
+	return required
+}
@@ -1,17 +1,20 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"inventory-management/constants"
 	"inventory-management/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AddressRepo interface {
-	Upsert(address *models.Address) error
-	Update(addressId string, address *models.Address) error
-	Get(addressId string) (*models.Address, error)
-	Delete(addressId string) error
+	Create(ctx context.Context, address *models.Address) error
+	Update(ctx context.Context, addressId string, address *models.Address) error
+	Get(ctx context.Context, addressId string) (*models.Address, error)
+	Delete(ctx context.Context, addressId string) error
 }
 
 type addressRepo struct {
@@ -28,28 +31,47 @@ func (o *addressRepo) getTable() string {
 	return "addresses"
 }
 
-func (o *addressRepo) Upsert(address *models.Address) error {
-	err := o.db.Table(o.getTable()).Save(address).Error
-	if err != nil {
-		return err
+// Create inserts the address. An address id that is already taken fails with
+// ErrorRecordExists rather than overwriting someone else's address.
+func (o *addressRepo) Create(ctx context.Context, address *models.Address) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Clauses(clause.OnConflict{DoNothing: true}).Create(address)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return constants.ErrorRecordExists
 	}
 
 	return nil
 }
 
-func (o *addressRepo) Update(addressId string, address *models.Address) error {
-	tx := o.db.Table(o.getTable()).Where("address_id = ?", addressId).UpdateColumns(address)
-	if tx.Error != nil || tx.RowsAffected == 0 {
+// Update overwrites every field of the address, so fields left empty are
+// cleared. Writing the same values again is not an error, even where the
+// database reports no rows changed.
+func (o *addressRepo) Update(ctx context.Context, addressId string, address *models.Address) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Where("address_id = ?", addressId).Select("*").Omit("address_id").Updates(address)
+	if tx.Error != nil {
+		return errors.New("error updating address")
+	}
+
+	if tx.RowsAffected > 0 {
+		return nil
+	}
+
+	var existing int64
+	err := o.db.WithContext(ctx).Table(o.getTable()).Where("address_id = ?", addressId).Count(&existing).Error
+	if err != nil || existing == 0 {
 		return errors.New("error updating address")
 	}
 
 	return nil
 }
 
-func (o *addressRepo) Get(addressId string) (*models.Address, error) {
+func (o *addressRepo) Get(ctx context.Context, addressId string) (*models.Address, error) {
 	var result *models.Address
 
-	err := o.db.Table(o.getTable()).Where("address_id = ?", addressId).First(&result).Error
+	err := o.db.WithContext(ctx).Table(o.getTable()).Where("address_id = ?", addressId).First(&result).Error
 	if err != nil {
 		return nil, err
 	}
@@ -57,8 +79,8 @@ func (o *addressRepo) Get(addressId string) (*models.Address, error) {
 	return result, nil
 }
 
-func (o *addressRepo) Delete(addressId string) error {
-	tx := o.db.Table(o.getTable()).Where("address_id = ?", addressId).Delete(&models.Address{})
+func (o *addressRepo) Delete(ctx context.Context, addressId string) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Where("address_id = ?", addressId).Delete(&models.Address{})
 	if tx.Error != nil || tx.RowsAffected == 0 {
 		return errors.New("error deleting address")
 	}
@@ -1,12 +1,13 @@
 package repository
 
 import (
+	"context"
+	"inventory-management/constants"
 	"inventory-management/models"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -21,16 +22,7 @@ func TestAddressRepoTestSuite(t *testing.T) {
 }
 
 func (suite *AddressRepoTestSuite) SetupTest() {
-	var err error
-	suite.db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	if err != nil {
-		suite.T().Fatal("failed to connect to database")
-	}
-
-	err = suite.db.AutoMigrate(&models.Address{})
-	if err != nil {
-		suite.T().Fatal("failed to migrate database")
-	}
+	suite.db = openTestDB(suite.T())
 
 	suite.addressRepo = NewAddressRepo(suite.db)
 }
@@ -40,7 +32,7 @@ func (suite *AddressRepoTestSuite) TearDownTest() {
 	sqlDB.Close()
 }
 
-func (suite *AddressRepoTestSuite) TestUpsertAddress() {
+func (suite *AddressRepoTestSuite) TestCreateAddress() {
 	address := &models.Address{
 		AddressId: "123",
 		Line1:     "123 Main St",
@@ -51,7 +43,7 @@ func (suite *AddressRepoTestSuite) TestUpsertAddress() {
 		ZipCode:   "62704",
 	}
 
-	err := suite.addressRepo.Upsert(address)
+	err := suite.addressRepo.Create(context.Background(), address)
 
 	assert.NoError(suite.T(), err)
 
@@ -61,7 +53,7 @@ func (suite *AddressRepoTestSuite) TestUpsertAddress() {
 	assert.Equal(suite.T(), address.Line1, savedAddress.Line1)
 }
 
-func (suite *AddressRepoTestSuite) TestUpsertAddressError() {
+func (suite *AddressRepoTestSuite) TestCreateAddressError() {
 	address := &models.Address{
 		AddressId: "123",
 		Line1:     "123 Main St",
@@ -71,10 +63,21 @@ func (suite *AddressRepoTestSuite) TestUpsertAddressError() {
 		ZipCode:   "62704",
 	}
 
-	err := suite.addressRepo.Upsert(address)
+	err := suite.addressRepo.Create(context.Background(), address)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *AddressRepoTestSuite) TestCreateAddressExists() {
+	err := suite.addressRepo.Create(context.Background(), &models.Address{AddressId: "123", Line1: "123 Main St", Country: "USA"})
+	assert.NoError(suite.T(), err)
+
+	err = suite.addressRepo.Create(context.Background(), &models.Address{AddressId: "123", Line1: "456 Elm St", Country: "USA"})
+	assert.Equal(suite.T(), constants.ErrorRecordExists, err)
+
+	result, _ := suite.addressRepo.Get(context.Background(), "123")
+	assert.Equal(suite.T(), "123 Main St", result.Line1)
+}
+
 func (suite *AddressRepoTestSuite) TestGetAddress() {
 	address := &models.Address{
 		AddressId: "123",
@@ -85,10 +88,10 @@ func (suite *AddressRepoTestSuite) TestGetAddress() {
 		Country:   "USA",
 		ZipCode:   "62704",
 	}
-	err := suite.addressRepo.Upsert(address)
+	err := suite.addressRepo.Create(context.Background(), address)
 	assert.NoError(suite.T(), err)
 
-	result, err := suite.addressRepo.Get("123")
+	result, err := suite.addressRepo.Get(context.Background(), "123")
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), address.AddressId, result.AddressId)
@@ -96,7 +99,7 @@ func (suite *AddressRepoTestSuite) TestGetAddress() {
 }
 
 func (suite *AddressRepoTestSuite) TestGetAddressError() {
-	result, err := suite.addressRepo.Get("123")
+	result, err := suite.addressRepo.Get(context.Background(), "123")
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), result)
@@ -113,12 +116,12 @@ func (suite *AddressRepoTestSuite) TestUpdateAddress() {
 		Country:   "USA",
 		ZipCode:   "62704",
 	}
-	err := suite.addressRepo.Upsert(address)
+	err := suite.addressRepo.Create(context.Background(), address)
 	assert.NoError(suite.T(), err)
 
 	address.Line1 = "456 Elm St"
 
-	err = suite.addressRepo.Update(address.AddressId, address)
+	err = suite.addressRepo.Update(context.Background(), address.AddressId, address)
 	assert.NoError(suite.T(), err)
 
 	var updatedAddress models.Address
@@ -126,6 +129,19 @@ func (suite *AddressRepoTestSuite) TestUpdateAddress() {
 	assert.Equal(suite.T(), "456 Elm St", updatedAddress.Line1)
 }
 
+func (suite *AddressRepoTestSuite) TestUpdateAddressClearsFields() {
+	err := suite.addressRepo.Create(context.Background(), &models.Address{AddressId: "123", Line1: "123 Main St", Line2: "Apt 4B", Country: "USA"})
+	assert.NoError(suite.T(), err)
+
+	err = suite.addressRepo.Update(context.Background(), "123", &models.Address{Line1: "456 Elm St", Country: "USA"})
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.addressRepo.Get(context.Background(), "123")
+	assert.Equal(suite.T(), "123", result.AddressId)
+	assert.Equal(suite.T(), "456 Elm St", result.Line1)
+	assert.Empty(suite.T(), result.Line2)
+}
+
 func (suite *AddressRepoTestSuite) TestUpdateAddressError() {
 	address := &models.Address{
 		AddressId: "123",
@@ -137,7 +153,7 @@ func (suite *AddressRepoTestSuite) TestUpdateAddressError() {
 		ZipCode:   "62704",
 	}
 
-	err := suite.addressRepo.Update(address.AddressId, address)
+	err := suite.addressRepo.Update(context.Background(), address.AddressId, address)
 	assert.Error(suite.T(), err)
 }
 
@@ -151,10 +167,10 @@ func (suite *AddressRepoTestSuite) TestDeleteAddress() {
 		Country:   "USA",
 		ZipCode:   "62704",
 	}
-	err := suite.addressRepo.Upsert(address)
+	err := suite.addressRepo.Create(context.Background(), address)
 	assert.NoError(suite.T(), err)
 
-	err = suite.addressRepo.Delete(address.AddressId)
+	err = suite.addressRepo.Delete(context.Background(), address.AddressId)
 
 	assert.NoError(suite.T(), err)
 
@@ -164,7 +180,7 @@ func (suite *AddressRepoTestSuite) TestDeleteAddress() {
 }
 
 func (suite *AddressRepoTestSuite) TestDeleteAddressError() {
-	err := suite.addressRepo.Delete("123")
+	err := suite.addressRepo.Delete(context.Background(), "123")
 
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "error deleting address", err.Error())
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type ArticlePriceRepo interface {
+	Upsert(ctx context.Context, prices ...*models.ArticlePrice) error
+	Get(ctx context.Context, articleId string, currency string) (*models.ArticlePrice, error)
+	GetByArticles(ctx context.Context, articleIds ...string) ([]*models.ArticlePrice, error)
+	DeleteByArticle(ctx context.Context, articleId string) error
+}
+
+type articlePriceRepo struct {
+	db *gorm.DB
+}
+
+func NewArticlePriceRepo(db *gorm.DB) ArticlePriceRepo {
+	return &articlePriceRepo{
+		db: db,
+	}
+}
+
+func (a *articlePriceRepo) getTable() string {
+	return "article_prices"
+}
+
+func (a *articlePriceRepo) Upsert(ctx context.Context, prices ...*models.ArticlePrice) error {
+	err := a.db.WithContext(ctx).Table(a.getTable()).Save(&prices).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *articlePriceRepo) Get(ctx context.Context, articleId string, currency string) (*models.ArticlePrice, error) {
+	var result *models.ArticlePrice
+
+	err := a.db.WithContext(ctx).Table(a.getTable()).Where("article_id = ? AND currency = ?", articleId, currency).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (a *articlePriceRepo) GetByArticles(ctx context.Context, articleIds ...string) ([]*models.ArticlePrice, error) {
+	var result []*models.ArticlePrice
+
+	err := a.db.WithContext(ctx).Table(a.getTable()).Where("article_id IN (?)", articleIds).Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (a *articlePriceRepo) DeleteByArticle(ctx context.Context, articleId string) error {
+	err := a.db.WithContext(ctx).Table(a.getTable()).Where("article_id = ?", articleId).Delete(&models.ArticlePrice{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
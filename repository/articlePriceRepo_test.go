@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ArticlePriceRepoTestSuite struct {
+	suite.Suite
+	db               *gorm.DB
+	articlePriceRepo ArticlePriceRepo
+}
+
+func TestArticlePriceRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(ArticlePriceRepoTestSuite))
+}
+
+func (suite *ArticlePriceRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.articlePriceRepo = NewArticlePriceRepo(suite.db)
+}
+
+func (suite *ArticlePriceRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *ArticlePriceRepoTestSuite) TestUpsertAndGet() {
+	price := &models.ArticlePrice{
+		ArticleId: "123",
+		Currency:  "USD",
+		Amount:    decimal.RequireFromString("10.25"),
+	}
+
+	err := suite.articlePriceRepo.Upsert(context.Background(), price)
+	assert.NoError(suite.T(), err)
+
+	price.Amount = decimal.RequireFromString("11.75")
+	err = suite.articlePriceRepo.Upsert(context.Background(), price)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.articlePriceRepo.Get(context.Background(), "123", "USD")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), decimal.RequireFromString("11.75").Equal(result.Amount))
+}
+
+func (suite *ArticlePriceRepoTestSuite) TestGetError() {
+	result, err := suite.articlePriceRepo.Get(context.Background(), "123", "USD")
+
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), gorm.ErrRecordNotFound, err)
+}
+
+func (suite *ArticlePriceRepoTestSuite) TestGetByArticles() {
+	err := suite.articlePriceRepo.Upsert(
+		context.Background(), &models.ArticlePrice{ArticleId: "123", Currency: "USD", Amount: decimal.NewFromInt(1)},
+		&models.ArticlePrice{ArticleId: "123", Currency: "EUR", Amount: decimal.NewFromInt(2)},
+		&models.ArticlePrice{ArticleId: "456", Currency: "USD", Amount: decimal.NewFromInt(3)},
+	)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.articlePriceRepo.GetByArticles(context.Background(), "123")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+}
+
+func (suite *ArticlePriceRepoTestSuite) TestDeleteByArticle() {
+	err := suite.articlePriceRepo.Upsert(
+		context.Background(), &models.ArticlePrice{ArticleId: "123", Currency: "USD", Amount: decimal.NewFromInt(1)},
+		&models.ArticlePrice{ArticleId: "456", Currency: "USD", Amount: decimal.NewFromInt(3)},
+	)
+	assert.NoError(suite.T(), err)
+
+	err = suite.articlePriceRepo.DeleteByArticle(context.Background(), "123")
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.articlePriceRepo.GetByArticles(context.Background(), "123", "456")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), "456", result[0].ArticleId)
+}
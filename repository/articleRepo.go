@@ -1,20 +1,29 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"inventory-management/constants"
 	"inventory-management/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ArticleRepo interface {
-	Create(article *models.Article) error
-	Update(articleId string, article *models.Article) error
-	Get(articleId string) (*models.Article, error)
-	GetAll() ([]*models.Article, error)
-	Delete(articleId string) error
-	UpdateArticleStock(articleId string, stock int64) error
+	Create(ctx context.Context, article *models.Article) error
+	Upsert(ctx context.Context, articles ...*models.Article) error
+	Update(ctx context.Context, articleId string, article *models.Article) error
+	Get(ctx context.Context, articleId string) (*models.Article, error)
+	GetForUpdate(ctx context.Context, articleId string) (*models.Article, error)
+	GetAll(ctx context.Context) ([]*models.Article, error)
+	FindInBatches(ctx context.Context, size int, fn func(articles []*models.Article) error) error
+	Delete(ctx context.Context, articleId string) error
+	UpdateArticleStock(ctx context.Context, articleId string, version int64, stock int64) error
+	AdjustStock(ctx context.Context, articleId string, stock int64, damaged int64) error
+	DeductStock(ctx context.Context, articleId string, quantity int64) error
+	LowestStock(ctx context.Context, limit int) ([]*models.Article, error)
+	CountOutOfStock(ctx context.Context) (int64, error)
 }
 
 type articleRepo struct {
@@ -31,8 +40,8 @@ func (a *articleRepo) getTable() string {
 	return "articles"
 }
 
-func (a *articleRepo) Create(article *models.Article) error {
-	err := a.db.Table(a.getTable()).Create(article).Error
+func (a *articleRepo) Create(ctx context.Context, article *models.Article) error {
+	err := a.db.WithContext(ctx).Table(a.getTable()).Create(article).Error
 	if err != nil {
 		return err
 	}
@@ -40,19 +49,48 @@ func (a *articleRepo) Create(article *models.Article) error {
 	return nil
 }
 
-func (a *articleRepo) Update(articleId string, article *models.Article) error {
-	tx := a.db.Table(a.getTable()).Where("article_id = ?", articleId).UpdateColumns(article)
-	if tx.Error != nil || tx.RowsAffected == 0 {
+// Upsert creates the articles that don't exist yet and overwrites the ones that
+// do, moving them to the next version. Damaged stock is left alone. The version
+// is qualified with the table since PostgreSQL can't tell the existing row's
+// column from the one being inserted.
+func (a *articleRepo) Upsert(ctx context.Context, articles ...*models.Article) error {
+	err := a.db.WithContext(ctx).Table(a.getTable()).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "article_id"}},
+		DoUpdates: append(clause.AssignmentColumns([]string{"article_name", "price_amount", "price_currency", "stock", "tax_class"}),
+			clause.Assignment{Column: clause.Column{Name: "version"}, Value: gorm.Expr(a.getTable() + ".version + 1")}),
+	}).Create(articles).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Update writes the article if it is still at article.Version and moves it to
+// the next version. It returns ErrorVersionMismatch when the article has
+// changed since that version was read. Zero values are written too, only the
+// id and the damaged stock are left alone.
+func (a *articleRepo) Update(ctx context.Context, articleId string, article *models.Article) error {
+	version := article.Version
+	article.Version = version + 1
+
+	tx := a.db.WithContext(ctx).Table(a.getTable()).Where("article_id = ? AND version = ?", articleId, version).
+		Select("*").Omit("article_id", "damaged_stock").Updates(article)
+	if tx.Error != nil {
 		return errors.New("error updating article")
 	}
 
+	if tx.RowsAffected == 0 {
+		return constants.ErrorVersionMismatch
+	}
+
 	return nil
 }
 
-func (a *articleRepo) Get(articleId string) (*models.Article, error) {
+func (a *articleRepo) Get(ctx context.Context, articleId string) (*models.Article, error) {
 	var result *models.Article
 
-	err := a.db.Table(a.getTable()).Where("article_id = ?", articleId).First(&result).Error
+	err := a.db.WithContext(ctx).Table(a.getTable()).Where("article_id = ?", articleId).First(&result).Error
 	if err != nil {
 		return nil, err
 	}
@@ -60,10 +98,23 @@ func (a *articleRepo) Get(articleId string) (*models.Article, error) {
 	return result, nil
 }
 
-func (a *articleRepo) GetAll() ([]*models.Article, error) {
+// GetForUpdate reads the article and keeps its row locked until the
+// surrounding transaction ends.
+func (a *articleRepo) GetForUpdate(ctx context.Context, articleId string) (*models.Article, error) {
+	var result *models.Article
+
+	err := a.db.WithContext(ctx).Table(a.getTable()).Clauses(clause.Locking{Strength: "UPDATE"}).Where("article_id = ?", articleId).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (a *articleRepo) GetAll(ctx context.Context) ([]*models.Article, error) {
 	var result []*models.Article
 
-	err := a.db.Table(a.getTable()).Where("1=1").Find(&result).Error
+	err := a.db.WithContext(ctx).Table(a.getTable()).Where("1=1").Find(&result).Error
 	if err != nil || len(result) == 0 {
 		return nil, constants.ErrorNotFound
 	}
@@ -71,8 +122,23 @@ func (a *articleRepo) GetAll() ([]*models.Article, error) {
 	return result, nil
 }
 
-func (a *articleRepo) Delete(articleId string) error {
-	tx := a.db.Table(a.getTable()).Where("article_id = ?", articleId).Delete(&models.Article{})
+// FindInBatches hands the articles to fn in batches of size, ordered by id, so
+// the whole catalog never has to be held in memory.
+func (a *articleRepo) FindInBatches(ctx context.Context, size int, fn func(articles []*models.Article) error) error {
+	var batch []*models.Article
+
+	err := a.db.WithContext(ctx).Table(a.getTable()).Order("article_id").FindInBatches(&batch, size, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *articleRepo) Delete(ctx context.Context, articleId string) error {
+	tx := a.db.WithContext(ctx).Table(a.getTable()).Where("article_id = ?", articleId).Delete(&models.Article{})
 	if tx.Error != nil || tx.RowsAffected == 0 {
 		return errors.New("error deleting article")
 	}
@@ -80,11 +146,76 @@ func (a *articleRepo) Delete(articleId string) error {
 	return nil
 }
 
-func (a *articleRepo) UpdateArticleStock(articleId string, stock int64) error {
-	tx := a.db.Table(a.getTable()).Where("article_id = ?", articleId).Update("stock", stock)
+func (a *articleRepo) UpdateArticleStock(ctx context.Context, articleId string, version int64, stock int64) error {
+	tx := a.db.WithContext(ctx).Table(a.getTable()).Where("article_id = ? AND version = ?", articleId, version).UpdateColumns(map[string]interface{}{
+		"stock":   stock,
+		"version": version + 1,
+	})
+	if tx.Error != nil {
+		return errors.New("error updating stock")
+	}
+
+	if tx.RowsAffected == 0 {
+		return constants.ErrorVersionMismatch
+	}
+
+	return nil
+}
+
+// AdjustStock adds to the sellable and damaged stock in the database rather
+// than writing back values read earlier, so concurrent adjustments add up.
+func (a *articleRepo) AdjustStock(ctx context.Context, articleId string, stock int64, damaged int64) error {
+	tx := a.db.WithContext(ctx).Table(a.getTable()).Where("article_id = ?", articleId).UpdateColumns(map[string]interface{}{
+		"stock":         gorm.Expr("stock + ?", stock),
+		"damaged_stock": gorm.Expr("damaged_stock + ?", damaged),
+		"version":       gorm.Expr("version + 1"),
+	})
 	if tx.Error != nil || tx.RowsAffected == 0 {
 		return errors.New("error updating stock")
 	}
 
 	return nil
 }
+
+// DeductStock takes quantity out of stock only when that much is in stock,
+// checked and updated in one statement.
+func (a *articleRepo) DeductStock(ctx context.Context, articleId string, quantity int64) error {
+	tx := a.db.WithContext(ctx).Table(a.getTable()).Where("article_id = ? AND stock >= ?", articleId, quantity).
+		UpdateColumns(map[string]interface{}{
+			"stock":   gorm.Expr("stock - ?", quantity),
+			"version": gorm.Expr("version + 1"),
+		})
+	if tx.Error != nil {
+		return errors.New("error updating stock")
+	}
+
+	if tx.RowsAffected == 0 {
+		return constants.ErrorInsufficientStock
+	}
+
+	return nil
+}
+
+// LowestStock returns the limit articles with the least stock, ties broken by
+// id.
+func (a *articleRepo) LowestStock(ctx context.Context, limit int) ([]*models.Article, error) {
+	var result []*models.Article
+
+	err := a.db.WithContext(ctx).Table(a.getTable()).Order("stock").Order("article_id").Limit(limit).Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (a *articleRepo) CountOutOfStock(ctx context.Context) (int64, error) {
+	var count int64
+
+	err := a.db.WithContext(ctx).Table(a.getTable()).Where("stock <= 0").Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -1,13 +1,14 @@
 package repository
 
 import (
+	"context"
 	"inventory-management/constants"
 	"inventory-management/models"
+	"inventory-management/money"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -22,16 +23,7 @@ func TestArticleRepoTestSuite(t *testing.T) {
 }
 
 func (suite *ArticleRepoTestSuite) SetupTest() {
-	var err error
-	suite.db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	if err != nil {
-		suite.T().Fatal("failed to connect to database")
-	}
-
-	err = suite.db.AutoMigrate(&models.Article{})
-	if err != nil {
-		suite.T().Fatal("failed to migrate database")
-	}
+	suite.db = openTestDB(suite.T())
 
 	suite.articleRepo = NewArticleRepo(suite.db)
 }
@@ -45,11 +37,11 @@ func (suite *ArticleRepoTestSuite) TestCreateArticle() {
 	article := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "article1",
-		Price:       100.5,
+		Price:       money.MustParse("100.5", "INR"),
 		Stock:       6,
 	}
 
-	err := suite.articleRepo.Create(article)
+	err := suite.articleRepo.Create(context.Background(), article)
 
 	assert.NoError(suite.T(), err)
 
@@ -63,21 +55,21 @@ func (suite *ArticleRepoTestSuite) TestCreateArticle_Error() {
 	article := &models.Article{
 		ArticleId:   "dup-id",
 		ArticleName: "Original Article",
-		Price:       50.0,
+		Price:       money.MustParse("50.0", "INR"),
 		Stock:       10,
 	}
 
-	err := suite.articleRepo.Create(article)
+	err := suite.articleRepo.Create(context.Background(), article)
 	assert.NoError(suite.T(), err)
 
 	duplicateArticle := &models.Article{
 		ArticleId:   "dup-id",
 		ArticleName: "Duplicate Article",
-		Price:       60.0,
+		Price:       money.MustParse("60.0", "INR"),
 		Stock:       5,
 	}
 
-	err = suite.articleRepo.Create(duplicateArticle)
+	err = suite.articleRepo.Create(context.Background(), duplicateArticle)
 	assert.Error(suite.T(), err)
 }
 
@@ -85,13 +77,13 @@ func (suite *ArticleRepoTestSuite) TestGetArticle() {
 	article := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "article1",
-		Price:       100.5,
+		Price:       money.MustParse("100.5", "INR"),
 		Stock:       6,
 	}
-	err := suite.articleRepo.Create(article)
+	err := suite.articleRepo.Create(context.Background(), article)
 	assert.NoError(suite.T(), err)
 
-	result, err := suite.articleRepo.Get("123")
+	result, err := suite.articleRepo.Get(context.Background(), "123")
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), article.ArticleId, result.ArticleId)
@@ -99,24 +91,34 @@ func (suite *ArticleRepoTestSuite) TestGetArticle() {
 }
 
 func (suite *ArticleRepoTestSuite) TestGetArticleError() {
-	result, err := suite.articleRepo.Get("123")
+	result, err := suite.articleRepo.Get(context.Background(), "123")
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), result)
 	assert.Equal(suite.T(), gorm.ErrRecordNotFound, err)
 }
 
+func (suite *ArticleRepoTestSuite) TestGetArticleCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := suite.articleRepo.Get(ctx, "123")
+
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	assert.Nil(suite.T(), result)
+}
+
 func (suite *ArticleRepoTestSuite) TestGetAllArticle() {
 	article := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "article1",
-		Price:       100.5,
+		Price:       money.MustParse("100.5", "INR"),
 		Stock:       6,
 	}
-	err := suite.articleRepo.Create(article)
+	err := suite.articleRepo.Create(context.Background(), article)
 	assert.NoError(suite.T(), err)
 
-	result, err := suite.articleRepo.GetAll()
+	result, err := suite.articleRepo.GetAll(context.Background())
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
@@ -124,57 +126,157 @@ func (suite *ArticleRepoTestSuite) TestGetAllArticle() {
 }
 
 func (suite *ArticleRepoTestSuite) TestGetAllArticleError() {
-	result, err := suite.articleRepo.GetAll()
+	result, err := suite.articleRepo.GetAll(context.Background())
 
 	assert.Error(suite.T(), err)
 	assert.Empty(suite.T(), result)
 	assert.Equal(suite.T(), err, constants.ErrorNotFound)
 }
 
+func (suite *ArticleRepoTestSuite) TestUpsertArticles() {
+	err := suite.articleRepo.Create(context.Background(), &models.Article{
+		ArticleId:    "1",
+		ArticleName:  "article1",
+		Price:        money.MustParse("100", "INR"),
+		Stock:        6,
+		DamagedStock: 2,
+		Version:      1,
+	})
+	assert.NoError(suite.T(), err)
+
+	err = suite.articleRepo.Upsert(
+		context.Background(), &models.Article{ArticleId: "1", ArticleName: "renamed", Price: money.MustParse("90", "INR"), Stock: 0, Version: 1},
+		&models.Article{ArticleId: "2", ArticleName: "article2", Price: money.MustParse("5", "INR"), Stock: 3, Version: 1},
+	)
+	assert.NoError(suite.T(), err)
+
+	updated, err := suite.articleRepo.Get(context.Background(), "1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "renamed", updated.ArticleName)
+	assert.Equal(suite.T(), "90.00 INR", updated.Price.String())
+	assert.Equal(suite.T(), int64(0), updated.Stock)
+	assert.Equal(suite.T(), int64(2), updated.DamagedStock)
+	assert.Equal(suite.T(), int64(2), updated.Version)
+
+	created, err := suite.articleRepo.Get(context.Background(), "2")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), created.Stock)
+	assert.Equal(suite.T(), int64(1), created.Version)
+}
+
+func (suite *ArticleRepoTestSuite) TestFindInBatches() {
+	for _, id := range []string{"3", "1", "5", "2", "4"} {
+		err := suite.articleRepo.Create(context.Background(), &models.Article{ArticleId: id, ArticleName: "article" + id, Price: money.MustParse("1", "INR")})
+		assert.NoError(suite.T(), err)
+	}
+
+	var sizes []int
+	var ids []string
+	err := suite.articleRepo.FindInBatches(context.Background(), 2, func(articles []*models.Article) error {
+		sizes = append(sizes, len(articles))
+		for _, v := range articles {
+			ids = append(ids, v.ArticleId)
+		}
+		return nil
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int{2, 2, 1}, sizes)
+	assert.Equal(suite.T(), []string{"1", "2", "3", "4", "5"}, ids)
+}
+
 func (suite *ArticleRepoTestSuite) TestUpdateArticle() {
 	article := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "article1",
-		Price:       100.5,
+		Price:       money.MustParse("100.5", "INR"),
 		Stock:       6,
 	}
-	err := suite.articleRepo.Create(article)
+	err := suite.articleRepo.Create(context.Background(), article)
 	assert.NoError(suite.T(), err)
 
 	article.ArticleName = "article2"
 
-	err = suite.articleRepo.Update(article.ArticleId, article)
+	err = suite.articleRepo.Update(context.Background(), article.ArticleId, article)
 	assert.NoError(suite.T(), err)
 
 	var updatedArticle models.Article
 	suite.db.Table("articles").Where("article_id = ?", article.ArticleId).First(&updatedArticle)
 	assert.Equal(suite.T(), "article2", updatedArticle.ArticleName)
+	assert.Equal(suite.T(), int64(2), updatedArticle.Version)
+}
+
+func (suite *ArticleRepoTestSuite) TestUpdateArticleZeroValues() {
+	article := &models.Article{
+		ArticleId:    "123",
+		ArticleName:  "article1",
+		Price:        money.MustParse("100.5", "INR"),
+		Stock:        6,
+		DamagedStock: 2,
+		TaxClass:     "standard",
+	}
+	err := suite.articleRepo.Create(context.Background(), article)
+	assert.NoError(suite.T(), err)
+
+	err = suite.articleRepo.Update(context.Background(), article.ArticleId, &models.Article{
+		ArticleId:   "123",
+		ArticleName: "article1",
+		Price:       money.Zero("INR"),
+		Version:     1,
+	})
+	assert.NoError(suite.T(), err)
+
+	var updatedArticle models.Article
+	suite.db.Table("articles").Where("article_id = ?", article.ArticleId).First(&updatedArticle)
+	assert.True(suite.T(), updatedArticle.Price.IsZero())
+	assert.Equal(suite.T(), int64(0), updatedArticle.Stock)
+	assert.Equal(suite.T(), "", updatedArticle.TaxClass)
+	assert.Equal(suite.T(), int64(2), updatedArticle.DamagedStock)
+}
+
+func (suite *ArticleRepoTestSuite) TestUpdateArticleStaleVersion() {
+	article := &models.Article{
+		ArticleId:   "123",
+		ArticleName: "article1",
+		Price:       money.MustParse("100.5", "INR"),
+		Stock:       6,
+	}
+	err := suite.articleRepo.Create(context.Background(), article)
+	assert.NoError(suite.T(), err)
+
+	err = suite.articleRepo.Update(context.Background(), article.ArticleId, &models.Article{ArticleName: "article2", Version: 1})
+	assert.NoError(suite.T(), err)
+
+	err = suite.articleRepo.Update(context.Background(), article.ArticleId, &models.Article{ArticleName: "article3", Version: 1})
+	assert.Equal(suite.T(), constants.ErrorVersionMismatch, err)
+
+	result, err := suite.articleRepo.Get(context.Background(), article.ArticleId)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "article2", result.ArticleName)
 }
 
 func (suite *ArticleRepoTestSuite) TestUpdateArticleError() {
 	article := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "article1",
-		Price:       100.5,
+		Price:       money.MustParse("100.5", "INR"),
 		Stock:       6,
 	}
 
-	err := suite.articleRepo.Update(article.ArticleId, article)
-	assert.Error(suite.T(), err)
-	assert.Contains(suite.T(), err.Error(), "error updating article")
+	err := suite.articleRepo.Update(context.Background(), article.ArticleId, article)
+	assert.Equal(suite.T(), constants.ErrorVersionMismatch, err)
 }
 
 func (suite *ArticleRepoTestSuite) TestDeleteArticle() {
 	article := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "article1",
-		Price:       100.5,
+		Price:       money.MustParse("100.5", "INR"),
 		Stock:       6,
 	}
-	err := suite.articleRepo.Create(article)
+	err := suite.articleRepo.Create(context.Background(), article)
 	assert.NoError(suite.T(), err)
 
-	err = suite.articleRepo.Delete(article.ArticleId)
+	err = suite.articleRepo.Delete(context.Background(), article.ArticleId)
 
 	assert.NoError(suite.T(), err)
 
@@ -184,7 +286,7 @@ func (suite *ArticleRepoTestSuite) TestDeleteArticle() {
 }
 
 func (suite *ArticleRepoTestSuite) TestDeleteArticleError() {
-	err := suite.articleRepo.Delete("123")
+	err := suite.articleRepo.Delete(context.Background(), "123")
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "error deleting article")
 }
@@ -193,7 +295,7 @@ func (suite *ArticleRepoTestSuite) TestUpdateArticleStock_Success() {
 	article := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
@@ -203,7 +305,7 @@ func (suite *ArticleRepoTestSuite) TestUpdateArticleStock_Success() {
 	}
 
 	newStock := int64(100)
-	err = suite.articleRepo.UpdateArticleStock(article.ArticleId, newStock)
+	err = suite.articleRepo.UpdateArticleStock(context.Background(), article.ArticleId, 1, newStock)
 
 	assert.NoError(suite.T(), err)
 
@@ -214,14 +316,85 @@ func (suite *ArticleRepoTestSuite) TestUpdateArticleStock_Success() {
 	}
 
 	assert.Equal(suite.T(), newStock, updatedArticle.Stock)
+	assert.Equal(suite.T(), int64(2), updatedArticle.Version)
+
+	err = suite.articleRepo.UpdateArticleStock(context.Background(), article.ArticleId, 1, newStock)
+	assert.Equal(suite.T(), constants.ErrorVersionMismatch, err)
 }
 
 func (suite *ArticleRepoTestSuite) TestUpdateArticleStock_Failure() {
 	nonExistentArticleId := "non-existent-id"
 	newStock := int64(100)
 
-	err := suite.articleRepo.UpdateArticleStock(nonExistentArticleId, newStock)
+	err := suite.articleRepo.UpdateArticleStock(context.Background(), nonExistentArticleId, 1, newStock)
 
-	assert.Error(suite.T(), err)
-	assert.Contains(suite.T(), err.Error(), "error updating stock")
+	assert.Equal(suite.T(), constants.ErrorVersionMismatch, err)
+}
+
+func (suite *ArticleRepoTestSuite) TestAdjustStock() {
+	article := &models.Article{ArticleId: "1", ArticleName: "Article 1", Stock: 10}
+	err := suite.articleRepo.Create(context.Background(), article)
+	assert.NoError(suite.T(), err)
+
+	err = suite.articleRepo.AdjustStock(context.Background(), "1", 2, 1)
+	assert.NoError(suite.T(), err)
+
+	err = suite.articleRepo.AdjustStock(context.Background(), "1", 3, 0)
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.articleRepo.Get(context.Background(), "1")
+	assert.Equal(suite.T(), int64(15), result.Stock)
+	assert.Equal(suite.T(), int64(1), result.DamagedStock)
+
+	err = suite.articleRepo.AdjustStock(context.Background(), "2", 1, 0)
+	assert.EqualError(suite.T(), err, "error updating stock")
+}
+
+func (suite *ArticleRepoTestSuite) TestDeductStock() {
+	article := &models.Article{ArticleId: "1", ArticleName: "Article 1", Stock: 5}
+	err := suite.articleRepo.Create(context.Background(), article)
+	assert.NoError(suite.T(), err)
+
+	err = suite.articleRepo.DeductStock(context.Background(), "1", 3)
+	assert.NoError(suite.T(), err)
+
+	err = suite.articleRepo.DeductStock(context.Background(), "1", 3)
+	assert.Equal(suite.T(), constants.ErrorInsufficientStock, err)
+
+	result, _ := suite.articleRepo.Get(context.Background(), "1")
+	assert.Equal(suite.T(), int64(2), result.Stock)
+}
+
+func (suite *ArticleRepoTestSuite) TestGetForUpdate() {
+	article := &models.Article{ArticleId: "1", ArticleName: "Article 1", Stock: 5}
+	err := suite.articleRepo.Create(context.Background(), article)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.articleRepo.GetForUpdate(context.Background(), "1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(5), result.Stock)
+
+	_, err = suite.articleRepo.GetForUpdate(context.Background(), "2")
+	assert.Equal(suite.T(), gorm.ErrRecordNotFound, err)
+}
+
+func (suite *ArticleRepoTestSuite) TestLowestStock() {
+	for _, v := range []*models.Article{
+		{ArticleId: "1", ArticleName: "Article 1", Stock: 5},
+		{ArticleId: "2", ArticleName: "Article 2", Stock: 0},
+		{ArticleId: "3", ArticleName: "Article 3", Stock: 0},
+		{ArticleId: "4", ArticleName: "Article 4", Stock: 2},
+	} {
+		err := suite.articleRepo.Create(context.Background(), v)
+		assert.NoError(suite.T(), err)
+	}
+
+	result, err := suite.articleRepo.LowestStock(context.Background(), 3)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 3)
+	assert.Equal(suite.T(), []string{"2", "3", "4"}, []string{result[0].ArticleId, result[1].ArticleId, result[2].ArticleId})
+
+	count, err := suite.articleRepo.CountOutOfStock(context.Background())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), count)
 }
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type BackorderRepo interface {
+	Create(ctx context.Context, backorders ...*models.Backorder) error
+	GetByOrder(ctx context.Context, orderId string) ([]*models.Backorder, error)
+	GetOpenByArticle(ctx context.Context, articleId string) ([]*models.Backorder, error)
+	Allocate(ctx context.Context, backorderId string, quantity int, status string) error
+	CancelByOrder(ctx context.Context, orderId string) error
+}
+
+type backorderRepo struct {
+	db *gorm.DB
+}
+
+func NewBackorderRepo(db *gorm.DB) BackorderRepo {
+	return &backorderRepo{
+		db: db,
+	}
+}
+
+func (b *backorderRepo) getTable() string {
+	return "backorders"
+}
+
+func (b *backorderRepo) Create(ctx context.Context, backorders ...*models.Backorder) error {
+	err := b.db.WithContext(ctx).Table(b.getTable()).Create(backorders).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *backorderRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.Backorder, error) {
+	var result []*models.Backorder
+
+	err := b.db.WithContext(ctx).Table(b.getTable()).Where("order_id = ?", orderId).Order("backorder_id").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetOpenByArticle returns the open backorders for the article, first ordered
+// first.
+func (b *backorderRepo) GetOpenByArticle(ctx context.Context, articleId string) ([]*models.Backorder, error) {
+	var result []*models.Backorder
+
+	err := b.db.WithContext(ctx).Table(b.getTable()).Where("article_id = ? AND status = ?", articleId, constants.BackorderStatusOpen).
+		Order("ordered_at, backorder_id").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (b *backorderRepo) Allocate(ctx context.Context, backorderId string, quantity int, status string) error {
+	tx := b.db.WithContext(ctx).Table(b.getTable()).Where("backorder_id = ? AND status = ?", backorderId, constants.BackorderStatusOpen).
+		UpdateColumns(map[string]interface{}{
+			"allocated_quantity": gorm.Expr("allocated_quantity + ?", quantity),
+			"status":             status,
+		})
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error allocating backorder")
+	}
+
+	return nil
+}
+
+func (b *backorderRepo) CancelByOrder(ctx context.Context, orderId string) error {
+	err := b.db.WithContext(ctx).Table(b.getTable()).Where("order_id = ? AND status = ?", orderId, constants.BackorderStatusOpen).
+		UpdateColumn("status", constants.BackorderStatusCancelled).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type BackorderRepoTestSuite struct {
+	suite.Suite
+	db            *gorm.DB
+	backorderRepo BackorderRepo
+}
+
+func TestBackorderRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(BackorderRepoTestSuite))
+}
+
+func (suite *BackorderRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.backorderRepo = NewBackorderRepo(suite.db)
+}
+
+func (suite *BackorderRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *BackorderRepoTestSuite) createBackorders() {
+	now := time.Now()
+
+	err := suite.backorderRepo.Create(
+		context.Background(), &models.Backorder{BackorderId: "b1", OrderId: "o2", OrderItemId: "i2", ArticleId: "a1", Quantity: 2, Status: "open", OrderedAt: now},
+		&models.Backorder{BackorderId: "b2", OrderId: "o1", OrderItemId: "i1", ArticleId: "a1", Quantity: 1, Status: "open", OrderedAt: now.Add(-time.Hour)},
+		&models.Backorder{BackorderId: "b3", OrderId: "o3", OrderItemId: "i3", ArticleId: "a2", Quantity: 4, Status: "open", OrderedAt: now},
+		&models.Backorder{BackorderId: "b4", OrderId: "o4", OrderItemId: "i4", ArticleId: "a1", Quantity: 4, Status: "cancelled", OrderedAt: now},
+	)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *BackorderRepoTestSuite) TestGetOpenByArticle() {
+	suite.createBackorders()
+
+	result, err := suite.backorderRepo.GetOpenByArticle(context.Background(), "a1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), "b2", result[0].BackorderId)
+	assert.Equal(suite.T(), "b1", result[1].BackorderId)
+}
+
+func (suite *BackorderRepoTestSuite) TestAllocate() {
+	suite.createBackorders()
+
+	err := suite.backorderRepo.Allocate(context.Background(), "b1", 1, "open")
+	assert.NoError(suite.T(), err)
+
+	err = suite.backorderRepo.Allocate(context.Background(), "b1", 1, "allocated")
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.backorderRepo.GetByOrder(context.Background(), "o2")
+	assert.Equal(suite.T(), 2, result[0].AllocatedQuantity)
+	assert.Equal(suite.T(), "allocated", result[0].Status)
+
+	err = suite.backorderRepo.Allocate(context.Background(), "b1", 1, "allocated")
+	assert.EqualError(suite.T(), err, "error allocating backorder")
+}
+
+func (suite *BackorderRepoTestSuite) TestCancelByOrder() {
+	suite.createBackorders()
+
+	err := suite.backorderRepo.CancelByOrder(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.backorderRepo.GetOpenByArticle(context.Background(), "a1")
+	assert.Len(suite.T(), result, 1)
+
+	cancelled, _ := suite.backorderRepo.GetByOrder(context.Background(), "o1")
+	assert.Equal(suite.T(), "cancelled", cancelled[0].Status)
+}
+
+func (suite *BackorderRepoTestSuite) TestCreateBackorderError() {
+	err := suite.backorderRepo.Create(context.Background(), &models.Backorder{BackorderId: "b1", OrderId: "o1", ArticleId: "a1", Status: "open"})
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CouponRepo interface {
+	Create(ctx context.Context, coupon *models.Coupon) error
+	Update(ctx context.Context, code string, coupon *models.Coupon) error
+	Get(ctx context.Context, code string) (*models.Coupon, error)
+	Delete(ctx context.Context, code string) error
+	Redeem(ctx context.Context, code string, customerId string, orderId string, at time.Time) error
+	Release(ctx context.Context, code string, orderId string) error
+}
+
+type couponRepo struct {
+	db *gorm.DB
+}
+
+func NewCouponRepo(db *gorm.DB) CouponRepo {
+	return &couponRepo{
+		db: db,
+	}
+}
+
+func (c *couponRepo) getTable() string {
+	return "coupons"
+}
+
+func (c *couponRepo) getRedemptionTable() string {
+	return "coupon_redemptions"
+}
+
+func (c *couponRepo) Create(ctx context.Context, coupon *models.Coupon) error {
+	err := c.db.WithContext(ctx).Table(c.getTable()).Create(coupon).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *couponRepo) Update(ctx context.Context, code string, coupon *models.Coupon) error {
+	tx := c.db.WithContext(ctx).Table(c.getTable()).Where("code = ?", code).Omit("used_count").UpdateColumns(coupon)
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error updating coupon")
+	}
+
+	return nil
+}
+
+func (c *couponRepo) Get(ctx context.Context, code string) (*models.Coupon, error) {
+	var result *models.Coupon
+
+	err := c.db.WithContext(ctx).Table(c.getTable()).Where("code = ?", code).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *couponRepo) Delete(ctx context.Context, code string) error {
+	tx := c.db.WithContext(ctx).Table(c.getTable()).Where("code = ?", code).Delete(&models.Coupon{})
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error deleting coupon")
+	}
+
+	return nil
+}
+
+// Redeem records one use of the coupon by the customer for the order. The
+// coupon row is locked while the per customer count is checked and the total
+// count is only incremented while it is below the limit, so concurrent orders
+// can never exceed either limit. Redeeming again for the same order is a no-op.
+func (c *couponRepo) Redeem(ctx context.Context, code string, customerId string, orderId string, at time.Time) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var coupon *models.Coupon
+
+		err := tx.Table(c.getTable()).Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code).First(&coupon).Error
+		if err != nil {
+			return err
+		}
+
+		var existing int64
+		err = tx.Table(c.getRedemptionTable()).Where("code = ? AND order_id = ?", code, orderId).Count(&existing).Error
+		if err != nil {
+			return err
+		}
+
+		if existing > 0 {
+			return nil
+		}
+
+		if coupon.MaxUsesPerCustomer > 0 {
+			var used int64
+			err = tx.Table(c.getRedemptionTable()).Where("code = ? AND customer_id = ?", code, customerId).Count(&used).Error
+			if err != nil {
+				return err
+			}
+
+			if used >= int64(coupon.MaxUsesPerCustomer) {
+				return constants.ErrorCouponUsageLimit
+			}
+		}
+
+		res := tx.Table(c.getTable()).
+			Where("code = ? AND (max_uses = 0 OR used_count < max_uses)", code).
+			UpdateColumn("used_count", gorm.Expr("used_count + 1"))
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return constants.ErrorCouponUsageLimit
+		}
+
+		return tx.Table(c.getRedemptionTable()).Create(&models.CouponRedemption{
+			RedemptionId: uuid.NewString(),
+			Code:         code,
+			CustomerId:   customerId,
+			OrderId:      orderId,
+			RedeemedAt:   at,
+		}).Error
+	})
+}
+
+// Release gives back the use of the coupon redeemed for the order, deleting
+// the redemption and decrementing the total count. Releasing a coupon the
+// order never redeemed is a no-op.
+func (c *couponRepo) Release(ctx context.Context, code string, orderId string) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Table(c.getRedemptionTable()).Where("code = ? AND order_id = ?", code, orderId).Delete(&models.CouponRedemption{})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		return tx.Table(c.getTable()).Where("code = ? AND used_count > 0", code).
+			UpdateColumn("used_count", gorm.Expr("used_count - 1")).Error
+	})
+}
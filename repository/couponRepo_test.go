@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/models"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type CouponRepoTestSuite struct {
+	suite.Suite
+	db         *gorm.DB
+	couponRepo CouponRepo
+}
+
+func TestCouponRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(CouponRepoTestSuite))
+}
+
+func (suite *CouponRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.couponRepo = NewCouponRepo(suite.db)
+}
+
+func (suite *CouponRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *CouponRepoTestSuite) newCoupon(maxUses int, maxUsesPerCustomer int) *models.Coupon {
+	return &models.Coupon{
+		Code:               "SAVE10",
+		DiscountType:       constants.DiscountTypePercentage,
+		Scope:              constants.CouponScopeOrder,
+		Value:              decimal.NewFromInt(10),
+		MaxUses:            maxUses,
+		MaxUsesPerCustomer: maxUsesPerCustomer,
+	}
+}
+
+func (suite *CouponRepoTestSuite) TestCreateCoupon() {
+	err := suite.couponRepo.Create(context.Background(), suite.newCoupon(0, 0))
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.couponRepo.Get(context.Background(), "SAVE10")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.DiscountTypePercentage, result.DiscountType)
+}
+
+func (suite *CouponRepoTestSuite) TestCreateCouponError() {
+	coupon := suite.newCoupon(0, 0)
+	coupon.Value = decimal.NewFromInt(150)
+
+	err := suite.couponRepo.Create(context.Background(), coupon)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CouponRepoTestSuite) TestUpdateCouponKeepsUsedCount() {
+	err := suite.couponRepo.Create(context.Background(), suite.newCoupon(5, 0))
+	assert.NoError(suite.T(), err)
+	err = suite.couponRepo.Redeem(context.Background(), "SAVE10", "c1", "o1", time.Now())
+	assert.NoError(suite.T(), err)
+
+	coupon := suite.newCoupon(10, 0)
+	coupon.UsedCount = 0
+	err = suite.couponRepo.Update(context.Background(), "SAVE10", coupon)
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.couponRepo.Get(context.Background(), "SAVE10")
+	assert.Equal(suite.T(), 10, result.MaxUses)
+	assert.Equal(suite.T(), 1, result.UsedCount)
+}
+
+func (suite *CouponRepoTestSuite) TestUpdateCouponError() {
+	err := suite.couponRepo.Update(context.Background(), "SAVE10", suite.newCoupon(0, 0))
+	assert.EqualError(suite.T(), err, "error updating coupon")
+}
+
+func (suite *CouponRepoTestSuite) TestDeleteCoupon() {
+	err := suite.couponRepo.Create(context.Background(), suite.newCoupon(0, 0))
+	assert.NoError(suite.T(), err)
+
+	err = suite.couponRepo.Delete(context.Background(), "SAVE10")
+	assert.NoError(suite.T(), err)
+
+	err = suite.couponRepo.Delete(context.Background(), "SAVE10")
+	assert.EqualError(suite.T(), err, "error deleting coupon")
+}
+
+func (suite *CouponRepoTestSuite) TestRedeemPerCustomerLimit() {
+	err := suite.couponRepo.Create(context.Background(), suite.newCoupon(0, 1))
+	assert.NoError(suite.T(), err)
+
+	err = suite.couponRepo.Redeem(context.Background(), "SAVE10", "c1", "o1", time.Now())
+	assert.NoError(suite.T(), err)
+
+	err = suite.couponRepo.Redeem(context.Background(), "SAVE10", "c1", "o1", time.Now())
+	assert.NoError(suite.T(), err, "redeeming again for the same order is a no-op")
+
+	err = suite.couponRepo.Redeem(context.Background(), "SAVE10", "c1", "o2", time.Now())
+	assert.Equal(suite.T(), constants.ErrorCouponUsageLimit, err)
+
+	err = suite.couponRepo.Redeem(context.Background(), "SAVE10", "c2", "o3", time.Now())
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.couponRepo.Get(context.Background(), "SAVE10")
+	assert.Equal(suite.T(), 2, result.UsedCount)
+}
+
+func (suite *CouponRepoTestSuite) TestRelease() {
+	err := suite.couponRepo.Create(context.Background(), suite.newCoupon(1, 1))
+	assert.NoError(suite.T(), err)
+
+	err = suite.couponRepo.Redeem(context.Background(), "SAVE10", "c1", "o1", time.Now())
+	assert.NoError(suite.T(), err)
+
+	err = suite.couponRepo.Release(context.Background(), "SAVE10", "o1")
+	assert.NoError(suite.T(), err)
+
+	err = suite.couponRepo.Release(context.Background(), "SAVE10", "o1")
+	assert.NoError(suite.T(), err, "releasing again is a no-op")
+
+	result, _ := suite.couponRepo.Get(context.Background(), "SAVE10")
+	assert.Equal(suite.T(), 0, result.UsedCount)
+
+	err = suite.couponRepo.Redeem(context.Background(), "SAVE10", "c1", "o2", time.Now())
+	assert.NoError(suite.T(), err, "both limits are given back")
+}
+
+func (suite *CouponRepoTestSuite) TestRedeemTotalLimitConcurrently() {
+	err := suite.couponRepo.Create(context.Background(), suite.newCoupon(3, 0))
+	assert.NoError(suite.T(), err)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	redeemed := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := suite.couponRepo.Redeem(context.Background(), "SAVE10", "c1", string(rune('a'+i)), time.Now())
+			if err == nil {
+				mu.Lock()
+				redeemed++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(suite.T(), 3, redeemed)
+
+	result, _ := suite.couponRepo.Get(context.Background(), "SAVE10")
+	assert.Equal(suite.T(), 3, result.UsedCount)
+}
+
+func (suite *CouponRepoTestSuite) TestRedeemNotFound() {
+	err := suite.couponRepo.Redeem(context.Background(), "NOPE", "c1", "o1", time.Now())
+	assert.Equal(suite.T(), gorm.ErrRecordNotFound, err)
+}
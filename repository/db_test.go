@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"inventory-management/database"
+	"inventory-management/migrations"
+	"os"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// openTestDB connects to the database named by TEST_DB_DRIVER and TEST_DB_URL,
+// a new in-memory SQLite database when they aren't set, and brings it to the
+// current schema with every table empty. The suites run against MySQL or
+// PostgreSQL by pointing them at a scratch database.
+func openTestDB(t *testing.T) *gorm.DB {
+	driver, url := os.Getenv("TEST_DB_DRIVER"), os.Getenv("TEST_DB_URL")
+	if driver == "" {
+		driver, url = database.DriverSQLite, ":memory:"
+	}
+
+	db, err := database.Open(driver, url)
+	if err != nil {
+		t.Fatal("failed to connect to database")
+	}
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatal("failed to load migrations")
+	}
+
+	_, err = migrator.Up()
+	if err != nil {
+		t.Fatal("failed to migrate database")
+	}
+
+	tables, err := db.Migrator().GetTables()
+	if err != nil {
+		t.Fatal("failed to list tables")
+	}
+
+	for _, v := range tables {
+		if v == "schema_migrations" {
+			continue
+		}
+
+		err = db.Exec("DELETE FROM " + v).Error
+		if err != nil {
+			t.Fatal("failed to empty " + v)
+		}
+	}
+
+	return db
+}
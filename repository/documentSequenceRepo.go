@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DocumentSequenceRepo interface {
+	Next(ctx context.Context, name string) (int64, error)
+}
+
+type documentSequenceRepo struct {
+	db *gorm.DB
+}
+
+func NewDocumentSequenceRepo(db *gorm.DB) DocumentSequenceRepo {
+	return &documentSequenceRepo{
+		db: db,
+	}
+}
+
+func (d *documentSequenceRepo) getTable() string {
+	return "document_sequences"
+}
+
+// Next hands out the next number of the named sequence. The sequence row stays
+// locked until the surrounding transaction ends, so numbers are only consumed
+// when the document using them is committed and the sequence has no gaps. The
+// row is created first if it's missing, so that the first two documents of a
+// sequence can't both miss the lock and insert it.
+func (d *documentSequenceRepo) Next(ctx context.Context, name string) (int64, error) {
+	err := d.db.WithContext(ctx).Table(d.getTable()).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.DocumentSequence{Name: name, NextValue: 1}).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var sequence *models.DocumentSequence
+
+	err = d.db.WithContext(ctx).Table(d.getTable()).Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(&sequence).Error
+	if err != nil {
+		return 0, err
+	}
+
+	value := sequence.NextValue
+
+	err = d.db.WithContext(ctx).Table(d.getTable()).Where("name = ?", name).UpdateColumn("next_value", value+1).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"inventory-management/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type DocumentSequenceRepoTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	sequenceRepo DocumentSequenceRepo
+}
+
+func TestDocumentSequenceRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(DocumentSequenceRepoTestSuite))
+}
+
+func (suite *DocumentSequenceRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.sequenceRepo = NewDocumentSequenceRepo(suite.db)
+}
+
+func (suite *DocumentSequenceRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *DocumentSequenceRepoTestSuite) TestNext() {
+	for i := int64(1); i <= 3; i++ {
+		value, err := suite.sequenceRepo.Next(context.Background(), "invoice")
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), i, value)
+	}
+
+	value, err := suite.sequenceRepo.Next(context.Background(), "credit_note")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), value)
+}
+
+func (suite *DocumentSequenceRepoTestSuite) TestNextExistingSequence() {
+	err := suite.db.Table("document_sequences").Create(&models.DocumentSequence{Name: "invoice", NextValue: 7}).Error
+	assert.NoError(suite.T(), err)
+
+	value, err := suite.sequenceRepo.Next(context.Background(), "invoice")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(7), value)
+}
+
+func (suite *DocumentSequenceRepoTestSuite) TestNextIsGaplessAfterRollback() {
+	_, err := suite.sequenceRepo.Next(context.Background(), "invoice")
+	assert.NoError(suite.T(), err)
+
+	err = suite.db.Transaction(func(tx *gorm.DB) error {
+		value, err := NewDocumentSequenceRepo(tx).Next(context.Background(), "invoice")
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(2), value)
+
+		return errors.New("invoice failed")
+	})
+	assert.Error(suite.T(), err)
+
+	value, err := suite.sequenceRepo.Next(context.Background(), "invoice")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), value)
+}
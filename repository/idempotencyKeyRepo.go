@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyKeyRepo interface {
+	Claim(ctx context.Context, key *models.IdempotencyKey) error
+	Get(ctx context.Context, key string) (*models.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, key string, statusCode int, body string) error
+	Delete(ctx context.Context, key string) error
+}
+
+type idempotencyKeyRepo struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepo(db *gorm.DB) IdempotencyKeyRepo {
+	return &idempotencyKeyRepo{
+		db: db,
+	}
+}
+
+func (i *idempotencyKeyRepo) getTable() string {
+	return "idempotency_keys"
+}
+
+// Claim stores the key unless it is already in use. A key that has expired is
+// removed first so it can be used again. It returns ErrorRecordExists when
+// the key is taken.
+func (i *idempotencyKeyRepo) Claim(ctx context.Context, key *models.IdempotencyKey) error {
+	err := i.db.WithContext(ctx).Table(i.getTable()).Where("idempotency_key = ? AND expires_at <= ?", key.Key, key.CreatedAt).
+		Delete(&models.IdempotencyKey{}).Error
+	if err != nil {
+		return err
+	}
+
+	tx := i.db.WithContext(ctx).Table(i.getTable()).Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return constants.ErrorRecordExists
+	}
+
+	return nil
+}
+
+func (i *idempotencyKeyRepo) Get(ctx context.Context, key string) (*models.IdempotencyKey, error) {
+	var result *models.IdempotencyKey
+
+	err := i.db.WithContext(ctx).Table(i.getTable()).Where("idempotency_key = ?", key).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (i *idempotencyKeyRepo) SaveResponse(ctx context.Context, key string, statusCode int, body string) error {
+	tx := i.db.WithContext(ctx).Table(i.getTable()).Where("idempotency_key = ?", key).UpdateColumns(map[string]interface{}{
+		"status_code":   statusCode,
+		"response_body": body,
+	})
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error saving idempotent response")
+	}
+
+	return nil
+}
+
+func (i *idempotencyKeyRepo) Delete(ctx context.Context, key string) error {
+	err := i.db.WithContext(ctx).Table(i.getTable()).Where("idempotency_key = ?", key).Delete(&models.IdempotencyKey{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type IdempotencyKeyRepoTestSuite struct {
+	suite.Suite
+	db                 *gorm.DB
+	idempotencyKeyRepo IdempotencyKeyRepo
+}
+
+func TestIdempotencyKeyRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyKeyRepoTestSuite))
+}
+
+func (suite *IdempotencyKeyRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.idempotencyKeyRepo = NewIdempotencyKeyRepo(suite.db)
+}
+
+func (suite *IdempotencyKeyRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func newIdempotencyKey(hash string, now time.Time, ttl time.Duration) *models.IdempotencyKey {
+	return &models.IdempotencyKey{
+		Key:         "POST /orders k1",
+		RequestHash: hash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+func (suite *IdempotencyKeyRepoTestSuite) TestClaim() {
+	now := time.Now().UTC()
+
+	err := suite.idempotencyKeyRepo.Claim(context.Background(), newIdempotencyKey("h1", now, time.Hour))
+	assert.NoError(suite.T(), err)
+
+	err = suite.idempotencyKeyRepo.Claim(context.Background(), newIdempotencyKey("h2", now, time.Hour))
+	assert.Equal(suite.T(), constants.ErrorRecordExists, err)
+
+	result, err := suite.idempotencyKeyRepo.Get(context.Background(), "POST /orders k1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "h1", result.RequestHash)
+	assert.Zero(suite.T(), result.StatusCode)
+}
+
+func (suite *IdempotencyKeyRepoTestSuite) TestClaimExpired() {
+	now := time.Now().UTC()
+
+	err := suite.idempotencyKeyRepo.Claim(context.Background(), newIdempotencyKey("h1", now.Add(-2*time.Hour), time.Hour))
+	assert.NoError(suite.T(), err)
+
+	err = suite.idempotencyKeyRepo.Claim(context.Background(), newIdempotencyKey("h2", now, time.Hour))
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.idempotencyKeyRepo.Get(context.Background(), "POST /orders k1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "h2", result.RequestHash)
+}
+
+func (suite *IdempotencyKeyRepoTestSuite) TestSaveResponse() {
+	err := suite.idempotencyKeyRepo.Claim(context.Background(), newIdempotencyKey("h1", time.Now().UTC(), time.Hour))
+	assert.NoError(suite.T(), err)
+
+	err = suite.idempotencyKeyRepo.SaveResponse(context.Background(), "POST /orders k1", 200, `{"order_id":"o1"}`)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.idempotencyKeyRepo.Get(context.Background(), "POST /orders k1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 200, result.StatusCode)
+	assert.Equal(suite.T(), `{"order_id":"o1"}`, result.ResponseBody)
+
+	err = suite.idempotencyKeyRepo.SaveResponse(context.Background(), "POST /orders k2", 200, "")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *IdempotencyKeyRepoTestSuite) TestDelete() {
+	err := suite.idempotencyKeyRepo.Claim(context.Background(), newIdempotencyKey("h1", time.Now().UTC(), time.Hour))
+	assert.NoError(suite.T(), err)
+
+	err = suite.idempotencyKeyRepo.Delete(context.Background(), "POST /orders k1")
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.idempotencyKeyRepo.Get(context.Background(), "POST /orders k1")
+	assert.Error(suite.T(), err)
+}
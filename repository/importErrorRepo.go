@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type ImportErrorRepo interface {
+	Create(ctx context.Context, importErrors ...*models.ImportError) error
+	GetByJob(ctx context.Context, jobId string) ([]*models.ImportError, error)
+}
+
+type importErrorRepo struct {
+	db *gorm.DB
+}
+
+func NewImportErrorRepo(db *gorm.DB) ImportErrorRepo {
+	return &importErrorRepo{
+		db: db,
+	}
+}
+
+func (i *importErrorRepo) getTable() string {
+	return "import_errors"
+}
+
+func (i *importErrorRepo) Create(ctx context.Context, importErrors ...*models.ImportError) error {
+	err := i.db.WithContext(ctx).Table(i.getTable()).Create(importErrors).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *importErrorRepo) GetByJob(ctx context.Context, jobId string) ([]*models.ImportError, error) {
+	var result []*models.ImportError
+
+	err := i.db.WithContext(ctx).Table(i.getTable()).Where("job_id = ?", jobId).Order("line, field").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
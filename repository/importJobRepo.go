@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type ImportJobRepo interface {
+	Create(ctx context.Context, job *models.ImportJob) error
+	Update(ctx context.Context, job *models.ImportJob) error
+	Get(ctx context.Context, jobId string) (*models.ImportJob, error)
+}
+
+type importJobRepo struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepo(db *gorm.DB) ImportJobRepo {
+	return &importJobRepo{
+		db: db,
+	}
+}
+
+func (i *importJobRepo) getTable() string {
+	return "import_jobs"
+}
+
+func (i *importJobRepo) Create(ctx context.Context, job *models.ImportJob) error {
+	err := i.db.WithContext(ctx).Table(i.getTable()).Create(job).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *importJobRepo) Update(ctx context.Context, job *models.ImportJob) error {
+	err := i.db.WithContext(ctx).Table(i.getTable()).Save(job).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *importJobRepo) Get(ctx context.Context, jobId string) (*models.ImportJob, error) {
+	var result *models.ImportJob
+
+	err := i.db.WithContext(ctx).Table(i.getTable()).Where("job_id = ?", jobId).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ImportJobRepoTestSuite struct {
+	suite.Suite
+	db              *gorm.DB
+	importJobRepo   ImportJobRepo
+	importErrorRepo ImportErrorRepo
+}
+
+func TestImportJobRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportJobRepoTestSuite))
+}
+
+func (suite *ImportJobRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.importJobRepo = NewImportJobRepo(suite.db)
+	suite.importErrorRepo = NewImportErrorRepo(suite.db)
+}
+
+func (suite *ImportJobRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *ImportJobRepoTestSuite) TestCreateAndUpdateJob() {
+	job := &models.ImportJob{
+		JobId:     "j1",
+		Type:      constants.ImportTypeArticles,
+		Format:    constants.FileFormatCSV,
+		Status:    constants.ImportStatusQueued,
+		TotalRows: 10,
+	}
+
+	err := suite.importJobRepo.Create(context.Background(), job)
+	assert.NoError(suite.T(), err)
+
+	job.Status = constants.ImportStatusCompleted
+	job.Processed = 10
+	job.Succeeded = 9
+	job.Failed = 1
+
+	err = suite.importJobRepo.Update(context.Background(), job)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.importJobRepo.Get(context.Background(), "j1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.ImportStatusCompleted, result.Status)
+	assert.Equal(suite.T(), 9, result.Succeeded)
+	assert.Equal(suite.T(), 1, result.Failed)
+}
+
+func (suite *ImportJobRepoTestSuite) TestCreateJobWithoutType() {
+	err := suite.importJobRepo.Create(context.Background(), &models.ImportJob{JobId: "j1"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ImportJobRepoTestSuite) TestGetJobNotFound() {
+	_, err := suite.importJobRepo.Get(context.Background(), "missing")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ImportJobRepoTestSuite) TestGetErrorsByJob() {
+	err := suite.importErrorRepo.Create(
+		context.Background(), &models.ImportError{ImportErrorId: "e1", JobId: "j1", Line: 4, Field: "stock", Rule: "gte", Param: "0"},
+		&models.ImportError{ImportErrorId: "e2", JobId: "j1", Line: 2, Field: "article_name", Rule: "required"},
+		&models.ImportError{ImportErrorId: "e3", JobId: "j2", Line: 2, Field: "price", Rule: "decimal"},
+	)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.importErrorRepo.GetByJob(context.Background(), "j1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), 2, result[0].Line)
+	assert.Equal(suite.T(), 4, result[1].Line)
+}
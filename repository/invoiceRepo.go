@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type InvoiceRepo interface {
+	Create(ctx context.Context, invoice *models.Invoice) error
+	GetByNumber(ctx context.Context, invoiceNumber string) (*models.Invoice, error)
+	GetByOrder(ctx context.Context, orderId string, invoiceType string) ([]*models.Invoice, error)
+}
+
+type invoiceRepo struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepo(db *gorm.DB) InvoiceRepo {
+	return &invoiceRepo{
+		db: db,
+	}
+}
+
+func (i *invoiceRepo) getTable() string {
+	return "invoices"
+}
+
+func (i *invoiceRepo) Create(ctx context.Context, invoice *models.Invoice) error {
+	err := i.db.WithContext(ctx).Table(i.getTable()).Create(invoice).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *invoiceRepo) GetByNumber(ctx context.Context, invoiceNumber string) (*models.Invoice, error) {
+	var result *models.Invoice
+
+	err := i.db.WithContext(ctx).Table(i.getTable()).Where("invoice_number = ?", invoiceNumber).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (i *invoiceRepo) GetByOrder(ctx context.Context, orderId string, invoiceType string) ([]*models.Invoice, error) {
+	var result []*models.Invoice
+
+	err := i.db.WithContext(ctx).Table(i.getTable()).Where("order_id = ? AND type = ?", orderId, invoiceType).Order("issued_at, invoice_number").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
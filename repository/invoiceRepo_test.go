@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+	"inventory-management/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type InvoiceRepoTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	invoiceRepo InvoiceRepo
+}
+
+func TestInvoiceRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(InvoiceRepoTestSuite))
+}
+
+func (suite *InvoiceRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.invoiceRepo = NewInvoiceRepo(suite.db)
+}
+
+func (suite *InvoiceRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *InvoiceRepoTestSuite) TestCreateAndGet() {
+	now := time.Now().UTC()
+
+	invoices := []*models.Invoice{
+		{InvoiceId: "1", InvoiceNumber: "INV-000001", Type: "invoice", OrderId: "o1", IssuedAt: now, TotalAmount: money.MustParse("100", "INR"), Document: "{}"},
+		{InvoiceId: "2", InvoiceNumber: "CN-000001", Type: "credit_note", OrderId: "o1", IssuedAt: now, InvoiceRef: "INV-000001", Document: "{}"},
+		{InvoiceId: "3", InvoiceNumber: "CN-000002", Type: "credit_note", OrderId: "o1", IssuedAt: now.Add(time.Minute), InvoiceRef: "INV-000001", Document: "{}"},
+	}
+
+	for _, v := range invoices {
+		err := suite.invoiceRepo.Create(context.Background(), v)
+		assert.NoError(suite.T(), err)
+	}
+
+	result, err := suite.invoiceRepo.GetByNumber(context.Background(), "INV-000001")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "100.00 INR", result.TotalAmount.String())
+
+	creditNotes, err := suite.invoiceRepo.GetByOrder(context.Background(), "o1", "credit_note")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), creditNotes, 2)
+	assert.Equal(suite.T(), "CN-000001", creditNotes[0].InvoiceNumber)
+}
+
+func (suite *InvoiceRepoTestSuite) TestCreateDuplicateNumber() {
+	err := suite.invoiceRepo.Create(context.Background(), &models.Invoice{InvoiceId: "1", InvoiceNumber: "INV-000001"})
+	assert.NoError(suite.T(), err)
+
+	err = suite.invoiceRepo.Create(context.Background(), &models.Invoice{InvoiceId: "2", InvoiceNumber: "INV-000001"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *InvoiceRepoTestSuite) TestGetByNumberNotFound() {
+	_, err := suite.invoiceRepo.GetByNumber(context.Background(), "INV-000009")
+	assert.Equal(suite.T(), gorm.ErrRecordNotFound, err)
+}
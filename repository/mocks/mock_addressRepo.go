@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	models "inventory-management/models"
 	reflect "reflect"
 
@@ -34,59 +35,59 @@ func (m *MockAddressRepo) EXPECT() *MockAddressRepoMockRecorder {
 	return m.recorder
 }
 
+// Create mocks base method.
+func (m *MockAddressRepo) Create(ctx context.Context, address *models.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAddressRepoMockRecorder) Create(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAddressRepo)(nil).Create), ctx, address)
+}
+
 // Delete mocks base method.
-func (m *MockAddressRepo) Delete(addressId string) error {
+func (m *MockAddressRepo) Delete(ctx context.Context, addressId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", addressId)
+	ret := m.ctrl.Call(m, "Delete", ctx, addressId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockAddressRepoMockRecorder) Delete(addressId interface{}) *gomock.Call {
+func (mr *MockAddressRepoMockRecorder) Delete(ctx, addressId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAddressRepo)(nil).Delete), addressId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAddressRepo)(nil).Delete), ctx, addressId)
 }
 
 // Get mocks base method.
-func (m *MockAddressRepo) Get(addressId string) (*models.Address, error) {
+func (m *MockAddressRepo) Get(ctx context.Context, addressId string) (*models.Address, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", addressId)
+	ret := m.ctrl.Call(m, "Get", ctx, addressId)
 	ret0, _ := ret[0].(*models.Address)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockAddressRepoMockRecorder) Get(addressId interface{}) *gomock.Call {
+func (mr *MockAddressRepoMockRecorder) Get(ctx, addressId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAddressRepo)(nil).Get), addressId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAddressRepo)(nil).Get), ctx, addressId)
 }
 
 // Update mocks base method.
-func (m *MockAddressRepo) Update(addressId string, address *models.Address) error {
+func (m *MockAddressRepo) Update(ctx context.Context, addressId string, address *models.Address) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", addressId, address)
+	ret := m.ctrl.Call(m, "Update", ctx, addressId, address)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockAddressRepoMockRecorder) Update(addressId, address interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAddressRepo)(nil).Update), addressId, address)
-}
-
-// Upsert mocks base method.
-func (m *MockAddressRepo) Upsert(address *models.Address) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Upsert", address)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Upsert indicates an expected call of Upsert.
-func (mr *MockAddressRepoMockRecorder) Upsert(address interface{}) *gomock.Call {
+func (mr *MockAddressRepoMockRecorder) Update(ctx, addressId, address interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockAddressRepo)(nil).Upsert), address)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAddressRepo)(nil).Update), ctx, addressId, address)
 }
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/articlePriceRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockArticlePriceRepo is a mock of ArticlePriceRepo interface.
+type MockArticlePriceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticlePriceRepoMockRecorder
+}
+
+// MockArticlePriceRepoMockRecorder is the mock recorder for MockArticlePriceRepo.
+type MockArticlePriceRepoMockRecorder struct {
+	mock *MockArticlePriceRepo
+}
+
+// NewMockArticlePriceRepo creates a new mock instance.
+func NewMockArticlePriceRepo(ctrl *gomock.Controller) *MockArticlePriceRepo {
+	mock := &MockArticlePriceRepo{ctrl: ctrl}
+	mock.recorder = &MockArticlePriceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticlePriceRepo) EXPECT() *MockArticlePriceRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteByArticle mocks base method.
+func (m *MockArticlePriceRepo) DeleteByArticle(ctx context.Context, articleId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticle", ctx, articleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticle indicates an expected call of DeleteByArticle.
+func (mr *MockArticlePriceRepoMockRecorder) DeleteByArticle(ctx, articleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticle", reflect.TypeOf((*MockArticlePriceRepo)(nil).DeleteByArticle), ctx, articleId)
+}
+
+// Get mocks base method.
+func (m *MockArticlePriceRepo) Get(ctx context.Context, articleId, currency string) (*models.ArticlePrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, articleId, currency)
+	ret0, _ := ret[0].(*models.ArticlePrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockArticlePriceRepoMockRecorder) Get(ctx, articleId, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArticlePriceRepo)(nil).Get), ctx, articleId, currency)
+}
+
+// GetByArticles mocks base method.
+func (m *MockArticlePriceRepo) GetByArticles(ctx context.Context, articleIds ...string) ([]*models.ArticlePrice, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range articleIds {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetByArticles", varargs...)
+	ret0, _ := ret[0].([]*models.ArticlePrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticles indicates an expected call of GetByArticles.
+func (mr *MockArticlePriceRepoMockRecorder) GetByArticles(ctx interface{}, articleIds ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, articleIds...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticles", reflect.TypeOf((*MockArticlePriceRepo)(nil).GetByArticles), varargs...)
+}
+
+// Upsert mocks base method.
+func (m *MockArticlePriceRepo) Upsert(ctx context.Context, prices ...*models.ArticlePrice) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range prices {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Upsert", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockArticlePriceRepoMockRecorder) Upsert(ctx interface{}, prices ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, prices...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockArticlePriceRepo)(nil).Upsert), varargs...)
+}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	models "inventory-management/models"
 	reflect "reflect"
 
@@ -34,88 +35,194 @@ func (m *MockArticleRepo) EXPECT() *MockArticleRepoMockRecorder {
 	return m.recorder
 }
 
+// AdjustStock mocks base method.
+func (m *MockArticleRepo) AdjustStock(ctx context.Context, articleId string, stock, damaged int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, articleId, stock, damaged)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockArticleRepoMockRecorder) AdjustStock(ctx, articleId, stock, damaged interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockArticleRepo)(nil).AdjustStock), ctx, articleId, stock, damaged)
+}
+
+// CountOutOfStock mocks base method.
+func (m *MockArticleRepo) CountOutOfStock(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOutOfStock", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOutOfStock indicates an expected call of CountOutOfStock.
+func (mr *MockArticleRepoMockRecorder) CountOutOfStock(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOutOfStock", reflect.TypeOf((*MockArticleRepo)(nil).CountOutOfStock), ctx)
+}
+
 // Create mocks base method.
-func (m *MockArticleRepo) Create(article *models.Article) error {
+func (m *MockArticleRepo) Create(ctx context.Context, article *models.Article) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", article)
+	ret := m.ctrl.Call(m, "Create", ctx, article)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockArticleRepoMockRecorder) Create(article interface{}) *gomock.Call {
+func (mr *MockArticleRepoMockRecorder) Create(ctx, article interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleRepo)(nil).Create), ctx, article)
+}
+
+// DeductStock mocks base method.
+func (m *MockArticleRepo) DeductStock(ctx context.Context, articleId string, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeductStock", ctx, articleId, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeductStock indicates an expected call of DeductStock.
+func (mr *MockArticleRepoMockRecorder) DeductStock(ctx, articleId, quantity interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleRepo)(nil).Create), article)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeductStock", reflect.TypeOf((*MockArticleRepo)(nil).DeductStock), ctx, articleId, quantity)
 }
 
 // Delete mocks base method.
-func (m *MockArticleRepo) Delete(articleId string) error {
+func (m *MockArticleRepo) Delete(ctx context.Context, articleId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", articleId)
+	ret := m.ctrl.Call(m, "Delete", ctx, articleId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockArticleRepoMockRecorder) Delete(articleId interface{}) *gomock.Call {
+func (mr *MockArticleRepoMockRecorder) Delete(ctx, articleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleRepo)(nil).Delete), ctx, articleId)
+}
+
+// FindInBatches mocks base method.
+func (m *MockArticleRepo) FindInBatches(ctx context.Context, size int, fn func([]*models.Article) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInBatches", ctx, size, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindInBatches indicates an expected call of FindInBatches.
+func (mr *MockArticleRepoMockRecorder) FindInBatches(ctx, size, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleRepo)(nil).Delete), articleId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInBatches", reflect.TypeOf((*MockArticleRepo)(nil).FindInBatches), ctx, size, fn)
 }
 
 // Get mocks base method.
-func (m *MockArticleRepo) Get(articleId string) (*models.Article, error) {
+func (m *MockArticleRepo) Get(ctx context.Context, articleId string) (*models.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", articleId)
+	ret := m.ctrl.Call(m, "Get", ctx, articleId)
 	ret0, _ := ret[0].(*models.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockArticleRepoMockRecorder) Get(articleId interface{}) *gomock.Call {
+func (mr *MockArticleRepoMockRecorder) Get(ctx, articleId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArticleRepo)(nil).Get), articleId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArticleRepo)(nil).Get), ctx, articleId)
 }
 
 // GetAll mocks base method.
-func (m *MockArticleRepo) GetAll() ([]*models.Article, error) {
+func (m *MockArticleRepo) GetAll(ctx context.Context) ([]*models.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
+	ret := m.ctrl.Call(m, "GetAll", ctx)
 	ret0, _ := ret[0].([]*models.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockArticleRepoMockRecorder) GetAll() *gomock.Call {
+func (mr *MockArticleRepoMockRecorder) GetAll(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockArticleRepo)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockArticleRepo)(nil).GetAll), ctx)
+}
+
+// GetForUpdate mocks base method.
+func (m *MockArticleRepo) GetForUpdate(ctx context.Context, articleId string) (*models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUpdate", ctx, articleId)
+	ret0, _ := ret[0].(*models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUpdate indicates an expected call of GetForUpdate.
+func (mr *MockArticleRepoMockRecorder) GetForUpdate(ctx, articleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUpdate", reflect.TypeOf((*MockArticleRepo)(nil).GetForUpdate), ctx, articleId)
+}
+
+// LowestStock mocks base method.
+func (m *MockArticleRepo) LowestStock(ctx context.Context, limit int) ([]*models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LowestStock", ctx, limit)
+	ret0, _ := ret[0].([]*models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LowestStock indicates an expected call of LowestStock.
+func (mr *MockArticleRepoMockRecorder) LowestStock(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LowestStock", reflect.TypeOf((*MockArticleRepo)(nil).LowestStock), ctx, limit)
 }
 
 // Update mocks base method.
-func (m *MockArticleRepo) Update(articleId string, article *models.Article) error {
+func (m *MockArticleRepo) Update(ctx context.Context, articleId string, article *models.Article) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", articleId, article)
+	ret := m.ctrl.Call(m, "Update", ctx, articleId, article)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockArticleRepoMockRecorder) Update(articleId, article interface{}) *gomock.Call {
+func (mr *MockArticleRepoMockRecorder) Update(ctx, articleId, article interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArticleRepo)(nil).Update), articleId, article)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArticleRepo)(nil).Update), ctx, articleId, article)
 }
 
 // UpdateArticleStock mocks base method.
-func (m *MockArticleRepo) UpdateArticleStock(articleId string, stock int64) error {
+func (m *MockArticleRepo) UpdateArticleStock(ctx context.Context, articleId string, version, stock int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateArticleStock", articleId, stock)
+	ret := m.ctrl.Call(m, "UpdateArticleStock", ctx, articleId, version, stock)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateArticleStock indicates an expected call of UpdateArticleStock.
-func (mr *MockArticleRepoMockRecorder) UpdateArticleStock(articleId, stock interface{}) *gomock.Call {
+func (mr *MockArticleRepoMockRecorder) UpdateArticleStock(ctx, articleId, version, stock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticleStock", reflect.TypeOf((*MockArticleRepo)(nil).UpdateArticleStock), ctx, articleId, version, stock)
+}
+
+// Upsert mocks base method.
+func (m *MockArticleRepo) Upsert(ctx context.Context, articles ...*models.Article) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range articles {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Upsert", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockArticleRepoMockRecorder) Upsert(ctx interface{}, articles ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticleStock", reflect.TypeOf((*MockArticleRepo)(nil).UpdateArticleStock), articleId, stock)
+	varargs := append([]interface{}{ctx}, articles...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockArticleRepo)(nil).Upsert), varargs...)
 }
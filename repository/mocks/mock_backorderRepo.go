@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/backorderRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBackorderRepo is a mock of BackorderRepo interface.
+type MockBackorderRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackorderRepoMockRecorder
+}
+
+// MockBackorderRepoMockRecorder is the mock recorder for MockBackorderRepo.
+type MockBackorderRepoMockRecorder struct {
+	mock *MockBackorderRepo
+}
+
+// NewMockBackorderRepo creates a new mock instance.
+func NewMockBackorderRepo(ctrl *gomock.Controller) *MockBackorderRepo {
+	mock := &MockBackorderRepo{ctrl: ctrl}
+	mock.recorder = &MockBackorderRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackorderRepo) EXPECT() *MockBackorderRepoMockRecorder {
+	return m.recorder
+}
+
+// Allocate mocks base method.
+func (m *MockBackorderRepo) Allocate(ctx context.Context, backorderId string, quantity int, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allocate", ctx, backorderId, quantity, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allocate indicates an expected call of Allocate.
+func (mr *MockBackorderRepoMockRecorder) Allocate(ctx, backorderId, quantity, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allocate", reflect.TypeOf((*MockBackorderRepo)(nil).Allocate), ctx, backorderId, quantity, status)
+}
+
+// CancelByOrder mocks base method.
+func (m *MockBackorderRepo) CancelByOrder(ctx context.Context, orderId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelByOrder", ctx, orderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelByOrder indicates an expected call of CancelByOrder.
+func (mr *MockBackorderRepoMockRecorder) CancelByOrder(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelByOrder", reflect.TypeOf((*MockBackorderRepo)(nil).CancelByOrder), ctx, orderId)
+}
+
+// Create mocks base method.
+func (m *MockBackorderRepo) Create(ctx context.Context, backorders ...*models.Backorder) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range backorders {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBackorderRepoMockRecorder) Create(ctx interface{}, backorders ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, backorders...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBackorderRepo)(nil).Create), varargs...)
+}
+
+// GetByOrder mocks base method.
+func (m *MockBackorderRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.Backorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrder", ctx, orderId)
+	ret0, _ := ret[0].([]*models.Backorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrder indicates an expected call of GetByOrder.
+func (mr *MockBackorderRepoMockRecorder) GetByOrder(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockBackorderRepo)(nil).GetByOrder), ctx, orderId)
+}
+
+// GetOpenByArticle mocks base method.
+func (m *MockBackorderRepo) GetOpenByArticle(ctx context.Context, articleId string) ([]*models.Backorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenByArticle", ctx, articleId)
+	ret0, _ := ret[0].([]*models.Backorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenByArticle indicates an expected call of GetOpenByArticle.
+func (mr *MockBackorderRepoMockRecorder) GetOpenByArticle(ctx, articleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenByArticle", reflect.TypeOf((*MockBackorderRepo)(nil).GetOpenByArticle), ctx, articleId)
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/couponRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCouponRepo is a mock of CouponRepo interface.
+type MockCouponRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCouponRepoMockRecorder
+}
+
+// MockCouponRepoMockRecorder is the mock recorder for MockCouponRepo.
+type MockCouponRepoMockRecorder struct {
+	mock *MockCouponRepo
+}
+
+// NewMockCouponRepo creates a new mock instance.
+func NewMockCouponRepo(ctrl *gomock.Controller) *MockCouponRepo {
+	mock := &MockCouponRepo{ctrl: ctrl}
+	mock.recorder = &MockCouponRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCouponRepo) EXPECT() *MockCouponRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCouponRepo) Create(ctx context.Context, coupon *models.Coupon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, coupon)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCouponRepoMockRecorder) Create(ctx, coupon interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCouponRepo)(nil).Create), ctx, coupon)
+}
+
+// Delete mocks base method.
+func (m *MockCouponRepo) Delete(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCouponRepoMockRecorder) Delete(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCouponRepo)(nil).Delete), ctx, code)
+}
+
+// Get mocks base method.
+func (m *MockCouponRepo) Get(ctx context.Context, code string) (*models.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, code)
+	ret0, _ := ret[0].(*models.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCouponRepoMockRecorder) Get(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCouponRepo)(nil).Get), ctx, code)
+}
+
+// Redeem mocks base method.
+func (m *MockCouponRepo) Redeem(ctx context.Context, code, customerId, orderId string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, code, customerId, orderId, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockCouponRepoMockRecorder) Redeem(ctx, code, customerId, orderId, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockCouponRepo)(nil).Redeem), ctx, code, customerId, orderId, at)
+}
+
+// Release mocks base method.
+func (m *MockCouponRepo) Release(ctx context.Context, code, orderId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, code, orderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockCouponRepoMockRecorder) Release(ctx, code, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockCouponRepo)(nil).Release), ctx, code, orderId)
+}
+
+// Update mocks base method.
+func (m *MockCouponRepo) Update(ctx context.Context, code string, coupon *models.Coupon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, code, coupon)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCouponRepoMockRecorder) Update(ctx, code, coupon interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCouponRepo)(nil).Update), ctx, code, coupon)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/documentSequenceRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDocumentSequenceRepo is a mock of DocumentSequenceRepo interface.
+type MockDocumentSequenceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentSequenceRepoMockRecorder
+}
+
+// MockDocumentSequenceRepoMockRecorder is the mock recorder for MockDocumentSequenceRepo.
+type MockDocumentSequenceRepoMockRecorder struct {
+	mock *MockDocumentSequenceRepo
+}
+
+// NewMockDocumentSequenceRepo creates a new mock instance.
+func NewMockDocumentSequenceRepo(ctrl *gomock.Controller) *MockDocumentSequenceRepo {
+	mock := &MockDocumentSequenceRepo{ctrl: ctrl}
+	mock.recorder = &MockDocumentSequenceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentSequenceRepo) EXPECT() *MockDocumentSequenceRepoMockRecorder {
+	return m.recorder
+}
+
+// Next mocks base method.
+func (m *MockDocumentSequenceRepo) Next(ctx context.Context, name string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next", ctx, name)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockDocumentSequenceRepoMockRecorder) Next(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockDocumentSequenceRepo)(nil).Next), ctx, name)
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/idempotencyKeyRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIdempotencyKeyRepo is a mock of IdempotencyKeyRepo interface.
+type MockIdempotencyKeyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyKeyRepoMockRecorder
+}
+
+// MockIdempotencyKeyRepoMockRecorder is the mock recorder for MockIdempotencyKeyRepo.
+type MockIdempotencyKeyRepoMockRecorder struct {
+	mock *MockIdempotencyKeyRepo
+}
+
+// NewMockIdempotencyKeyRepo creates a new mock instance.
+func NewMockIdempotencyKeyRepo(ctrl *gomock.Controller) *MockIdempotencyKeyRepo {
+	mock := &MockIdempotencyKeyRepo{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyKeyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyKeyRepo) EXPECT() *MockIdempotencyKeyRepoMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockIdempotencyKeyRepo) Claim(ctx context.Context, key *models.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockIdempotencyKeyRepoMockRecorder) Claim(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockIdempotencyKeyRepo)(nil).Claim), ctx, key)
+}
+
+// Delete mocks base method.
+func (m *MockIdempotencyKeyRepo) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdempotencyKeyRepoMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdempotencyKeyRepo)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockIdempotencyKeyRepo) Get(ctx context.Context, key string) (*models.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(*models.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIdempotencyKeyRepoMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIdempotencyKeyRepo)(nil).Get), ctx, key)
+}
+
+// SaveResponse mocks base method.
+func (m *MockIdempotencyKeyRepo) SaveResponse(ctx context.Context, key string, statusCode int, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveResponse", ctx, key, statusCode, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveResponse indicates an expected call of SaveResponse.
+func (mr *MockIdempotencyKeyRepoMockRecorder) SaveResponse(ctx, key, statusCode, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveResponse", reflect.TypeOf((*MockIdempotencyKeyRepo)(nil).SaveResponse), ctx, key, statusCode, body)
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/importErrorRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockImportErrorRepo is a mock of ImportErrorRepo interface.
+type MockImportErrorRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportErrorRepoMockRecorder
+}
+
+// MockImportErrorRepoMockRecorder is the mock recorder for MockImportErrorRepo.
+type MockImportErrorRepoMockRecorder struct {
+	mock *MockImportErrorRepo
+}
+
+// NewMockImportErrorRepo creates a new mock instance.
+func NewMockImportErrorRepo(ctrl *gomock.Controller) *MockImportErrorRepo {
+	mock := &MockImportErrorRepo{ctrl: ctrl}
+	mock.recorder = &MockImportErrorRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportErrorRepo) EXPECT() *MockImportErrorRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImportErrorRepo) Create(ctx context.Context, importErrors ...*models.ImportError) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range importErrors {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImportErrorRepoMockRecorder) Create(ctx interface{}, importErrors ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, importErrors...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImportErrorRepo)(nil).Create), varargs...)
+}
+
+// GetByJob mocks base method.
+func (m *MockImportErrorRepo) GetByJob(ctx context.Context, jobId string) ([]*models.ImportError, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByJob", ctx, jobId)
+	ret0, _ := ret[0].([]*models.ImportError)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByJob indicates an expected call of GetByJob.
+func (mr *MockImportErrorRepoMockRecorder) GetByJob(ctx, jobId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByJob", reflect.TypeOf((*MockImportErrorRepo)(nil).GetByJob), ctx, jobId)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/importJobRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockImportJobRepo is a mock of ImportJobRepo interface.
+type MockImportJobRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportJobRepoMockRecorder
+}
+
+// MockImportJobRepoMockRecorder is the mock recorder for MockImportJobRepo.
+type MockImportJobRepoMockRecorder struct {
+	mock *MockImportJobRepo
+}
+
+// NewMockImportJobRepo creates a new mock instance.
+func NewMockImportJobRepo(ctrl *gomock.Controller) *MockImportJobRepo {
+	mock := &MockImportJobRepo{ctrl: ctrl}
+	mock.recorder = &MockImportJobRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportJobRepo) EXPECT() *MockImportJobRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImportJobRepo) Create(ctx context.Context, job *models.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImportJobRepoMockRecorder) Create(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImportJobRepo)(nil).Create), ctx, job)
+}
+
+// Get mocks base method.
+func (m *MockImportJobRepo) Get(ctx context.Context, jobId string) (*models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, jobId)
+	ret0, _ := ret[0].(*models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockImportJobRepoMockRecorder) Get(ctx, jobId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockImportJobRepo)(nil).Get), ctx, jobId)
+}
+
+// Update mocks base method.
+func (m *MockImportJobRepo) Update(ctx context.Context, job *models.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockImportJobRepoMockRecorder) Update(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockImportJobRepo)(nil).Update), ctx, job)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/invoiceRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInvoiceRepo is a mock of InvoiceRepo interface.
+type MockInvoiceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceRepoMockRecorder
+}
+
+// MockInvoiceRepoMockRecorder is the mock recorder for MockInvoiceRepo.
+type MockInvoiceRepoMockRecorder struct {
+	mock *MockInvoiceRepo
+}
+
+// NewMockInvoiceRepo creates a new mock instance.
+func NewMockInvoiceRepo(ctrl *gomock.Controller) *MockInvoiceRepo {
+	mock := &MockInvoiceRepo{ctrl: ctrl}
+	mock.recorder = &MockInvoiceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceRepo) EXPECT() *MockInvoiceRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockInvoiceRepo) Create(ctx context.Context, invoice *models.Invoice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, invoice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInvoiceRepoMockRecorder) Create(ctx, invoice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvoiceRepo)(nil).Create), ctx, invoice)
+}
+
+// GetByNumber mocks base method.
+func (m *MockInvoiceRepo) GetByNumber(ctx context.Context, invoiceNumber string) (*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByNumber", ctx, invoiceNumber)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByNumber indicates an expected call of GetByNumber.
+func (mr *MockInvoiceRepoMockRecorder) GetByNumber(ctx, invoiceNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByNumber", reflect.TypeOf((*MockInvoiceRepo)(nil).GetByNumber), ctx, invoiceNumber)
+}
+
+// GetByOrder mocks base method.
+func (m *MockInvoiceRepo) GetByOrder(ctx context.Context, orderId, invoiceType string) ([]*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrder", ctx, orderId, invoiceType)
+	ret0, _ := ret[0].([]*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrder indicates an expected call of GetByOrder.
+func (mr *MockInvoiceRepoMockRecorder) GetByOrder(ctx, orderId, invoiceType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockInvoiceRepo)(nil).GetByOrder), ctx, orderId, invoiceType)
+}
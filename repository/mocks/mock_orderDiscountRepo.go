@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/orderDiscountRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrderDiscountRepo is a mock of OrderDiscountRepo interface.
+type MockOrderDiscountRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderDiscountRepoMockRecorder
+}
+
+// MockOrderDiscountRepoMockRecorder is the mock recorder for MockOrderDiscountRepo.
+type MockOrderDiscountRepoMockRecorder struct {
+	mock *MockOrderDiscountRepo
+}
+
+// NewMockOrderDiscountRepo creates a new mock instance.
+func NewMockOrderDiscountRepo(ctrl *gomock.Controller) *MockOrderDiscountRepo {
+	mock := &MockOrderDiscountRepo{ctrl: ctrl}
+	mock.recorder = &MockOrderDiscountRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderDiscountRepo) EXPECT() *MockOrderDiscountRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrderDiscountRepo) Create(ctx context.Context, discounts ...*models.OrderDiscount) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range discounts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderDiscountRepoMockRecorder) Create(ctx interface{}, discounts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, discounts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderDiscountRepo)(nil).Create), varargs...)
+}
+
+// DeleteByOrder mocks base method.
+func (m *MockOrderDiscountRepo) DeleteByOrder(ctx context.Context, orderId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByOrder", ctx, orderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByOrder indicates an expected call of DeleteByOrder.
+func (mr *MockOrderDiscountRepoMockRecorder) DeleteByOrder(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByOrder", reflect.TypeOf((*MockOrderDiscountRepo)(nil).DeleteByOrder), ctx, orderId)
+}
+
+// GetByOrder mocks base method.
+func (m *MockOrderDiscountRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.OrderDiscount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrder", ctx, orderId)
+	ret0, _ := ret[0].([]*models.OrderDiscount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrder indicates an expected call of GetByOrder.
+func (mr *MockOrderDiscountRepoMockRecorder) GetByOrder(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockOrderDiscountRepo)(nil).GetByOrder), ctx, orderId)
+}
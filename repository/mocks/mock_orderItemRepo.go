@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	models "inventory-management/models"
 	reflect "reflect"
 
@@ -34,10 +35,24 @@ func (m *MockOrderItemRepo) EXPECT() *MockOrderItemRepoMockRecorder {
 	return m.recorder
 }
 
+// Allocate mocks base method.
+func (m *MockOrderItemRepo) Allocate(ctx context.Context, orderItemId string, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allocate", ctx, orderItemId, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allocate indicates an expected call of Allocate.
+func (mr *MockOrderItemRepoMockRecorder) Allocate(ctx, orderItemId, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allocate", reflect.TypeOf((*MockOrderItemRepo)(nil).Allocate), ctx, orderItemId, quantity)
+}
+
 // Create mocks base method.
-func (m *MockOrderItemRepo) Create(orderItem ...*models.OrderItem) error {
+func (m *MockOrderItemRepo) Create(ctx context.Context, orderItem ...*models.OrderItem) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
+	varargs := []interface{}{ctx}
 	for _, a := range orderItem {
 		varargs = append(varargs, a)
 	}
@@ -47,87 +62,88 @@ func (m *MockOrderItemRepo) Create(orderItem ...*models.OrderItem) error {
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockOrderItemRepoMockRecorder) Create(orderItem ...interface{}) *gomock.Call {
+func (mr *MockOrderItemRepoMockRecorder) Create(ctx interface{}, orderItem ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderItemRepo)(nil).Create), orderItem...)
+	varargs := append([]interface{}{ctx}, orderItem...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderItemRepo)(nil).Create), varargs...)
 }
 
 // Delete mocks base method.
-func (m *MockOrderItemRepo) Delete(orderItemId string) error {
+func (m *MockOrderItemRepo) Delete(ctx context.Context, orderItemId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", orderItemId)
+	ret := m.ctrl.Call(m, "Delete", ctx, orderItemId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockOrderItemRepoMockRecorder) Delete(orderItemId interface{}) *gomock.Call {
+func (mr *MockOrderItemRepoMockRecorder) Delete(ctx, orderItemId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderItemRepo)(nil).Delete), orderItemId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderItemRepo)(nil).Delete), ctx, orderItemId)
 }
 
 // DeleteAll mocks base method.
-func (m *MockOrderItemRepo) DeleteAll(orderItemIds []string) error {
+func (m *MockOrderItemRepo) DeleteAll(ctx context.Context, orderItemIds []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAll", orderItemIds)
+	ret := m.ctrl.Call(m, "DeleteAll", ctx, orderItemIds)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAll indicates an expected call of DeleteAll.
-func (mr *MockOrderItemRepoMockRecorder) DeleteAll(orderItemIds interface{}) *gomock.Call {
+func (mr *MockOrderItemRepoMockRecorder) DeleteAll(ctx, orderItemIds interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockOrderItemRepo)(nil).DeleteAll), orderItemIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockOrderItemRepo)(nil).DeleteAll), ctx, orderItemIds)
 }
 
 // Get mocks base method.
-func (m *MockOrderItemRepo) Get(orderItemId string) (*models.OrderItem, error) {
+func (m *MockOrderItemRepo) Get(ctx context.Context, orderItemId string) (*models.OrderItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", orderItemId)
+	ret := m.ctrl.Call(m, "Get", ctx, orderItemId)
 	ret0, _ := ret[0].(*models.OrderItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockOrderItemRepoMockRecorder) Get(orderItemId interface{}) *gomock.Call {
+func (mr *MockOrderItemRepoMockRecorder) Get(ctx, orderItemId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrderItemRepo)(nil).Get), orderItemId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrderItemRepo)(nil).Get), ctx, orderItemId)
 }
 
 // GetByOrder mocks base method.
-func (m *MockOrderItemRepo) GetByOrder(orderId string) ([]*models.OrderItem, error) {
+func (m *MockOrderItemRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.OrderItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByOrder", orderId)
+	ret := m.ctrl.Call(m, "GetByOrder", ctx, orderId)
 	ret0, _ := ret[0].([]*models.OrderItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByOrder indicates an expected call of GetByOrder.
-func (mr *MockOrderItemRepoMockRecorder) GetByOrder(orderId interface{}) *gomock.Call {
+func (mr *MockOrderItemRepoMockRecorder) GetByOrder(ctx, orderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockOrderItemRepo)(nil).GetByOrder), orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockOrderItemRepo)(nil).GetByOrder), ctx, orderId)
 }
 
 // Update mocks base method.
-func (m *MockOrderItemRepo) Update(orderItemId string, orderItem *models.OrderItem) error {
+func (m *MockOrderItemRepo) Update(ctx context.Context, orderItemId string, orderItem *models.OrderItem) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", orderItemId, orderItem)
+	ret := m.ctrl.Call(m, "Update", ctx, orderItemId, orderItem)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockOrderItemRepoMockRecorder) Update(orderItemId, orderItem interface{}) *gomock.Call {
+func (mr *MockOrderItemRepoMockRecorder) Update(ctx, orderItemId, orderItem interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderItemRepo)(nil).Update), orderItemId, orderItem)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderItemRepo)(nil).Update), ctx, orderItemId, orderItem)
 }
 
 // Upsert mocks base method.
-func (m *MockOrderItemRepo) Upsert(orderItems ...*models.OrderItem) error {
+func (m *MockOrderItemRepo) Upsert(ctx context.Context, orderItems ...*models.OrderItem) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
+	varargs := []interface{}{ctx}
 	for _, a := range orderItems {
 		varargs = append(varargs, a)
 	}
@@ -137,7 +153,8 @@ func (m *MockOrderItemRepo) Upsert(orderItems ...*models.OrderItem) error {
 }
 
 // Upsert indicates an expected call of Upsert.
-func (mr *MockOrderItemRepoMockRecorder) Upsert(orderItems ...interface{}) *gomock.Call {
+func (mr *MockOrderItemRepoMockRecorder) Upsert(ctx interface{}, orderItems ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockOrderItemRepo)(nil).Upsert), orderItems...)
+	varargs := append([]interface{}{ctx}, orderItems...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockOrderItemRepo)(nil).Upsert), varargs...)
 }
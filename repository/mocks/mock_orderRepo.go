@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	models "inventory-management/models"
 	reflect "reflect"
 
@@ -35,58 +36,87 @@ func (m *MockOrderRepo) EXPECT() *MockOrderRepoMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockOrderRepo) Create(order *models.Order) error {
+func (m *MockOrderRepo) Create(ctx context.Context, order *models.Order) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", order)
+	ret := m.ctrl.Call(m, "Create", ctx, order)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockOrderRepoMockRecorder) Create(order interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) Create(ctx, order interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderRepo)(nil).Create), order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderRepo)(nil).Create), ctx, order)
 }
 
 // Delete mocks base method.
-func (m *MockOrderRepo) Delete(orderId string) error {
+func (m *MockOrderRepo) Delete(ctx context.Context, orderId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", orderId)
+	ret := m.ctrl.Call(m, "Delete", ctx, orderId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockOrderRepoMockRecorder) Delete(orderId interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) Delete(ctx, orderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepo)(nil).Delete), orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepo)(nil).Delete), ctx, orderId)
 }
 
 // Get mocks base method.
-func (m *MockOrderRepo) Get(orderId string) (*models.Order, error) {
+func (m *MockOrderRepo) Get(ctx context.Context, orderId string) (*models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", orderId)
+	ret := m.ctrl.Call(m, "Get", ctx, orderId)
 	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockOrderRepoMockRecorder) Get(orderId interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) Get(ctx, orderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrderRepo)(nil).Get), orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrderRepo)(nil).Get), ctx, orderId)
+}
+
+// GetForUpdate mocks base method.
+func (m *MockOrderRepo) GetForUpdate(ctx context.Context, orderId string) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUpdate", ctx, orderId)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUpdate indicates an expected call of GetForUpdate.
+func (mr *MockOrderRepoMockRecorder) GetForUpdate(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUpdate", reflect.TypeOf((*MockOrderRepo)(nil).GetForUpdate), ctx, orderId)
 }
 
 // Update mocks base method.
-func (m *MockOrderRepo) Update(orderId string, order *models.Order) error {
+func (m *MockOrderRepo) Update(ctx context.Context, orderId string, order *models.Order) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", orderId, order)
+	ret := m.ctrl.Call(m, "Update", ctx, orderId, order)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockOrderRepoMockRecorder) Update(orderId, order interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) Update(ctx, orderId, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderRepo)(nil).Update), ctx, orderId, order)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockOrderRepo) UpdateStatus(ctx context.Context, orderId, from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, orderId, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockOrderRepoMockRecorder) UpdateStatus(ctx, orderId, from, to interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderRepo)(nil).Update), orderId, order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepo)(nil).UpdateStatus), ctx, orderId, from, to)
 }
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/paymentRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentRepo is a mock of PaymentRepo interface.
+type MockPaymentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepoMockRecorder
+}
+
+// MockPaymentRepoMockRecorder is the mock recorder for MockPaymentRepo.
+type MockPaymentRepoMockRecorder struct {
+	mock *MockPaymentRepo
+}
+
+// NewMockPaymentRepo creates a new mock instance.
+func NewMockPaymentRepo(ctrl *gomock.Controller) *MockPaymentRepo {
+	mock := &MockPaymentRepo{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepo) EXPECT() *MockPaymentRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPaymentRepo) Create(ctx context.Context, payment *models.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentRepoMockRecorder) Create(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentRepo)(nil).Create), ctx, payment)
+}
+
+// Get mocks base method.
+func (m *MockPaymentRepo) Get(ctx context.Context, paymentId string) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, paymentId)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPaymentRepoMockRecorder) Get(ctx, paymentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPaymentRepo)(nil).Get), ctx, paymentId)
+}
+
+// GetByOrder mocks base method.
+func (m *MockPaymentRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrder", ctx, orderId)
+	ret0, _ := ret[0].([]*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrder indicates an expected call of GetByOrder.
+func (mr *MockPaymentRepoMockRecorder) GetByOrder(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockPaymentRepo)(nil).GetByOrder), ctx, orderId)
+}
+
+// Settle mocks base method.
+func (m *MockPaymentRepo) Settle(ctx context.Context, paymentId, externalRef, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Settle", ctx, paymentId, externalRef, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Settle indicates an expected call of Settle.
+func (mr *MockPaymentRepoMockRecorder) Settle(ctx, paymentId, externalRef, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Settle", reflect.TypeOf((*MockPaymentRepo)(nil).Settle), ctx, paymentId, externalRef, status)
+}
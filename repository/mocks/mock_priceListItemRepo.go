@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/priceListItemRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPriceListItemRepo is a mock of PriceListItemRepo interface.
+type MockPriceListItemRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceListItemRepoMockRecorder
+}
+
+// MockPriceListItemRepoMockRecorder is the mock recorder for MockPriceListItemRepo.
+type MockPriceListItemRepoMockRecorder struct {
+	mock *MockPriceListItemRepo
+}
+
+// NewMockPriceListItemRepo creates a new mock instance.
+func NewMockPriceListItemRepo(ctrl *gomock.Controller) *MockPriceListItemRepo {
+	mock := &MockPriceListItemRepo{ctrl: ctrl}
+	mock.recorder = &MockPriceListItemRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceListItemRepo) EXPECT() *MockPriceListItemRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteByPriceList mocks base method.
+func (m *MockPriceListItemRepo) DeleteByPriceList(ctx context.Context, priceListId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByPriceList", ctx, priceListId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByPriceList indicates an expected call of DeleteByPriceList.
+func (mr *MockPriceListItemRepoMockRecorder) DeleteByPriceList(ctx, priceListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByPriceList", reflect.TypeOf((*MockPriceListItemRepo)(nil).DeleteByPriceList), ctx, priceListId)
+}
+
+// GetByPriceList mocks base method.
+func (m *MockPriceListItemRepo) GetByPriceList(ctx context.Context, priceListId string) ([]*models.PriceListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPriceList", ctx, priceListId)
+	ret0, _ := ret[0].([]*models.PriceListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPriceList indicates an expected call of GetByPriceList.
+func (mr *MockPriceListItemRepoMockRecorder) GetByPriceList(ctx, priceListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPriceList", reflect.TypeOf((*MockPriceListItemRepo)(nil).GetByPriceList), ctx, priceListId)
+}
+
+// GetByPriceLists mocks base method.
+func (m *MockPriceListItemRepo) GetByPriceLists(ctx context.Context, priceListIds, articleIds []string) ([]*models.PriceListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPriceLists", ctx, priceListIds, articleIds)
+	ret0, _ := ret[0].([]*models.PriceListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPriceLists indicates an expected call of GetByPriceLists.
+func (mr *MockPriceListItemRepoMockRecorder) GetByPriceLists(ctx, priceListIds, articleIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPriceLists", reflect.TypeOf((*MockPriceListItemRepo)(nil).GetByPriceLists), ctx, priceListIds, articleIds)
+}
+
+// Upsert mocks base method.
+func (m *MockPriceListItemRepo) Upsert(ctx context.Context, items ...*models.PriceListItem) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range items {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Upsert", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockPriceListItemRepoMockRecorder) Upsert(ctx interface{}, items ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, items...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockPriceListItemRepo)(nil).Upsert), varargs...)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/priceListRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPriceListRepo is a mock of PriceListRepo interface.
+type MockPriceListRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceListRepoMockRecorder
+}
+
+// MockPriceListRepoMockRecorder is the mock recorder for MockPriceListRepo.
+type MockPriceListRepoMockRecorder struct {
+	mock *MockPriceListRepo
+}
+
+// NewMockPriceListRepo creates a new mock instance.
+func NewMockPriceListRepo(ctrl *gomock.Controller) *MockPriceListRepo {
+	mock := &MockPriceListRepo{ctrl: ctrl}
+	mock.recorder = &MockPriceListRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceListRepo) EXPECT() *MockPriceListRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPriceListRepo) Create(ctx context.Context, priceList *models.PriceList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, priceList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPriceListRepoMockRecorder) Create(ctx, priceList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPriceListRepo)(nil).Create), ctx, priceList)
+}
+
+// Delete mocks base method.
+func (m *MockPriceListRepo) Delete(ctx context.Context, priceListId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, priceListId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPriceListRepoMockRecorder) Delete(ctx, priceListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPriceListRepo)(nil).Delete), ctx, priceListId)
+}
+
+// Get mocks base method.
+func (m *MockPriceListRepo) Get(ctx context.Context, priceListId string) (*models.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, priceListId)
+	ret0, _ := ret[0].(*models.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPriceListRepoMockRecorder) Get(ctx, priceListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPriceListRepo)(nil).Get), ctx, priceListId)
+}
+
+// GetApplicable mocks base method.
+func (m *MockPriceListRepo) GetApplicable(ctx context.Context, customerId, customerGroup, currency string, at time.Time) ([]*models.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicable", ctx, customerId, customerGroup, currency, at)
+	ret0, _ := ret[0].([]*models.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicable indicates an expected call of GetApplicable.
+func (mr *MockPriceListRepoMockRecorder) GetApplicable(ctx, customerId, customerGroup, currency, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicable", reflect.TypeOf((*MockPriceListRepo)(nil).GetApplicable), ctx, customerId, customerGroup, currency, at)
+}
+
+// Update mocks base method.
+func (m *MockPriceListRepo) Update(ctx context.Context, priceListId string, priceList *models.PriceList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, priceListId, priceList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPriceListRepoMockRecorder) Update(ctx, priceListId, priceList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPriceListRepo)(nil).Update), ctx, priceListId, priceList)
+}
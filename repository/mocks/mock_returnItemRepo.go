@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/returnItemRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReturnItemRepo is a mock of ReturnItemRepo interface.
+type MockReturnItemRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnItemRepoMockRecorder
+}
+
+// MockReturnItemRepoMockRecorder is the mock recorder for MockReturnItemRepo.
+type MockReturnItemRepoMockRecorder struct {
+	mock *MockReturnItemRepo
+}
+
+// NewMockReturnItemRepo creates a new mock instance.
+func NewMockReturnItemRepo(ctrl *gomock.Controller) *MockReturnItemRepo {
+	mock := &MockReturnItemRepo{ctrl: ctrl}
+	mock.recorder = &MockReturnItemRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnItemRepo) EXPECT() *MockReturnItemRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReturnItemRepo) Create(ctx context.Context, returnItems ...*models.ReturnItem) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range returnItems {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReturnItemRepoMockRecorder) Create(ctx interface{}, returnItems ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, returnItems...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReturnItemRepo)(nil).Create), varargs...)
+}
+
+// GetByReturn mocks base method.
+func (m *MockReturnItemRepo) GetByReturn(ctx context.Context, returnId string) ([]*models.ReturnItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByReturn", ctx, returnId)
+	ret0, _ := ret[0].([]*models.ReturnItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByReturn indicates an expected call of GetByReturn.
+func (mr *MockReturnItemRepoMockRecorder) GetByReturn(ctx, returnId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByReturn", reflect.TypeOf((*MockReturnItemRepo)(nil).GetByReturn), ctx, returnId)
+}
+
+// GetReturnedByOrder mocks base method.
+func (m *MockReturnItemRepo) GetReturnedByOrder(ctx context.Context, orderId string) ([]*models.ReturnItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnedByOrder", ctx, orderId)
+	ret0, _ := ret[0].([]*models.ReturnItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnedByOrder indicates an expected call of GetReturnedByOrder.
+func (mr *MockReturnItemRepoMockRecorder) GetReturnedByOrder(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnedByOrder", reflect.TypeOf((*MockReturnItemRepo)(nil).GetReturnedByOrder), ctx, orderId)
+}
+
+// UpdateInspection mocks base method.
+func (m *MockReturnItemRepo) UpdateInspection(ctx context.Context, returnItemId string, restocked, damaged int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInspection", ctx, returnItemId, restocked, damaged)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInspection indicates an expected call of UpdateInspection.
+func (mr *MockReturnItemRepoMockRecorder) UpdateInspection(ctx, returnItemId, restocked, damaged interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInspection", reflect.TypeOf((*MockReturnItemRepo)(nil).UpdateInspection), ctx, returnItemId, restocked, damaged)
+}
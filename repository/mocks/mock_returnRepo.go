@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/returnRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReturnRepo is a mock of ReturnRepo interface.
+type MockReturnRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnRepoMockRecorder
+}
+
+// MockReturnRepoMockRecorder is the mock recorder for MockReturnRepo.
+type MockReturnRepoMockRecorder struct {
+	mock *MockReturnRepo
+}
+
+// NewMockReturnRepo creates a new mock instance.
+func NewMockReturnRepo(ctrl *gomock.Controller) *MockReturnRepo {
+	mock := &MockReturnRepo{ctrl: ctrl}
+	mock.recorder = &MockReturnRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnRepo) EXPECT() *MockReturnRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReturnRepo) Create(ctx context.Context, ret *models.Return) error {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "Create", ctx, ret)
+	ret0, _ := ret_2[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReturnRepoMockRecorder) Create(ctx, ret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReturnRepo)(nil).Create), ctx, ret)
+}
+
+// Get mocks base method.
+func (m *MockReturnRepo) Get(ctx context.Context, returnId string) (*models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, returnId)
+	ret0, _ := ret[0].(*models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReturnRepoMockRecorder) Get(ctx, returnId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReturnRepo)(nil).Get), ctx, returnId)
+}
+
+// GetByOrder mocks base method.
+func (m *MockReturnRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrder", ctx, orderId)
+	ret0, _ := ret[0].([]*models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrder indicates an expected call of GetByOrder.
+func (mr *MockReturnRepoMockRecorder) GetByOrder(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockReturnRepo)(nil).GetByOrder), ctx, orderId)
+}
+
+// UpdateRefund mocks base method.
+func (m *MockReturnRepo) UpdateRefund(ctx context.Context, returnId string, ret *models.Return) error {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "UpdateRefund", ctx, returnId, ret)
+	ret0, _ := ret_2[0].(error)
+	return ret0
+}
+
+// UpdateRefund indicates an expected call of UpdateRefund.
+func (mr *MockReturnRepoMockRecorder) UpdateRefund(ctx, returnId, ret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRefund", reflect.TypeOf((*MockReturnRepo)(nil).UpdateRefund), ctx, returnId, ret)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockReturnRepo) UpdateStatus(ctx context.Context, returnId, from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, returnId, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockReturnRepoMockRecorder) UpdateStatus(ctx, returnId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockReturnRepo)(nil).UpdateStatus), ctx, returnId, from, to)
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/shipmentItemRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockShipmentItemRepo is a mock of ShipmentItemRepo interface.
+type MockShipmentItemRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentItemRepoMockRecorder
+}
+
+// MockShipmentItemRepoMockRecorder is the mock recorder for MockShipmentItemRepo.
+type MockShipmentItemRepoMockRecorder struct {
+	mock *MockShipmentItemRepo
+}
+
+// NewMockShipmentItemRepo creates a new mock instance.
+func NewMockShipmentItemRepo(ctrl *gomock.Controller) *MockShipmentItemRepo {
+	mock := &MockShipmentItemRepo{ctrl: ctrl}
+	mock.recorder = &MockShipmentItemRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentItemRepo) EXPECT() *MockShipmentItemRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShipmentItemRepo) Create(ctx context.Context, shipmentItems ...*models.ShipmentItem) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range shipmentItems {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShipmentItemRepoMockRecorder) Create(ctx interface{}, shipmentItems ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, shipmentItems...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShipmentItemRepo)(nil).Create), varargs...)
+}
+
+// GetByOrder mocks base method.
+func (m *MockShipmentItemRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.ShipmentItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrder", ctx, orderId)
+	ret0, _ := ret[0].([]*models.ShipmentItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrder indicates an expected call of GetByOrder.
+func (mr *MockShipmentItemRepoMockRecorder) GetByOrder(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockShipmentItemRepo)(nil).GetByOrder), ctx, orderId)
+}
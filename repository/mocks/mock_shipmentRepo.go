@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/shipmentRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockShipmentRepo is a mock of ShipmentRepo interface.
+type MockShipmentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentRepoMockRecorder
+}
+
+// MockShipmentRepoMockRecorder is the mock recorder for MockShipmentRepo.
+type MockShipmentRepoMockRecorder struct {
+	mock *MockShipmentRepo
+}
+
+// NewMockShipmentRepo creates a new mock instance.
+func NewMockShipmentRepo(ctrl *gomock.Controller) *MockShipmentRepo {
+	mock := &MockShipmentRepo{ctrl: ctrl}
+	mock.recorder = &MockShipmentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentRepo) EXPECT() *MockShipmentRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShipmentRepo) Create(ctx context.Context, shipment *models.Shipment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, shipment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShipmentRepoMockRecorder) Create(ctx, shipment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShipmentRepo)(nil).Create), ctx, shipment)
+}
+
+// Get mocks base method.
+func (m *MockShipmentRepo) Get(ctx context.Context, shipmentId string) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, shipmentId)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockShipmentRepoMockRecorder) Get(ctx, shipmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockShipmentRepo)(nil).Get), ctx, shipmentId)
+}
+
+// GetByOrder mocks base method.
+func (m *MockShipmentRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrder", ctx, orderId)
+	ret0, _ := ret[0].([]*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrder indicates an expected call of GetByOrder.
+func (mr *MockShipmentRepoMockRecorder) GetByOrder(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrder", reflect.TypeOf((*MockShipmentRepo)(nil).GetByOrder), ctx, orderId)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/taxRuleRepo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "inventory-management/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaxRuleRepo is a mock of TaxRuleRepo interface.
+type MockTaxRuleRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRuleRepoMockRecorder
+}
+
+// MockTaxRuleRepoMockRecorder is the mock recorder for MockTaxRuleRepo.
+type MockTaxRuleRepoMockRecorder struct {
+	mock *MockTaxRuleRepo
+}
+
+// NewMockTaxRuleRepo creates a new mock instance.
+func NewMockTaxRuleRepo(ctrl *gomock.Controller) *MockTaxRuleRepo {
+	mock := &MockTaxRuleRepo{ctrl: ctrl}
+	mock.recorder = &MockTaxRuleRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRuleRepo) EXPECT() *MockTaxRuleRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTaxRuleRepo) Create(ctx context.Context, taxRule *models.TaxRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, taxRule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaxRuleRepoMockRecorder) Create(ctx, taxRule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaxRuleRepo)(nil).Create), ctx, taxRule)
+}
+
+// Delete mocks base method.
+func (m *MockTaxRuleRepo) Delete(ctx context.Context, taxRuleId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, taxRuleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaxRuleRepoMockRecorder) Delete(ctx, taxRuleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaxRuleRepo)(nil).Delete), ctx, taxRuleId)
+}
+
+// Get mocks base method.
+func (m *MockTaxRuleRepo) Get(ctx context.Context, taxRuleId string) (*models.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, taxRuleId)
+	ret0, _ := ret[0].(*models.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTaxRuleRepoMockRecorder) Get(ctx, taxRuleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTaxRuleRepo)(nil).Get), ctx, taxRuleId)
+}
+
+// GetApplicable mocks base method.
+func (m *MockTaxRuleRepo) GetApplicable(ctx context.Context, country string, at time.Time) ([]*models.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicable", ctx, country, at)
+	ret0, _ := ret[0].([]*models.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicable indicates an expected call of GetApplicable.
+func (mr *MockTaxRuleRepoMockRecorder) GetApplicable(ctx, country, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicable", reflect.TypeOf((*MockTaxRuleRepo)(nil).GetApplicable), ctx, country, at)
+}
+
+// Update mocks base method.
+func (m *MockTaxRuleRepo) Update(ctx context.Context, taxRuleId string, taxRule *models.TaxRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, taxRuleId, taxRule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTaxRuleRepoMockRecorder) Update(ctx, taxRuleId, taxRule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaxRuleRepo)(nil).Update), ctx, taxRuleId, taxRule)
+}
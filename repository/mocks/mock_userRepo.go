@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	models "inventory-management/models"
 	reflect "reflect"
 
@@ -34,59 +35,59 @@ func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
 	return m.recorder
 }
 
+// Create mocks base method.
+func (m *MockUserRepo) Create(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserRepoMockRecorder) Create(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepo)(nil).Create), ctx, user)
+}
+
 // Delete mocks base method.
-func (m *MockUserRepo) Delete(userId string) error {
+func (m *MockUserRepo) Delete(ctx context.Context, userId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId)
+	ret := m.ctrl.Call(m, "Delete", ctx, userId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserRepoMockRecorder) Delete(userId interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) Delete(ctx, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepo)(nil).Delete), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepo)(nil).Delete), ctx, userId)
 }
 
 // Get mocks base method.
-func (m *MockUserRepo) Get(userId string) (*models.User, error) {
+func (m *MockUserRepo) Get(ctx context.Context, userId string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", userId)
+	ret := m.ctrl.Call(m, "Get", ctx, userId)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockUserRepoMockRecorder) Get(userId interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) Get(ctx, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserRepo)(nil).Get), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserRepo)(nil).Get), ctx, userId)
 }
 
 // Update mocks base method.
-func (m *MockUserRepo) Update(userId string, user *models.User) error {
+func (m *MockUserRepo) Update(ctx context.Context, userId string, user *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", userId, user)
+	ret := m.ctrl.Call(m, "Update", ctx, userId, user)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockUserRepoMockRecorder) Update(userId, user interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepo)(nil).Update), userId, user)
-}
-
-// Upsert mocks base method.
-func (m *MockUserRepo) Upsert(user *models.User) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Upsert", user)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Upsert indicates an expected call of Upsert.
-func (mr *MockUserRepoMockRecorder) Upsert(user interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) Update(ctx, userId, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockUserRepo)(nil).Upsert), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepo)(nil).Update), ctx, userId, user)
 }
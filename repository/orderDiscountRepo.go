@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type OrderDiscountRepo interface {
+	Create(ctx context.Context, discounts ...*models.OrderDiscount) error
+	GetByOrder(ctx context.Context, orderId string) ([]*models.OrderDiscount, error)
+	DeleteByOrder(ctx context.Context, orderId string) error
+}
+
+type orderDiscountRepo struct {
+	db *gorm.DB
+}
+
+func NewOrderDiscountRepo(db *gorm.DB) OrderDiscountRepo {
+	return &orderDiscountRepo{
+		db: db,
+	}
+}
+
+func (o *orderDiscountRepo) getTable() string {
+	return "order_discounts"
+}
+
+func (o *orderDiscountRepo) Create(ctx context.Context, discounts ...*models.OrderDiscount) error {
+	err := o.db.WithContext(ctx).Table(o.getTable()).Create(&discounts).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *orderDiscountRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.OrderDiscount, error) {
+	var result []*models.OrderDiscount
+
+	err := o.db.WithContext(ctx).Table(o.getTable()).Where("order_id = ?", orderId).Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (o *orderDiscountRepo) DeleteByOrder(ctx context.Context, orderId string) error {
+	err := o.db.WithContext(ctx).Table(o.getTable()).Where("order_id = ?", orderId).Delete(&models.OrderDiscount{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
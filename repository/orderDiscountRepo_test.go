@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+	"inventory-management/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type OrderDiscountRepoTestSuite struct {
+	suite.Suite
+	db                *gorm.DB
+	orderDiscountRepo OrderDiscountRepo
+}
+
+func TestOrderDiscountRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(OrderDiscountRepoTestSuite))
+}
+
+func (suite *OrderDiscountRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.orderDiscountRepo = NewOrderDiscountRepo(suite.db)
+}
+
+func (suite *OrderDiscountRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *OrderDiscountRepoTestSuite) TestCreateAndGetByOrder() {
+	err := suite.orderDiscountRepo.Create(
+		context.Background(), &models.OrderDiscount{OrderDiscountId: "d1", OrderId: "o1", CouponCode: "SAVE10", Amount: money.MustParse("10", "INR")},
+		&models.OrderDiscount{OrderDiscountId: "d2", OrderId: "o2", CouponCode: "SAVE10", Amount: money.MustParse("5", "INR")},
+	)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.orderDiscountRepo.GetByOrder(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), "10.00 INR", result[0].Amount.String())
+}
+
+func (suite *OrderDiscountRepoTestSuite) TestCreateError() {
+	discount := &models.OrderDiscount{OrderDiscountId: "d1", OrderId: "o1"}
+
+	err := suite.orderDiscountRepo.Create(context.Background(), discount)
+	assert.NoError(suite.T(), err)
+
+	err = suite.orderDiscountRepo.Create(context.Background(), discount)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *OrderDiscountRepoTestSuite) TestDeleteByOrder() {
+	err := suite.orderDiscountRepo.Create(context.Background(), &models.OrderDiscount{OrderDiscountId: "d1", OrderId: "o1"})
+	assert.NoError(suite.T(), err)
+
+	err = suite.orderDiscountRepo.DeleteByOrder(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.orderDiscountRepo.GetByOrder(context.Background(), "o1")
+	assert.Empty(suite.T(), result)
+}
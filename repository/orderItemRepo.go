@@ -1,20 +1,24 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"inventory-management/constants"
 	"inventory-management/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderItemRepo interface {
-	Create(orderItem ...*models.OrderItem) error
-	Update(orderItemId string, orderItem *models.OrderItem) error
-	Get(orderItemId string) (*models.OrderItem, error)
-	Delete(orderItemId string) error
-	GetByOrder(orderId string) ([]*models.OrderItem, error)
-	Upsert(orderItems ...*models.OrderItem) error
-	DeleteAll(orderItemIds []string) error
+	Create(ctx context.Context, orderItem ...*models.OrderItem) error
+	Update(ctx context.Context, orderItemId string, orderItem *models.OrderItem) error
+	Get(ctx context.Context, orderItemId string) (*models.OrderItem, error)
+	Delete(ctx context.Context, orderItemId string) error
+	GetByOrder(ctx context.Context, orderId string) ([]*models.OrderItem, error)
+	Upsert(ctx context.Context, orderItems ...*models.OrderItem) error
+	DeleteAll(ctx context.Context, orderItemIds []string) error
+	Allocate(ctx context.Context, orderItemId string, quantity int) error
 }
 
 type orderItemRepo struct {
@@ -31,17 +35,27 @@ func (o *orderItemRepo) getTable() string {
 	return "order_items"
 }
 
-func (o *orderItemRepo) Create(orderItem ...*models.OrderItem) error {
-	err := o.db.Table(o.getTable()).Save(orderItem).Error
-	if err != nil {
-		return err
+// Create inserts the order items. An item id that is already taken fails with
+// ErrorRecordExists rather than overwriting the item, whichever order has it.
+func (o *orderItemRepo) Create(ctx context.Context, orderItem ...*models.OrderItem) error {
+	if len(orderItem) == 0 {
+		return nil
+	}
+
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Clauses(clause.OnConflict{DoNothing: true}).Create(orderItem)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected < int64(len(orderItem)) {
+		return constants.ErrorRecordExists
 	}
 
 	return nil
 }
 
-func (o *orderItemRepo) Update(orderItemId string, orderItem *models.OrderItem) error {
-	tx := o.db.Table(o.getTable()).Where("order_item_id = ?", orderItemId).UpdateColumns(orderItem)
+func (o *orderItemRepo) Update(ctx context.Context, orderItemId string, orderItem *models.OrderItem) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Where("order_item_id = ?", orderItemId).UpdateColumns(orderItem)
 	if tx.Error != nil || tx.RowsAffected == 0 {
 		return errors.New("error updating orderItem")
 	}
@@ -49,19 +63,45 @@ func (o *orderItemRepo) Update(orderItemId string, orderItem *models.OrderItem)
 	return nil
 }
 
-func (o *orderItemRepo) Upsert(orderItems ...*models.OrderItem) error {
-	err := o.db.Table(o.getTable()).Save(&orderItems).Error
-	if err != nil {
-		return err
+// Upsert inserts the order items and overwrites those their order already has.
+// An item id taken by another order fails with ErrorRecordExists rather than
+// moving the item.
+func (o *orderItemRepo) Upsert(ctx context.Context, orderItems ...*models.OrderItem) error {
+	for _, v := range orderItems {
+		tx := o.db.WithContext(ctx).Table(o.getTable()).Clauses(clause.OnConflict{DoNothing: true}).Create(v)
+		if tx.Error != nil {
+			return tx.Error
+		}
+
+		if tx.RowsAffected > 0 {
+			continue
+		}
+
+		var owned int64
+		err := o.db.WithContext(ctx).Table(o.getTable()).Where("order_item_id = ? AND order_id = ?", v.OrderItemId, v.OrderId).
+			Count(&owned).Error
+		if err != nil {
+			return err
+		}
+
+		if owned == 0 {
+			return constants.ErrorRecordExists
+		}
+
+		err = o.db.WithContext(ctx).Table(o.getTable()).Where("order_item_id = ? AND order_id = ?", v.OrderItemId, v.OrderId).
+			Select("*").Omit("order_item_id", "order_id").Updates(v).Error
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (o *orderItemRepo) Get(orderItemId string) (*models.OrderItem, error) {
+func (o *orderItemRepo) Get(ctx context.Context, orderItemId string) (*models.OrderItem, error) {
 	var result *models.OrderItem
 
-	err := o.db.Table(o.getTable()).Where("order_item_id = ?", orderItemId).First(&result).Error
+	err := o.db.WithContext(ctx).Table(o.getTable()).Where("order_item_id = ?", orderItemId).First(&result).Error
 	if err != nil {
 		return nil, err
 	}
@@ -69,8 +109,8 @@ func (o *orderItemRepo) Get(orderItemId string) (*models.OrderItem, error) {
 	return result, nil
 }
 
-func (o *orderItemRepo) Delete(orderItemId string) error {
-	tx := o.db.Table(o.getTable()).Where("order_item_id = ?", orderItemId).Delete(&models.OrderItem{})
+func (o *orderItemRepo) Delete(ctx context.Context, orderItemId string) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Where("order_item_id = ?", orderItemId).Delete(&models.OrderItem{})
 	if tx.Error != nil || tx.RowsAffected == 0 {
 		return errors.New("error deleting orderItem")
 	}
@@ -78,10 +118,10 @@ func (o *orderItemRepo) Delete(orderItemId string) error {
 	return nil
 }
 
-func (o *orderItemRepo) GetByOrder(orderId string) ([]*models.OrderItem, error) {
+func (o *orderItemRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.OrderItem, error) {
 	var result []*models.OrderItem
 
-	err := o.db.Table(o.getTable()).Where("order_id = ?", orderId).Find(&result).Error
+	err := o.db.WithContext(ctx).Table(o.getTable()).Where("order_id = ?", orderId).Find(&result).Error
 	if err != nil || len(result) == 0 {
 		return nil, errors.New("error getting orderItems")
 	}
@@ -89,11 +129,23 @@ func (o *orderItemRepo) GetByOrder(orderId string) ([]*models.OrderItem, error)
 	return result, nil
 }
 
-func (o *orderItemRepo) DeleteAll(orderItemIds []string) error {
-	tx := o.db.Table(o.getTable()).Where(`order_item_id IN (?)`, orderItemIds).Delete(&models.OrderItem{})
+func (o *orderItemRepo) DeleteAll(ctx context.Context, orderItemIds []string) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Where(`order_item_id IN (?)`, orderItemIds).Delete(&models.OrderItem{})
 	if tx.Error != nil || tx.RowsAffected == 0 {
 		return errors.New("error deleting orderItem")
 	}
 
 	return nil
 }
+
+// Allocate adds quantity to what is allocated to the order item. A negative
+// quantity releases an allocation.
+func (o *orderItemRepo) Allocate(ctx context.Context, orderItemId string, quantity int) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Where("order_item_id = ?", orderItemId).
+		UpdateColumn("allocated_quantity", gorm.Expr("allocated_quantity + ?", quantity))
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error allocating orderItem")
+	}
+
+	return nil
+}
@@ -1,12 +1,13 @@
 package repository
 
 import (
+	"context"
+	"inventory-management/constants"
 	"inventory-management/models"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -21,16 +22,7 @@ func TestOrderItemRepoTestSuite(t *testing.T) {
 }
 
 func (suite *OrderItemRepoTestSuite) SetupTest() {
-	var err error
-	suite.db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	if err != nil {
-		suite.T().Fatal("failed to connect to database")
-	}
-
-	err = suite.db.AutoMigrate(&models.OrderItem{})
-	if err != nil {
-		suite.T().Fatal("failed to migrate database")
-	}
+	suite.db = openTestDB(suite.T())
 
 	suite.orderItemRepo = NewOrderItemRepo(suite.db)
 }
@@ -48,7 +40,7 @@ func (suite *OrderItemRepoTestSuite) TestCreateOrderItem() {
 		Quantity:    5,
 	}
 
-	err := suite.orderItemRepo.Create(orderItem)
+	err := suite.orderItemRepo.Create(context.Background(), orderItem)
 
 	assert.NoError(suite.T(), err)
 
@@ -65,7 +57,7 @@ func (suite *OrderItemRepoTestSuite) TestCreaterderItemError() {
 		Quantity:    5,
 	}
 
-	err := suite.orderItemRepo.Create(orderItem)
+	err := suite.orderItemRepo.Create(context.Background(), orderItem)
 	assert.Error(suite.T(), err)
 }
 
@@ -76,17 +68,17 @@ func (suite *OrderItemRepoTestSuite) TestGetOrderItem() {
 		ArticleId:   "453",
 		Quantity:    5,
 	}
-	err := suite.orderItemRepo.Create(orderItem)
+	err := suite.orderItemRepo.Create(context.Background(), orderItem)
 	assert.NoError(suite.T(), err)
 
-	result, err := suite.orderItemRepo.Get("1234")
+	result, err := suite.orderItemRepo.Get(context.Background(), "1234")
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), orderItem.OrderItemId, result.OrderItemId)
 }
 
 func (suite *OrderItemRepoTestSuite) TestGetOrderItemError() {
-	result, err := suite.orderItemRepo.Get("123")
+	result, err := suite.orderItemRepo.Get(context.Background(), "123")
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), result)
@@ -100,12 +92,12 @@ func (suite *OrderItemRepoTestSuite) TestUpdateOrderItem() {
 		ArticleId:   "453",
 		Quantity:    5,
 	}
-	err := suite.orderItemRepo.Create(orderItem)
+	err := suite.orderItemRepo.Create(context.Background(), orderItem)
 	assert.NoError(suite.T(), err)
 
 	orderItem.ArticleId = "523"
 
-	err = suite.orderItemRepo.Update(orderItem.OrderItemId, orderItem)
+	err = suite.orderItemRepo.Update(context.Background(), orderItem.OrderItemId, orderItem)
 	assert.NoError(suite.T(), err)
 
 	var updatedOrderItem models.OrderItem
@@ -120,7 +112,7 @@ func (suite *OrderItemRepoTestSuite) TestUpdateOrderItemError() {
 		Quantity:    5,
 	}
 
-	err := suite.orderItemRepo.Update(orderItem.OrderItemId, orderItem)
+	err := suite.orderItemRepo.Update(context.Background(), orderItem.OrderItemId, orderItem)
 	assert.Error(suite.T(), err)
 }
 
@@ -131,10 +123,10 @@ func (suite *OrderItemRepoTestSuite) TestDeleteOrderItem() {
 		ArticleId:   "453",
 		Quantity:    5,
 	}
-	err := suite.orderItemRepo.Create(orderItem)
+	err := suite.orderItemRepo.Create(context.Background(), orderItem)
 	assert.NoError(suite.T(), err)
 
-	err = suite.orderItemRepo.Delete(orderItem.OrderItemId)
+	err = suite.orderItemRepo.Delete(context.Background(), orderItem.OrderItemId)
 
 	assert.NoError(suite.T(), err)
 
@@ -144,7 +136,7 @@ func (suite *OrderItemRepoTestSuite) TestDeleteOrderItem() {
 }
 
 func (suite *OrderItemRepoTestSuite) TestDeleteOrderItemError() {
-	err := suite.orderItemRepo.Delete("123")
+	err := suite.orderItemRepo.Delete(context.Background(), "123")
 
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "error deleting orderItem", err.Error())
@@ -157,16 +149,16 @@ func (suite *OrderItemRepoTestSuite) TestGetByOrder() {
 		ArticleId:   "453",
 		Quantity:    5,
 	}
-	err := suite.orderItemRepo.Create(orderItem)
+	err := suite.orderItemRepo.Create(context.Background(), orderItem)
 	assert.NoError(suite.T(), err)
 
-	result, err := suite.orderItemRepo.GetByOrder("12")
+	result, err := suite.orderItemRepo.GetByOrder(context.Background(), "12")
 	assert.NoError(suite.T(), err)
 	assert.NotEmpty(suite.T(), result)
 }
 
 func (suite *OrderItemRepoTestSuite) TestGetByOrderError() {
-	result, err := suite.orderItemRepo.GetByOrder("12")
+	result, err := suite.orderItemRepo.GetByOrder(context.Background(), "12")
 
 	assert.Error(suite.T(), err)
 	assert.Empty(suite.T(), result)
@@ -180,10 +172,10 @@ func (suite *OrderItemRepoTestSuite) TestDeleteAllOrderItem() {
 		ArticleId:   "453",
 		Quantity:    5,
 	}
-	err := suite.orderItemRepo.Create(orderItem)
+	err := suite.orderItemRepo.Create(context.Background(), orderItem)
 	assert.NoError(suite.T(), err)
 
-	err = suite.orderItemRepo.DeleteAll([]string{orderItem.OrderItemId})
+	err = suite.orderItemRepo.DeleteAll(context.Background(), []string{orderItem.OrderItemId})
 
 	assert.NoError(suite.T(), err)
 
@@ -193,7 +185,7 @@ func (suite *OrderItemRepoTestSuite) TestDeleteAllOrderItem() {
 }
 
 func (suite *OrderItemRepoTestSuite) TestDeleteAllOrderItemError() {
-	err := suite.orderItemRepo.DeleteAll([]string{"123"})
+	err := suite.orderItemRepo.DeleteAll(context.Background(), []string{"123"})
 
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "error deleting orderItem", err.Error())
@@ -207,7 +199,7 @@ func (suite *OrderItemRepoTestSuite) TestUpsertOrderItem() {
 		Quantity:    10,
 	}
 
-	err := suite.orderItemRepo.Upsert(orderItem)
+	err := suite.orderItemRepo.Upsert(context.Background(), orderItem)
 	assert.NoError(suite.T(), err)
 
 	var savedOrderItem models.OrderItem
@@ -218,6 +210,46 @@ func (suite *OrderItemRepoTestSuite) TestUpsertOrderItem() {
 	assert.Equal(suite.T(), orderItem.Quantity, savedOrderItem.Quantity)
 }
 
+func (suite *OrderItemRepoTestSuite) TestCreateOrderItemExists() {
+	err := suite.orderItemRepo.Create(context.Background(), &models.OrderItem{OrderItemId: "i1", OrderId: "o1", ArticleId: "a1", Quantity: 1})
+	assert.NoError(suite.T(), err)
+
+	err = suite.orderItemRepo.Create(context.Background(), &models.OrderItem{OrderItemId: "i1", OrderId: "o2", ArticleId: "a2", Quantity: 5})
+	assert.Equal(suite.T(), constants.ErrorRecordExists, err)
+
+	result, _ := suite.orderItemRepo.Get(context.Background(), "i1")
+	assert.Equal(suite.T(), "o1", result.OrderId)
+	assert.Equal(suite.T(), 1, result.Quantity)
+}
+
+func (suite *OrderItemRepoTestSuite) TestUpsertOrderItemUpdatesOwnItem() {
+	err := suite.orderItemRepo.Create(context.Background(), &models.OrderItem{OrderItemId: "i1", OrderId: "o1", ArticleId: "a1", Quantity: 1})
+	assert.NoError(suite.T(), err)
+
+	err = suite.orderItemRepo.Upsert(context.Background(), &models.OrderItem{OrderItemId: "i1", OrderId: "o1", ArticleId: "a2", Quantity: 3},
+		&models.OrderItem{OrderItemId: "i2", OrderId: "o1", ArticleId: "a1", Quantity: 2})
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.orderItemRepo.GetByOrder(context.Background(), "o1")
+	assert.Len(suite.T(), result, 2)
+
+	item, _ := suite.orderItemRepo.Get(context.Background(), "i1")
+	assert.Equal(suite.T(), "a2", item.ArticleId)
+	assert.Equal(suite.T(), 3, item.Quantity)
+}
+
+func (suite *OrderItemRepoTestSuite) TestUpsertOrderItemOfAnotherOrder() {
+	err := suite.orderItemRepo.Create(context.Background(), &models.OrderItem{OrderItemId: "i1", OrderId: "o1", ArticleId: "a1", Quantity: 1})
+	assert.NoError(suite.T(), err)
+
+	err = suite.orderItemRepo.Upsert(context.Background(), &models.OrderItem{OrderItemId: "i1", OrderId: "o2", ArticleId: "a2", Quantity: 5})
+	assert.Equal(suite.T(), constants.ErrorRecordExists, err)
+
+	result, _ := suite.orderItemRepo.Get(context.Background(), "i1")
+	assert.Equal(suite.T(), "o1", result.OrderId)
+	assert.Equal(suite.T(), 1, result.Quantity)
+}
+
 func (suite *OrderItemRepoTestSuite) TestUpsertOrderItemError() {
 	orderItem := &models.OrderItem{
 		OrderItemId: "upsert-err-001",
@@ -225,6 +257,24 @@ func (suite *OrderItemRepoTestSuite) TestUpsertOrderItemError() {
 		Quantity:    2,
 	}
 
-	err := suite.orderItemRepo.Upsert(orderItem)
+	err := suite.orderItemRepo.Upsert(context.Background(), orderItem)
 	assert.Error(suite.T(), err)
 }
+
+func (suite *OrderItemRepoTestSuite) TestAllocate() {
+	orderItem := &models.OrderItem{OrderItemId: "1", OrderId: "123", ArticleId: "a1", Quantity: 5}
+	err := suite.orderItemRepo.Create(context.Background(), orderItem)
+	assert.NoError(suite.T(), err)
+
+	err = suite.orderItemRepo.Allocate(context.Background(), "1", 3)
+	assert.NoError(suite.T(), err)
+
+	err = suite.orderItemRepo.Allocate(context.Background(), "1", -1)
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.orderItemRepo.Get(context.Background(), "1")
+	assert.Equal(suite.T(), 2, result.AllocatedQuantity)
+
+	err = suite.orderItemRepo.Allocate(context.Background(), "2", 1)
+	assert.EqualError(suite.T(), err, "error allocating orderItem")
+}
@@ -1,17 +1,22 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"inventory-management/constants"
 	"inventory-management/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderRepo interface {
-	Create(order *models.Order) error
-	Update(orderId string, order *models.Order) error
-	Get(orderId string) (*models.Order, error)
-	Delete(orderId string) error
+	Create(ctx context.Context, order *models.Order) error
+	Update(ctx context.Context, orderId string, order *models.Order) error
+	Get(ctx context.Context, orderId string) (*models.Order, error)
+	GetForUpdate(ctx context.Context, orderId string) (*models.Order, error)
+	Delete(ctx context.Context, orderId string) error
+	UpdateStatus(ctx context.Context, orderId string, from string, to string) error
 }
 
 type orderRepo struct {
@@ -28,28 +33,58 @@ func (o *orderRepo) getTable() string {
 	return "orders"
 }
 
-func (o *orderRepo) Create(order *models.Order) error {
-	err := o.db.Table(o.getTable()).Save(order).Error
-	if err != nil {
-		return err
+// Create inserts the order and returns ErrorRecordExists when the id is taken.
+func (o *orderRepo) Create(ctx context.Context, order *models.Order) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Clauses(clause.OnConflict{DoNothing: true}).Create(order)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return constants.ErrorRecordExists
 	}
 
 	return nil
 }
 
-func (o *orderRepo) Update(orderId string, order *models.Order) error {
-	tx := o.db.Table(o.getTable()).Where("order_id = ?", orderId).UpdateColumns(order)
-	if tx.Error != nil || tx.RowsAffected == 0 {
+// Update writes the order if it is still at order.Version and moves it to the
+// next version. It returns ErrorVersionMismatch when the order has changed
+// since that version was read. Zero values are written too, the status only
+// changes through UpdateStatus.
+func (o *orderRepo) Update(ctx context.Context, orderId string, order *models.Order) error {
+	version := order.Version
+	order.Version = version + 1
+
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Where("order_id = ? AND version = ?", orderId, version).
+		Select("*").Omit("order_id", "status").Updates(order)
+	if tx.Error != nil {
 		return errors.New("error updating order")
 	}
 
+	if tx.RowsAffected == 0 {
+		return constants.ErrorVersionMismatch
+	}
+
 	return nil
 }
 
-func (o *orderRepo) Get(orderId string) (*models.Order, error) {
+func (o *orderRepo) Get(ctx context.Context, orderId string) (*models.Order, error) {
+	var result *models.Order
+
+	err := o.db.WithContext(ctx).Table(o.getTable()).Where("order_id = ?", orderId).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetForUpdate reads the order and keeps its row locked until the surrounding
+// transaction ends, so changes that depend on the order are serialised.
+func (o *orderRepo) GetForUpdate(ctx context.Context, orderId string) (*models.Order, error) {
 	var result *models.Order
 
-	err := o.db.Table(o.getTable()).Where("order_id = ?", orderId).First(&result).Error
+	err := o.db.WithContext(ctx).Table(o.getTable()).Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_id = ?", orderId).First(&result).Error
 	if err != nil {
 		return nil, err
 	}
@@ -57,11 +92,25 @@ func (o *orderRepo) Get(orderId string) (*models.Order, error) {
 	return result, nil
 }
 
-func (o *orderRepo) Delete(orderId string) error {
-	tx := o.db.Table(o.getTable()).Where("order_id = ?", orderId).Delete(&models.Order{})
+func (o *orderRepo) Delete(ctx context.Context, orderId string) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Where("order_id = ?", orderId).Delete(&models.Order{})
 	if tx.Error != nil || tx.RowsAffected == 0 {
 		return errors.New("error deleting order")
 	}
 
 	return nil
 }
+
+// UpdateStatus only moves the order to the new status while it still has the
+// status it was read with.
+func (o *orderRepo) UpdateStatus(ctx context.Context, orderId string, from string, to string) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Where("order_id = ? AND status = ?", orderId, from).UpdateColumns(map[string]interface{}{
+		"status":  to,
+		"version": gorm.Expr("version + 1"),
+	})
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error updating order status")
+	}
+
+	return nil
+}
@@ -1,13 +1,15 @@
 package repository
 
 import (
+	"context"
+	"inventory-management/constants"
 	"inventory-management/models"
+	"inventory-management/money"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -22,16 +24,7 @@ func TestOrderRepoTestSuite(t *testing.T) {
 }
 
 func (suite *OrderRepoTestSuite) SetupTest() {
-	var err error
-	suite.db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	if err != nil {
-		suite.T().Fatal("failed to connect to database")
-	}
-
-	err = suite.db.AutoMigrate(&models.Order{})
-	if err != nil {
-		suite.T().Fatal("failed to migrate database")
-	}
+	suite.db = openTestDB(suite.T())
 
 	suite.orderRepo = NewOrderRepo(suite.db)
 }
@@ -46,11 +39,11 @@ func (suite *OrderRepoTestSuite) TestCreateOrder() {
 		OrderId:     "123",
 		CustomerId:  "254",
 		OrderedAt:   time.Now(),
-		TotalAmount: 220.5,
+		TotalAmount: money.MustParse("220.5", "INR"),
 		NoOfItems:   5,
 	}
 
-	err := suite.orderRepo.Create(order)
+	err := suite.orderRepo.Create(context.Background(), order)
 
 	assert.NoError(suite.T(), err)
 
@@ -60,16 +53,46 @@ func (suite *OrderRepoTestSuite) TestCreateOrder() {
 	assert.Equal(suite.T(), order.OrderId, savedOrder.OrderId)
 }
 
+func (suite *OrderRepoTestSuite) TestCreateOrderExists() {
+	order := &models.Order{
+		OrderId:     "123",
+		CustomerId:  "254",
+		OrderedAt:   time.Now(),
+		Status:      constants.OrderStatusConfirmed,
+		TotalAmount: money.MustParse("220.5", "INR"),
+		NoOfItems:   5,
+		Version:     3,
+	}
+	err := suite.orderRepo.Create(context.Background(), order)
+	assert.NoError(suite.T(), err)
+
+	err = suite.orderRepo.Create(context.Background(), &models.Order{
+		OrderId:     "123",
+		CustomerId:  "999",
+		OrderedAt:   time.Now(),
+		Status:      constants.OrderStatusPending,
+		TotalAmount: money.MustParse("1", "INR"),
+		Version:     1,
+	})
+	assert.ErrorIs(suite.T(), err, constants.ErrorRecordExists)
+
+	saved, err := suite.orderRepo.Get(context.Background(), "123")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "254", saved.CustomerId)
+	assert.Equal(suite.T(), constants.OrderStatusConfirmed, saved.Status)
+	assert.Equal(suite.T(), int64(3), saved.Version)
+}
+
 func (suite *OrderRepoTestSuite) TestCreaterderItemError() {
 	order := &models.Order{
 		OrderId:     "123",
 		CustomerId:  "",
 		OrderedAt:   time.Now(),
-		TotalAmount: 220.5,
+		TotalAmount: money.MustParse("220.5", "INR"),
 		NoOfItems:   5,
 	}
 
-	err := suite.orderRepo.Create(order)
+	err := suite.orderRepo.Create(context.Background(), order)
 	assert.Error(suite.T(), err)
 }
 
@@ -78,20 +101,20 @@ func (suite *OrderRepoTestSuite) TestGetOrder() {
 		OrderId:     "123",
 		CustomerId:  "254",
 		OrderedAt:   time.Now(),
-		TotalAmount: 220.5,
+		TotalAmount: money.MustParse("220.5", "INR"),
 		NoOfItems:   5,
 	}
-	err := suite.orderRepo.Create(order)
+	err := suite.orderRepo.Create(context.Background(), order)
 	assert.NoError(suite.T(), err)
 
-	result, err := suite.orderRepo.Get("123")
+	result, err := suite.orderRepo.Get(context.Background(), "123")
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), order.OrderId, result.OrderId)
 }
 
 func (suite *OrderRepoTestSuite) TestGetOrderError() {
-	result, err := suite.orderRepo.Get("123")
+	result, err := suite.orderRepo.Get(context.Background(), "123")
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), result)
@@ -103,19 +126,33 @@ func (suite *OrderRepoTestSuite) TestUpdateOrder() {
 		OrderId:     "123",
 		CustomerId:  "254",
 		OrderedAt:   time.Now(),
-		TotalAmount: 220.5,
+		Status:      "confirmed",
+		CouponCode:  "SAVE10",
+		TotalAmount: money.MustParse("220.5", "INR"),
 		NoOfItems:   5,
 	}
-	err := suite.orderRepo.Create(order)
+	err := suite.orderRepo.Create(context.Background(), order)
 	assert.NoError(suite.T(), err)
 
 	order.CustomerId = "523"
+	order.Status = ""
+	order.CouponCode = ""
 
-	err = suite.orderRepo.Update(order.OrderId, order)
+	err = suite.orderRepo.Update(context.Background(), order.OrderId, order)
 	assert.NoError(suite.T(), err)
 
 	var updatedOrder models.Order
 	suite.db.Table("orders").Where("order_id = ?", order.OrderId).First(&updatedOrder)
+	assert.Equal(suite.T(), "523", updatedOrder.CustomerId)
+	assert.Equal(suite.T(), "", updatedOrder.CouponCode)
+	assert.Equal(suite.T(), "confirmed", updatedOrder.Status)
+	assert.Equal(suite.T(), int64(2), updatedOrder.Version)
+
+	order.CustomerId = "524"
+	order.Version = 1
+
+	err = suite.orderRepo.Update(context.Background(), order.OrderId, order)
+	assert.Equal(suite.T(), constants.ErrorVersionMismatch, err)
 }
 
 func (suite *OrderRepoTestSuite) TestUpdateOrderError() {
@@ -123,11 +160,11 @@ func (suite *OrderRepoTestSuite) TestUpdateOrderError() {
 		OrderId:     "123",
 		CustomerId:  "254",
 		OrderedAt:   time.Now(),
-		TotalAmount: 220.5,
+		TotalAmount: money.MustParse("220.5", "INR"),
 		NoOfItems:   5,
 	}
 
-	err := suite.orderRepo.Update(order.OrderId, order)
+	err := suite.orderRepo.Update(context.Background(), order.OrderId, order)
 	assert.Error(suite.T(), err)
 }
 
@@ -136,13 +173,13 @@ func (suite *OrderRepoTestSuite) TestDeleteOrder() {
 		OrderId:     "123",
 		CustomerId:  "254",
 		OrderedAt:   time.Now(),
-		TotalAmount: 220.5,
+		TotalAmount: money.MustParse("220.5", "INR"),
 		NoOfItems:   5,
 	}
-	err := suite.orderRepo.Create(order)
+	err := suite.orderRepo.Create(context.Background(), order)
 	assert.NoError(suite.T(), err)
 
-	err = suite.orderRepo.Delete(order.OrderId)
+	err = suite.orderRepo.Delete(context.Background(), order.OrderId)
 
 	assert.NoError(suite.T(), err)
 
@@ -152,8 +189,51 @@ func (suite *OrderRepoTestSuite) TestDeleteOrder() {
 }
 
 func (suite *OrderRepoTestSuite) TestDeleteOrderError() {
-	err := suite.orderRepo.Delete("123")
+	err := suite.orderRepo.Delete(context.Background(), "123")
 
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "error deleting order", err.Error())
 }
+
+func (suite *OrderRepoTestSuite) TestUpdateStatus() {
+	order := &models.Order{
+		OrderId:    "123",
+		CustomerId: "254",
+		OrderedAt:  time.Now(),
+		Status:     "pending",
+	}
+
+	err := suite.orderRepo.Create(context.Background(), order)
+	assert.NoError(suite.T(), err)
+
+	err = suite.orderRepo.UpdateStatus(context.Background(), "123", "pending", "confirmed")
+	assert.NoError(suite.T(), err)
+
+	err = suite.orderRepo.UpdateStatus(context.Background(), "123", "pending", "cancelled")
+	assert.EqualError(suite.T(), err, "error updating order status")
+
+	result, _ := suite.orderRepo.Get(context.Background(), "123")
+	assert.Equal(suite.T(), "confirmed", result.Status)
+}
+
+func (suite *OrderRepoTestSuite) TestGetForUpdate() {
+	order := &models.Order{
+		OrderId:    "123",
+		CustomerId: "254",
+		OrderedAt:  time.Now(),
+	}
+
+	err := suite.orderRepo.Create(context.Background(), order)
+	assert.NoError(suite.T(), err)
+
+	err = suite.db.Transaction(func(tx *gorm.DB) error {
+		result, err := NewOrderRepo(tx).GetForUpdate(context.Background(), "123")
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "254", result.CustomerId)
+		return nil
+	})
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.orderRepo.GetForUpdate(context.Background(), "456")
+	assert.Equal(suite.T(), gorm.ErrRecordNotFound, err)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type PaymentRepo interface {
+	Create(ctx context.Context, payment *models.Payment) error
+	Get(ctx context.Context, paymentId string) (*models.Payment, error)
+	GetByOrder(ctx context.Context, orderId string) ([]*models.Payment, error)
+	Settle(ctx context.Context, paymentId string, externalRef string, status string) error
+}
+
+type paymentRepo struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepo(db *gorm.DB) PaymentRepo {
+	return &paymentRepo{
+		db: db,
+	}
+}
+
+func (p *paymentRepo) getTable() string {
+	return "payments"
+}
+
+func (p *paymentRepo) Create(ctx context.Context, payment *models.Payment) error {
+	err := p.db.WithContext(ctx).Table(p.getTable()).Create(payment).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *paymentRepo) Get(ctx context.Context, paymentId string) (*models.Payment, error) {
+	var result *models.Payment
+
+	err := p.db.WithContext(ctx).Table(p.getTable()).Where("payment_id = ?", paymentId).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (p *paymentRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.Payment, error) {
+	var result []*models.Payment
+
+	err := p.db.WithContext(ctx).Table(p.getTable()).Where("order_id = ?", orderId).Order("created_at, payment_id").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Settle records the provider's answer for a pending payment or refund. It
+// fails if the payment isn't pending, so a result is only recorded once.
+func (p *paymentRepo) Settle(ctx context.Context, paymentId string, externalRef string, status string) error {
+	tx := p.db.WithContext(ctx).Table(p.getTable()).Where("payment_id = ? AND status = ?", paymentId, constants.PaymentStatusPending).
+		UpdateColumns(map[string]interface{}{
+			"external_ref": externalRef,
+			"status":       status,
+		})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return errors.New("error settling payment")
+	}
+
+	return nil
+}
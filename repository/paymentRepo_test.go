@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/models"
+	"inventory-management/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PaymentRepoTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	paymentRepo PaymentRepo
+}
+
+func TestPaymentRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(PaymentRepoTestSuite))
+}
+
+func (suite *PaymentRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.paymentRepo = NewPaymentRepo(suite.db)
+}
+
+func (suite *PaymentRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *PaymentRepoTestSuite) TestCreatePayment() {
+	payment := &models.Payment{
+		PaymentId:   "p1",
+		OrderId:     "o1",
+		Type:        constants.PaymentTypePayment,
+		Method:      "card",
+		Amount:      money.MustParse("365.85", "INR"),
+		Status:      constants.PaymentStatusSucceeded,
+		ExternalRef: "ch_1",
+		CreatedAt:   time.Now(),
+	}
+
+	err := suite.paymentRepo.Create(context.Background(), payment)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.paymentRepo.Get(context.Background(), "p1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "365.85 INR", result.Amount.String())
+	assert.Equal(suite.T(), "ch_1", result.ExternalRef)
+}
+
+func (suite *PaymentRepoTestSuite) TestCreatePaymentInvalid() {
+	err := suite.paymentRepo.Create(context.Background(), &models.Payment{PaymentId: "p1", OrderId: "o1", Type: constants.PaymentTypePayment,
+		Amount: money.Zero("INR")})
+	assert.Error(suite.T(), err)
+
+	err = suite.paymentRepo.Create(context.Background(), &models.Payment{PaymentId: "p1", OrderId: "o1", Type: "gift",
+		Amount: money.MustParse("10", "INR")})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *PaymentRepoTestSuite) TestGetByOrder() {
+	now := time.Now()
+
+	for _, v := range []*models.Payment{
+		{PaymentId: "p2", OrderId: "o1", Type: constants.PaymentTypeRefund, Amount: money.MustParse("50", "INR"), RefundOf: "p1", CreatedAt: now.Add(time.Minute)},
+		{PaymentId: "p1", OrderId: "o1", Type: constants.PaymentTypePayment, Amount: money.MustParse("100", "INR"), CreatedAt: now},
+		{PaymentId: "p3", OrderId: "o2", Type: constants.PaymentTypePayment, Amount: money.MustParse("100", "INR"), CreatedAt: now},
+	} {
+		assert.NoError(suite.T(), suite.paymentRepo.Create(context.Background(), v))
+	}
+
+	result, err := suite.paymentRepo.GetByOrder(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), "p1", result[0].PaymentId)
+	assert.Equal(suite.T(), "p1", result[1].RefundOf)
+}
+
+func (suite *PaymentRepoTestSuite) TestSettle() {
+	err := suite.paymentRepo.Create(context.Background(), &models.Payment{PaymentId: "p1", OrderId: "o1", Type: constants.PaymentTypePayment,
+		Method: "card", Amount: money.MustParse("10", "INR"), Status: constants.PaymentStatusPending, CreatedAt: time.Now()})
+	assert.NoError(suite.T(), err)
+
+	err = suite.paymentRepo.Settle(context.Background(), "p1", "ch_1", constants.PaymentStatusSucceeded)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.paymentRepo.Get(context.Background(), "p1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.PaymentStatusSucceeded, result.Status)
+	assert.Equal(suite.T(), "ch_1", result.ExternalRef)
+
+	err = suite.paymentRepo.Settle(context.Background(), "p1", "ch_2", constants.PaymentStatusFailed)
+	assert.Error(suite.T(), err)
+}
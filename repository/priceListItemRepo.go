@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type PriceListItemRepo interface {
+	Upsert(ctx context.Context, items ...*models.PriceListItem) error
+	GetByPriceList(ctx context.Context, priceListId string) ([]*models.PriceListItem, error)
+	GetByPriceLists(ctx context.Context, priceListIds []string, articleIds []string) ([]*models.PriceListItem, error)
+	DeleteByPriceList(ctx context.Context, priceListId string) error
+}
+
+type priceListItemRepo struct {
+	db *gorm.DB
+}
+
+func NewPriceListItemRepo(db *gorm.DB) PriceListItemRepo {
+	return &priceListItemRepo{
+		db: db,
+	}
+}
+
+func (p *priceListItemRepo) getTable() string {
+	return "price_list_items"
+}
+
+func (p *priceListItemRepo) Upsert(ctx context.Context, items ...*models.PriceListItem) error {
+	err := p.db.WithContext(ctx).Table(p.getTable()).Save(&items).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *priceListItemRepo) GetByPriceList(ctx context.Context, priceListId string) ([]*models.PriceListItem, error) {
+	var result []*models.PriceListItem
+
+	err := p.db.WithContext(ctx).Table(p.getTable()).Where("price_list_id = ?", priceListId).Order("article_id, min_quantity").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (p *priceListItemRepo) GetByPriceLists(ctx context.Context, priceListIds []string, articleIds []string) ([]*models.PriceListItem, error) {
+	var result []*models.PriceListItem
+
+	err := p.db.WithContext(ctx).Table(p.getTable()).Where("price_list_id IN (?) AND article_id IN (?)", priceListIds, articleIds).Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (p *priceListItemRepo) DeleteByPriceList(ctx context.Context, priceListId string) error {
+	err := p.db.WithContext(ctx).Table(p.getTable()).Where("price_list_id = ?", priceListId).Delete(&models.PriceListItem{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
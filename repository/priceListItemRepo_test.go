@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PriceListItemRepoTestSuite struct {
+	suite.Suite
+	db                *gorm.DB
+	priceListItemRepo PriceListItemRepo
+}
+
+func TestPriceListItemRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(PriceListItemRepoTestSuite))
+}
+
+func (suite *PriceListItemRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.priceListItemRepo = NewPriceListItemRepo(suite.db)
+}
+
+func (suite *PriceListItemRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *PriceListItemRepoTestSuite) TestUpsertAndGetByPriceList() {
+	items := []*models.PriceListItem{
+		{PriceListItemId: "1", PriceListId: "pl1", ArticleId: "a1", MinQuantity: 10, DiscountPercent: decimal.NewNullDecimal(decimal.NewFromInt(5))},
+		{PriceListItemId: "2", PriceListId: "pl1", ArticleId: "a1", MinQuantity: 1, UnitPrice: decimal.NewNullDecimal(decimal.NewFromInt(90))},
+	}
+
+	err := suite.priceListItemRepo.Upsert(context.Background(), items...)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.priceListItemRepo.GetByPriceList(context.Background(), "pl1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), 1, result[0].MinQuantity)
+	assert.True(suite.T(), result[0].UnitPrice.Valid)
+	assert.False(suite.T(), result[0].DiscountPercent.Valid)
+}
+
+func (suite *PriceListItemRepoTestSuite) TestUpsertError() {
+	item := &models.PriceListItem{PriceListItemId: "1", PriceListId: "pl1", ArticleId: "a1", MinQuantity: 1}
+
+	err := suite.priceListItemRepo.Upsert(context.Background(), item)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *PriceListItemRepoTestSuite) TestGetByPriceLists() {
+	items := []*models.PriceListItem{
+		{PriceListItemId: "1", PriceListId: "pl1", ArticleId: "a1", MinQuantity: 1, UnitPrice: decimal.NewNullDecimal(decimal.NewFromInt(90))},
+		{PriceListItemId: "2", PriceListId: "pl2", ArticleId: "a2", MinQuantity: 1, UnitPrice: decimal.NewNullDecimal(decimal.NewFromInt(90))},
+		{PriceListItemId: "3", PriceListId: "pl3", ArticleId: "a1", MinQuantity: 1, UnitPrice: decimal.NewNullDecimal(decimal.NewFromInt(90))},
+	}
+	err := suite.priceListItemRepo.Upsert(context.Background(), items...)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.priceListItemRepo.GetByPriceLists(context.Background(), []string{"pl1", "pl2"}, []string{"a1"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), "1", result[0].PriceListItemId)
+}
+
+func (suite *PriceListItemRepoTestSuite) TestDeleteByPriceList() {
+	items := []*models.PriceListItem{
+		{PriceListItemId: "1", PriceListId: "pl1", ArticleId: "a1", MinQuantity: 1, UnitPrice: decimal.NewNullDecimal(decimal.NewFromInt(90))},
+		{PriceListItemId: "2", PriceListId: "pl2", ArticleId: "a2", MinQuantity: 1, UnitPrice: decimal.NewNullDecimal(decimal.NewFromInt(90))},
+	}
+	err := suite.priceListItemRepo.Upsert(context.Background(), items...)
+	assert.NoError(suite.T(), err)
+
+	err = suite.priceListItemRepo.DeleteByPriceList(context.Background(), "pl1")
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.priceListItemRepo.GetByPriceList(context.Background(), "pl1")
+	assert.Empty(suite.T(), result)
+}
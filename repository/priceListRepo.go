@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"inventory-management/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PriceListRepo interface {
+	Create(ctx context.Context, priceList *models.PriceList) error
+	Update(ctx context.Context, priceListId string, priceList *models.PriceList) error
+	Get(ctx context.Context, priceListId string) (*models.PriceList, error)
+	Delete(ctx context.Context, priceListId string) error
+	GetApplicable(ctx context.Context, customerId string, customerGroup string, currency string, at time.Time) ([]*models.PriceList, error)
+}
+
+type priceListRepo struct {
+	db *gorm.DB
+}
+
+func NewPriceListRepo(db *gorm.DB) PriceListRepo {
+	return &priceListRepo{
+		db: db,
+	}
+}
+
+func (p *priceListRepo) getTable() string {
+	return "price_lists"
+}
+
+func (p *priceListRepo) Create(ctx context.Context, priceList *models.PriceList) error {
+	err := p.db.WithContext(ctx).Table(p.getTable()).Create(priceList).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *priceListRepo) Update(ctx context.Context, priceListId string, priceList *models.PriceList) error {
+	tx := p.db.WithContext(ctx).Table(p.getTable()).Where("price_list_id = ?", priceListId).UpdateColumns(priceList)
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error updating price list")
+	}
+
+	return nil
+}
+
+func (p *priceListRepo) Get(ctx context.Context, priceListId string) (*models.PriceList, error) {
+	var result *models.PriceList
+
+	err := p.db.WithContext(ctx).Table(p.getTable()).Where("price_list_id = ?", priceListId).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (p *priceListRepo) Delete(ctx context.Context, priceListId string) error {
+	tx := p.db.WithContext(ctx).Table(p.getTable()).Where("price_list_id = ?", priceListId).Delete(&models.PriceList{})
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error deleting price list")
+	}
+
+	return nil
+}
+
+func (p *priceListRepo) GetApplicable(ctx context.Context, customerId string, customerGroup string, currency string, at time.Time) ([]*models.PriceList, error) {
+	var result []*models.PriceList
+
+	err := p.db.WithContext(ctx).Table(p.getTable()).
+		Where("(customer_id = ? AND customer_id <> '') OR (customer_group = ? AND customer_group <> '')", customerId, customerGroup).
+		Where("currency = ?", currency).
+		Where("valid_from IS NULL OR valid_from <= ?", at).
+		Where("valid_to IS NULL OR valid_to >= ?", at).
+		Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PriceListRepoTestSuite struct {
+	suite.Suite
+	db            *gorm.DB
+	priceListRepo PriceListRepo
+}
+
+func TestPriceListRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(PriceListRepoTestSuite))
+}
+
+func (suite *PriceListRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.priceListRepo = NewPriceListRepo(suite.db)
+}
+
+func (suite *PriceListRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *PriceListRepoTestSuite) TestCreatePriceList() {
+	priceList := &models.PriceList{
+		PriceListId: "pl1",
+		Name:        "Wholesale",
+		Currency:    "INR",
+		CustomerId:  "c1",
+	}
+
+	err := suite.priceListRepo.Create(context.Background(), priceList)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.priceListRepo.Get(context.Background(), "pl1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Wholesale", result.Name)
+}
+
+func (suite *PriceListRepoTestSuite) TestCreatePriceListError() {
+	priceList := &models.PriceList{
+		PriceListId: "pl1",
+		Name:        "Unassigned",
+		Currency:    "INR",
+	}
+
+	err := suite.priceListRepo.Create(context.Background(), priceList)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *PriceListRepoTestSuite) TestUpdatePriceList() {
+	priceList := &models.PriceList{PriceListId: "pl1", Name: "Wholesale", Currency: "INR", CustomerId: "c1"}
+	err := suite.priceListRepo.Create(context.Background(), priceList)
+	assert.NoError(suite.T(), err)
+
+	priceList.Name = "Wholesale 2025"
+	err = suite.priceListRepo.Update(context.Background(), "pl1", priceList)
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.priceListRepo.Get(context.Background(), "pl1")
+	assert.Equal(suite.T(), "Wholesale 2025", result.Name)
+}
+
+func (suite *PriceListRepoTestSuite) TestUpdatePriceListError() {
+	priceList := &models.PriceList{PriceListId: "pl1", Name: "Wholesale", Currency: "INR", CustomerId: "c1"}
+
+	err := suite.priceListRepo.Update(context.Background(), "pl1", priceList)
+	assert.EqualError(suite.T(), err, "error updating price list")
+}
+
+func (suite *PriceListRepoTestSuite) TestDeletePriceList() {
+	priceList := &models.PriceList{PriceListId: "pl1", Name: "Wholesale", Currency: "INR", CustomerId: "c1"}
+	err := suite.priceListRepo.Create(context.Background(), priceList)
+	assert.NoError(suite.T(), err)
+
+	err = suite.priceListRepo.Delete(context.Background(), "pl1")
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.priceListRepo.Get(context.Background(), "pl1")
+	assert.Equal(suite.T(), gorm.ErrRecordNotFound, err)
+}
+
+func (suite *PriceListRepoTestSuite) TestDeletePriceListError() {
+	err := suite.priceListRepo.Delete(context.Background(), "pl1")
+	assert.EqualError(suite.T(), err, "error deleting price list")
+}
+
+func (suite *PriceListRepoTestSuite) TestGetApplicable() {
+	now := time.Now()
+	lastYear := now.AddDate(-1, 0, 0)
+	lastMonth := now.AddDate(0, -1, 0)
+	nextMonth := now.AddDate(0, 1, 0)
+
+	priceLists := []*models.PriceList{
+		{PriceListId: "customer", Name: "c1", Currency: "INR", CustomerId: "c1"},
+		{PriceListId: "group", Name: "group", Currency: "INR", CustomerGroup: "wholesale", ValidFrom: &lastMonth, ValidTo: &nextMonth},
+		{PriceListId: "expired", Name: "expired", Currency: "INR", CustomerId: "c1", ValidFrom: &lastYear, ValidTo: &lastMonth},
+		{PriceListId: "future", Name: "future", Currency: "INR", CustomerId: "c1", ValidFrom: &nextMonth},
+		{PriceListId: "usd", Name: "usd", Currency: "USD", CustomerId: "c1"},
+		{PriceListId: "other", Name: "other", Currency: "INR", CustomerId: "c2"},
+	}
+	for _, v := range priceLists {
+		assert.NoError(suite.T(), suite.priceListRepo.Create(context.Background(), v))
+	}
+
+	result, err := suite.priceListRepo.GetApplicable(context.Background(), "c1", "wholesale", "INR", now)
+	assert.NoError(suite.T(), err)
+
+	var ids []string
+	for _, v := range result {
+		ids = append(ids, v.PriceListId)
+	}
+	assert.ElementsMatch(suite.T(), []string{"customer", "group"}, ids)
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type ReturnItemRepo interface {
+	Create(ctx context.Context, returnItems ...*models.ReturnItem) error
+	GetByReturn(ctx context.Context, returnId string) ([]*models.ReturnItem, error)
+	GetReturnedByOrder(ctx context.Context, orderId string) ([]*models.ReturnItem, error)
+	UpdateInspection(ctx context.Context, returnItemId string, restocked int, damaged int) error
+}
+
+type returnItemRepo struct {
+	db *gorm.DB
+}
+
+func NewReturnItemRepo(db *gorm.DB) ReturnItemRepo {
+	return &returnItemRepo{
+		db: db,
+	}
+}
+
+func (r *returnItemRepo) getTable() string {
+	return "return_items"
+}
+
+func (r *returnItemRepo) Create(ctx context.Context, returnItems ...*models.ReturnItem) error {
+	err := r.db.WithContext(ctx).Table(r.getTable()).Create(returnItems).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *returnItemRepo) GetByReturn(ctx context.Context, returnId string) ([]*models.ReturnItem, error) {
+	var result []*models.ReturnItem
+
+	err := r.db.WithContext(ctx).Table(r.getTable()).Where("return_id = ?", returnId).Order("return_item_id").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetReturnedByOrder returns the items of every return for the order that was
+// not rejected.
+func (r *returnItemRepo) GetReturnedByOrder(ctx context.Context, orderId string) ([]*models.ReturnItem, error) {
+	var result []*models.ReturnItem
+
+	err := r.db.WithContext(ctx).Table(r.getTable()).
+		Joins("JOIN returns ON returns.return_id = return_items.return_id").
+		Where("returns.order_id = ? AND returns.status <> ?", orderId, constants.ReturnStatusRejected).
+		Select("return_items.*").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *returnItemRepo) UpdateInspection(ctx context.Context, returnItemId string, restocked int, damaged int) error {
+	tx := r.db.WithContext(ctx).Table(r.getTable()).Where("return_item_id = ?", returnItemId).
+		UpdateColumns(map[string]interface{}{"restocked_quantity": restocked, "damaged_quantity": damaged})
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error updating return item")
+	}
+
+	return nil
+}
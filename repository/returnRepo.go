@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type ReturnRepo interface {
+	Create(ctx context.Context, ret *models.Return) error
+	Get(ctx context.Context, returnId string) (*models.Return, error)
+	GetByOrder(ctx context.Context, orderId string) ([]*models.Return, error)
+	UpdateStatus(ctx context.Context, returnId string, from string, to string) error
+	UpdateRefund(ctx context.Context, returnId string, ret *models.Return) error
+}
+
+type returnRepo struct {
+	db *gorm.DB
+}
+
+func NewReturnRepo(db *gorm.DB) ReturnRepo {
+	return &returnRepo{
+		db: db,
+	}
+}
+
+func (r *returnRepo) getTable() string {
+	return "returns"
+}
+
+func (r *returnRepo) Create(ctx context.Context, ret *models.Return) error {
+	err := r.db.WithContext(ctx).Table(r.getTable()).Create(ret).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *returnRepo) Get(ctx context.Context, returnId string) (*models.Return, error) {
+	var result *models.Return
+
+	err := r.db.WithContext(ctx).Table(r.getTable()).Where("return_id = ?", returnId).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *returnRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.Return, error) {
+	var result []*models.Return
+
+	err := r.db.WithContext(ctx).Table(r.getTable()).Where("order_id = ?", orderId).Order("requested_at, return_id").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateStatus only moves the return to the new status while it still has the
+// status it was read with.
+func (r *returnRepo) UpdateStatus(ctx context.Context, returnId string, from string, to string) error {
+	tx := r.db.WithContext(ctx).Table(r.getTable()).Where("return_id = ? AND status = ?", returnId, from).UpdateColumn("status", to)
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error updating return status")
+	}
+
+	return nil
+}
+
+func (r *returnRepo) UpdateRefund(ctx context.Context, returnId string, ret *models.Return) error {
+	tx := r.db.WithContext(ctx).Table(r.getTable()).Where("return_id = ?", returnId).UpdateColumns(map[string]interface{}{
+		"refund_amount":      ret.RefundAmount.Amount,
+		"refund_currency":    ret.RefundAmount.Currency,
+		"credit_note_number": ret.CreditNoteNumber,
+	})
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error updating return refund")
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+	"inventory-management/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ReturnRepoTestSuite struct {
+	suite.Suite
+	db             *gorm.DB
+	returnRepo     ReturnRepo
+	returnItemRepo ReturnItemRepo
+}
+
+func TestReturnRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(ReturnRepoTestSuite))
+}
+
+func (suite *ReturnRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.returnRepo = NewReturnRepo(suite.db)
+	suite.returnItemRepo = NewReturnItemRepo(suite.db)
+}
+
+func (suite *ReturnRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *ReturnRepoTestSuite) TestCreateReturn() {
+	ret := &models.Return{
+		ReturnId:     "r1",
+		OrderId:      "o1",
+		Status:       "requested",
+		RequestedAt:  time.Now(),
+		RefundAmount: money.MustParse("118", "INR"),
+	}
+
+	err := suite.returnRepo.Create(context.Background(), ret)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.returnRepo.Get(context.Background(), "r1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "requested", result.Status)
+	assert.Equal(suite.T(), "118.00 INR", result.RefundAmount.String())
+
+	err = suite.returnRepo.Create(context.Background(), &models.Return{ReturnId: "r2"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReturnRepoTestSuite) TestGetByOrder() {
+	now := time.Now()
+	rets := []*models.Return{
+		{ReturnId: "r2", OrderId: "o1", RequestedAt: now},
+		{ReturnId: "r1", OrderId: "o1", RequestedAt: now.Add(-time.Hour)},
+		{ReturnId: "r3", OrderId: "o2", RequestedAt: now},
+	}
+	for _, v := range rets {
+		assert.NoError(suite.T(), suite.returnRepo.Create(context.Background(), v))
+	}
+
+	result, err := suite.returnRepo.GetByOrder(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), "r1", result[0].ReturnId)
+}
+
+func (suite *ReturnRepoTestSuite) TestUpdateStatus() {
+	err := suite.returnRepo.Create(context.Background(), &models.Return{ReturnId: "r1", OrderId: "o1", Status: "requested"})
+	assert.NoError(suite.T(), err)
+
+	err = suite.returnRepo.UpdateStatus(context.Background(), "r1", "requested", "approved")
+	assert.NoError(suite.T(), err)
+
+	err = suite.returnRepo.UpdateStatus(context.Background(), "r1", "requested", "rejected")
+	assert.EqualError(suite.T(), err, "error updating return status")
+
+	result, _ := suite.returnRepo.Get(context.Background(), "r1")
+	assert.Equal(suite.T(), "approved", result.Status)
+}
+
+func (suite *ReturnRepoTestSuite) TestUpdateRefund() {
+	err := suite.returnRepo.Create(context.Background(), &models.Return{ReturnId: "r1", OrderId: "o1", Status: "inspected", Reason: "broken"})
+	assert.NoError(suite.T(), err)
+
+	err = suite.returnRepo.UpdateRefund(context.Background(), "r1", &models.Return{RefundAmount: money.MustParse("59", "INR"), CreditNoteNumber: "CN-000001"})
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.returnRepo.Get(context.Background(), "r1")
+	assert.Equal(suite.T(), "59.00 INR", result.RefundAmount.String())
+	assert.Equal(suite.T(), "CN-000001", result.CreditNoteNumber)
+	assert.Equal(suite.T(), "broken", result.Reason)
+	assert.Equal(suite.T(), "inspected", result.Status)
+
+	err = suite.returnRepo.UpdateRefund(context.Background(), "r2", &models.Return{CreditNoteNumber: "CN-000002"})
+	assert.EqualError(suite.T(), err, "error updating return refund")
+}
+
+func (suite *ReturnRepoTestSuite) TestReturnItems() {
+	rets := []*models.Return{
+		{ReturnId: "r1", OrderId: "o1", Status: "approved"},
+		{ReturnId: "r2", OrderId: "o1", Status: "rejected"},
+	}
+	for _, v := range rets {
+		assert.NoError(suite.T(), suite.returnRepo.Create(context.Background(), v))
+	}
+
+	err := suite.returnItemRepo.Create(
+		context.Background(), &models.ReturnItem{ReturnItemId: "ri1", ReturnId: "r1", OrderItemId: "i1", ArticleId: "a1", Quantity: 2},
+		&models.ReturnItem{ReturnItemId: "ri2", ReturnId: "r2", OrderItemId: "i1", ArticleId: "a1", Quantity: 1},
+	)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.returnItemRepo.GetByReturn(context.Background(), "r1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+
+	returned, err := suite.returnItemRepo.GetReturnedByOrder(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), returned, 1)
+	assert.Equal(suite.T(), "ri1", returned[0].ReturnItemId)
+
+	err = suite.returnItemRepo.UpdateInspection(context.Background(), "ri1", 1, 1)
+	assert.NoError(suite.T(), err)
+
+	result, _ = suite.returnItemRepo.GetByReturn(context.Background(), "r1")
+	assert.Equal(suite.T(), 1, result[0].RestockedQuantity)
+	assert.Equal(suite.T(), 1, result[0].DamagedQuantity)
+
+	err = suite.returnItemRepo.UpdateInspection(context.Background(), "ri3", 1, 0)
+	assert.EqualError(suite.T(), err, "error updating return item")
+}
+
+func (suite *ReturnRepoTestSuite) TestCreateReturnItemError() {
+	err := suite.returnItemRepo.Create(context.Background(), &models.ReturnItem{ReturnItemId: "ri1", ReturnId: "r1", OrderItemId: "i1", Quantity: 0})
+	assert.Error(suite.T(), err)
+
+	err = suite.returnItemRepo.Create(context.Background(), &models.ReturnItem{ReturnItemId: "ri1", ReturnId: "r1", OrderItemId: "i1", Quantity: 1, DamagedQuantity: 2})
+	assert.Error(suite.T(), err)
+}
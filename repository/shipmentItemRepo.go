@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type ShipmentItemRepo interface {
+	Create(ctx context.Context, shipmentItems ...*models.ShipmentItem) error
+	GetByOrder(ctx context.Context, orderId string) ([]*models.ShipmentItem, error)
+}
+
+type shipmentItemRepo struct {
+	db *gorm.DB
+}
+
+func NewShipmentItemRepo(db *gorm.DB) ShipmentItemRepo {
+	return &shipmentItemRepo{
+		db: db,
+	}
+}
+
+func (s *shipmentItemRepo) getTable() string {
+	return "shipment_items"
+}
+
+func (s *shipmentItemRepo) Create(ctx context.Context, shipmentItems ...*models.ShipmentItem) error {
+	err := s.db.WithContext(ctx).Table(s.getTable()).Create(shipmentItems).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *shipmentItemRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.ShipmentItem, error) {
+	var result []*models.ShipmentItem
+
+	err := s.db.WithContext(ctx).Table(s.getTable()).Where("order_id = ?", orderId).Order("shipment_item_id").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+
+	"gorm.io/gorm"
+)
+
+type ShipmentRepo interface {
+	Create(ctx context.Context, shipment *models.Shipment) error
+	Get(ctx context.Context, shipmentId string) (*models.Shipment, error)
+	GetByOrder(ctx context.Context, orderId string) ([]*models.Shipment, error)
+}
+
+type shipmentRepo struct {
+	db *gorm.DB
+}
+
+func NewShipmentRepo(db *gorm.DB) ShipmentRepo {
+	return &shipmentRepo{
+		db: db,
+	}
+}
+
+func (s *shipmentRepo) getTable() string {
+	return "shipments"
+}
+
+func (s *shipmentRepo) Create(ctx context.Context, shipment *models.Shipment) error {
+	err := s.db.WithContext(ctx).Table(s.getTable()).Create(shipment).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *shipmentRepo) Get(ctx context.Context, shipmentId string) (*models.Shipment, error) {
+	var result *models.Shipment
+
+	err := s.db.WithContext(ctx).Table(s.getTable()).Where("shipment_id = ?", shipmentId).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *shipmentRepo) GetByOrder(ctx context.Context, orderId string) ([]*models.Shipment, error) {
+	var result []*models.Shipment
+
+	err := s.db.WithContext(ctx).Table(s.getTable()).Where("order_id = ?", orderId).Order("shipped_at, shipment_id").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
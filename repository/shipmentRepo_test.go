@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ShipmentRepoTestSuite struct {
+	suite.Suite
+	db               *gorm.DB
+	shipmentRepo     ShipmentRepo
+	shipmentItemRepo ShipmentItemRepo
+}
+
+func TestShipmentRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(ShipmentRepoTestSuite))
+}
+
+func (suite *ShipmentRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.shipmentRepo = NewShipmentRepo(suite.db)
+	suite.shipmentItemRepo = NewShipmentItemRepo(suite.db)
+}
+
+func (suite *ShipmentRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *ShipmentRepoTestSuite) TestCreateShipment() {
+	shipment := &models.Shipment{
+		ShipmentId:     "s1",
+		OrderId:        "o1",
+		Carrier:        "DHL",
+		TrackingNumber: "JD0001",
+		ShippedAt:      time.Now(),
+	}
+
+	err := suite.shipmentRepo.Create(context.Background(), shipment)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.shipmentRepo.Get(context.Background(), "s1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "JD0001", result.TrackingNumber)
+
+	err = suite.shipmentRepo.Create(context.Background(), &models.Shipment{ShipmentId: "s2"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ShipmentRepoTestSuite) TestGetByOrder() {
+	now := time.Now()
+	shipments := []*models.Shipment{
+		{ShipmentId: "s2", OrderId: "o1", ShippedAt: now},
+		{ShipmentId: "s1", OrderId: "o1", ShippedAt: now.Add(-time.Hour)},
+		{ShipmentId: "s3", OrderId: "o2", ShippedAt: now},
+	}
+	for _, v := range shipments {
+		assert.NoError(suite.T(), suite.shipmentRepo.Create(context.Background(), v))
+	}
+
+	result, err := suite.shipmentRepo.GetByOrder(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), "s1", result[0].ShipmentId)
+}
+
+func (suite *ShipmentRepoTestSuite) TestShipmentItems() {
+	err := suite.shipmentItemRepo.Create(
+		context.Background(), &models.ShipmentItem{ShipmentItemId: "si1", ShipmentId: "s1", OrderId: "o1", OrderItemId: "i1", Quantity: 2},
+		&models.ShipmentItem{ShipmentItemId: "si2", ShipmentId: "s2", OrderId: "o1", OrderItemId: "i1", Quantity: 1},
+		&models.ShipmentItem{ShipmentItemId: "si3", ShipmentId: "s3", OrderId: "o2", OrderItemId: "i3", Quantity: 1},
+	)
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.shipmentItemRepo.GetByOrder(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+
+	err = suite.shipmentItemRepo.Create(context.Background(), &models.ShipmentItem{ShipmentItemId: "si4", ShipmentId: "s1", OrderId: "o1", OrderItemId: "i1"})
+	assert.Error(suite.T(), err)
+}
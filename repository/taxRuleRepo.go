@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"inventory-management/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TaxRuleRepo interface {
+	Create(ctx context.Context, taxRule *models.TaxRule) error
+	Update(ctx context.Context, taxRuleId string, taxRule *models.TaxRule) error
+	Get(ctx context.Context, taxRuleId string) (*models.TaxRule, error)
+	Delete(ctx context.Context, taxRuleId string) error
+	GetApplicable(ctx context.Context, country string, at time.Time) ([]*models.TaxRule, error)
+}
+
+type taxRuleRepo struct {
+	db *gorm.DB
+}
+
+func NewTaxRuleRepo(db *gorm.DB) TaxRuleRepo {
+	return &taxRuleRepo{
+		db: db,
+	}
+}
+
+func (t *taxRuleRepo) getTable() string {
+	return "tax_rules"
+}
+
+func (t *taxRuleRepo) Create(ctx context.Context, taxRule *models.TaxRule) error {
+	err := t.db.WithContext(ctx).Table(t.getTable()).Create(taxRule).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *taxRuleRepo) Update(ctx context.Context, taxRuleId string, taxRule *models.TaxRule) error {
+	// select every column so that a zero rate or cleared state is written
+	tx := t.db.WithContext(ctx).Table(t.getTable()).Where("tax_rule_id = ?", taxRuleId).Select("*").Omit("tax_rule_id").Updates(taxRule)
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error updating tax rule")
+	}
+
+	return nil
+}
+
+func (t *taxRuleRepo) Get(ctx context.Context, taxRuleId string) (*models.TaxRule, error) {
+	var result *models.TaxRule
+
+	err := t.db.WithContext(ctx).Table(t.getTable()).Where("tax_rule_id = ?", taxRuleId).First(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (t *taxRuleRepo) Delete(ctx context.Context, taxRuleId string) error {
+	tx := t.db.WithContext(ctx).Table(t.getTable()).Where("tax_rule_id = ?", taxRuleId).Delete(&models.TaxRule{})
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return errors.New("error deleting tax rule")
+	}
+
+	return nil
+}
+
+func (t *taxRuleRepo) GetApplicable(ctx context.Context, country string, at time.Time) ([]*models.TaxRule, error) {
+	var result []*models.TaxRule
+
+	err := t.db.WithContext(ctx).Table(t.getTable()).
+		Where("country = ?", country).
+		Where("valid_from IS NULL OR valid_from <= ?", at).
+		Where("valid_to IS NULL OR valid_to >= ?", at).
+		Order("tax_rule_id").
+		Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"inventory-management/models"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TaxRuleRepoTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	taxRuleRepo TaxRuleRepo
+}
+
+func TestTaxRuleRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(TaxRuleRepoTestSuite))
+}
+
+func (suite *TaxRuleRepoTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.taxRuleRepo = NewTaxRuleRepo(suite.db)
+}
+
+func (suite *TaxRuleRepoTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *TaxRuleRepoTestSuite) newTaxRule(id string, rate int64) *models.TaxRule {
+	return &models.TaxRule{
+		TaxRuleId: id,
+		Name:      "GST",
+		Country:   "IN",
+		TaxClass:  "standard",
+		Rate:      decimal.NewFromInt(rate),
+	}
+}
+
+func (suite *TaxRuleRepoTestSuite) TestCreateTaxRule() {
+	err := suite.taxRuleRepo.Create(context.Background(), suite.newTaxRule("t1", 18))
+	assert.NoError(suite.T(), err)
+
+	result, err := suite.taxRuleRepo.Get(context.Background(), "t1")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), decimal.NewFromInt(18).Equal(result.Rate))
+}
+
+func (suite *TaxRuleRepoTestSuite) TestCreateTaxRuleError() {
+	err := suite.taxRuleRepo.Create(context.Background(), suite.newTaxRule("t1", 120))
+	assert.Error(suite.T(), err)
+
+	taxRule := suite.newTaxRule("t2", 18)
+	taxRule.Country = ""
+	err = suite.taxRuleRepo.Create(context.Background(), taxRule)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *TaxRuleRepoTestSuite) TestUpdateTaxRuleToZero() {
+	taxRule := suite.newTaxRule("t1", 18)
+	taxRule.State = "KA"
+	err := suite.taxRuleRepo.Create(context.Background(), taxRule)
+	assert.NoError(suite.T(), err)
+
+	err = suite.taxRuleRepo.Update(context.Background(), "t1", suite.newTaxRule("t1", 0))
+	assert.NoError(suite.T(), err)
+
+	result, _ := suite.taxRuleRepo.Get(context.Background(), "t1")
+	assert.True(suite.T(), result.Rate.IsZero())
+	assert.Equal(suite.T(), "", result.State)
+}
+
+func (suite *TaxRuleRepoTestSuite) TestUpdateTaxRuleError() {
+	err := suite.taxRuleRepo.Update(context.Background(), "t1", suite.newTaxRule("t1", 5))
+	assert.EqualError(suite.T(), err, "error updating tax rule")
+}
+
+func (suite *TaxRuleRepoTestSuite) TestDeleteTaxRule() {
+	err := suite.taxRuleRepo.Create(context.Background(), suite.newTaxRule("t1", 18))
+	assert.NoError(suite.T(), err)
+
+	err = suite.taxRuleRepo.Delete(context.Background(), "t1")
+	assert.NoError(suite.T(), err)
+
+	err = suite.taxRuleRepo.Delete(context.Background(), "t1")
+	assert.EqualError(suite.T(), err, "error deleting tax rule")
+}
+
+func (suite *TaxRuleRepoTestSuite) TestGetApplicable() {
+	now := time.Now().UTC()
+	past := now.Add(-48 * time.Hour)
+	yesterday := now.Add(-24 * time.Hour)
+
+	expired := suite.newTaxRule("t1", 12)
+	expired.ValidTo = &yesterday
+	current := suite.newTaxRule("t2", 18)
+	current.ValidFrom = &past
+	other := suite.newTaxRule("t3", 20)
+	other.Country = "GB"
+
+	for _, v := range []*models.TaxRule{expired, current, other} {
+		err := suite.taxRuleRepo.Create(context.Background(), v)
+		assert.NoError(suite.T(), err)
+	}
+
+	result, err := suite.taxRuleRepo.GetApplicable(context.Background(), "IN", now)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), "t2", result[0].TaxRuleId)
+
+	result, err = suite.taxRuleRepo.GetApplicable(context.Background(), "IN", past.Add(time.Hour))
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+}
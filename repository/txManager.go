@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Repos groups the repositories that take part in a transaction. Every repo is
+// bound to the same underlying transaction.
+type Repos struct {
+	Orders         OrderRepo
+	OrderItems     OrderItemRepo
+	OrderDiscounts OrderDiscountRepo
+	Coupons        CouponRepo
+	Invoices       InvoiceRepo
+	Sequences      DocumentSequenceRepo
+	Articles       ArticleRepo
+	ArticlePrices  ArticlePriceRepo
+	Returns        ReturnRepo
+	ReturnItems    ReturnItemRepo
+	Shipments      ShipmentRepo
+	ShipmentItems  ShipmentItemRepo
+	Backorders     BackorderRepo
+	Payments       PaymentRepo
+	Users          UserRepo
+	Addresses      AddressRepo
+}
+
+func NewRepos(db *gorm.DB) *Repos {
+	return &Repos{
+		Orders:         NewOrderRepo(db),
+		OrderItems:     NewOrderItemRepo(db),
+		OrderDiscounts: NewOrderDiscountRepo(db),
+		Coupons:        NewCouponRepo(db),
+		Invoices:       NewInvoiceRepo(db),
+		Sequences:      NewDocumentSequenceRepo(db),
+		Articles:       NewArticleRepo(db),
+		ArticlePrices:  NewArticlePriceRepo(db),
+		Returns:        NewReturnRepo(db),
+		ReturnItems:    NewReturnItemRepo(db),
+		Shipments:      NewShipmentRepo(db),
+		ShipmentItems:  NewShipmentItemRepo(db),
+		Backorders:     NewBackorderRepo(db),
+		Payments:       NewPaymentRepo(db),
+		Users:          NewUserRepo(db),
+		Addresses:      NewAddressRepo(db),
+	}
+}
+
+type TxManager interface {
+	WithTransaction(ctx context.Context, fn func(repos *Repos) error) error
+}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) TxManager {
+	return &txManager{
+		db: db,
+	}
+}
+
+// WithTransaction commits when fn returns nil and rolls everything back when
+// it returns an error.
+func (t *txManager) WithTransaction(ctx context.Context, fn func(repos *Repos) error) error {
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepos(tx))
+	})
+}
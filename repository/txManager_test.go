@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"inventory-management/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type TxManagerTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	txManager TxManager
+}
+
+func TestTxManagerTestSuite(t *testing.T) {
+	suite.Run(t, new(TxManagerTestSuite))
+}
+
+func (suite *TxManagerTestSuite) SetupTest() {
+	suite.db = openTestDB(suite.T())
+
+	suite.txManager = NewTxManager(suite.db)
+}
+
+func (suite *TxManagerTestSuite) TearDownTest() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+}
+
+func (suite *TxManagerTestSuite) TestCommit() {
+	err := suite.txManager.WithTransaction(context.Background(), func(repos *Repos) error {
+		return repos.Orders.Create(context.Background(), &models.Order{OrderId: "o1", CustomerId: "c1", OrderedAt: time.Now()})
+	})
+	assert.NoError(suite.T(), err)
+
+	_, err = NewOrderRepo(suite.db).Get(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *TxManagerTestSuite) TestRollback() {
+	err := suite.txManager.WithTransaction(context.Background(), func(repos *Repos) error {
+		err := repos.Orders.Create(context.Background(), &models.Order{OrderId: "o1", CustomerId: "c1", OrderedAt: time.Now()})
+		if err != nil {
+			return err
+		}
+
+		return errors.New("item failed")
+	})
+	assert.EqualError(suite.T(), err, "item failed")
+
+	_, err = NewOrderRepo(suite.db).Get(context.Background(), "o1")
+	assert.Equal(suite.T(), gorm.ErrRecordNotFound, err)
+}
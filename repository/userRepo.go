@@ -1,17 +1,20 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"inventory-management/constants"
 	"inventory-management/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepo interface {
-	Upsert(user *models.User) error
-	Update(userId string, user *models.User) error
-	Get(userId string) (*models.User, error)
-	Delete(userId string) error
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, userId string, user *models.User) error
+	Get(ctx context.Context, userId string) (*models.User, error)
+	Delete(ctx context.Context, userId string) error
 }
 
 type userRepo struct {
@@ -28,28 +31,44 @@ func (o *userRepo) getTable() string {
 	return "users"
 }
 
-func (o *userRepo) Upsert(user *models.User) error {
-	err := o.db.Table(o.getTable()).Save(user).Error
-	if err != nil {
-		return err
+// Create inserts the user and returns ErrorRecordExists when the id is taken.
+func (o *userRepo) Create(ctx context.Context, user *models.User) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Clauses(clause.OnConflict{DoNothing: true}).Create(user)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return constants.ErrorRecordExists
 	}
 
 	return nil
 }
 
-func (o *userRepo) Update(userId string, user *models.User) error {
-	tx := o.db.Table(o.getTable()).Where("id = ?", userId).UpdateColumns(user)
-	if tx.Error != nil || tx.RowsAffected == 0 {
+// Update writes the user if it is still at user.Version and moves it to the
+// next version. It returns ErrorVersionMismatch when the user has changed
+// since that version was read. Zero values are written too.
+func (o *userRepo) Update(ctx context.Context, userId string, user *models.User) error {
+	version := user.Version
+	user.Version = version + 1
+
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Where("id = ? AND version = ?", userId, version).
+		Select("*").Omit("id").Updates(user)
+	if tx.Error != nil {
 		return errors.New("error updating user")
 	}
 
+	if tx.RowsAffected == 0 {
+		return constants.ErrorVersionMismatch
+	}
+
 	return nil
 }
 
-func (o *userRepo) Get(userId string) (*models.User, error) {
+func (o *userRepo) Get(ctx context.Context, userId string) (*models.User, error) {
 	var result *models.User
 
-	err := o.db.Table(o.getTable()).Where("id = ?", userId).First(&result).Error
+	err := o.db.WithContext(ctx).Table(o.getTable()).Where("id = ?", userId).First(&result).Error
 	if err != nil {
 		return nil, err
 	}
@@ -57,8 +76,8 @@ func (o *userRepo) Get(userId string) (*models.User, error) {
 	return result, nil
 }
 
-func (o *userRepo) Delete(userId string) error {
-	tx := o.db.Table(o.getTable()).Where("id = ?", userId).Delete(&models.User{})
+func (o *userRepo) Delete(ctx context.Context, userId string) error {
+	tx := o.db.WithContext(ctx).Table(o.getTable()).Where("id = ?", userId).Delete(&models.User{})
 	if tx.Error != nil || tx.RowsAffected == 0 {
 		return errors.New("error deleting user")
 	}
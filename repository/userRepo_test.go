@@ -1,13 +1,13 @@
 package repository
 
 import (
+	"context"
 	"inventory-management/constants"
 	"inventory-management/models"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -22,16 +22,7 @@ func TestUserRepoTestSuite(t *testing.T) {
 }
 
 func (suite *UserRepoTestSuite) SetupTest() {
-	var err error
-	suite.db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	if err != nil {
-		suite.T().Fatal("failed to connect to database")
-	}
-
-	err = suite.db.AutoMigrate(&models.User{})
-	if err != nil {
-		suite.T().Fatal("failed to migrate database")
-	}
+	suite.db = openTestDB(suite.T())
 
 	suite.userRepo = NewUserRepo(suite.db)
 }
@@ -51,7 +42,7 @@ func (suite *UserRepoTestSuite) TestCreateUser() {
 		Role:      constants.RoleCustomer,
 	}
 
-	err := suite.userRepo.Upsert(user)
+	err := suite.userRepo.Create(context.Background(), user)
 
 	assert.NoError(suite.T(), err)
 
@@ -61,6 +52,34 @@ func (suite *UserRepoTestSuite) TestCreateUser() {
 	assert.Equal(suite.T(), user.Mobile, savedUser.Mobile)
 }
 
+func (suite *UserRepoTestSuite) TestCreateUserExists() {
+	user := &models.User{
+		Id:        "250",
+		Name:      "John",
+		Email:     "john@abc.com",
+		AddressId: "25",
+		Role:      constants.RoleCustomer,
+		Version:   4,
+	}
+	err := suite.userRepo.Create(context.Background(), user)
+	assert.NoError(suite.T(), err)
+
+	err = suite.userRepo.Create(context.Background(), &models.User{
+		Id:        "250",
+		Name:      "Mallory",
+		Email:     "mallory@abc.com",
+		AddressId: "26",
+		Role:      constants.RoleAdmin,
+		Version:   1,
+	})
+	assert.ErrorIs(suite.T(), err, constants.ErrorRecordExists)
+
+	saved, err := suite.userRepo.Get(context.Background(), "250")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "John", saved.Name)
+	assert.Equal(suite.T(), int64(4), saved.Version)
+}
+
 func (suite *UserRepoTestSuite) TestCreaterderItemError() {
 	user := &models.User{
 		Id:        "250",
@@ -71,7 +90,7 @@ func (suite *UserRepoTestSuite) TestCreaterderItemError() {
 		Role:      constants.RoleCustomer,
 	}
 
-	err := suite.userRepo.Upsert(user)
+	err := suite.userRepo.Create(context.Background(), user)
 	assert.Error(suite.T(), err)
 }
 
@@ -84,17 +103,17 @@ func (suite *UserRepoTestSuite) TestGetUser() {
 		AddressId: "25",
 		Role:      constants.RoleCustomer,
 	}
-	err := suite.userRepo.Upsert(user)
+	err := suite.userRepo.Create(context.Background(), user)
 	assert.NoError(suite.T(), err)
 
-	result, err := suite.userRepo.Get("250")
+	result, err := suite.userRepo.Get(context.Background(), "250")
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), user.Email, result.Email)
 }
 
 func (suite *UserRepoTestSuite) TestGetUserError() {
-	result, err := suite.userRepo.Get("123")
+	result, err := suite.userRepo.Get(context.Background(), "123")
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), result)
@@ -110,16 +129,22 @@ func (suite *UserRepoTestSuite) TestUpdateUser() {
 		AddressId: "25",
 		Role:      constants.RoleCustomer,
 	}
-	err := suite.userRepo.Upsert(user)
+	err := suite.userRepo.Create(context.Background(), user)
 	assert.NoError(suite.T(), err)
 
 	user.Name = "Joe"
 
-	err = suite.userRepo.Update(user.Id, user)
+	err = suite.userRepo.Update(context.Background(), user.Id, user)
 	assert.NoError(suite.T(), err)
 
 	var updatedUser models.User
 	suite.db.Table("users").Where("id = ?", user.Id).First(&updatedUser)
+	assert.Equal(suite.T(), "Joe", updatedUser.Name)
+	assert.Equal(suite.T(), int64(2), updatedUser.Version)
+
+	user.Version = 1
+	err = suite.userRepo.Update(context.Background(), user.Id, user)
+	assert.Equal(suite.T(), constants.ErrorVersionMismatch, err)
 }
 
 func (suite *UserRepoTestSuite) TestUpdateUserError() {
@@ -132,7 +157,7 @@ func (suite *UserRepoTestSuite) TestUpdateUserError() {
 		Role:      constants.RoleCustomer,
 	}
 
-	err := suite.userRepo.Update(user.Id, user)
+	err := suite.userRepo.Update(context.Background(), user.Id, user)
 	assert.Error(suite.T(), err)
 }
 
@@ -145,10 +170,10 @@ func (suite *UserRepoTestSuite) TestDeleteUser() {
 		AddressId: "25",
 		Role:      constants.RoleCustomer,
 	}
-	err := suite.userRepo.Upsert(user)
+	err := suite.userRepo.Create(context.Background(), user)
 	assert.NoError(suite.T(), err)
 
-	err = suite.userRepo.Delete(user.Id)
+	err = suite.userRepo.Delete(context.Background(), user.Id)
 
 	assert.NoError(suite.T(), err)
 
@@ -158,8 +183,19 @@ func (suite *UserRepoTestSuite) TestDeleteUser() {
 }
 
 func (suite *UserRepoTestSuite) TestDeleteUserError() {
-	err := suite.userRepo.Delete("123")
+	err := suite.userRepo.Delete(context.Background(), "123")
 
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "error deleting user", err.Error())
 }
+
+func (suite *UserRepoTestSuite) TestCreateUserInvalidBackorderPolicy() {
+	user := &models.User{
+		Id:              "250",
+		Name:            "John",
+		BackorderPolicy: "sometimes",
+	}
+
+	err := suite.userRepo.Create(context.Background(), user)
+	assert.EqualError(suite.T(), err, "invalid backorder policy")
+}
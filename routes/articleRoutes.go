@@ -1,23 +1,38 @@
 package routes
 
 import (
+	"inventory-management/config"
 	"inventory-management/handlers"
 	"inventory-management/repository"
 	"inventory-management/services/articles"
+	"inventory-management/services/backorders"
+	"inventory-management/services/catalog"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func ArticleRoutes(r *gin.Engine, db *gorm.DB) {
+func ArticleRoutes(r *gin.Engine, db *gorm.DB, config *config.Config, idempotency gin.HandlerFunc) catalog.CatalogService {
 	articleRepo := repository.NewArticleRepo(db)
-	articleService := articles.NewArticleService(articleRepo)
+	articlePriceRepo := repository.NewArticlePriceRepo(db)
+	txManager := repository.NewTxManager(db)
+	backorderService := backorders.NewBackorderService(articleRepo, repository.NewBackorderRepo(db), repository.NewUserRepo(db), txManager)
+	articleService := articles.NewArticleService(articleRepo, articlePriceRepo, txManager, backorderService, config.BaseCurrency)
 	articleHandler := handlers.NewArticleHandler(articleService)
+	catalogService := catalog.NewCatalogService(articleRepo, repository.NewImportJobRepo(db), repository.NewImportErrorRepo(db), txManager,
+		backorderService, config.BaseCurrency)
+	catalogHandler := handlers.NewCatalogHandler(catalogService)
 
 	r.GET("/articles/:id", articleHandler.GetArticle)
-	r.POST("/articles", articleHandler.CreateArticle)
+	r.POST("/articles", idempotency, articleHandler.CreateArticle)
 	r.DELETE("/articles/:id", articleHandler.DeleteArticle)
 	r.PUT("/articles/:id", articleHandler.UpdateArticle)
 	r.GET("/articles-list", articleHandler.ListArticles)
-	r.PATCH("/articles/:id", articleHandler.UpdateArticleStock)
+	r.PATCH("/articles/:id", articleHandler.PatchArticle)
+	r.PUT("/articles/:id/stock", articleHandler.UpdateArticleStock)
+	r.POST("/articles/import", catalogHandler.ImportArticles)
+	r.GET("/articles/import/:id", catalogHandler.GetImportJob)
+	r.GET("/articles/export", catalogHandler.ExportArticles)
+
+	return catalogService
 }
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"inventory-management/handlers"
+	"inventory-management/repository"
+	"inventory-management/services/backorders"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func BackorderRoutes(r *gin.Engine, db *gorm.DB) {
+	articleRepo := repository.NewArticleRepo(db)
+	backorderRepo := repository.NewBackorderRepo(db)
+	userRepo := repository.NewUserRepo(db)
+
+	backorderService := backorders.NewBackorderService(articleRepo, backorderRepo, userRepo, repository.NewTxManager(db))
+	backorderHandler := handlers.NewBackorderHandler(backorderService)
+
+	r.POST("/articles/:id/receipts", backorderHandler.ReceiveStock)
+	r.GET("/articles/:id/backorders", backorderHandler.GetArticleBackorders)
+}
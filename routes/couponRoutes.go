@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"inventory-management/config"
+	"inventory-management/handlers"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/services/coupons"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func CouponRoutes(r *gin.Engine, db *gorm.DB, config *config.Config) {
+	couponRepo := repository.NewCouponRepo(db)
+
+	rates := money.NewRates(config.BaseCurrency, config.ExchangeRates)
+	couponService := coupons.NewCouponService(couponRepo, rates)
+	couponHandler := handlers.NewCouponHandler(couponService)
+
+	r.GET("/coupons/:code", couponHandler.GetCoupon)
+	r.POST("/coupons", couponHandler.CreateCoupon)
+	r.DELETE("/coupons/:code", couponHandler.DeleteCoupon)
+	r.PUT("/coupons/:code", couponHandler.UpdateCoupon)
+}
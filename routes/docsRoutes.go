@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"encoding/json"
+	"inventory-management/config"
+	"inventory-management/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func DocsRoutes(r *gin.Engine, config *config.Config) error {
+	document, err := json.Marshal(apiDocument(config))
+	if err != nil {
+		return err
+	}
+
+	docsHandler := handlers.NewDocsHandler(document)
+
+	r.GET("/openapi.json", docsHandler.OpenAPI)
+	r.GET("/docs", docsHandler.Docs)
+	r.GET("/docs/:file", docsHandler.SwaggerUI)
+
+	return nil
+}
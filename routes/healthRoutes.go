@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"inventory-management/config"
+	"inventory-management/handlers"
+	"inventory-management/services/health"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func HealthRoutes(r *gin.Engine, db *gorm.DB, config *config.Config, workers []health.Worker) health.HealthService {
+	healthService := health.NewHealthService(db, workers, config.AppName)
+	healthHandler := handlers.NewHealthHandler(healthService)
+
+	r.GET("/healthz", healthHandler.Healthz)
+	r.GET("/readyz", healthHandler.Readyz)
+	r.GET("/version", healthHandler.Version)
+
+	return healthService
+}
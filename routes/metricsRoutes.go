@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"inventory-management/config"
+	"inventory-management/metrics"
+	"inventory-management/repository"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func MetricsRoutes(r *gin.Engine, db *gorm.DB, config *config.Config) error {
+	err := metrics.Registry.Register(metrics.NewStockCollector(repository.NewArticleRepo(db), config.MetricsStockLimit))
+	if err != nil {
+		return err
+	}
+
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	return nil
+}
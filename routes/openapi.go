@@ -0,0 +1,197 @@
+package routes
+
+import (
+	"inventory-management/config"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/openapi"
+	"inventory-management/tabular"
+	"net/http"
+)
+
+// The errors each kind of route can answer with, besides those of the
+// middleware: the deadline shows up as a 500.
+var (
+	readErrors       = []int{http.StatusInternalServerError}
+	cachedErrors     = []int{http.StatusNotModified, http.StatusInternalServerError}
+	writeErrors      = []int{http.StatusBadRequest, http.StatusInternalServerError}
+	validatedErrors  = []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError}
+	createErrors     = []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError}
+	idempotentErrors = []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError}
+	versionedErrors  = []int{http.StatusBadRequest, http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnprocessableEntity,
+		http.StatusPreconditionRequired, http.StatusInternalServerError}
+	patchErrors = []int{http.StatusBadRequest, http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnsupportedMediaType,
+		http.StatusUnprocessableEntity, http.StatusPreconditionRequired, http.StatusInternalServerError}
+)
+
+var (
+	fileFormat    = openapi.Query("format", "Defaults to csv.", constants.FileFormatCSV, constants.FileFormatXLSX)
+	invoiceFormat = openapi.Query("format", "Defaults to json.", constants.InvoiceFormatJSON, constants.InvoiceFormatPDF,
+		constants.InvoiceFormatUBL)
+	invoiceFiles = []string{"application/pdf", "application/xml"}
+	tableFiles   = []string{tabular.ContentTypes[constants.FileFormatCSV], tabular.ContentTypes[constants.FileFormatXLSX]}
+)
+
+var apiTags = []*openapi.Tag{
+	{Name: "articles", Description: "Articles, their prices and stock."},
+	{Name: "catalog", Description: "Bulk article import and export."},
+	{Name: "backorders", Description: "Stock owed to customers and goods received."},
+	{Name: "orders", Description: "Orders and B2B order import."},
+	{Name: "invoices", Description: "Invoices and credit notes."},
+	{Name: "payments", Description: "Payments and refunds against orders."},
+	{Name: "shipments", Description: "Shipments and order fulfilment."},
+	{Name: "returns", Description: "Returns and their inspection."},
+	{Name: "users", Description: "Customers, suppliers and admins."},
+	{Name: "price-lists", Description: "Customer price lists and quantity breaks."},
+	{Name: "coupons", Description: "Coupons applied to orders."},
+	{Name: "tax-rules", Description: "Tax rates by destination and tax class."},
+	{Name: "operations", Description: "Health, metrics and these docs."},
+}
+
+// apiRoutes describes every route Router registers. The tests fail when a
+// route is missing.
+var apiRoutes = []*openapi.Route{
+	{Method: http.MethodGet, Path: "/articles/:id", Id: "getArticle", Tag: "articles", Summary: "Get an article",
+		Parameters: []*openapi.Parameter{openapi.IfNoneMatch}, Response: dtos.Article{}, Errors: cachedErrors},
+	{Method: http.MethodPost, Path: "/articles", Id: "createArticle", Tag: "articles", Summary: "Create an article",
+		Parameters: []*openapi.Parameter{openapi.IdempotencyKey}, Request: dtos.Article{}, Response: openapi.Message(),
+		Errors: idempotentErrors},
+	{Method: http.MethodDelete, Path: "/articles/:id", Id: "deleteArticle", Tag: "articles", Summary: "Delete an article",
+		Response: openapi.Message(), Errors: readErrors},
+	{Method: http.MethodPut, Path: "/articles/:id", Id: "updateArticle", Tag: "articles", Summary: "Replace an article",
+		Parameters: []*openapi.Parameter{openapi.IfMatch}, Request: dtos.Article{}, Response: openapi.Message(),
+		Errors: versionedErrors},
+	{Method: http.MethodPatch, Path: "/articles/:id", Id: "patchArticle", Tag: "articles", Summary: "Merge patch an article",
+		Parameters: []*openapi.Parameter{openapi.IfMatch}, Request: dtos.Article{}, Patch: true, Response: openapi.Message(),
+		Errors: patchErrors},
+	{Method: http.MethodGet, Path: "/articles-list", Id: "listArticles", Tag: "articles", Summary: "List every article",
+		Response: []*dtos.Article{}, Errors: readErrors},
+	{Method: http.MethodPut, Path: "/articles/:id/stock", Id: "updateArticleStock", Tag: "articles", Summary: "Set an article's stock and fill backorders",
+		Parameters: []*openapi.Parameter{openapi.IfMatch}, Request: dtos.UpdateStock{}, Response: openapi.Message(),
+		Errors: versionedErrors},
+
+	{Method: http.MethodPost, Path: "/articles/import", Id: "importArticles", Tag: "catalog", Summary: "Start an article import job",
+		Upload: true, Status: http.StatusAccepted, Response: dtos.ImportJob{},
+		Errors: []int{http.StatusBadRequest, http.StatusInternalServerError, http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/articles/import/:id", Id: "getImportJob", Tag: "catalog", Summary: "Get an import job",
+		Response: dtos.ImportJob{}, Errors: readErrors},
+	{Method: http.MethodGet, Path: "/articles/export", Id: "exportArticles", Tag: "catalog", Summary: "Export every article",
+		Parameters: []*openapi.Parameter{fileFormat}, Files: tableFiles, Errors: writeErrors},
+
+	{Method: http.MethodPost, Path: "/articles/:id/receipts", Id: "receiveStock", Tag: "backorders",
+		Summary: "Receive stock and fill backorders", Request: dtos.ReceiveStock{}, Response: openapi.Message(), Errors: writeErrors},
+	{Method: http.MethodGet, Path: "/articles/:id/backorders", Id: "getArticleBackorders", Tag: "backorders",
+		Summary: "List an article's open backorders", Response: dtos.BackorderReport{}, Errors: readErrors},
+
+	{Method: http.MethodGet, Path: "/orders/:id", Id: "getOrder", Tag: "orders", Summary: "Get an order",
+		Parameters: []*openapi.Parameter{openapi.IfNoneMatch}, Response: dtos.Order{}, Errors: cachedErrors},
+	{Method: http.MethodPost, Path: "/orders", Id: "createOrder", Tag: "orders", Summary: "Create an order",
+		Parameters: []*openapi.Parameter{openapi.IdempotencyKey}, Request: dtos.Order{}, Response: openapi.Message("order_id"),
+		Errors: idempotentErrors},
+	{Method: http.MethodPost, Path: "/orders/import", Id: "importOrders", Tag: "orders", Summary: "Import orders from a file",
+		Upload: true, Response: dtos.OrderImportReport{}, Errors: writeErrors},
+	{Method: http.MethodDelete, Path: "/orders/:id", Id: "deleteOrder", Tag: "orders", Summary: "Delete an order",
+		Response: openapi.Message(), Errors: readErrors},
+	{Method: http.MethodPut, Path: "/orders/:id", Id: "updateOrder", Tag: "orders", Summary: "Replace an order",
+		Parameters: []*openapi.Parameter{openapi.IfMatch}, Request: dtos.Order{}, Response: openapi.Message(),
+		Errors: versionedErrors},
+	{Method: http.MethodPatch, Path: "/orders/:id", Id: "patchOrder", Tag: "orders", Summary: "Merge patch an order",
+		Parameters: []*openapi.Parameter{openapi.IfMatch}, Request: dtos.Order{}, Patch: true, Response: openapi.Message(),
+		Errors: patchErrors},
+	{Method: http.MethodPut, Path: "/orders/:id/status", Id: "updateOrderStatus", Tag: "orders", Summary: "Move an order to a status",
+		Request: dtos.UpdateOrderStatus{}, Response: openapi.Message(), Errors: validatedErrors},
+
+	{Method: http.MethodGet, Path: "/orders/:id/invoice", Id: "getOrderInvoice", Tag: "invoices", Summary: "Get an order's invoice",
+		Parameters: []*openapi.Parameter{invoiceFormat}, Response: dtos.Invoice{}, Files: invoiceFiles, Errors: writeErrors},
+	{Method: http.MethodGet, Path: "/orders/:id/credit-notes", Id: "getOrderCreditNotes", Tag: "invoices",
+		Summary: "List an order's credit notes", Response: []*dtos.Invoice{}, Errors: readErrors},
+	{Method: http.MethodGet, Path: "/invoices/:number", Id: "getInvoice", Tag: "invoices", Summary: "Get an invoice or credit note",
+		Parameters: []*openapi.Parameter{invoiceFormat}, Response: dtos.Invoice{}, Files: invoiceFiles, Errors: writeErrors},
+
+	{Method: http.MethodPost, Path: "/orders/:id/payments", Id: "recordPayment", Tag: "payments",
+		Summary: "Record a payment, or charge a pending one again by its id", Request: dtos.Payment{}, Response: openapi.Message("payment_id"),
+		Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError}},
+	{Method: http.MethodGet, Path: "/orders/:id/payments", Id: "getOrderPayments", Tag: "payments",
+		Summary: "Get an order's balance and payments", Response: dtos.OrderBalance{}, Errors: readErrors},
+	{Method: http.MethodPost, Path: "/orders/:id/refunds", Id: "settleRefunds", Tag: "payments",
+		Summary: "Send the order's pending refunds to the payment provider again", Response: openapi.Message(), Errors: readErrors},
+
+	{Method: http.MethodPost, Path: "/orders/:id/shipments", Id: "createShipment", Tag: "shipments", Summary: "Ship order items",
+		Request: dtos.Shipment{}, Response: openapi.Message("shipment_id"), Errors: writeErrors},
+	{Method: http.MethodGet, Path: "/orders/:id/shipments", Id: "getOrderShipments", Tag: "shipments",
+		Summary: "Get an order's fulfilment", Response: dtos.OrderFulfilment{}, Errors: readErrors},
+
+	{Method: http.MethodPost, Path: "/orders/:id/returns", Id: "createReturn", Tag: "returns", Summary: "Request a return",
+		Request: dtos.Return{}, Response: openapi.Message("return_id"), Errors: writeErrors},
+	{Method: http.MethodGet, Path: "/orders/:id/returns", Id: "getOrderReturns", Tag: "returns", Summary: "List an order's returns",
+		Response: []*dtos.Return{}, Errors: readErrors},
+	{Method: http.MethodGet, Path: "/returns/:id", Id: "getReturn", Tag: "returns", Summary: "Get a return",
+		Response: dtos.Return{}, Errors: readErrors},
+	{Method: http.MethodPut, Path: "/returns/:id/status", Id: "updateReturnStatus", Tag: "returns", Summary: "Move a return to a status",
+		Request: dtos.UpdateReturnStatus{}, Response: openapi.Message(), Errors: writeErrors},
+
+	{Method: http.MethodGet, Path: "/users/:id", Id: "getUser", Tag: "users", Summary: "Get a user",
+		Parameters: []*openapi.Parameter{openapi.IfNoneMatch}, Response: dtos.User{}, Errors: cachedErrors},
+	{Method: http.MethodPost, Path: "/users", Id: "createUser", Tag: "users", Summary: "Create a user",
+		Request: dtos.User{}, Response: openapi.Message(), Errors: createErrors},
+	{Method: http.MethodDelete, Path: "/users/:id", Id: "deleteUser", Tag: "users", Summary: "Delete a user",
+		Response: openapi.Message(), Errors: readErrors},
+	{Method: http.MethodPut, Path: "/users/:id", Id: "updateUser", Tag: "users", Summary: "Replace a user",
+		Parameters: []*openapi.Parameter{openapi.IfMatch}, Request: dtos.User{}, Response: openapi.Message(),
+		Errors: versionedErrors},
+	{Method: http.MethodPatch, Path: "/users/:id", Id: "patchUser", Tag: "users", Summary: "Merge patch a user",
+		Parameters: []*openapi.Parameter{openapi.IfMatch}, Request: dtos.User{}, Patch: true, Response: openapi.Message(),
+		Errors: patchErrors},
+
+	{Method: http.MethodGet, Path: "/price-lists/:id", Id: "getPriceList", Tag: "price-lists", Summary: "Get a price list",
+		Response: dtos.PriceList{}, Errors: readErrors},
+	{Method: http.MethodPost, Path: "/price-lists", Id: "createPriceList", Tag: "price-lists", Summary: "Create a price list",
+		Request: dtos.PriceList{}, Response: openapi.Message("price_list_id"), Errors: writeErrors},
+	{Method: http.MethodDelete, Path: "/price-lists/:id", Id: "deletePriceList", Tag: "price-lists", Summary: "Delete a price list",
+		Response: openapi.Message(), Errors: readErrors},
+	{Method: http.MethodPut, Path: "/price-lists/:id", Id: "updatePriceList", Tag: "price-lists", Summary: "Replace a price list",
+		Request: dtos.PriceList{}, Response: openapi.Message(), Errors: writeErrors},
+
+	{Method: http.MethodGet, Path: "/coupons/:code", Id: "getCoupon", Tag: "coupons", Summary: "Get a coupon",
+		Response: dtos.Coupon{}, Errors: readErrors},
+	{Method: http.MethodPost, Path: "/coupons", Id: "createCoupon", Tag: "coupons", Summary: "Create a coupon",
+		Request: dtos.Coupon{}, Response: openapi.Message("code"), Errors: writeErrors},
+	{Method: http.MethodDelete, Path: "/coupons/:code", Id: "deleteCoupon", Tag: "coupons", Summary: "Delete a coupon",
+		Response: openapi.Message(), Errors: readErrors},
+	{Method: http.MethodPut, Path: "/coupons/:code", Id: "updateCoupon", Tag: "coupons", Summary: "Replace a coupon",
+		Request: dtos.Coupon{}, Response: openapi.Message(), Errors: writeErrors},
+
+	{Method: http.MethodGet, Path: "/tax-rules/:id", Id: "getTaxRule", Tag: "tax-rules", Summary: "Get a tax rule",
+		Response: dtos.TaxRule{}, Errors: readErrors},
+	{Method: http.MethodPost, Path: "/tax-rules", Id: "createTaxRule", Tag: "tax-rules", Summary: "Create a tax rule",
+		Request: dtos.TaxRule{}, Response: openapi.Message("tax_rule_id"), Errors: writeErrors},
+	{Method: http.MethodDelete, Path: "/tax-rules/:id", Id: "deleteTaxRule", Tag: "tax-rules", Summary: "Delete a tax rule",
+		Response: openapi.Message(), Errors: readErrors},
+	{Method: http.MethodPut, Path: "/tax-rules/:id", Id: "updateTaxRule", Tag: "tax-rules", Summary: "Replace a tax rule",
+		Request: dtos.TaxRule{}, Response: openapi.Message(), Errors: writeErrors},
+
+	{Method: http.MethodGet, Path: "/healthz", Id: "healthz", Tag: "operations", Summary: "Check the process is up",
+		Response: &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{"status": {Type: "string"}}}},
+	{Method: http.MethodGet, Path: "/readyz", Id: "readyz", Tag: "operations", Summary: "Check the app can serve traffic",
+		Response: dtos.Readiness{}, Errors: []int{http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/version", Id: "version", Tag: "operations", Summary: "Get the build",
+		Response: dtos.Version{}},
+	{Method: http.MethodGet, Path: "/metrics", Id: "metrics", Tag: "operations", Summary: "Prometheus metrics",
+		Files: []string{"text/plain"}},
+	{Method: http.MethodGet, Path: "/openapi.json", Id: "openapi", Tag: "operations", Summary: "This document",
+		Response: &openapi.Schema{Type: "object"}},
+	{Method: http.MethodGet, Path: "/docs", Id: "docs", Tag: "operations", Summary: "Interactive docs for this document",
+		Files: []string{"text/html"}},
+	{Method: http.MethodGet, Path: "/docs/:file", Id: "docsFile", Tag: "operations", Summary: "A Swagger UI file the docs load",
+		Files: []string{"text/css", "text/javascript"}, Errors: []int{http.StatusNotFound}},
+}
+
+// apiDocument describes the API the way Router sets it up.
+func apiDocument(config *config.Config) *openapi.Document {
+	return openapi.Build(openapi.Info{
+		Title:   config.AppName,
+		Version: "1.0.0",
+		Description: "Errors are sent as a JSON string with the message. The API has no authentication of its " +
+			"own and is meant to be run behind a gateway that authenticates callers.",
+	}, apiTags, apiRoutes)
+}
@@ -0,0 +1,74 @@
+package routes
+
+import (
+	"encoding/json"
+	"inventory-management/config"
+	"inventory-management/database"
+	"inventory-management/openapi"
+	"inventory-management/services/payments"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestDocumentMatchesRoutes fails when a route is registered without being
+// described in the OpenAPI document, or described without being registered.
+func TestDocumentMatchesRoutes(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+
+	config := config.Default()
+	config.DbDriver = database.DriverSQLite
+	config.DbUrl = ":memory:"
+
+	r := gin.New()
+	_, err = Router(r, db, config, payments.NewFakeProvider())
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var document openapi.Document
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &document))
+	assert.Equal(t, openapi.Version, document.OpenAPI)
+
+	registered := make(map[string]bool)
+	for _, v := range r.Routes() {
+		key := v.Method + " " + openapi.Path(v.Path)
+		registered[key] = true
+
+		operation := document.Paths[openapi.Path(v.Path)][strings.ToLower(v.Method)]
+		assert.NotNil(t, operation, "%s is not in the OpenAPI document", key)
+	}
+
+	for path, item := range document.Paths {
+		for method := range item {
+			key := strings.ToUpper(method) + " " + path
+			assert.True(t, registered[key], "%s is documented but not registered", key)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "/openapi.json")
+	assert.NotContains(t, w.Body.String(), "https://", "the docs load nothing from elsewhere")
+
+	for _, v := range []string{"/docs/swagger-ui.css", "/docs/swagger-ui-bundle.js"} {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, v, nil))
+		assert.Equal(t, http.StatusOK, w.Code, v)
+		assert.NotEmpty(t, w.Body.Bytes(), v)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/missing.js", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
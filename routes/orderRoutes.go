@@ -1,23 +1,58 @@
 package routes
 
 import (
+	"inventory-management/config"
 	"inventory-management/handlers"
+	"inventory-management/money"
 	"inventory-management/repository"
+	"inventory-management/services/backorders"
+	"inventory-management/services/coupons"
+	"inventory-management/services/invoices"
 	"inventory-management/services/orders"
+	"inventory-management/services/payments"
+	"inventory-management/services/pricing"
+	"inventory-management/services/taxes"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func OrderRoutes(r *gin.Engine, db *gorm.DB) {
+func OrderRoutes(r *gin.Engine, db *gorm.DB, config *config.Config, provider payments.Provider, idempotency gin.HandlerFunc) {
 	orderRepo := repository.NewOrderRepo(db)
 	orderItemRepo := repository.NewOrderItemRepo(db)
+	orderDiscountRepo := repository.NewOrderDiscountRepo(db)
+	couponRepo := repository.NewCouponRepo(db)
+	articleRepo := repository.NewArticleRepo(db)
+	articlePriceRepo := repository.NewArticlePriceRepo(db)
+	priceListRepo := repository.NewPriceListRepo(db)
+	priceListItemRepo := repository.NewPriceListItemRepo(db)
+	userRepo := repository.NewUserRepo(db)
+	addressRepo := repository.NewAddressRepo(db)
+	taxRuleRepo := repository.NewTaxRuleRepo(db)
+	invoiceRepo := repository.NewInvoiceRepo(db)
+	backorderRepo := repository.NewBackorderRepo(db)
+	txManager := repository.NewTxManager(db)
 
-	orderService := orders.NewOrderService(orderRepo, orderItemRepo)
+	rates := money.NewRates(config.BaseCurrency, config.ExchangeRates)
+	pricingService := pricing.NewPricingService(articleRepo, articlePriceRepo, priceListRepo, priceListItemRepo, userRepo, rates)
+	couponService := coupons.NewCouponService(couponRepo, rates)
+	taxService := taxes.NewTaxService(taxRuleRepo, addressRepo, userRepo, articleRepo)
+	invoiceService := invoices.NewInvoiceService(invoiceRepo, userRepo, addressRepo, articleRepo, config.SellerId)
+	backorderService := backorders.NewBackorderService(articleRepo, backorderRepo, userRepo, txManager)
+	orderService := orders.NewOrderService(orderRepo, orderItemRepo, orderDiscountRepo, txManager, pricingService, couponService,
+		taxService, invoiceService, backorderService, newPaymentService(db, provider))
 	orderHandler := handlers.NewOrderHandler(orderService)
+	orderImportHandler := handlers.NewOrderImportHandler(orders.NewOrderImportService(orderService, articleRepo, userRepo))
+	invoiceHandler := handlers.NewInvoiceHandler(invoiceService)
 
 	r.GET("/orders/:id", orderHandler.GetOrder)
-	r.POST("/orders", orderHandler.CreateOrder)
+	r.POST("/orders", idempotency, orderHandler.CreateOrder)
+	r.POST("/orders/import", orderImportHandler.ImportOrders)
 	r.DELETE("/orders/:id", orderHandler.DeleteOrder)
 	r.PUT("/orders/:id", orderHandler.UpdateOrder)
+	r.PATCH("/orders/:id", orderHandler.PatchOrder)
+	r.PUT("/orders/:id/status", orderHandler.UpdateOrderStatus)
+	r.GET("/orders/:id/invoice", invoiceHandler.GetOrderInvoice)
+	r.GET("/orders/:id/credit-notes", invoiceHandler.GetOrderCreditNotes)
+	r.GET("/invoices/:number", invoiceHandler.GetInvoice)
 }
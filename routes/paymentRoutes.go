@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"inventory-management/handlers"
+	"inventory-management/repository"
+	"inventory-management/services/payments"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func PaymentRoutes(r *gin.Engine, db *gorm.DB, provider payments.Provider) {
+	paymentService := newPaymentService(db, provider)
+	paymentHandler := handlers.NewPaymentHandler(paymentService)
+
+	r.POST("/orders/:id/payments", paymentHandler.RecordPayment)
+	r.GET("/orders/:id/payments", paymentHandler.GetOrderPayments)
+	r.POST("/orders/:id/refunds", paymentHandler.SettleRefunds)
+}
+
+func newPaymentService(db *gorm.DB, provider payments.Provider) payments.PaymentService {
+	return payments.NewPaymentService(repository.NewOrderRepo(db), repository.NewPaymentRepo(db), repository.NewInvoiceRepo(db),
+		repository.NewTxManager(db), provider)
+}
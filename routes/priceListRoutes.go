@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"inventory-management/handlers"
+	"inventory-management/repository"
+	"inventory-management/services/pricelists"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func PriceListRoutes(r *gin.Engine, db *gorm.DB) {
+	priceListRepo := repository.NewPriceListRepo(db)
+	priceListItemRepo := repository.NewPriceListItemRepo(db)
+
+	priceListService := pricelists.NewPriceListService(priceListRepo, priceListItemRepo)
+	priceListHandler := handlers.NewPriceListHandler(priceListService)
+
+	r.GET("/price-lists/:id", priceListHandler.GetPriceList)
+	r.POST("/price-lists", priceListHandler.CreatePriceList)
+	r.DELETE("/price-lists/:id", priceListHandler.DeletePriceList)
+	r.PUT("/price-lists/:id", priceListHandler.UpdatePriceList)
+}
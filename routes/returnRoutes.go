@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"inventory-management/config"
+	"inventory-management/handlers"
+	"inventory-management/repository"
+	"inventory-management/services/backorders"
+	"inventory-management/services/invoices"
+	"inventory-management/services/payments"
+	"inventory-management/services/returns"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func ReturnRoutes(r *gin.Engine, db *gorm.DB, config *config.Config, provider payments.Provider) {
+	returnRepo := repository.NewReturnRepo(db)
+	returnItemRepo := repository.NewReturnItemRepo(db)
+	invoiceRepo := repository.NewInvoiceRepo(db)
+	userRepo := repository.NewUserRepo(db)
+	addressRepo := repository.NewAddressRepo(db)
+	articleRepo := repository.NewArticleRepo(db)
+	txManager := repository.NewTxManager(db)
+
+	invoiceService := invoices.NewInvoiceService(invoiceRepo, userRepo, addressRepo, articleRepo, config.SellerId)
+	backorderService := backorders.NewBackorderService(articleRepo, repository.NewBackorderRepo(db), userRepo, txManager)
+	returnService := returns.NewReturnService(returnRepo, returnItemRepo, txManager, invoiceService,
+		newPaymentService(db, provider), backorderService)
+	returnHandler := handlers.NewReturnHandler(returnService)
+
+	r.POST("/orders/:id/returns", returnHandler.CreateReturn)
+	r.GET("/orders/:id/returns", returnHandler.GetOrderReturns)
+	r.GET("/returns/:id", returnHandler.GetReturn)
+	r.PUT("/returns/:id/status", returnHandler.UpdateReturnStatus)
+}
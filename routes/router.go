@@ -1,12 +1,86 @@
 package routes
 
 import (
+	"inventory-management/config"
+	"inventory-management/middlewares"
+	"inventory-management/repository"
+	"inventory-management/services/health"
+	"inventory-management/services/payments"
+	"log/slog"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func Router(r *gin.Engine, db *gorm.DB) {
-	ArticleRoutes(r, db)
-	OrderRoutes(r, db)
+// Lifecycle is what main needs to stop the app: the health service to fail
+// readiness with while draining, and the workers to stop.
+type Lifecycle struct {
+	Health  health.HealthService
+	Workers []health.Worker
+}
+
+// bulkRoutes read or write whole files and get the longer bulk_timeout.
+var bulkRoutes = []string{
+	"POST /articles/import",
+	"GET /articles/export",
+	"POST /orders/import",
+}
+
+// Router registers every route and returns the services to shut down.
+func Router(r *gin.Engine, db *gorm.DB, config *config.Config, provider payments.Provider) (*Lifecycle, error) {
+	ttl, err := time.ParseDuration(config.IdempotencyTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	requestTimeout, err := time.ParseDuration(config.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	bulkTimeout, err := time.ParseDuration(config.BulkTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]time.Duration)
+	for _, v := range bulkRoutes {
+		overrides[v] = bulkTimeout
+	}
+
+	idempotency := middlewares.Idempotency(repository.NewIdempotencyKeyRepo(db), ttl)
+
+	logger := slog.Default()
+	r.Use(middlewares.RequestId(), middlewares.Tracing(), middlewares.Logger(logger), middlewares.Recovery(logger), middlewares.Metrics(),
+		middlewares.Deadline(requestTimeout, overrides))
+
+	catalogService := ArticleRoutes(r, db, config, idempotency)
+	OrderRoutes(r, db, config, provider, idempotency)
 	UserRoutes(r, db)
+	PriceListRoutes(r, db)
+	CouponRoutes(r, db, config)
+	TaxRuleRoutes(r, db)
+	ReturnRoutes(r, db, config, provider)
+	ShipmentRoutes(r, db, provider)
+	BackorderRoutes(r, db)
+	PaymentRoutes(r, db, provider)
+
+	workers := []health.Worker{catalogService}
+	healthService := HealthRoutes(r, db, config, workers)
+
+	err = MetricsRoutes(r, db, config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = DocsRoutes(r, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Lifecycle{
+		Health:  healthService,
+		Workers: workers,
+	}, nil
 }
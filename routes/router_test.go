@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"inventory-management/config"
+	"inventory-management/logging"
+	"inventory-management/middlewares"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestRequestIdReachesLogs follows a request id from the X-Request-ID header
+// through the handler and service into the service's own log line and the
+// repository's failed query.
+func TestRequestIdReachesLogs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, "debug", logging.FormatJSON)
+
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	// No migrations are run, so reading an article fails in the repository.
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logging.NewGormLogger(logger, 0)})
+	assert.NoError(t, err)
+
+	r := gin.New()
+	r.Use(middlewares.RequestId())
+	ArticleRoutes(r, db, config.Default(), func(ctx *gin.Context) {})
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/a1", nil)
+	req.Header.Set(middlewares.RequestIdHeader, "req-47")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "req-47", w.Header().Get(middlewares.RequestIdHeader))
+
+	messages := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+
+		message, _ := record["msg"].(string)
+		requestId, _ := record["request_id"].(string)
+		messages[message] = requestId
+	}
+
+	assert.Equal(t, "req-47", messages["query failed"])
+	assert.Equal(t, "req-47", messages["unable to get article"])
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"inventory-management/handlers"
+	"inventory-management/repository"
+	"inventory-management/services/payments"
+	"inventory-management/services/shipments"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func ShipmentRoutes(r *gin.Engine, db *gorm.DB, provider payments.Provider) {
+	orderRepo := repository.NewOrderRepo(db)
+	orderItemRepo := repository.NewOrderItemRepo(db)
+	shipmentRepo := repository.NewShipmentRepo(db)
+	shipmentItemRepo := repository.NewShipmentItemRepo(db)
+
+	shipmentService := shipments.NewShipmentService(orderRepo, orderItemRepo, shipmentRepo, shipmentItemRepo, repository.NewTxManager(db),
+		newPaymentService(db, provider))
+	shipmentHandler := handlers.NewShipmentHandler(shipmentService)
+
+	r.POST("/orders/:id/shipments", shipmentHandler.CreateShipment)
+	r.GET("/orders/:id/shipments", shipmentHandler.GetOrderShipments)
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"inventory-management/handlers"
+	"inventory-management/repository"
+	"inventory-management/services/taxes"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func TaxRuleRoutes(r *gin.Engine, db *gorm.DB) {
+	taxRuleRepo := repository.NewTaxRuleRepo(db)
+	addressRepo := repository.NewAddressRepo(db)
+	userRepo := repository.NewUserRepo(db)
+	articleRepo := repository.NewArticleRepo(db)
+
+	taxService := taxes.NewTaxService(taxRuleRepo, addressRepo, userRepo, articleRepo)
+	taxRuleHandler := handlers.NewTaxRuleHandler(taxService)
+
+	r.GET("/tax-rules/:id", taxRuleHandler.GetTaxRule)
+	r.POST("/tax-rules", taxRuleHandler.CreateTaxRule)
+	r.DELETE("/tax-rules/:id", taxRuleHandler.DeleteTaxRule)
+	r.PUT("/tax-rules/:id", taxRuleHandler.UpdateTaxRule)
+}
@@ -13,11 +13,12 @@ func UserRoutes(r *gin.Engine, db *gorm.DB) {
 	userRepo := repository.NewUserRepo(db)
 	addressRepo := repository.NewAddressRepo(db)
 
-	userService := users.NewUserService(userRepo, addressRepo)
+	userService := users.NewUserService(userRepo, addressRepo, repository.NewTxManager(db))
 	userHandler := handlers.NewUserHandler(userService)
 
 	r.GET("/users/:id", userHandler.GetUser)
 	r.POST("/users", userHandler.CreateUser)
 	r.DELETE("/users/:id", userHandler.DeleteUser)
 	r.PUT("/users/:id", userHandler.UpdateUser)
+	r.PATCH("/users/:id", userHandler.PatchUser)
 }
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"inventory-management/routes"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// serve runs server on listener until ctx is done, then fails readiness for
+// delay so load balancers stop sending traffic, and shuts down within timeout:
+// it stops accepting connections and waits for the requests in flight, stops
+// the workers and closes the database pool last, as the others may still be
+// writing to it.
+func serve(ctx context.Context, server *http.Server, listener net.Listener, lifecycle *routes.Lifecycle, db *gorm.DB,
+	delay time.Duration, timeout time.Duration) error {
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down", "delay", delay, "timeout", timeout)
+
+	lifecycle.Health.Drain()
+	time.Sleep(delay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var errs []error
+
+	err := server.Shutdown(ctx)
+	if err != nil {
+		errs = append(errs, err)
+		server.Close()
+	}
+
+	for _, v := range lifecycle.Workers {
+		err = v.Shutdown(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	sqlDB, err := db.DB()
+	if err == nil {
+		err = sqlDB.Close()
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	err = <-served
+	if !errors.Is(err, http.ErrServerClosed) {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/database"
+	"inventory-management/routes"
+	"inventory-management/services/health"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeWorker struct {
+	stopped bool
+}
+
+func (f *fakeWorker) Running() bool {
+	return !f.stopped
+}
+
+func (f *fakeWorker) Shutdown(ctx context.Context) error {
+	f.stopped = true
+	return nil
+}
+
+func TestServeDrainsRequests(t *testing.T) {
+	db, err := database.Open(database.DriverSQLite, ":memory:")
+	assert.NoError(t, err)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	})}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	ctx, stop := context.WithCancel(context.Background())
+	worker := &fakeWorker{}
+	lifecycle := &routes.Lifecycle{
+		Health:  health.NewHealthService(db, []health.Worker{worker}, "inventory-management"),
+		Workers: []health.Worker{worker},
+	}
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(ctx, server, listener, lifecycle, db, 0, 5*time.Second)
+	}()
+
+	responses := make(chan int, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			responses <- 0
+			return
+		}
+		resp.Body.Close()
+		responses <- resp.StatusCode
+	}()
+
+	<-started
+	stop()
+
+	// New connections are refused while the request in flight finishes.
+	assert.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err != nil {
+			return true
+		}
+
+		// Shutdown waits on a connection that never sent a request.
+		conn.Close()
+		return false
+	}, time.Second, 10*time.Millisecond)
+
+	close(release)
+	assert.Equal(t, http.StatusCreated, <-responses)
+	assert.NoError(t, <-served)
+	assert.True(t, worker.stopped)
+	assert.Equal(t, constants.ErrorShuttingDown.Error(), lifecycle.Health.Ready(context.Background()).Checks["shutdown"])
+
+	sqlDB, _ := db.DB()
+	assert.Error(t, sqlDB.Ping())
+}
+
+func TestServeTimesOut(t *testing.T) {
+	db, err := database.Open(database.DriverSQLite, ":memory:")
+	assert.NoError(t, err)
+
+	started := make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	})}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	ctx, stop := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(ctx, server, listener, &routes.Lifecycle{Health: health.NewHealthService(db, nil, "")}, db, 0,
+			50*time.Millisecond)
+	}()
+
+	go http.Get("http://" + listener.Addr().String())
+
+	<-started
+	stop()
+
+	assert.ErrorIs(t, <-served, context.DeadlineExceeded)
+}
@@ -1,76 +1,165 @@
 package articles
 
 import (
+	"context"
+	"inventory-management/constants"
 	"inventory-management/dtos"
 	"inventory-management/models"
+	"inventory-management/money"
 	"inventory-management/repository"
-	"log"
+	"inventory-management/services/backorders"
+	"inventory-management/tracing"
+	"log/slog"
+	"strings"
 )
 
 type ArticleService interface {
-	CreateArticle(req *dtos.Article) error
-	UpdateArticle(id string, req *dtos.Article) error
-	GetArticle(articleId string) (*dtos.Article, error)
-	ListArticle() ([]*dtos.Article, error)
-	DeleteArticle(articleId string) error
-	UpdateArticleStock(articleId string, req *dtos.UpdateStock) error
+	CreateArticle(ctx context.Context, req *dtos.Article) error
+	UpdateArticle(ctx context.Context, id string, req *dtos.Article) error
+	GetArticle(ctx context.Context, articleId string) (*dtos.Article, error)
+	ListArticle(ctx context.Context) ([]*dtos.Article, error)
+	DeleteArticle(ctx context.Context, articleId string) error
+	UpdateArticleStock(ctx context.Context, articleId string, req *dtos.UpdateStock) error
 }
 
 type articleService struct {
-	articleRepo repository.ArticleRepo
+	articleRepo      repository.ArticleRepo
+	articlePriceRepo repository.ArticlePriceRepo
+	txManager        repository.TxManager
+	backorderService backorders.BackorderService
+	baseCurrency     string
 }
 
-func NewArticleService(articleRepo repository.ArticleRepo) ArticleService {
+func NewArticleService(articleRepo repository.ArticleRepo, articlePriceRepo repository.ArticlePriceRepo, txManager repository.TxManager,
+	backorderService backorders.BackorderService, baseCurrency string) ArticleService {
 	return &articleService{
-		articleRepo: articleRepo,
+		articleRepo:      articleRepo,
+		articlePriceRepo: articlePriceRepo,
+		txManager:        txManager,
+		backorderService: backorderService,
+		baseCurrency:     money.NormalizeCurrency(baseCurrency),
 	}
 }
 
-func (a *articleService) CreateArticle(req *dtos.Article) error {
-	model := ArticleDtosToModel(req)
+func (a *articleService) CreateArticle(ctx context.Context, req *dtos.Article) error {
+	ctx, span := tracing.Start(ctx, "articleService.CreateArticle")
+	defer span.End()
 
-	err := a.articleRepo.Create(model)
+	err := a.normalizePrices(req)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	model := ArticleDtosToModel(req)
+	model.Version = 1
+
+	return a.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		err := repos.Articles.Create(ctx, model)
+		if err != nil {
+			return err
+		}
+
+		prices := ArticlePricesDtosToModel(req)
+		if len(prices) > 0 {
+			err = repos.ArticlePrices.Upsert(ctx, prices...)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
-func (a *articleService) UpdateArticle(id string, req *dtos.Article) error {
-	model := ArticleDtosToModel(req)
+func (a *articleService) UpdateArticle(ctx context.Context, id string, req *dtos.Article) error {
+	ctx, span := tracing.Start(ctx, "articleService.UpdateArticle")
+	defer span.End()
 
-	err := a.articleRepo.Update(id, model)
+	err := a.normalizePrices(req)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	model := ArticleDtosToModel(req)
+
+	req.ArticleId = id
+	prices := ArticlePricesDtosToModel(req)
+
+	return a.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		err := repos.Articles.Update(ctx, id, model)
+		if err != nil {
+			return err
+		}
+
+		err = repos.ArticlePrices.DeleteByArticle(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(prices) > 0 {
+			err = repos.ArticlePrices.Upsert(ctx, prices...)
+			if err != nil {
+				return err
+			}
+		}
+
+		// The stock is written as sent, so whatever it went up by goes to
+		// the article's open backorders, as it does for a stock update.
+		return a.backorderService.AllocateStock(ctx, repos, id)
+	})
 }
 
-func (a *articleService) GetArticle(articleId string) (*dtos.Article, error) {
-	article, err := a.articleRepo.Get(articleId)
+func (a *articleService) GetArticle(ctx context.Context, articleId string) (*dtos.Article, error) {
+	ctx, span := tracing.Start(ctx, "articleService.GetArticle")
+	defer span.End()
+
+	article, err := a.articleRepo.Get(ctx, articleId)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get article", "article_id", articleId, "error", err)
+		return nil, err
+	}
+
+	prices, err := a.articlePriceRepo.GetByArticles(ctx, articleId)
 	if err != nil {
-		log.Println("unable to get article")
 		return nil, err
 	}
 
 	result := ArticleModelToDtos(article)
+	attachPrices(result, prices)
 
 	return result[0], nil
 }
 
-func (a *articleService) ListArticle() ([]*dtos.Article, error) {
-	articles, err := a.articleRepo.GetAll()
+func (a *articleService) ListArticle(ctx context.Context) ([]*dtos.Article, error) {
+	ctx, span := tracing.Start(ctx, "articleService.ListArticle")
+	defer span.End()
+
+	articles, err := a.articleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var articleIds []string
+	for _, v := range articles {
+		articleIds = append(articleIds, v.ArticleId)
+	}
+
+	prices, err := a.articlePriceRepo.GetByArticles(ctx, articleIds...)
 	if err != nil {
 		return nil, err
 	}
 
-	return ArticleModelToDtos(articles...), nil
+	result := ArticleModelToDtos(articles...)
+	attachPrices(result, prices)
+
+	return result, nil
 }
 
-func (a *articleService) DeleteArticle(articleId string) error {
-	err := a.articleRepo.Delete(articleId)
+func (a *articleService) DeleteArticle(ctx context.Context, articleId string) error {
+	ctx, span := tracing.Start(ctx, "articleService.DeleteArticle")
+	defer span.End()
+
+	err := a.articleRepo.Delete(ctx, articleId)
 	if err != nil {
 		return err
 	}
@@ -78,24 +167,68 @@ func (a *articleService) DeleteArticle(articleId string) error {
 	return nil
 }
 
-func (a *articleService) UpdateArticleStock(articleId string, req *dtos.UpdateStock) error {
-	err := a.articleRepo.UpdateArticleStock(articleId, req.NewStock)
-	if err != nil {
-		return err
+// UpdateArticleStock sets the article's stock and allocates it to the
+// article's open backorders, oldest order first.
+func (a *articleService) UpdateArticleStock(ctx context.Context, articleId string, req *dtos.UpdateStock) error {
+	ctx, span := tracing.Start(ctx, "articleService.UpdateArticleStock")
+	defer span.End()
+
+	return a.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		err := repos.Articles.UpdateArticleStock(ctx, articleId, req.Version, req.NewStock)
+		if err != nil {
+			return err
+		}
+
+		return a.backorderService.AllocateStock(ctx, repos, articleId)
+	})
+}
+
+// normalizePrices defaults the base price to the configured base currency and
+// makes sure every price list entry names a valid, distinct currency.
+func (a *articleService) normalizePrices(req *dtos.Article) error {
+	if req.Price.Currency == "" {
+		req.Price.Currency = a.baseCurrency
+	}
+
+	if !money.ValidCurrency(req.Price.Currency) {
+		return constants.ErrorInvalidCurrency
+	}
+
+	seen := make(map[string]struct{})
+	for _, v := range req.Prices {
+		if _, exists := seen[v.Currency]; exists || !money.ValidCurrency(v.Currency) {
+			return constants.ErrorInvalidCurrency
+		}
+
+		seen[v.Currency] = struct{}{}
 	}
 
 	return nil
 }
 
+func attachPrices(articles []*dtos.Article, prices []*models.ArticlePrice) {
+	byArticle := make(map[string][]money.Money)
+	for _, v := range prices {
+		byArticle[v.ArticleId] = append(byArticle[v.ArticleId], v.Money())
+	}
+
+	for _, v := range articles {
+		v.Prices = byArticle[v.ArticleId]
+	}
+}
+
 func ArticleModelToDtos(m ...*models.Article) []*dtos.Article {
 	var a []*dtos.Article
 
 	for _, v := range m {
 		a = append(a, &dtos.Article{
-			ArticleId:   v.ArticleId,
-			ArticleName: v.ArticleName,
-			Price:       v.Price,
-			Stock:       v.Stock,
+			ArticleId:    v.ArticleId,
+			ArticleName:  v.ArticleName,
+			Price:        v.Price,
+			Stock:        v.Stock,
+			DamagedStock: v.DamagedStock,
+			TaxClass:     v.TaxClass,
+			Version:      v.Version,
 		})
 	}
 
@@ -106,7 +239,24 @@ func ArticleDtosToModel(m *dtos.Article) *models.Article {
 	return &models.Article{
 		ArticleId:   m.ArticleId,
 		ArticleName: m.ArticleName,
-		Price:       m.Price,
+		Price:       m.Price.Round(),
 		Stock:       m.Stock,
+		TaxClass:    strings.ToLower(strings.TrimSpace(m.TaxClass)),
+		Version:     m.Version,
 	}
 }
+
+func ArticlePricesDtosToModel(m *dtos.Article) []*models.ArticlePrice {
+	var prices []*models.ArticlePrice
+
+	for _, v := range m.Prices {
+		price := v.Round()
+		prices = append(prices, &models.ArticlePrice{
+			ArticleId: m.ArticleId,
+			Currency:  price.Currency,
+			Amount:    price.Amount,
+		})
+	}
+
+	return prices
+}
@@ -1,23 +1,31 @@
 package articles
 
 import (
+	"context"
 	"errors"
 	"inventory-management/constants"
 	"inventory-management/dtos"
 	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
 	"inventory-management/repository/mocks"
+	serviceMocks "inventory-management/services/mocks"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
 type articleServiceTestSuite struct {
 	suite.Suite
-	mockCtrl        *gomock.Controller
-	mockArticleRepo *mocks.MockArticleRepo
-	articleService  ArticleService
+	mockCtrl             *gomock.Controller
+	mockArticleRepo      *mocks.MockArticleRepo
+	mockArticlePriceRepo *mocks.MockArticlePriceRepo
+	mockTxManager        *mocks.MockTxManager
+	mockBackorderService *serviceMocks.MockBackorderService
+	articleService       ArticleService
 }
 
 func TestArticleTestSuite(t *testing.T) {
@@ -28,28 +36,41 @@ func (suite *articleServiceTestSuite) SetupTest() {
 	suite.mockCtrl = gomock.NewController(suite.T())
 
 	suite.mockArticleRepo = mocks.NewMockArticleRepo(suite.mockCtrl)
+	suite.mockArticlePriceRepo = mocks.NewMockArticlePriceRepo(suite.mockCtrl)
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
+	suite.mockBackorderService = serviceMocks.NewMockBackorderService(suite.mockCtrl)
 
-	suite.articleService = NewArticleService(suite.mockArticleRepo)
+	repos := &repository.Repos{
+		Articles:      suite.mockArticleRepo,
+		ArticlePrices: suite.mockArticlePriceRepo,
+	}
+	suite.mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(repos *repository.Repos) error) error {
+			return fn(repos)
+		}).AnyTimes()
+
+	suite.articleService = NewArticleService(suite.mockArticleRepo, suite.mockArticlePriceRepo, suite.mockTxManager, suite.mockBackorderService, "INR")
 }
 
 func (suite *articleServiceTestSuite) TestCreateArticle() {
 	req := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
 	model := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
+		Version:     1,
 	}
 
-	suite.mockArticleRepo.EXPECT().Create(model).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Create(gomock.Any(), model).Return(nil).Times(1)
 
-	err := suite.articleService.CreateArticle(req)
+	err := suite.articleService.CreateArticle(context.Background(), req)
 	assert.NoError(suite.T(), err)
 }
 
@@ -57,20 +78,21 @@ func (suite *articleServiceTestSuite) TestCreateArticleRepoError() {
 	req := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
 	model := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
+		Version:     1,
 	}
 
-	suite.mockArticleRepo.EXPECT().Create(model).Return(errors.New("repo error")).Times(1)
+	suite.mockArticleRepo.EXPECT().Create(gomock.Any(), model).Return(errors.New("repo error")).Times(1)
 
-	err := suite.articleService.CreateArticle(req)
+	err := suite.articleService.CreateArticle(context.Background(), req)
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "repo error", err.Error())
 }
@@ -79,20 +101,21 @@ func (suite *articleServiceTestSuite) TestGetArticle() {
 	expected := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
 	mockArticle := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
-	suite.mockArticleRepo.EXPECT().Get("123").Return(mockArticle, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "123").Return(mockArticle, nil).Times(1)
+	suite.mockArticlePriceRepo.EXPECT().GetByArticles(gomock.Any(), "123").Return(nil, nil).Times(1)
 
-	result, err := suite.articleService.GetArticle("123")
+	result, err := suite.articleService.GetArticle(context.Background(), "123")
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expected, result)
 }
@@ -100,9 +123,9 @@ func (suite *articleServiceTestSuite) TestGetArticle() {
 func (suite *articleServiceTestSuite) TestGetArticleError() {
 	expectedError := constants.ErrorNotFound
 
-	suite.mockArticleRepo.EXPECT().Get("123").Return(nil, expectedError).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "123").Return(nil, expectedError).Times(1)
 
-	result, err := suite.articleService.GetArticle("123")
+	result, err := suite.articleService.GetArticle(context.Background(), "123")
 
 	assert.Nil(suite.T(), result)
 	assert.Error(suite.T(), err)
@@ -113,55 +136,165 @@ func (suite *articleServiceTestSuite) TestUpdateArticle() {
 	req := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
+		Version:     3,
 	}
 
 	model := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
+		Version:     3,
 	}
 
-	suite.mockArticleRepo.EXPECT().Update("123", model).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Update(gomock.Any(), "123", model).Return(nil).Times(1)
+	suite.mockArticlePriceRepo.EXPECT().DeleteByArticle(gomock.Any(), "123").Return(nil).Times(1)
+	suite.mockBackorderService.EXPECT().AllocateStock(gomock.Any(), gomock.Any(), "123").Return(nil).Times(1)
 
-	err := suite.articleService.UpdateArticle("123", req)
+	err := suite.articleService.UpdateArticle(context.Background(), "123", req)
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *articleServiceTestSuite) TestUpdateArticleAllocateStockError() {
+	req := &dtos.Article{
+		ArticleName: "Test Article",
+		Price:       money.MustParse("100", "INR"),
+		Stock:       50,
+		Version:     3,
+	}
+
+	suite.mockArticleRepo.EXPECT().Update(gomock.Any(), "123", gomock.Any()).Return(nil).Times(1)
+	suite.mockArticlePriceRepo.EXPECT().DeleteByArticle(gomock.Any(), "123").Return(nil).Times(1)
+	suite.mockBackorderService.EXPECT().AllocateStock(gomock.Any(), gomock.Any(), "123").Return(errors.New("allocation failed")).Times(1)
+
+	err := suite.articleService.UpdateArticle(context.Background(), "123", req)
+	assert.EqualError(suite.T(), err, "allocation failed")
+}
+
 func (suite *articleServiceTestSuite) TestUpdateArticleError() {
 	req := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
+		Version:     3,
 	}
 
 	model := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
+		Version:     3,
 	}
 
-	suite.mockArticleRepo.EXPECT().Update("123", model).Return(constants.ErrorNotFound).Times(1)
+	suite.mockArticleRepo.EXPECT().Update(gomock.Any(), "123", model).Return(constants.ErrorNotFound).Times(1)
 
-	err := suite.articleService.UpdateArticle("123", req)
+	err := suite.articleService.UpdateArticle(context.Background(), "123", req)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *articleServiceTestSuite) TestCreateArticleWithPrices() {
+	req := &dtos.Article{
+		ArticleId:   "123",
+		ArticleName: "Test Article",
+		Price:       money.Money{Amount: decimal.NewFromInt(100)},
+		Prices:      []money.Money{money.MustParse("1.25", "usd")},
+		Stock:       50,
+	}
+
+	model := &models.Article{
+		ArticleId:   "123",
+		ArticleName: "Test Article",
+		Price:       money.MustParse("100", "INR"),
+		Stock:       50,
+		Version:     1,
+	}
+
+	price := &models.ArticlePrice{
+		ArticleId: "123",
+		Currency:  "USD",
+		Amount:    money.MustParse("1.25", "USD").Amount,
+	}
+
+	suite.mockArticleRepo.EXPECT().Create(gomock.Any(), model).Return(nil).Times(1)
+	suite.mockArticlePriceRepo.EXPECT().Upsert(gomock.Any(), price).Return(nil).Times(1)
+
+	err := suite.articleService.CreateArticle(context.Background(), req)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *articleServiceTestSuite) TestUpdateArticleWritesInTransaction() {
+	txArticleRepo := mocks.NewMockArticleRepo(suite.mockCtrl)
+	txArticlePriceRepo := mocks.NewMockArticlePriceRepo(suite.mockCtrl)
+	txManager := mocks.NewMockTxManager(suite.mockCtrl)
+	txManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(repos *repository.Repos) error) error {
+			return fn(&repository.Repos{Articles: txArticleRepo, ArticlePrices: txArticlePriceRepo})
+		}).Times(1)
+
+	service := NewArticleService(suite.mockArticleRepo, suite.mockArticlePriceRepo, txManager, suite.mockBackorderService, "INR")
+
+	req := &dtos.Article{
+		ArticleName: "Test Article",
+		Price:       money.MustParse("100", "INR"),
+		Prices:      []money.Money{money.MustParse("1.25", "USD")},
+	}
+
+	txArticleRepo.EXPECT().Update(gomock.Any(), "123", gomock.Any()).Return(nil).Times(1)
+	txArticlePriceRepo.EXPECT().DeleteByArticle(gomock.Any(), "123").Return(nil).Times(1)
+	txArticlePriceRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(errors.New("price write failed")).Times(1)
+
+	err := service.UpdateArticle(context.Background(), "123", req)
+	assert.EqualError(suite.T(), err, "price write failed")
+}
+
+func (suite *articleServiceTestSuite) TestCreateArticleInvalidCurrency() {
+	req := &dtos.Article{
+		ArticleId:   "123",
+		ArticleName: "Test Article",
+		Price:       money.MustParse("100", "RUPEES"),
+		Stock:       50,
+	}
+
+	err := suite.articleService.CreateArticle(context.Background(), req)
+	assert.Equal(suite.T(), constants.ErrorInvalidCurrency, err)
+}
+
+func (suite *articleServiceTestSuite) TestGetArticleWithPrices() {
+	mockArticle := &models.Article{
+		ArticleId:   "123",
+		ArticleName: "Test Article",
+		Price:       money.MustParse("100", "INR"),
+		Stock:       50,
+	}
+
+	prices := []*models.ArticlePrice{
+		{ArticleId: "123", Currency: "USD", Amount: decimal.RequireFromString("1.25")},
+	}
+
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "123").Return(mockArticle, nil).Times(1)
+	suite.mockArticlePriceRepo.EXPECT().GetByArticles(gomock.Any(), "123").Return(prices, nil).Times(1)
+
+	result, err := suite.articleService.GetArticle(context.Background(), "123")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Prices, 1)
+	assert.True(suite.T(), result.Prices[0].Equal(money.MustParse("1.25", "USD")))
+}
+
 func (suite *articleServiceTestSuite) TestDeleteArticle() {
-	suite.mockArticleRepo.EXPECT().Delete("123").Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Delete(gomock.Any(), "123").Return(nil).Times(1)
 
-	err := suite.articleService.DeleteArticle("123")
+	err := suite.articleService.DeleteArticle(context.Background(), "123")
 	assert.NoError(suite.T(), err)
 }
 
 func (suite *articleServiceTestSuite) TestDeleteArticleError() {
-	suite.mockArticleRepo.EXPECT().Delete("123").Return(constants.ErrorNotFound).Times(1)
+	suite.mockArticleRepo.EXPECT().Delete(gomock.Any(), "123").Return(constants.ErrorNotFound).Times(1)
 
-	err := suite.articleService.DeleteArticle("123")
+	err := suite.articleService.DeleteArticle(context.Background(), "123")
 	assert.Error(suite.T(), err)
 }
 
@@ -170,13 +303,13 @@ func (suite *articleServiceTestSuite) TestListArticle() {
 		{
 			ArticleId:   "123",
 			ArticleName: "Test 1",
-			Price:       100,
+			Price:       money.MustParse("100", "INR"),
 			Stock:       50,
 		},
 		{
 			ArticleId:   "321",
 			ArticleName: "Test 2",
-			Price:       200,
+			Price:       money.MustParse("200", "INR"),
 			Stock:       20,
 		},
 	}
@@ -185,28 +318,29 @@ func (suite *articleServiceTestSuite) TestListArticle() {
 		{
 			ArticleId:   "123",
 			ArticleName: "Test 1",
-			Price:       100,
+			Price:       money.MustParse("100", "INR"),
 			Stock:       50,
 		},
 		{
 			ArticleId:   "321",
 			ArticleName: "Test 2",
-			Price:       200,
+			Price:       money.MustParse("200", "INR"),
 			Stock:       20,
 		},
 	}
 
-	suite.mockArticleRepo.EXPECT().GetAll().Return(mockModel, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().GetAll(gomock.Any()).Return(mockModel, nil).Times(1)
+	suite.mockArticlePriceRepo.EXPECT().GetByArticles(gomock.Any(), "123", "321").Return(nil, nil).Times(1)
 
-	result, err := suite.articleService.ListArticle()
+	result, err := suite.articleService.ListArticle(context.Background())
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedDto, result)
 }
 
 func (suite *articleServiceTestSuite) TestListArticleError() {
-	suite.mockArticleRepo.EXPECT().GetAll().Return(nil, errors.New("repo error")).Times(1)
+	suite.mockArticleRepo.EXPECT().GetAll(gomock.Any()).Return(nil, errors.New("repo error")).Times(1)
 
-	result, err := suite.articleService.ListArticle()
+	result, err := suite.articleService.ListArticle(context.Background())
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), result)
 }
@@ -214,22 +348,25 @@ func (suite *articleServiceTestSuite) TestListArticleError() {
 func (suite *articleServiceTestSuite) TestUpdateArticleStock() {
 	req := &dtos.UpdateStock{
 		NewStock: 50,
+		Version:  3,
 	}
 
-	suite.mockArticleRepo.EXPECT().UpdateArticleStock("123", int64(50)).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().UpdateArticleStock(gomock.Any(), "123", int64(3), int64(50)).Return(nil).Times(1)
+	suite.mockBackorderService.EXPECT().AllocateStock(gomock.Any(), gomock.Any(), "123").Return(nil).Times(1)
 
-	err := suite.articleService.UpdateArticleStock("123", req)
+	err := suite.articleService.UpdateArticleStock(context.Background(), "123", req)
 	assert.NoError(suite.T(), err)
 }
 
 func (suite *articleServiceTestSuite) TestUpdateArticleStockError() {
 	req := &dtos.UpdateStock{
 		NewStock: 50,
+		Version:  3,
 	}
 
-	suite.mockArticleRepo.EXPECT().UpdateArticleStock("123", int64(50)).Return(constants.ErrorNotFound).Times(1)
+	suite.mockArticleRepo.EXPECT().UpdateArticleStock(gomock.Any(), "123", int64(3), int64(50)).Return(constants.ErrorNotFound).Times(1)
 
-	err := suite.articleService.UpdateArticleStock("123", req)
+	err := suite.articleService.UpdateArticleStock(context.Background(), "123", req)
 	assert.Error(suite.T(), err)
 }
 
@@ -237,14 +374,14 @@ func (suite *articleServiceTestSuite) TestArticleDtosToModel() {
 	req := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
 	expected := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
@@ -256,14 +393,14 @@ func (suite *articleServiceTestSuite) TestArticleModelToDtos() {
 	model := &models.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
 	expected := &dtos.Article{
 		ArticleId:   "123",
 		ArticleName: "Test Article",
-		Price:       100,
+		Price:       money.MustParse("100", "INR"),
 		Stock:       50,
 	}
 
@@ -0,0 +1,325 @@
+package backorders
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/metrics"
+	"inventory-management/models"
+	"inventory-management/repository"
+	"inventory-management/tracing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BackorderService interface {
+	Allocate(ctx context.Context, repos *repository.Repos, order *models.Order) error
+	Release(ctx context.Context, repos *repository.Repos, orderId string) error
+	AllocateStock(ctx context.Context, repos *repository.Repos, articleId string) error
+	ReceiveStock(ctx context.Context, articleId string, req *dtos.ReceiveStock) error
+	GetArticleBackorders(ctx context.Context, articleId string) (*dtos.BackorderReport, error)
+}
+
+type backorderService struct {
+	articleRepo   repository.ArticleRepo
+	backorderRepo repository.BackorderRepo
+	userRepo      repository.UserRepo
+	txManager     repository.TxManager
+}
+
+func NewBackorderService(articleRepo repository.ArticleRepo, backorderRepo repository.BackorderRepo, userRepo repository.UserRepo,
+	txManager repository.TxManager) BackorderService {
+	return &backorderService{
+		articleRepo:   articleRepo,
+		backorderRepo: backorderRepo,
+		userRepo:      userRepo,
+		txManager:     txManager,
+	}
+}
+
+// Allocate takes the order's items out of stock inside the caller's
+// transaction. When stock is short the order's backorder policy decides
+// whether the remainder is backordered or the whole allocation fails.
+func (b *backorderService) Allocate(ctx context.Context, repos *repository.Repos, order *models.Order) error {
+	ctx, span := tracing.Start(ctx, "backorderService.Allocate")
+	defer span.End()
+
+	policy, err := b.policy(ctx, order)
+	if err != nil {
+		return err
+	}
+
+	items, err := repos.OrderItems.GetByOrder(ctx, order.OrderId)
+	if err != nil {
+		return err
+	}
+
+	available := make(map[string]int64)
+	var backorders []*models.Backorder
+	for _, v := range items {
+		outstanding := v.Quantity - v.AllocatedQuantity
+		if outstanding <= 0 {
+			continue
+		}
+
+		stock, ok := available[v.ArticleId]
+		if !ok {
+			article, err := repos.Articles.GetForUpdate(ctx, v.ArticleId)
+			if err != nil {
+				return err
+			}
+
+			stock = article.Stock
+		}
+
+		allocated := min(int64(outstanding), max(stock, 0))
+		if int(allocated) < outstanding {
+			if policy != constants.BackorderPolicyAllow {
+				metrics.StockOut(metrics.StockOutRejected)
+				return constants.ErrorInsufficientStock
+			}
+
+			metrics.StockOut(metrics.StockOutBackordered)
+		}
+
+		if allocated > 0 {
+			err = allocate(ctx, repos, v.ArticleId, v.OrderItemId, allocated)
+			if err != nil {
+				return err
+			}
+		}
+
+		available[v.ArticleId] = stock - allocated
+
+		if remainder := outstanding - int(allocated); remainder > 0 {
+			backorders = append(backorders, &models.Backorder{
+				BackorderId: uuid.NewString(),
+				OrderId:     order.OrderId,
+				OrderItemId: v.OrderItemId,
+				ArticleId:   v.ArticleId,
+				Quantity:    remainder,
+				Status:      constants.BackorderStatusOpen,
+				OrderedAt:   order.OrderedAt,
+			})
+		}
+	}
+
+	if len(backorders) == 0 {
+		return nil
+	}
+
+	return repos.Backorders.Create(ctx, backorders...)
+}
+
+// Release puts whatever is allocated to the order but not shipped back into
+// stock, cancels its open backorders and hands the released stock on to other
+// backorders for the same articles.
+func (b *backorderService) Release(ctx context.Context, repos *repository.Repos, orderId string) error {
+	ctx, span := tracing.Start(ctx, "backorderService.Release")
+	defer span.End()
+
+	items, err := repos.OrderItems.GetByOrder(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	shipmentItems, err := repos.ShipmentItems.GetByOrder(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	shipped := make(map[string]int)
+	for _, v := range shipmentItems {
+		shipped[v.OrderItemId] += v.Quantity
+	}
+
+	err = repos.Backorders.CancelByOrder(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	released := make(map[string]bool)
+	var articles []string
+	for _, v := range items {
+		quantity := v.AllocatedQuantity - shipped[v.OrderItemId]
+		if quantity <= 0 {
+			continue
+		}
+
+		err = repos.OrderItems.Allocate(ctx, v.OrderItemId, -quantity)
+		if err != nil {
+			return err
+		}
+
+		err = repos.Articles.AdjustStock(ctx, v.ArticleId, int64(quantity), 0)
+		if err != nil {
+			return err
+		}
+
+		if !released[v.ArticleId] {
+			released[v.ArticleId] = true
+			articles = append(articles, v.ArticleId)
+		}
+	}
+
+	for _, v := range articles {
+		err = allocateBackorders(ctx, repos, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AllocateStock hands the article's stock to its open backorders inside the
+// caller's transaction, for changes to stock made outside this service.
+func (b *backorderService) AllocateStock(ctx context.Context, repos *repository.Repos, articleId string) error {
+	ctx, span := tracing.Start(ctx, "backorderService.AllocateStock")
+	defer span.End()
+
+	return allocateBackorders(ctx, repos, articleId)
+}
+
+// ReceiveStock adds received units to the article's stock and allocates them
+// to its open backorders, oldest order first.
+func (b *backorderService) ReceiveStock(ctx context.Context, articleId string, req *dtos.ReceiveStock) error {
+	ctx, span := tracing.Start(ctx, "backorderService.ReceiveStock")
+	defer span.End()
+
+	if req.Quantity <= 0 {
+		return constants.ErrorInvalidQuantity
+	}
+
+	return b.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		err := repos.Articles.AdjustStock(ctx, articleId, req.Quantity, 0)
+		if err != nil {
+			return err
+		}
+
+		return allocateBackorders(ctx, repos, articleId)
+	})
+}
+
+func (b *backorderService) GetArticleBackorders(ctx context.Context, articleId string) (*dtos.BackorderReport, error) {
+	ctx, span := tracing.Start(ctx, "backorderService.GetArticleBackorders")
+	defer span.End()
+
+	article, err := b.articleRepo.Get(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	backorders, err := b.backorderRepo.GetOpenByArticle(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dtos.BackorderReport{
+		ArticleId:  articleId,
+		Stock:      article.Stock,
+		Backorders: BackorderModelToDtos(backorders),
+	}
+
+	for _, v := range backorders {
+		report.Backordered += v.Quantity - v.AllocatedQuantity
+	}
+
+	return report, nil
+}
+
+// policy is the order's backorder policy, falling back to the customer's. A
+// customer without an account gets the default policy.
+func (b *backorderService) policy(ctx context.Context, order *models.Order) (string, error) {
+	if order.BackorderPolicy != "" {
+		return order.BackorderPolicy, nil
+	}
+
+	user, err := b.userRepo.Get(ctx, order.CustomerId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return constants.BackorderPolicyReject, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if user.BackorderPolicy == "" {
+		return constants.BackorderPolicyReject, nil
+	}
+
+	return user.BackorderPolicy, nil
+}
+
+// allocateBackorders hands the article's stock to its open backorders in FIFO
+// order of the time they were ordered. The article row stays locked until the
+// transaction ends.
+func allocateBackorders(ctx context.Context, repos *repository.Repos, articleId string) error {
+	article, err := repos.Articles.GetForUpdate(ctx, articleId)
+	if err != nil {
+		return err
+	}
+
+	backorders, err := repos.Backorders.GetOpenByArticle(ctx, articleId)
+	if err != nil {
+		return err
+	}
+
+	stock := article.Stock
+	for _, v := range backorders {
+		if stock <= 0 {
+			break
+		}
+
+		outstanding := int64(v.Quantity - v.AllocatedQuantity)
+		allocated := min(outstanding, stock)
+
+		err = allocate(ctx, repos, articleId, v.OrderItemId, allocated)
+		if err != nil {
+			return err
+		}
+
+		status := constants.BackorderStatusOpen
+		if allocated == outstanding {
+			status = constants.BackorderStatusAllocated
+		}
+
+		err = repos.Backorders.Allocate(ctx, v.BackorderId, int(allocated), status)
+		if err != nil {
+			return err
+		}
+
+		stock -= allocated
+	}
+
+	return nil
+}
+
+func allocate(ctx context.Context, repos *repository.Repos, articleId string, orderItemId string, quantity int64) error {
+	err := repos.Articles.DeductStock(ctx, articleId, quantity)
+	if err != nil {
+		return err
+	}
+
+	return repos.OrderItems.Allocate(ctx, orderItemId, int(quantity))
+}
+
+func BackorderModelToDtos(m []*models.Backorder) []*dtos.Backorder {
+	backorders := []*dtos.Backorder{}
+
+	for _, v := range m {
+		backorders = append(backorders, &dtos.Backorder{
+			BackorderId: v.BackorderId,
+			OrderId:     v.OrderId,
+			OrderItemId: v.OrderItemId,
+			ArticleId:   v.ArticleId,
+			Quantity:    v.Quantity,
+			Allocated:   v.AllocatedQuantity,
+			Status:      v.Status,
+			OrderedAt:   v.OrderedAt,
+		})
+	}
+
+	return backorders
+}
@@ -0,0 +1,208 @@
+package backorders
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/repository"
+	"inventory-management/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type backorderServiceTestSuite struct {
+	suite.Suite
+	mockCtrl             *gomock.Controller
+	mockArticleRepo      *mocks.MockArticleRepo
+	mockBackorderRepo    *mocks.MockBackorderRepo
+	mockUserRepo         *mocks.MockUserRepo
+	mockOrderItemRepo    *mocks.MockOrderItemRepo
+	mockShipmentItemRepo *mocks.MockShipmentItemRepo
+	mockTxManager        *mocks.MockTxManager
+	repos                *repository.Repos
+	backorderService     BackorderService
+}
+
+func TestBackorderServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(backorderServiceTestSuite))
+}
+
+func (suite *backorderServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockArticleRepo = mocks.NewMockArticleRepo(suite.mockCtrl)
+	suite.mockBackorderRepo = mocks.NewMockBackorderRepo(suite.mockCtrl)
+	suite.mockUserRepo = mocks.NewMockUserRepo(suite.mockCtrl)
+	suite.mockOrderItemRepo = mocks.NewMockOrderItemRepo(suite.mockCtrl)
+	suite.mockShipmentItemRepo = mocks.NewMockShipmentItemRepo(suite.mockCtrl)
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
+
+	suite.repos = &repository.Repos{
+		Articles:      suite.mockArticleRepo,
+		Backorders:    suite.mockBackorderRepo,
+		OrderItems:    suite.mockOrderItemRepo,
+		ShipmentItems: suite.mockShipmentItemRepo,
+	}
+	suite.mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(repos *repository.Repos) error) error {
+			return fn(suite.repos)
+		}).AnyTimes()
+
+	suite.backorderService = NewBackorderService(suite.mockArticleRepo, suite.mockBackorderRepo, suite.mockUserRepo, suite.mockTxManager)
+}
+
+func (suite *backorderServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *backorderServiceTestSuite) expectOrderItems() {
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return([]*models.OrderItem{
+		{OrderItemId: "i1", OrderId: "o1", ArticleId: "a1", Quantity: 3},
+		{OrderItemId: "i2", OrderId: "o1", ArticleId: "a1", Quantity: 2},
+	}, nil).Times(1)
+}
+
+func (suite *backorderServiceTestSuite) TestAllocateInStock() {
+	suite.expectOrderItems()
+
+	suite.mockArticleRepo.EXPECT().GetForUpdate(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Stock: 5}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().DeductStock(gomock.Any(), "a1", int64(3)).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Allocate(gomock.Any(), "i1", 3).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().DeductStock(gomock.Any(), "a1", int64(2)).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Allocate(gomock.Any(), "i2", 2).Return(nil).Times(1)
+
+	order := &models.Order{OrderId: "o1", CustomerId: "c1", BackorderPolicy: constants.BackorderPolicyReject}
+
+	err := suite.backorderService.Allocate(context.Background(), suite.repos, order)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *backorderServiceTestSuite) TestAllocateBackordersRemainder() {
+	orderedAt := time.Now()
+
+	suite.expectOrderItems()
+
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c1").Return(&models.User{Id: "c1", BackorderPolicy: constants.BackorderPolicyAllow}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().GetForUpdate(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Stock: 4}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().DeductStock(gomock.Any(), "a1", int64(3)).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Allocate(gomock.Any(), "i1", 3).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().DeductStock(gomock.Any(), "a1", int64(1)).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Allocate(gomock.Any(), "i2", 1).Return(nil).Times(1)
+	suite.mockBackorderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, backorders ...*models.Backorder) error {
+		assert.Len(suite.T(), backorders, 1)
+		assert.Equal(suite.T(), "i2", backorders[0].OrderItemId)
+		assert.Equal(suite.T(), 1, backorders[0].Quantity)
+		assert.Equal(suite.T(), constants.BackorderStatusOpen, backorders[0].Status)
+		assert.Equal(suite.T(), orderedAt, backorders[0].OrderedAt)
+		return nil
+	}).Times(1)
+
+	order := &models.Order{OrderId: "o1", CustomerId: "c1", OrderedAt: orderedAt}
+
+	err := suite.backorderService.Allocate(context.Background(), suite.repos, order)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *backorderServiceTestSuite) TestAllocateRejectsShortStock() {
+	suite.expectOrderItems()
+
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c1").Return(&models.User{Id: "c1"}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().GetForUpdate(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Stock: 4}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().DeductStock(gomock.Any(), "a1", int64(3)).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Allocate(gomock.Any(), "i1", 3).Return(nil).Times(1)
+
+	order := &models.Order{OrderId: "o1", CustomerId: "c1"}
+
+	err := suite.backorderService.Allocate(context.Background(), suite.repos, order)
+	assert.Equal(suite.T(), constants.ErrorInsufficientStock, err)
+}
+
+func (suite *backorderServiceTestSuite) TestAllocateUnknownCustomerRejects() {
+	suite.expectOrderItems()
+
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c1").Return(nil, gorm.ErrRecordNotFound).Times(1)
+	suite.mockArticleRepo.EXPECT().GetForUpdate(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Stock: 2}, nil).Times(1)
+
+	order := &models.Order{OrderId: "o1", CustomerId: "c1"}
+
+	err := suite.backorderService.Allocate(context.Background(), suite.repos, order)
+	assert.Equal(suite.T(), constants.ErrorInsufficientStock, err)
+}
+
+func (suite *backorderServiceTestSuite) TestAllocateStock() {
+	suite.mockArticleRepo.EXPECT().GetForUpdate(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Stock: 1}, nil).Times(1)
+	suite.mockBackorderRepo.EXPECT().GetOpenByArticle(gomock.Any(), "a1").Return([]*models.Backorder{
+		{BackorderId: "b1", OrderItemId: "i1", ArticleId: "a1", Quantity: 2, Status: constants.BackorderStatusOpen},
+	}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().DeductStock(gomock.Any(), "a1", int64(1)).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Allocate(gomock.Any(), "i1", 1).Return(nil).Times(1)
+	suite.mockBackorderRepo.EXPECT().Allocate(gomock.Any(), "b1", 1, constants.BackorderStatusOpen).Return(nil).Times(1)
+
+	err := suite.backorderService.AllocateStock(context.Background(), suite.repos, "a1")
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *backorderServiceTestSuite) TestReceiveStockAllocatesFIFO() {
+	suite.mockArticleRepo.EXPECT().AdjustStock(gomock.Any(), "a1", int64(3), int64(0)).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().GetForUpdate(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Stock: 3}, nil).Times(1)
+	suite.mockBackorderRepo.EXPECT().GetOpenByArticle(gomock.Any(), "a1").Return([]*models.Backorder{
+		{BackorderId: "b2", OrderItemId: "i2", ArticleId: "a1", Quantity: 1, Status: constants.BackorderStatusOpen},
+		{BackorderId: "b1", OrderItemId: "i1", ArticleId: "a1", Quantity: 4, AllocatedQuantity: 1, Status: constants.BackorderStatusOpen},
+		{BackorderId: "b3", OrderItemId: "i3", ArticleId: "a1", Quantity: 1, Status: constants.BackorderStatusOpen},
+	}, nil).Times(1)
+
+	gomock.InOrder(
+		suite.mockArticleRepo.EXPECT().DeductStock(gomock.Any(), "a1", int64(1)).Return(nil),
+		suite.mockOrderItemRepo.EXPECT().Allocate(gomock.Any(), "i2", 1).Return(nil),
+		suite.mockBackorderRepo.EXPECT().Allocate(gomock.Any(), "b2", 1, constants.BackorderStatusAllocated).Return(nil),
+		suite.mockArticleRepo.EXPECT().DeductStock(gomock.Any(), "a1", int64(2)).Return(nil),
+		suite.mockOrderItemRepo.EXPECT().Allocate(gomock.Any(), "i1", 2).Return(nil),
+		suite.mockBackorderRepo.EXPECT().Allocate(gomock.Any(), "b1", 2, constants.BackorderStatusOpen).Return(nil),
+	)
+
+	err := suite.backorderService.ReceiveStock(context.Background(), "a1", &dtos.ReceiveStock{Quantity: 3})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *backorderServiceTestSuite) TestReceiveStockInvalidQuantity() {
+	err := suite.backorderService.ReceiveStock(context.Background(), "a1", &dtos.ReceiveStock{Quantity: 0})
+	assert.Equal(suite.T(), constants.ErrorInvalidQuantity, err)
+}
+
+func (suite *backorderServiceTestSuite) TestRelease() {
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return([]*models.OrderItem{
+		{OrderItemId: "i1", OrderId: "o1", ArticleId: "a1", Quantity: 3, AllocatedQuantity: 3},
+		{OrderItemId: "i2", OrderId: "o1", ArticleId: "a2", Quantity: 2},
+	}, nil).Times(1)
+	suite.mockShipmentItemRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return([]*models.ShipmentItem{
+		{ShipmentItemId: "si1", OrderItemId: "i1", Quantity: 1},
+	}, nil).Times(1)
+	suite.mockBackorderRepo.EXPECT().CancelByOrder(gomock.Any(), "o1").Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Allocate(gomock.Any(), "i1", -2).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().AdjustStock(gomock.Any(), "a1", int64(2), int64(0)).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().GetForUpdate(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Stock: 2}, nil).Times(1)
+	suite.mockBackorderRepo.EXPECT().GetOpenByArticle(gomock.Any(), "a1").Return(nil, nil).Times(1)
+
+	err := suite.backorderService.Release(context.Background(), suite.repos, "o1")
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *backorderServiceTestSuite) TestGetArticleBackorders() {
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Stock: 0}, nil).Times(1)
+	suite.mockBackorderRepo.EXPECT().GetOpenByArticle(gomock.Any(), "a1").Return([]*models.Backorder{
+		{BackorderId: "b2", OrderId: "o2", ArticleId: "a1", Quantity: 1, Status: constants.BackorderStatusOpen},
+		{BackorderId: "b1", OrderId: "o1", ArticleId: "a1", Quantity: 4, AllocatedQuantity: 1, Status: constants.BackorderStatusOpen},
+	}, nil).Times(1)
+
+	result, err := suite.backorderService.GetArticleBackorders(context.Background(), "a1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 4, result.Backordered)
+	assert.Len(suite.T(), result.Backorders, 2)
+	assert.Equal(suite.T(), "o2", result.Backorders[0].OrderId)
+}
@@ -0,0 +1,432 @@
+package catalog
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/services/articles"
+	"inventory-management/services/backorders"
+	"inventory-management/tabular"
+	"inventory-management/tracing"
+	"inventory-management/validation"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	importBatchSize = 100
+	exportBatchSize = 500
+)
+
+// articleColumns are the article fields in a catalog file, in export order.
+// Imports find each field in the column named after it unless the mapping
+// says otherwise. Currency and tax class may be left out of a file.
+var articleColumns = []string{"article_id", "article_name", "price", "currency", "stock", "tax_class"}
+
+var optionalColumns = map[string]bool{"currency": true, "tax_class": true}
+
+type CatalogService interface {
+	ImportArticles(ctx context.Context, req *dtos.ArticleImport, data []byte) (*dtos.ImportJob, error)
+	GetImportJob(ctx context.Context, jobId string) (*dtos.ImportJob, error)
+	ExportArticles(ctx context.Context, format string, w io.Writer) error
+	Running() bool
+	Shutdown(ctx context.Context) error
+}
+
+type catalogService struct {
+	articleRepo      repository.ArticleRepo
+	importJobRepo    repository.ImportJobRepo
+	importErrorRepo  repository.ImportErrorRepo
+	txManager        repository.TxManager
+	backorderService backorders.BackorderService
+	baseCurrency     string
+	jobs             sync.WaitGroup
+	mu               sync.Mutex
+	closed           bool
+	stop             chan struct{}
+}
+
+func NewCatalogService(articleRepo repository.ArticleRepo, importJobRepo repository.ImportJobRepo, importErrorRepo repository.ImportErrorRepo,
+	txManager repository.TxManager, backorderService backorders.BackorderService, baseCurrency string) CatalogService {
+	return &catalogService{
+		articleRepo:      articleRepo,
+		importJobRepo:    importJobRepo,
+		importErrorRepo:  importErrorRepo,
+		txManager:        txManager,
+		backorderService: backorderService,
+		baseCurrency:     money.NormalizeCurrency(baseCurrency),
+		stop:             make(chan struct{}),
+	}
+}
+
+type importRow struct {
+	line  int
+	cells []string
+}
+
+// ImportArticles checks the file can be read and imports it in the background.
+// Every row is upserted as a whole article, keyed on its id, unless the import
+// is a dry run. The returned job reports progress and the rows that failed.
+func (c *catalogService) ImportArticles(ctx context.Context, req *dtos.ArticleImport, data []byte) (*dtos.ImportJob, error) {
+	ctx, span := tracing.Start(ctx, "catalogService.ImportArticles")
+	defer span.End()
+
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+
+	records, err := tabular.ReadRows(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, constants.ErrorImportFileEmpty
+	}
+
+	columns, err := tabular.MapColumns(records[0], articleColumns, optionalColumns, req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	for n, v := range records[1:] {
+		if !tabular.Blank(v) {
+			rows = append(rows, importRow{line: n + 2, cells: v})
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, constants.ErrorImportFileEmpty
+	}
+
+	job := &models.ImportJob{
+		JobId:     uuid.NewString(),
+		Type:      constants.ImportTypeArticles,
+		Format:    format,
+		DryRun:    req.DryRun,
+		Status:    constants.ImportStatusQueued,
+		TotalRows: len(rows),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, constants.ErrorShuttingDown
+	}
+
+	err = c.importJobRepo.Create(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	result := ImportJobModelToDtos(job, nil)
+
+	// The import outlives the request, so it keeps the request's values for
+	// logging but not its cancellation.
+	ctx = context.WithoutCancel(ctx)
+
+	c.jobs.Add(1)
+	go func() {
+		defer c.jobs.Done()
+		c.runImport(ctx, job, rows, columns)
+	}()
+
+	return result, nil
+}
+
+func (c *catalogService) runImport(ctx context.Context, job *models.ImportJob, rows []importRow, columns tabular.Columns) {
+	ctx, span := tracing.Start(ctx, "catalogService.runImport")
+	defer span.End()
+
+	job.Status = constants.ImportStatusRunning
+	err := c.importJobRepo.Update(ctx, job)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to start import", "job_id", job.JobId, "error", err)
+		return
+	}
+
+	seen := make(map[string]struct{})
+	var batch []*models.Article
+	var rowErrors []*models.ImportError
+
+	for n, row := range rows {
+		article, fieldErrors := c.parseArticle(row.cells, columns)
+		if _, exists := seen[article.ArticleId]; exists && len(fieldErrors) == 0 {
+			fieldErrors = append(fieldErrors, &dtos.FieldError{Field: "article_id", Rule: "unique"})
+		}
+
+		if len(fieldErrors) > 0 {
+			job.Failed++
+			for _, v := range fieldErrors {
+				rowErrors = append(rowErrors, &models.ImportError{
+					ImportErrorId: uuid.NewString(),
+					JobId:         job.JobId,
+					Line:          row.line,
+					Field:         v.Field,
+					Rule:          v.Rule,
+					Param:         v.Param,
+				})
+			}
+		} else {
+			seen[article.ArticleId] = struct{}{}
+
+			model := articles.ArticleDtosToModel(article)
+			model.Version = 1
+			batch = append(batch, model)
+		}
+
+		job.Processed++
+		if job.Processed%importBatchSize != 0 && n < len(rows)-1 {
+			continue
+		}
+
+		err = c.flushImport(ctx, job, batch, rowErrors)
+		if err != nil {
+			job.Status = constants.ImportStatusFailed
+			job.Error = err.Error()
+			c.saveJob(ctx, job)
+			return
+		}
+
+		batch, rowErrors = nil, nil
+
+		if n < len(rows)-1 && c.stopping() {
+			job.Status = constants.ImportStatusFailed
+			job.Error = constants.ErrorShuttingDown.Error()
+			c.saveJob(ctx, job)
+			return
+		}
+	}
+
+	job.Status = constants.ImportStatusCompleted
+	c.saveJob(ctx, job)
+}
+
+func (c *catalogService) stopping() bool {
+	select {
+	case <-c.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// Running is true until the service starts shutting down.
+func (c *catalogService) Running() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return !c.closed
+}
+
+// Shutdown refuses new imports and stops the running ones after their current
+// batch. A stopped job is marked failed with the rows it got through, and can
+// be imported again as rows are upserted. It returns ctx.Err() if the jobs
+// haven't stopped by the time ctx is done.
+func (c *catalogService) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.stop)
+	}
+	c.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		c.jobs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flushImport writes a batch of articles, allocating their stock to open
+// backorders, and the errors found since the last batch, then records the
+// progress.
+func (c *catalogService) flushImport(ctx context.Context, job *models.ImportJob, batch []*models.Article, rowErrors []*models.ImportError) error {
+	if len(batch) > 0 && !job.DryRun {
+		err := c.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+			err := repos.Articles.Upsert(ctx, batch...)
+			if err != nil {
+				return err
+			}
+
+			for _, v := range batch {
+				err = c.backorderService.AllocateStock(ctx, repos, v.ArticleId)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	job.Succeeded += len(batch)
+
+	if len(rowErrors) > 0 {
+		err := c.importErrorRepo.Create(ctx, rowErrors...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.importJobRepo.Update(ctx, job)
+}
+
+func (c *catalogService) saveJob(ctx context.Context, job *models.ImportJob) {
+	err := c.importJobRepo.Update(ctx, job)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save import", "job_id", job.JobId, "error", err)
+	}
+}
+
+// parseArticle reads a row into an article and checks it against the same
+// rules as a request body.
+func (c *catalogService) parseArticle(cells []string, columns tabular.Columns) (*dtos.Article, []*dtos.FieldError) {
+	value := func(field string) string {
+		return columns.Value(cells, field)
+	}
+
+	article := &dtos.Article{
+		ArticleId:   value("article_id"),
+		ArticleName: value("article_name"),
+		TaxClass:    value("tax_class"),
+	}
+
+	var fieldErrors []*dtos.FieldError
+
+	currency := money.NormalizeCurrency(value("currency"))
+	if currency == "" {
+		currency = c.baseCurrency
+	}
+	if !money.ValidCurrency(currency) {
+		fieldErrors = append(fieldErrors, &dtos.FieldError{Field: "currency", Rule: "iso4217"})
+	}
+
+	amount, err := decimal.NewFromString(value("price"))
+	if err != nil {
+		fieldErrors = append(fieldErrors, &dtos.FieldError{Field: "price", Rule: "decimal"})
+	}
+	article.Price = money.Money{Amount: amount, Currency: currency}
+
+	// The upsert overwrites stock, so a blank cell isn't taken as zero.
+	if stock := value("stock"); stock == "" {
+		fieldErrors = append(fieldErrors, &dtos.FieldError{Field: "stock", Rule: "required"})
+	} else {
+		article.Stock, err = strconv.ParseInt(stock, 10, 64)
+		if err != nil {
+			fieldErrors = append(fieldErrors, &dtos.FieldError{Field: "stock", Rule: "integer"})
+		}
+	}
+
+	err = validation.Struct(article)
+	if err != nil {
+		fields, _ := validation.Fields(err)
+		fieldErrors = append(fieldErrors, fields...)
+	}
+
+	return article, fieldErrors
+}
+
+func (c *catalogService) GetImportJob(ctx context.Context, jobId string) (*dtos.ImportJob, error) {
+	ctx, span := tracing.Start(ctx, "catalogService.GetImportJob")
+	defer span.End()
+
+	job, err := c.importJobRepo.Get(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	rowErrors, err := c.importErrorRepo.GetByJob(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	return ImportJobModelToDtos(job, rowErrors), nil
+}
+
+// ExportArticles writes the whole catalog to w, reading it in batches, with
+// the same columns an import expects.
+func (c *catalogService) ExportArticles(ctx context.Context, format string, w io.Writer) error {
+	ctx, span := tracing.Start(ctx, "catalogService.ExportArticles")
+	defer span.End()
+
+	writer, err := tabular.NewWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	err = writer.Write(articleColumns)
+	if err != nil {
+		return err
+	}
+
+	err = c.articleRepo.FindInBatches(ctx, exportBatchSize, func(batch []*models.Article) error {
+		for _, v := range batch {
+			err := writer.Write([]string{
+				v.ArticleId,
+				v.ArticleName,
+				v.Price.Amount.StringFixed(money.MinorUnits(v.Price.Currency)),
+				v.Price.Currency,
+				strconv.FormatInt(v.Stock, 10),
+				v.TaxClass,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func ImportJobModelToDtos(m *models.ImportJob, e []*models.ImportError) *dtos.ImportJob {
+	job := &dtos.ImportJob{
+		JobId:     m.JobId,
+		Type:      m.Type,
+		Format:    m.Format,
+		DryRun:    m.DryRun,
+		Status:    m.Status,
+		TotalRows: m.TotalRows,
+		Processed: m.Processed,
+		Succeeded: m.Succeeded,
+		Failed:    m.Failed,
+		Error:     m.Error,
+		Errors:    []*dtos.ImportRowError{},
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+
+	for _, v := range e {
+		job.Errors = append(job.Errors, &dtos.ImportRowError{
+			Line:  v.Line,
+			Field: v.Field,
+			Rule:  v.Rule,
+			Param: v.Param,
+		})
+	}
+
+	return job
+}
@@ -0,0 +1,277 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/repository/mocks"
+	serviceMocks "inventory-management/services/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type catalogServiceTestSuite struct {
+	suite.Suite
+	mockCtrl             *gomock.Controller
+	mockArticleRepo      *mocks.MockArticleRepo
+	mockImportJobRepo    *mocks.MockImportJobRepo
+	mockImportErrorRepo  *mocks.MockImportErrorRepo
+	mockTxManager        *mocks.MockTxManager
+	mockBackorderService *serviceMocks.MockBackorderService
+	job                  models.ImportJob
+	rowErrors            []*models.ImportError
+	catalogService       CatalogService
+}
+
+func TestCatalogServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(catalogServiceTestSuite))
+}
+
+// SetupTest keeps the last saved state of the job and every stored row error,
+// so a test can wait for the import and check the outcome.
+func (suite *catalogServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockArticleRepo = mocks.NewMockArticleRepo(suite.mockCtrl)
+	suite.mockImportJobRepo = mocks.NewMockImportJobRepo(suite.mockCtrl)
+	suite.mockImportErrorRepo = mocks.NewMockImportErrorRepo(suite.mockCtrl)
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
+	suite.mockBackorderService = serviceMocks.NewMockBackorderService(suite.mockCtrl)
+
+	repos := &repository.Repos{
+		Articles: suite.mockArticleRepo,
+	}
+	suite.mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(repos *repository.Repos) error) error {
+			return fn(repos)
+		}).AnyTimes()
+
+	suite.job = models.ImportJob{}
+	suite.rowErrors = nil
+
+	save := func(_ context.Context, job *models.ImportJob) error {
+		suite.job = *job
+		return nil
+	}
+	suite.mockImportJobRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(save).AnyTimes()
+	suite.mockImportJobRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(save).AnyTimes()
+	suite.mockImportErrorRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, rowErrors ...*models.ImportError) error {
+		suite.rowErrors = append(suite.rowErrors, rowErrors...)
+		return nil
+	}).AnyTimes()
+
+	suite.catalogService = NewCatalogService(suite.mockArticleRepo, suite.mockImportJobRepo, suite.mockImportErrorRepo, suite.mockTxManager,
+		suite.mockBackorderService, "INR")
+}
+
+func (suite *catalogServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *catalogServiceTestSuite) wait() {
+	suite.catalogService.(*catalogService).jobs.Wait()
+}
+
+func (suite *catalogServiceTestSuite) TestImportArticlesCSV() {
+	file := "SKU,article_name,Price,stock,currency\n" +
+		"a1,Widget,10.50,5,\n" +
+		"a2,,abc,-1,\n" +
+		",,,,\n" +
+		"a1,Widget again,11,5,\n" +
+		"a3,Gadget,2,0,usd\n"
+
+	suite.mockArticleRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, articles ...*models.Article) error {
+		assert.Len(suite.T(), articles, 2)
+		assert.Equal(suite.T(), "a1", articles[0].ArticleId)
+		assert.Equal(suite.T(), "10.50 INR", articles[0].Price.String())
+		assert.Equal(suite.T(), int64(5), articles[0].Stock)
+		assert.Equal(suite.T(), "a3", articles[1].ArticleId)
+		assert.Equal(suite.T(), "2.00 USD", articles[1].Price.String())
+		return nil
+	}).Times(1)
+	suite.mockBackorderService.EXPECT().AllocateStock(gomock.Any(), gomock.Any(), "a1").Return(nil).Times(1)
+	suite.mockBackorderService.EXPECT().AllocateStock(gomock.Any(), gomock.Any(), "a3").Return(nil).Times(1)
+
+	job, err := suite.catalogService.ImportArticles(context.Background(), &dtos.ArticleImport{
+		Format:  "CSV",
+		Mapping: map[string]string{"article_id": "sku"},
+	}, []byte(file))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.ImportStatusQueued, job.Status)
+	assert.Equal(suite.T(), 4, job.TotalRows)
+
+	suite.wait()
+
+	assert.Equal(suite.T(), constants.ImportStatusCompleted, suite.job.Status)
+	assert.Equal(suite.T(), 4, suite.job.Processed)
+	assert.Equal(suite.T(), 2, suite.job.Succeeded)
+	assert.Equal(suite.T(), 2, suite.job.Failed)
+
+	var found []dtos.ImportRowError
+	for _, v := range suite.rowErrors {
+		found = append(found, dtos.ImportRowError{Line: v.Line, Field: v.Field, Rule: v.Rule, Param: v.Param})
+	}
+	assert.ElementsMatch(suite.T(), []dtos.ImportRowError{
+		{Line: 3, Field: "price", Rule: "decimal"},
+		{Line: 3, Field: "article_name", Rule: "required"},
+		{Line: 3, Field: "stock", Rule: "gte", Param: "0"},
+		{Line: 5, Field: "article_id", Rule: "unique"},
+	}, found)
+}
+
+func (suite *catalogServiceTestSuite) TestImportArticlesDryRun() {
+	file := "article_id,article_name,price,stock\na1,Widget,10,5\na2,Gadget,-2,1\n"
+
+	_, err := suite.catalogService.ImportArticles(context.Background(), &dtos.ArticleImport{Format: "csv", DryRun: true}, []byte(file))
+	assert.NoError(suite.T(), err)
+
+	suite.wait()
+
+	assert.Equal(suite.T(), constants.ImportStatusCompleted, suite.job.Status)
+	assert.Equal(suite.T(), 1, suite.job.Succeeded)
+	assert.Equal(suite.T(), 1, suite.job.Failed)
+	assert.Len(suite.T(), suite.rowErrors, 1)
+}
+
+func (suite *catalogServiceTestSuite) TestImportArticlesBlankStock() {
+	file := "article_id,article_name,price,stock\na1,Widget,10,\n"
+
+	_, err := suite.catalogService.ImportArticles(context.Background(), &dtos.ArticleImport{Format: "csv"}, []byte(file))
+	assert.NoError(suite.T(), err)
+
+	suite.wait()
+
+	assert.Equal(suite.T(), constants.ImportStatusCompleted, suite.job.Status)
+	assert.Equal(suite.T(), 0, suite.job.Succeeded)
+	assert.Equal(suite.T(), 1, suite.job.Failed)
+	assert.Len(suite.T(), suite.rowErrors, 1)
+	assert.Equal(suite.T(), "stock", suite.rowErrors[0].Field)
+	assert.Equal(suite.T(), "required", suite.rowErrors[0].Rule)
+}
+
+func (suite *catalogServiceTestSuite) TestImportArticlesUpsertError() {
+	file := "article_id,article_name,price,stock\na1,Widget,10,5\n"
+
+	suite.mockArticleRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(errors.New("upsert failed")).Times(1)
+
+	_, err := suite.catalogService.ImportArticles(context.Background(), &dtos.ArticleImport{Format: "csv"}, []byte(file))
+	assert.NoError(suite.T(), err)
+
+	suite.wait()
+
+	assert.Equal(suite.T(), constants.ImportStatusFailed, suite.job.Status)
+	assert.Equal(suite.T(), "upsert failed", suite.job.Error)
+}
+
+func (suite *catalogServiceTestSuite) TestShutdownStopsImports() {
+	file := "article_id,article_name,price,stock\n"
+	for n := 0; n < importBatchSize+50; n++ {
+		file += fmt.Sprintf("a%d,Widget,10,5\n", n)
+	}
+
+	// Shutting down during the first batch stops the job before the second.
+	// The job is still running, so the already expired context runs out.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	suite.mockArticleRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, articles ...*models.Article) error {
+		assert.ErrorIs(suite.T(), suite.catalogService.Shutdown(ctx), context.Canceled)
+		return nil
+	}).Times(1)
+	suite.mockBackorderService.EXPECT().AllocateStock(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(importBatchSize)
+
+	_, err := suite.catalogService.ImportArticles(context.Background(), &dtos.ArticleImport{Format: "csv"}, []byte(file))
+	assert.NoError(suite.T(), err)
+
+	assert.NoError(suite.T(), suite.catalogService.Shutdown(context.Background()))
+
+	assert.Equal(suite.T(), constants.ImportStatusFailed, suite.job.Status)
+	assert.Equal(suite.T(), constants.ErrorShuttingDown.Error(), suite.job.Error)
+	assert.Equal(suite.T(), importBatchSize, suite.job.Succeeded)
+
+	_, err = suite.catalogService.ImportArticles(context.Background(), &dtos.ArticleImport{Format: "csv"}, []byte(file))
+	assert.Equal(suite.T(), constants.ErrorShuttingDown, err)
+}
+
+func (suite *catalogServiceTestSuite) TestImportArticlesMissingColumn() {
+	_, err := suite.catalogService.ImportArticles(context.Background(), &dtos.ArticleImport{Format: "csv"}, []byte("article_id,price,stock\na1,10,5\n"))
+	assert.Equal(suite.T(), constants.ErrorImportColumnMissing, err)
+
+	_, err = suite.catalogService.ImportArticles(context.Background(), &dtos.ArticleImport{
+		Format:  "csv",
+		Mapping: map[string]string{"name": "Name"},
+	}, []byte("article_id,article_name,price,stock\na1,Widget,10,5\n"))
+	assert.Equal(suite.T(), constants.ErrorInvalidColumnMapping, err)
+}
+
+func (suite *catalogServiceTestSuite) TestImportArticlesEmptyFile() {
+	_, err := suite.catalogService.ImportArticles(context.Background(), &dtos.ArticleImport{Format: "csv"}, []byte("article_id,article_name,price,stock\n"))
+	assert.Equal(suite.T(), constants.ErrorImportFileEmpty, err)
+}
+
+func (suite *catalogServiceTestSuite) TestImportArticlesUnsupportedFormat() {
+	_, err := suite.catalogService.ImportArticles(context.Background(), &dtos.ArticleImport{Format: "ods"}, []byte("article_id"))
+	assert.Equal(suite.T(), constants.ErrorUnsupportedFileFormat, err)
+}
+
+func (suite *catalogServiceTestSuite) TestExportAndImportXLSX() {
+	suite.mockArticleRepo.EXPECT().FindInBatches(gomock.Any(), exportBatchSize, gomock.Any()).DoAndReturn(
+		func(_ context.Context, size int, fn func(articles []*models.Article) error) error {
+			return fn([]*models.Article{
+				{ArticleId: "a1", ArticleName: "Widget", Price: money.MustParse("10.5", "INR"), Stock: 5, TaxClass: "standard"},
+				{ArticleId: "a2", ArticleName: "Gadget", Price: money.MustParse("3", "USD"), Stock: 0},
+			})
+		}).Times(1)
+
+	var buf bytes.Buffer
+	err := suite.catalogService.ExportArticles(context.Background(), constants.FileFormatXLSX, &buf)
+	assert.NoError(suite.T(), err)
+
+	suite.mockArticleRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, articles ...*models.Article) error {
+		assert.Len(suite.T(), articles, 2)
+		assert.Equal(suite.T(), "standard", articles[0].TaxClass)
+		assert.Equal(suite.T(), "3.00 USD", articles[1].Price.String())
+		return nil
+	}).Times(1)
+	suite.mockBackorderService.EXPECT().AllocateStock(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	_, err = suite.catalogService.ImportArticles(context.Background(), &dtos.ArticleImport{Format: "xlsx"}, buf.Bytes())
+	assert.NoError(suite.T(), err)
+
+	suite.wait()
+
+	assert.Equal(suite.T(), constants.ImportStatusCompleted, suite.job.Status)
+	assert.Equal(suite.T(), 2, suite.job.Succeeded)
+}
+
+func (suite *catalogServiceTestSuite) TestExportArticlesCSV() {
+	suite.mockArticleRepo.EXPECT().FindInBatches(gomock.Any(), exportBatchSize, gomock.Any()).DoAndReturn(
+		func(_ context.Context, size int, fn func(articles []*models.Article) error) error {
+			return fn([]*models.Article{
+				{ArticleId: "a1", ArticleName: "Widget, large", Price: money.MustParse("10.5", "INR"), Stock: 5},
+			})
+		}).Times(1)
+
+	var buf bytes.Buffer
+	err := suite.catalogService.ExportArticles(context.Background(), constants.FileFormatCSV, &buf)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "article_id,article_name,price,currency,stock,tax_class\na1,\"Widget, large\",10.50,INR,5,\n", buf.String())
+}
+
+func (suite *catalogServiceTestSuite) TestGetImportJob() {
+	suite.mockImportJobRepo.EXPECT().Get(gomock.Any(), "j1").Return(&models.ImportJob{JobId: "j1", Status: constants.ImportStatusRunning}, nil).Times(1)
+	suite.mockImportErrorRepo.EXPECT().GetByJob(gomock.Any(), "j1").Return([]*models.ImportError{{JobId: "j1", Line: 2, Field: "price", Rule: "decimal"}}, nil).Times(1)
+
+	job, err := suite.catalogService.GetImportJob(context.Background(), "j1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.ImportStatusRunning, job.Status)
+	assert.Equal(suite.T(), []*dtos.ImportRowError{{Line: 2, Field: "price", Rule: "decimal"}}, job.Errors)
+}
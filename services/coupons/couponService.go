@@ -0,0 +1,254 @@
+package coupons
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/tracing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type CouponService interface {
+	CreateCoupon(ctx context.Context, req *dtos.Coupon) error
+	UpdateCoupon(ctx context.Context, code string, req *dtos.Coupon) error
+	GetCoupon(ctx context.Context, code string) (*dtos.Coupon, error)
+	DeleteCoupon(ctx context.Context, code string) error
+	ApplyCoupon(ctx context.Context, code string, order *models.Order, items []*models.OrderItem) ([]*models.OrderDiscount, error)
+}
+
+type couponService struct {
+	couponRepo repository.CouponRepo
+	rates      *money.Rates
+}
+
+func NewCouponService(couponRepo repository.CouponRepo, rates *money.Rates) CouponService {
+	return &couponService{
+		couponRepo: couponRepo,
+		rates:      rates,
+	}
+}
+
+func (c *couponService) CreateCoupon(ctx context.Context, req *dtos.Coupon) error {
+	ctx, span := tracing.Start(ctx, "couponService.CreateCoupon")
+	defer span.End()
+
+	model := CouponDtosToModel(req)
+	if model.Currency != "" && !money.ValidCurrency(model.Currency) {
+		return constants.ErrorInvalidCurrency
+	}
+
+	err := c.couponRepo.Create(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *couponService) UpdateCoupon(ctx context.Context, code string, req *dtos.Coupon) error {
+	ctx, span := tracing.Start(ctx, "couponService.UpdateCoupon")
+	defer span.End()
+
+	req.Code = code
+
+	model := CouponDtosToModel(req)
+	if model.Currency != "" && !money.ValidCurrency(model.Currency) {
+		return constants.ErrorInvalidCurrency
+	}
+
+	err := c.couponRepo.Update(ctx, code, model)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *couponService) GetCoupon(ctx context.Context, code string) (*dtos.Coupon, error) {
+	ctx, span := tracing.Start(ctx, "couponService.GetCoupon")
+	defer span.End()
+
+	coupon, err := c.couponRepo.Get(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return CouponModelToDtos(coupon), nil
+}
+
+func (c *couponService) DeleteCoupon(ctx context.Context, code string) error {
+	ctx, span := tracing.Start(ctx, "couponService.DeleteCoupon")
+	defer span.End()
+
+	err := c.couponRepo.Delete(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ApplyCoupon checks the coupon against an already priced order and returns
+// the discount lines it produces, updating the order discount and total in
+// place. Usage limits are only enforced when the coupon is redeemed.
+func (c *couponService) ApplyCoupon(ctx context.Context, code string, order *models.Order, items []*models.OrderItem) ([]*models.OrderDiscount, error) {
+	ctx, span := tracing.Start(ctx, "couponService.ApplyCoupon")
+	defer span.End()
+
+	coupon, err := c.couponRepo.Get(ctx, code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrorCouponInvalid
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if (coupon.StartsAt != nil && order.OrderedAt.Before(*coupon.StartsAt)) || (coupon.EndsAt != nil && order.OrderedAt.After(*coupon.EndsAt)) {
+		return nil, constants.ErrorCouponNotActive
+	}
+
+	if coupon.MinOrderValue.IsPositive() {
+		minOrderValue, err := c.couponAmount(coupon, coupon.MinOrderValue, order.Subtotal.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		if order.Subtotal.Amount.LessThan(minOrderValue.Amount) {
+			return nil, constants.ErrorCouponMinOrderValue
+		}
+	}
+
+	var discounts []*models.OrderDiscount
+	switch coupon.Scope {
+	case constants.CouponScopeOrder:
+		amount, err := c.discountFor(coupon, order.Subtotal, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		discounts = append(discounts, &models.OrderDiscount{
+			OrderDiscountId: uuid.NewString(),
+			OrderId:         order.OrderId,
+			CouponCode:      coupon.Code,
+			Description:     fmt.Sprintf("coupon %s on order", coupon.Code),
+			Amount:          amount,
+		})
+	case constants.CouponScopeArticle:
+		for _, v := range items {
+			if v.ArticleId != coupon.ArticleId {
+				continue
+			}
+
+			amount, err := c.discountFor(coupon, v.UnitPrice.Mul(int64(v.Quantity)), int64(v.Quantity))
+			if err != nil {
+				return nil, err
+			}
+
+			discounts = append(discounts, &models.OrderDiscount{
+				OrderDiscountId: uuid.NewString(),
+				OrderId:         order.OrderId,
+				OrderItemId:     v.OrderItemId,
+				CouponCode:      coupon.Code,
+				Description:     fmt.Sprintf("coupon %s on article %s", coupon.Code, v.ArticleId),
+				Amount:          amount,
+			})
+		}
+	}
+
+	if len(discounts) == 0 {
+		return nil, constants.ErrorCouponNotApplicable
+	}
+
+	discountAmount := money.Zero(order.Subtotal.Currency)
+	for _, v := range discounts {
+		discountAmount, err = discountAmount.Add(v.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	total, err := order.Subtotal.Sub(discountAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	order.CouponCode = coupon.Code
+	order.DiscountAmount = discountAmount
+	order.TotalAmount = total
+
+	return discounts, nil
+}
+
+// discountFor never discounts more than base. Fixed coupons take their value
+// off every unit.
+func (c *couponService) discountFor(coupon *models.Coupon, base money.Money, units int64) (money.Money, error) {
+	var amount money.Money
+
+	if coupon.DiscountType == constants.DiscountTypePercentage {
+		amount = money.New(base.Amount.Mul(coupon.Value).Div(decimal.NewFromInt(100)), base.Currency)
+	} else {
+		fixed, err := c.couponAmount(coupon, coupon.Value, base.Currency)
+		if err != nil {
+			return money.Money{}, err
+		}
+
+		amount = fixed.Mul(units)
+	}
+
+	if amount.Amount.GreaterThan(base.Amount) {
+		amount = base
+	}
+
+	return amount, nil
+}
+
+func (c *couponService) couponAmount(coupon *models.Coupon, amount decimal.Decimal, currency string) (money.Money, error) {
+	couponCurrency := coupon.Currency
+	if couponCurrency == "" {
+		couponCurrency = c.rates.Base
+	}
+
+	return c.rates.Convert(money.New(amount, couponCurrency), currency)
+}
+
+func CouponModelToDtos(m *models.Coupon) *dtos.Coupon {
+	return &dtos.Coupon{
+		Code:               m.Code,
+		DiscountType:       m.DiscountType,
+		Scope:              m.Scope,
+		ArticleId:          m.ArticleId,
+		Value:              m.Value,
+		Currency:           m.Currency,
+		MinOrderValue:      m.MinOrderValue,
+		MaxUses:            m.MaxUses,
+		MaxUsesPerCustomer: m.MaxUsesPerCustomer,
+		UsedCount:          m.UsedCount,
+		StartsAt:           m.StartsAt,
+		EndsAt:             m.EndsAt,
+	}
+}
+
+func CouponDtosToModel(m *dtos.Coupon) *models.Coupon {
+	return &models.Coupon{
+		Code:               m.Code,
+		DiscountType:       m.DiscountType,
+		Scope:              m.Scope,
+		ArticleId:          m.ArticleId,
+		Value:              m.Value,
+		Currency:           money.NormalizeCurrency(m.Currency),
+		MinOrderValue:      m.MinOrderValue,
+		MaxUses:            m.MaxUses,
+		MaxUsesPerCustomer: m.MaxUsesPerCustomer,
+		StartsAt:           m.StartsAt,
+		EndsAt:             m.EndsAt,
+	}
+}
@@ -0,0 +1,235 @@
+package coupons
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type couponServiceTestSuite struct {
+	suite.Suite
+	mockCtrl       *gomock.Controller
+	mockCouponRepo *mocks.MockCouponRepo
+	couponService  CouponService
+}
+
+func TestCouponServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(couponServiceTestSuite))
+}
+
+func (suite *couponServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockCouponRepo = mocks.NewMockCouponRepo(suite.mockCtrl)
+
+	rates := money.NewRates("INR", map[string]decimal.Decimal{"USD": decimal.RequireFromString("0.0125")})
+	suite.couponService = NewCouponService(suite.mockCouponRepo, rates)
+}
+
+func (suite *couponServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *couponServiceTestSuite) pricedOrder() (*models.Order, []*models.OrderItem) {
+	order := &models.Order{
+		OrderId:        "o1",
+		CustomerId:     "c1",
+		OrderedAt:      time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		Subtotal:       money.MustParse("500", "INR"),
+		DiscountAmount: money.Zero("INR"),
+		TotalAmount:    money.MustParse("500", "INR"),
+	}
+
+	items := []*models.OrderItem{
+		{OrderItemId: "i1", OrderId: "o1", ArticleId: "a1", Quantity: 2, UnitPrice: money.MustParse("100", "INR")},
+		{OrderItemId: "i2", OrderId: "o1", ArticleId: "a2", Quantity: 3, UnitPrice: money.MustParse("100", "INR")},
+	}
+
+	return order, items
+}
+
+func (suite *couponServiceTestSuite) TestCreateCoupon() {
+	req := &dtos.Coupon{
+		Code:         "SAVE10",
+		DiscountType: constants.DiscountTypeFixed,
+		Scope:        constants.CouponScopeOrder,
+		Value:        decimal.NewFromInt(10),
+		Currency:     "usd",
+	}
+
+	suite.mockCouponRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.Coupon) error {
+		assert.Equal(suite.T(), "USD", m.Currency)
+		return nil
+	}).Times(1)
+
+	err := suite.couponService.CreateCoupon(context.Background(), req)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *couponServiceTestSuite) TestCreateCouponInvalidCurrency() {
+	req := &dtos.Coupon{Code: "SAVE10", Currency: "dollars"}
+
+	err := suite.couponService.CreateCoupon(context.Background(), req)
+	assert.Equal(suite.T(), constants.ErrorInvalidCurrency, err)
+}
+
+func (suite *couponServiceTestSuite) TestUpdateCoupon() {
+	req := &dtos.Coupon{DiscountType: constants.DiscountTypePercentage, Scope: constants.CouponScopeOrder, Value: decimal.NewFromInt(5)}
+
+	suite.mockCouponRepo.EXPECT().Update(gomock.Any(), "SAVE10", gomock.Any()).Return(nil).Times(1)
+
+	err := suite.couponService.UpdateCoupon(context.Background(), "SAVE10", req)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "SAVE10", req.Code)
+}
+
+func (suite *couponServiceTestSuite) TestGetCoupon() {
+	suite.mockCouponRepo.EXPECT().Get(gomock.Any(), "SAVE10").Return(&models.Coupon{Code: "SAVE10", UsedCount: 2}, nil).Times(1)
+
+	result, err := suite.couponService.GetCoupon(context.Background(), "SAVE10")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.UsedCount)
+}
+
+func (suite *couponServiceTestSuite) TestDeleteCouponError() {
+	suite.mockCouponRepo.EXPECT().Delete(gomock.Any(), "SAVE10").Return(constants.ErrorNotFound).Times(1)
+
+	err := suite.couponService.DeleteCoupon(context.Background(), "SAVE10")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *couponServiceTestSuite) TestApplyPercentageOrderCoupon() {
+	order, items := suite.pricedOrder()
+
+	suite.mockCouponRepo.EXPECT().Get(gomock.Any(), "SAVE10").Return(&models.Coupon{
+		Code:         "SAVE10",
+		DiscountType: constants.DiscountTypePercentage,
+		Scope:        constants.CouponScopeOrder,
+		Value:        decimal.NewFromInt(10),
+	}, nil).Times(1)
+
+	discounts, err := suite.couponService.ApplyCoupon(context.Background(), "SAVE10", order, items)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), discounts, 1)
+	assert.Equal(suite.T(), "50.00 INR", discounts[0].Amount.String())
+	assert.Equal(suite.T(), "50.00 INR", order.DiscountAmount.String())
+	assert.Equal(suite.T(), "450.00 INR", order.TotalAmount.String())
+	assert.Equal(suite.T(), "SAVE10", order.CouponCode)
+}
+
+func (suite *couponServiceTestSuite) TestApplyFixedArticleCoupon() {
+	order, items := suite.pricedOrder()
+
+	suite.mockCouponRepo.EXPECT().Get(gomock.Any(), "A1OFF").Return(&models.Coupon{
+		Code:         "A1OFF",
+		DiscountType: constants.DiscountTypeFixed,
+		Scope:        constants.CouponScopeArticle,
+		ArticleId:    "a1",
+		Value:        decimal.NewFromInt(15),
+	}, nil).Times(1)
+
+	discounts, err := suite.couponService.ApplyCoupon(context.Background(), "A1OFF", order, items)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), discounts, 1)
+	assert.Equal(suite.T(), "i1", discounts[0].OrderItemId)
+	assert.Equal(suite.T(), "30.00 INR", discounts[0].Amount.String())
+	assert.Equal(suite.T(), "470.00 INR", order.TotalAmount.String())
+}
+
+func (suite *couponServiceTestSuite) TestApplyFixedCouponCappedAtLine() {
+	order, items := suite.pricedOrder()
+
+	suite.mockCouponRepo.EXPECT().Get(gomock.Any(), "BIG").Return(&models.Coupon{
+		Code:         "BIG",
+		DiscountType: constants.DiscountTypeFixed,
+		Scope:        constants.CouponScopeArticle,
+		ArticleId:    "a1",
+		Value:        decimal.NewFromInt(150),
+	}, nil).Times(1)
+
+	discounts, err := suite.couponService.ApplyCoupon(context.Background(), "BIG", order, items)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "200.00 INR", discounts[0].Amount.String())
+}
+
+func (suite *couponServiceTestSuite) TestApplyCouponConvertsCurrency() {
+	order, items := suite.pricedOrder()
+
+	suite.mockCouponRepo.EXPECT().Get(gomock.Any(), "USD1").Return(&models.Coupon{
+		Code:         "USD1",
+		DiscountType: constants.DiscountTypeFixed,
+		Scope:        constants.CouponScopeOrder,
+		Value:        decimal.NewFromInt(1),
+		Currency:     "USD",
+	}, nil).Times(1)
+
+	discounts, err := suite.couponService.ApplyCoupon(context.Background(), "USD1", order, items)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "80.00 INR", discounts[0].Amount.String())
+}
+
+func (suite *couponServiceTestSuite) TestApplyCouponMinOrderValue() {
+	order, items := suite.pricedOrder()
+
+	suite.mockCouponRepo.EXPECT().Get(gomock.Any(), "SAVE10").Return(&models.Coupon{
+		Code:          "SAVE10",
+		DiscountType:  constants.DiscountTypePercentage,
+		Scope:         constants.CouponScopeOrder,
+		Value:         decimal.NewFromInt(10),
+		MinOrderValue: decimal.NewFromInt(1000),
+	}, nil).Times(1)
+
+	_, err := suite.couponService.ApplyCoupon(context.Background(), "SAVE10", order, items)
+	assert.Equal(suite.T(), constants.ErrorCouponMinOrderValue, err)
+}
+
+func (suite *couponServiceTestSuite) TestApplyCouponNotActive() {
+	order, items := suite.pricedOrder()
+	endsAt := order.OrderedAt.Add(-time.Hour)
+
+	suite.mockCouponRepo.EXPECT().Get(gomock.Any(), "SAVE10").Return(&models.Coupon{
+		Code:         "SAVE10",
+		DiscountType: constants.DiscountTypePercentage,
+		Scope:        constants.CouponScopeOrder,
+		Value:        decimal.NewFromInt(10),
+		EndsAt:       &endsAt,
+	}, nil).Times(1)
+
+	_, err := suite.couponService.ApplyCoupon(context.Background(), "SAVE10", order, items)
+	assert.Equal(suite.T(), constants.ErrorCouponNotActive, err)
+}
+
+func (suite *couponServiceTestSuite) TestApplyCouponNotApplicable() {
+	order, items := suite.pricedOrder()
+
+	suite.mockCouponRepo.EXPECT().Get(gomock.Any(), "A9OFF").Return(&models.Coupon{
+		Code:         "A9OFF",
+		DiscountType: constants.DiscountTypePercentage,
+		Scope:        constants.CouponScopeArticle,
+		ArticleId:    "a9",
+		Value:        decimal.NewFromInt(10),
+	}, nil).Times(1)
+
+	_, err := suite.couponService.ApplyCoupon(context.Background(), "A9OFF", order, items)
+	assert.Equal(suite.T(), constants.ErrorCouponNotApplicable, err)
+}
+
+func (suite *couponServiceTestSuite) TestApplyCouponInvalid() {
+	order, items := suite.pricedOrder()
+
+	suite.mockCouponRepo.EXPECT().Get(gomock.Any(), "NOPE").Return(nil, gorm.ErrRecordNotFound).Times(1)
+
+	_, err := suite.couponService.ApplyCoupon(context.Background(), "NOPE", order, items)
+	assert.Equal(suite.T(), constants.ErrorCouponInvalid, err)
+}
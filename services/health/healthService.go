@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/migrations"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// checkTimeout bounds the database queries of a readiness check, so a hung
+// database fails the probe instead of blocking it.
+const checkTimeout = 2 * time.Second
+
+// Commit and BuildTime are set at build time with
+//
+//	-ldflags "-X inventory-management/services/health.Commit=... -X inventory-management/services/health.BuildTime=..."
+//
+// and otherwise fall back to the commit and commit time Go stamps into the
+// binary.
+var (
+	Commit    string
+	BuildTime string
+)
+
+// Worker is a service doing work in the background, which has to be running
+// for the app to be ready and has to stop before the database is closed.
+type Worker interface {
+	Running() bool
+	Shutdown(ctx context.Context) error
+}
+
+type HealthService interface {
+	Ready(ctx context.Context) *dtos.Readiness
+	Version() *dtos.Version
+	Drain()
+}
+
+type healthService struct {
+	db       *gorm.DB
+	workers  []Worker
+	appName  string
+	draining atomic.Bool
+}
+
+func NewHealthService(db *gorm.DB, workers []Worker, appName string) HealthService {
+	return &healthService{
+		db:      db,
+		workers: workers,
+		appName: appName,
+	}
+}
+
+// Ready checks the database answers, has every migration of this build and
+// the workers are running. Every check is reported, and the app is ready only
+// when all pass and it isn't shutting down.
+func (h *healthService) Ready(ctx context.Context) *dtos.Readiness {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	checks := make(map[string]string)
+	check := func(name string, err error) {
+		checks[name] = constants.HealthStatusOk
+		if err != nil {
+			checks[name] = err.Error()
+		}
+	}
+
+	if h.draining.Load() {
+		check("shutdown", constants.ErrorShuttingDown)
+	}
+
+	sqlDB, err := h.db.DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+	check("database", err)
+
+	if err == nil {
+		var migrator migrations.Migrator
+		migrator, err = migrations.NewMigrator(h.db.WithContext(ctx))
+		if err == nil {
+			err = migrator.Check()
+		}
+	}
+	check("migrations", err)
+
+	err = nil
+	for _, v := range h.workers {
+		if !v.Running() {
+			err = constants.ErrorWorkerStopped
+		}
+	}
+	check("workers", err)
+
+	result := &dtos.Readiness{Status: constants.HealthStatusOk, Checks: checks}
+	for _, v := range checks {
+		if v != constants.HealthStatusOk {
+			result.Status = constants.HealthStatusFailing
+		}
+	}
+
+	return result
+}
+
+// Drain makes the app report not ready from now on, so load balancers stop
+// sending it traffic before it shuts down.
+func (h *healthService) Drain() {
+	h.draining.Store(true)
+}
+
+func (h *healthService) Version() *dtos.Version {
+	result := &dtos.Version{
+		AppName:   h.appName,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, v := range info.Settings {
+			if v.Key == "vcs.revision" && result.Commit == "" {
+				result.Commit = v.Value
+			}
+			if v.Key == "vcs.time" && result.BuildTime == "" {
+				result.BuildTime = v.Value
+			}
+		}
+	}
+
+	if result.Commit == "" {
+		result.Commit = "unknown"
+	}
+	if result.BuildTime == "" {
+		result.BuildTime = "unknown"
+	}
+
+	return result
+}
@@ -0,0 +1,75 @@
+package health
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/migrations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type fakeWorker struct {
+	running bool
+}
+
+func (f *fakeWorker) Running() bool {
+	return f.running
+}
+
+func (f *fakeWorker) Shutdown(ctx context.Context) error {
+	f.running = false
+	return nil
+}
+
+func TestReady(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+
+	worker := &fakeWorker{running: true}
+	healthService := NewHealthService(db, []Worker{worker}, "inventory-management")
+
+	readiness := healthService.Ready(context.Background())
+	assert.Equal(t, constants.HealthStatusFailing, readiness.Status)
+	assert.Equal(t, constants.HealthStatusOk, readiness.Checks["database"])
+	assert.Contains(t, readiness.Checks["migrations"], constants.ErrorSchemaOutdated.Error())
+
+	migrator, err := migrations.NewMigrator(db)
+	assert.NoError(t, err)
+	_, err = migrator.Up()
+	assert.NoError(t, err)
+
+	readiness = healthService.Ready(context.Background())
+	assert.Equal(t, constants.HealthStatusOk, readiness.Status)
+	assert.Equal(t, map[string]string{"database": "ok", "migrations": "ok", "workers": "ok"}, readiness.Checks)
+
+	worker.Shutdown(context.Background())
+	readiness = healthService.Ready(context.Background())
+	assert.Equal(t, constants.HealthStatusFailing, readiness.Status)
+	assert.Equal(t, constants.ErrorWorkerStopped.Error(), readiness.Checks["workers"])
+
+	worker.running = true
+	healthService.Drain()
+	readiness = healthService.Ready(context.Background())
+	assert.Equal(t, constants.HealthStatusFailing, readiness.Status)
+	assert.Equal(t, constants.ErrorShuttingDown.Error(), readiness.Checks["shutdown"])
+
+	sqlDB, _ := db.DB()
+	sqlDB.Close()
+	readiness = healthService.Ready(context.Background())
+	assert.NotEqual(t, constants.HealthStatusOk, readiness.Checks["database"])
+	assert.NotEqual(t, constants.HealthStatusOk, readiness.Checks["migrations"])
+}
+
+func TestVersion(t *testing.T) {
+	Commit, BuildTime = "0210c54", "2026-10-19T12:00:00Z"
+	defer func() { Commit, BuildTime = "", "" }()
+
+	version := NewHealthService(nil, nil, "inventory-management").Version()
+	assert.Equal(t, "inventory-management", version.AppName)
+	assert.Equal(t, "0210c54", version.Commit)
+	assert.Equal(t, "2026-10-19T12:00:00Z", version.BuildTime)
+	assert.NotEmpty(t, version.GoVersion)
+}
@@ -0,0 +1,497 @@
+package invoices
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/services/taxes"
+	"inventory-management/tracing"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type InvoiceService interface {
+	IssueInvoice(ctx context.Context, repos *repository.Repos, order *models.Order) (*dtos.Invoice, error)
+	IssueCreditNote(ctx context.Context, repos *repository.Repos, orderId string, quantities map[string]int) (*dtos.Invoice, error)
+	QuoteCreditNote(ctx context.Context, repos *repository.Repos, orderId string, quantities map[string]int) (*dtos.Invoice, error)
+	GetOrderInvoice(ctx context.Context, orderId string) (*dtos.Invoice, error)
+	GetCreditNotes(ctx context.Context, orderId string) ([]*dtos.Invoice, error)
+	GetInvoice(ctx context.Context, invoiceNumber string) (*dtos.Invoice, error)
+}
+
+type invoiceService struct {
+	invoiceRepo repository.InvoiceRepo
+	userRepo    repository.UserRepo
+	addressRepo repository.AddressRepo
+	articleRepo repository.ArticleRepo
+	sellerId    string
+}
+
+func NewInvoiceService(invoiceRepo repository.InvoiceRepo, userRepo repository.UserRepo, addressRepo repository.AddressRepo,
+	articleRepo repository.ArticleRepo, sellerId string) InvoiceService {
+	return &invoiceService{
+		invoiceRepo: invoiceRepo,
+		userRepo:    userRepo,
+		addressRepo: addressRepo,
+		articleRepo: articleRepo,
+		sellerId:    sellerId,
+	}
+}
+
+// IssueInvoice issues the invoice for a confirmed order inside the caller's
+// transaction. An order only ever gets one invoice; issuing again returns it.
+func (i *invoiceService) IssueInvoice(ctx context.Context, repos *repository.Repos, order *models.Order) (*dtos.Invoice, error) {
+	ctx, span := tracing.Start(ctx, "invoiceService.IssueInvoice")
+	defer span.End()
+
+	existing, err := repos.Invoices.GetByOrder(ctx, order.OrderId, constants.InvoiceTypeInvoice)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing) > 0 {
+		return InvoiceModelToDtos(existing[0])
+	}
+
+	items, err := repos.OrderItems.GetByOrder(ctx, order.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	discounts, err := repos.OrderDiscounts.GetByOrder(ctx, order.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	seller, err := i.seller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	buyer, err := i.party(ctx, order.CustomerId, order.ShippingAddressId)
+	if err != nil {
+		return nil, err
+	}
+
+	taxable, err := taxes.TaxableAmounts(order, items, discounts)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := order.TotalAmount.Currency
+	invoice := &dtos.Invoice{
+		Type:           constants.InvoiceTypeInvoice,
+		OrderId:        order.OrderId,
+		IssuedAt:       time.Now().UTC(),
+		Currency:       currency,
+		Seller:         seller,
+		Buyer:          buyer,
+		Subtotal:       order.Subtotal,
+		DiscountAmount: order.DiscountAmount,
+		NetAmount:      money.New(order.TotalAmount.Amount.Sub(order.TaxAmount.Amount), currency),
+		TaxAmount:      order.TaxAmount,
+		TotalAmount:    order.TotalAmount,
+	}
+
+	for n, v := range items {
+		description, err := i.description(ctx, v.ArticleId)
+		if err != nil {
+			return nil, err
+		}
+
+		lineAmount := v.UnitPrice.Mul(int64(v.Quantity))
+		netAmount := taxable[v.OrderItemId].Amount
+		if order.PricesIncludeTax {
+			netAmount = netAmount.Sub(v.TaxAmount.Amount)
+		}
+
+		invoice.Lines = append(invoice.Lines, &dtos.InvoiceLines{
+			LineNumber:     n + 1,
+			OrderItemId:    v.OrderItemId,
+			ArticleId:      v.ArticleId,
+			Description:    description,
+			Quantity:       v.Quantity,
+			UnitPrice:      v.UnitPrice,
+			LineAmount:     lineAmount,
+			DiscountAmount: money.New(lineAmount.Amount.Sub(taxable[v.OrderItemId].Amount), currency),
+			NetAmount:      money.New(netAmount, currency),
+			TaxRate:        v.TaxRate,
+			TaxAmount:      money.New(v.TaxAmount.Amount, currency),
+		})
+	}
+
+	invoice.Taxes = taxSummary(invoice.Lines, currency)
+
+	err = i.save(ctx, repos, invoice, "INV")
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// IssueCreditNote credits the given quantity of each invoice line, keyed by
+// order item id, or everything not yet credited when quantities is nil. The
+// last credit for a line takes whatever amount is left so that credits always
+// add up to the invoice exactly.
+func (i *invoiceService) IssueCreditNote(ctx context.Context, repos *repository.Repos, orderId string, quantities map[string]int) (*dtos.Invoice, error) {
+	ctx, span := tracing.Start(ctx, "invoiceService.IssueCreditNote")
+	defer span.End()
+
+	creditNote, err := i.QuoteCreditNote(ctx, repos, orderId, quantities)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.save(ctx, repos, creditNote, "CN")
+	if err != nil {
+		return nil, err
+	}
+
+	return creditNote, nil
+}
+
+// QuoteCreditNote works out the credit note IssueCreditNote would issue
+// without numbering or storing it.
+func (i *invoiceService) QuoteCreditNote(ctx context.Context, repos *repository.Repos, orderId string, quantities map[string]int) (*dtos.Invoice, error) {
+	ctx, span := tracing.Start(ctx, "invoiceService.QuoteCreditNote")
+	defer span.End()
+
+	invoices, err := repos.Invoices.GetByOrder(ctx, orderId, constants.InvoiceTypeInvoice)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invoices) == 0 {
+		return nil, constants.ErrorNotFound
+	}
+
+	invoice, err := InvoiceModelToDtos(invoices[0])
+	if err != nil {
+		return nil, err
+	}
+
+	credited, err := i.credited(ctx, repos, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := invoice.Currency
+	creditNote := &dtos.Invoice{
+		Type:       constants.InvoiceTypeCreditNote,
+		OrderId:    orderId,
+		InvoiceRef: invoice.InvoiceNumber,
+		IssuedAt:   time.Now().UTC(),
+		Currency:   currency,
+		Seller:     invoice.Seller,
+		Buyer:      invoice.Buyer,
+	}
+
+	matched := 0
+	subtotal, discount, net, tax := decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero
+	for _, v := range invoice.Lines {
+		previous := credited[v.OrderItemId]
+		if previous == nil {
+			previous = &dtos.InvoiceLines{}
+		}
+
+		remaining := v.Quantity - previous.Quantity
+
+		quantity := remaining
+		if quantities != nil {
+			var ok bool
+			quantity, ok = quantities[v.OrderItemId]
+			if ok {
+				matched++
+			}
+		}
+
+		if quantity > remaining || quantity < 0 {
+			return nil, constants.ErrorCreditExceedsInvoice
+		}
+
+		if quantity == 0 {
+			continue
+		}
+
+		line := &dtos.InvoiceLines{
+			LineNumber:  len(creditNote.Lines) + 1,
+			OrderItemId: v.OrderItemId,
+			ArticleId:   v.ArticleId,
+			Description: v.Description,
+			Quantity:    quantity,
+			UnitPrice:   v.UnitPrice,
+			LineAmount:  v.UnitPrice.Mul(int64(quantity)),
+			TaxRate:     v.TaxRate,
+		}
+
+		if quantity == remaining {
+			line.DiscountAmount = money.New(v.DiscountAmount.Amount.Sub(previous.DiscountAmount.Amount), currency)
+			line.NetAmount = money.New(v.NetAmount.Amount.Sub(previous.NetAmount.Amount), currency)
+			line.TaxAmount = money.New(v.TaxAmount.Amount.Sub(previous.TaxAmount.Amount), currency)
+		} else {
+			line.DiscountAmount = share(v.DiscountAmount, quantity, v.Quantity)
+			line.NetAmount = share(v.NetAmount, quantity, v.Quantity)
+			line.TaxAmount = share(v.TaxAmount, quantity, v.Quantity)
+		}
+
+		creditNote.Lines = append(creditNote.Lines, line)
+
+		subtotal = subtotal.Add(line.LineAmount.Amount)
+		discount = discount.Add(line.DiscountAmount.Amount)
+		net = net.Add(line.NetAmount.Amount)
+		tax = tax.Add(line.TaxAmount.Amount)
+	}
+
+	if matched != len(quantities) || len(creditNote.Lines) == 0 {
+		return nil, constants.ErrorCreditExceedsInvoice
+	}
+
+	creditNote.Subtotal = money.New(subtotal, currency)
+	creditNote.DiscountAmount = money.New(discount, currency)
+	creditNote.NetAmount = money.New(net, currency)
+	creditNote.TaxAmount = money.New(tax, currency)
+	creditNote.TotalAmount = money.New(net.Add(tax), currency)
+	creditNote.Taxes = taxSummary(creditNote.Lines, currency)
+
+	return creditNote, nil
+}
+
+func (i *invoiceService) GetOrderInvoice(ctx context.Context, orderId string) (*dtos.Invoice, error) {
+	ctx, span := tracing.Start(ctx, "invoiceService.GetOrderInvoice")
+	defer span.End()
+
+	invoices, err := i.invoiceRepo.GetByOrder(ctx, orderId, constants.InvoiceTypeInvoice)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invoices) == 0 {
+		return nil, constants.ErrorNotFound
+	}
+
+	return InvoiceModelToDtos(invoices[0])
+}
+
+func (i *invoiceService) GetCreditNotes(ctx context.Context, orderId string) ([]*dtos.Invoice, error) {
+	ctx, span := tracing.Start(ctx, "invoiceService.GetCreditNotes")
+	defer span.End()
+
+	creditNotes, err := i.invoiceRepo.GetByOrder(ctx, orderId, constants.InvoiceTypeCreditNote)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*dtos.Invoice{}
+	for _, v := range creditNotes {
+		creditNote, err := InvoiceModelToDtos(v)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, creditNote)
+	}
+
+	return result, nil
+}
+
+func (i *invoiceService) GetInvoice(ctx context.Context, invoiceNumber string) (*dtos.Invoice, error) {
+	ctx, span := tracing.Start(ctx, "invoiceService.GetInvoice")
+	defer span.End()
+
+	invoice, err := i.invoiceRepo.GetByNumber(ctx, invoiceNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return InvoiceModelToDtos(invoice)
+}
+
+// save numbers the document from its sequence and stores it in the same
+// transaction, so a rolled back document never uses up a number.
+func (i *invoiceService) save(ctx context.Context, repos *repository.Repos, invoice *dtos.Invoice, prefix string) error {
+	number, err := repos.Sequences.Next(ctx, invoice.Type)
+	if err != nil {
+		return err
+	}
+
+	invoice.InvoiceNumber = fmt.Sprintf("%s-%06d", prefix, number)
+
+	model, err := InvoiceDtosToModel(invoice)
+	if err != nil {
+		return err
+	}
+
+	return repos.Invoices.Create(ctx, model)
+}
+
+// credited adds up what earlier credit notes for the order already credited,
+// per order item.
+func (i *invoiceService) credited(ctx context.Context, repos *repository.Repos, orderId string) (map[string]*dtos.InvoiceLines, error) {
+	creditNotes, err := repos.Invoices.GetByOrder(ctx, orderId, constants.InvoiceTypeCreditNote)
+	if err != nil {
+		return nil, err
+	}
+
+	credited := make(map[string]*dtos.InvoiceLines)
+	for _, v := range creditNotes {
+		creditNote, err := InvoiceModelToDtos(v)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, line := range creditNote.Lines {
+			total, ok := credited[line.OrderItemId]
+			if !ok {
+				total = &dtos.InvoiceLines{}
+				credited[line.OrderItemId] = total
+			}
+
+			total.Quantity += line.Quantity
+			total.DiscountAmount.Amount = total.DiscountAmount.Amount.Add(line.DiscountAmount.Amount)
+			total.NetAmount.Amount = total.NetAmount.Amount.Add(line.NetAmount.Amount)
+			total.TaxAmount.Amount = total.TaxAmount.Amount.Add(line.TaxAmount.Amount)
+		}
+	}
+
+	return credited, nil
+}
+
+func (i *invoiceService) seller(ctx context.Context) (dtos.Party, error) {
+	if i.sellerId == "" {
+		return dtos.Party{}, constants.ErrorSellerNotConfigured
+	}
+
+	seller, err := i.party(ctx, i.sellerId, "")
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dtos.Party{}, constants.ErrorSellerNotConfigured
+	}
+
+	return seller, err
+}
+
+// party builds the invoice details of a user, using addressId instead of the
+// user's own address when it is set.
+func (i *invoiceService) party(ctx context.Context, userId string, addressId string) (dtos.Party, error) {
+	user, err := i.userRepo.Get(ctx, userId)
+	if err != nil {
+		return dtos.Party{}, err
+	}
+
+	if addressId == "" {
+		addressId = user.AddressId
+	}
+
+	party := dtos.Party{
+		Id:     user.Id,
+		Name:   user.Name,
+		Email:  user.Email,
+		Mobile: user.Mobile,
+	}
+
+	if addressId == "" {
+		return party, nil
+	}
+
+	address, err := i.addressRepo.Get(ctx, addressId)
+	if err != nil {
+		return dtos.Party{}, err
+	}
+
+	party.Address = dtos.Address{
+		AddressId: address.AddressId,
+		Line1:     address.Line1,
+		Line2:     address.Line2,
+		City:      address.City,
+		State:     address.State,
+		Country:   address.Country,
+		ZipCode:   address.ZipCode,
+	}
+
+	return party, nil
+}
+
+func (i *invoiceService) description(ctx context.Context, articleId string) (string, error) {
+	article, err := i.articleRepo.Get(ctx, articleId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return articleId, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return article.ArticleName, nil
+}
+
+func share(amount money.Money, quantity int, of int) money.Money {
+	return money.New(amount.Amount.Mul(decimal.NewFromInt(int64(quantity))).Div(decimal.NewFromInt(int64(of))), amount.Currency)
+}
+
+func taxSummary(lines []*dtos.InvoiceLines, currency string) []*dtos.InvoiceTaxes {
+	byRate := make(map[string]*dtos.InvoiceTaxes)
+	summary := []*dtos.InvoiceTaxes{}
+
+	for _, v := range lines {
+		key := v.TaxRate.String()
+
+		tax, ok := byRate[key]
+		if !ok {
+			tax = &dtos.InvoiceTaxes{
+				TaxRate:       v.TaxRate,
+				TaxableAmount: money.Zero(currency),
+				TaxAmount:     money.Zero(currency),
+			}
+			byRate[key] = tax
+			summary = append(summary, tax)
+		}
+
+		tax.TaxableAmount = money.New(tax.TaxableAmount.Amount.Add(v.NetAmount.Amount), currency)
+		tax.TaxAmount = money.New(tax.TaxAmount.Amount.Add(v.TaxAmount.Amount), currency)
+	}
+
+	sort.Slice(summary, func(a, b int) bool {
+		return summary[a].TaxRate.LessThan(summary[b].TaxRate)
+	})
+
+	return summary
+}
+
+func InvoiceModelToDtos(m *models.Invoice) (*dtos.Invoice, error) {
+	var invoice *dtos.Invoice
+
+	err := json.Unmarshal([]byte(m.Document), &invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func InvoiceDtosToModel(m *dtos.Invoice) (*models.Invoice, error) {
+	document, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Invoice{
+		InvoiceId:     uuid.NewString(),
+		InvoiceNumber: m.InvoiceNumber,
+		Type:          m.Type,
+		OrderId:       m.OrderId,
+		InvoiceRef:    m.InvoiceRef,
+		IssuedAt:      m.IssuedAt,
+		TotalAmount:   m.TotalAmount,
+		Document:      string(document),
+	}, nil
+}
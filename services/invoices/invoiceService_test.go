@@ -0,0 +1,269 @@
+package invoices
+
+import (
+	"bytes"
+	"context"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/repository/mocks"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type invoiceServiceTestSuite struct {
+	suite.Suite
+	mockCtrl              *gomock.Controller
+	mockInvoiceRepo       *mocks.MockInvoiceRepo
+	mockSequenceRepo      *mocks.MockDocumentSequenceRepo
+	mockOrderItemRepo     *mocks.MockOrderItemRepo
+	mockOrderDiscountRepo *mocks.MockOrderDiscountRepo
+	mockUserRepo          *mocks.MockUserRepo
+	mockAddressRepo       *mocks.MockAddressRepo
+	mockArticleRepo       *mocks.MockArticleRepo
+	repos                 *repository.Repos
+	issued                []*models.Invoice
+	invoiceService        InvoiceService
+}
+
+func TestInvoiceServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(invoiceServiceTestSuite))
+}
+
+func (suite *invoiceServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockInvoiceRepo = mocks.NewMockInvoiceRepo(suite.mockCtrl)
+	suite.mockSequenceRepo = mocks.NewMockDocumentSequenceRepo(suite.mockCtrl)
+	suite.mockOrderItemRepo = mocks.NewMockOrderItemRepo(suite.mockCtrl)
+	suite.mockOrderDiscountRepo = mocks.NewMockOrderDiscountRepo(suite.mockCtrl)
+	suite.mockUserRepo = mocks.NewMockUserRepo(suite.mockCtrl)
+	suite.mockAddressRepo = mocks.NewMockAddressRepo(suite.mockCtrl)
+	suite.mockArticleRepo = mocks.NewMockArticleRepo(suite.mockCtrl)
+
+	suite.repos = &repository.Repos{
+		Invoices:       suite.mockInvoiceRepo,
+		Sequences:      suite.mockSequenceRepo,
+		OrderItems:     suite.mockOrderItemRepo,
+		OrderDiscounts: suite.mockOrderDiscountRepo,
+	}
+
+	// the fake invoice table keeps whatever the service issues
+	suite.issued = nil
+	suite.mockInvoiceRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.Invoice) error {
+		suite.issued = append(suite.issued, m)
+		return nil
+	}).AnyTimes()
+	suite.mockInvoiceRepo.EXPECT().GetByOrder(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, orderId string, invoiceType string) ([]*models.Invoice, error) {
+		var result []*models.Invoice
+		for _, v := range suite.issued {
+			if v.OrderId == orderId && v.Type == invoiceType {
+				result = append(result, v)
+			}
+		}
+		return result, nil
+	}).AnyTimes()
+
+	sequences := map[string]int64{}
+	suite.mockSequenceRepo.EXPECT().Next(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, name string) (int64, error) {
+		sequences[name]++
+		return sequences[name], nil
+	}).AnyTimes()
+
+	suite.invoiceService = NewInvoiceService(suite.mockInvoiceRepo, suite.mockUserRepo, suite.mockAddressRepo, suite.mockArticleRepo, "seller")
+}
+
+func (suite *invoiceServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+// issue invoices an order of 3 x 100 at 18% and 1 x 50 at 5% with 35 off the
+// order.
+func (suite *invoiceServiceTestSuite) issue() *dtos.Invoice {
+	order := &models.Order{
+		OrderId:           "o1",
+		CustomerId:        "c1",
+		ShippingAddressId: "ship1",
+		Subtotal:          money.MustParse("350", "INR"),
+		DiscountAmount:    money.MustParse("35", "INR"),
+		TaxAmount:         money.MustParse("50.85", "INR"),
+		TotalAmount:       money.MustParse("365.85", "INR"),
+	}
+
+	items := []*models.OrderItem{
+		{OrderItemId: "i1", OrderId: "o1", ArticleId: "a1", Quantity: 3, UnitPrice: money.MustParse("100", "INR"),
+			TaxRate: decimal.NewFromInt(18), TaxAmount: money.MustParse("48.60", "INR")},
+		{OrderItemId: "i2", OrderId: "o1", ArticleId: "a2", Quantity: 1, UnitPrice: money.MustParse("50", "INR"),
+			TaxRate: decimal.NewFromInt(5), TaxAmount: money.MustParse("2.25", "INR")},
+	}
+
+	discounts := []*models.OrderDiscount{
+		{OrderDiscountId: "d1", OrderId: "o1", Amount: money.MustParse("35", "INR")},
+	}
+
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return(items, nil).Times(1)
+	suite.mockOrderDiscountRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return(discounts, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "seller").Return(&models.User{Id: "seller", Name: "Acme Traders", AddressId: "sa1"}, nil).Times(1)
+	suite.mockAddressRepo.EXPECT().Get(gomock.Any(), "sa1").Return(&models.Address{AddressId: "sa1", City: "Chennai", Country: "IN"}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c1").Return(&models.User{Id: "c1", Name: "John", AddressId: "home"}, nil).Times(1)
+	suite.mockAddressRepo.EXPECT().Get(gomock.Any(), "ship1").Return(&models.Address{AddressId: "ship1", City: "Bengaluru", Country: "IN"}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", ArticleName: "Widget"}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a2").Return(nil, gorm.ErrRecordNotFound).Times(1)
+
+	invoice, err := suite.invoiceService.IssueInvoice(context.Background(), suite.repos, order)
+	assert.NoError(suite.T(), err)
+
+	return invoice
+}
+
+func (suite *invoiceServiceTestSuite) TestIssueInvoice() {
+	invoice := suite.issue()
+
+	assert.Equal(suite.T(), "INV-000001", invoice.InvoiceNumber)
+	assert.Equal(suite.T(), "Acme Traders", invoice.Seller.Name)
+	assert.Equal(suite.T(), "Bengaluru", invoice.Buyer.Address.City)
+	assert.Len(suite.T(), invoice.Lines, 2)
+	assert.Equal(suite.T(), "Widget", invoice.Lines[0].Description)
+	assert.Equal(suite.T(), "a2", invoice.Lines[1].Description)
+	assert.Equal(suite.T(), "30.00 INR", invoice.Lines[0].DiscountAmount.String())
+	assert.Equal(suite.T(), "270.00 INR", invoice.Lines[0].NetAmount.String())
+	assert.Equal(suite.T(), "45.00 INR", invoice.Lines[1].NetAmount.String())
+	assert.Equal(suite.T(), "315.00 INR", invoice.NetAmount.String())
+	assert.Len(suite.T(), invoice.Taxes, 2)
+	assert.True(suite.T(), invoice.Taxes[0].TaxRate.Equal(decimal.NewFromInt(5)))
+
+	assert.Len(suite.T(), suite.issued, 1)
+	assert.Equal(suite.T(), "365.85 INR", suite.issued[0].TotalAmount.String())
+
+	stored, err := InvoiceModelToDtos(suite.issued[0])
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), invoice.Lines[0].NetAmount, stored.Lines[0].NetAmount)
+}
+
+func (suite *invoiceServiceTestSuite) TestIssueInvoiceTwice() {
+	first := suite.issue()
+
+	second, err := suite.invoiceService.IssueInvoice(context.Background(), suite.repos, &models.Order{OrderId: "o1"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), first.InvoiceNumber, second.InvoiceNumber)
+	assert.Len(suite.T(), suite.issued, 1)
+}
+
+func (suite *invoiceServiceTestSuite) TestIssueInvoiceSellerNotConfigured() {
+	invoiceService := NewInvoiceService(suite.mockInvoiceRepo, suite.mockUserRepo, suite.mockAddressRepo, suite.mockArticleRepo, "")
+
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return(nil, nil).Times(1)
+	suite.mockOrderDiscountRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return(nil, nil).Times(1)
+
+	_, err := invoiceService.IssueInvoice(context.Background(), suite.repos, &models.Order{OrderId: "o1"})
+	assert.Equal(suite.T(), constants.ErrorSellerNotConfigured, err)
+}
+
+func (suite *invoiceServiceTestSuite) TestCreditNotesAddUpToInvoice() {
+	invoice := suite.issue()
+
+	partial, err := suite.invoiceService.IssueCreditNote(context.Background(), suite.repos, "o1", map[string]int{"i1": 1})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "CN-000001", partial.InvoiceNumber)
+	assert.Equal(suite.T(), "INV-000001", partial.InvoiceRef)
+	assert.Equal(suite.T(), "90.00 INR", partial.NetAmount.String())
+	assert.Equal(suite.T(), "16.20 INR", partial.TaxAmount.String())
+
+	rest, err := suite.invoiceService.IssueCreditNote(context.Background(), suite.repos, "o1", nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "CN-000002", rest.InvoiceNumber)
+	assert.Len(suite.T(), rest.Lines, 2)
+	assert.Equal(suite.T(), 2, rest.Lines[0].Quantity)
+
+	total, _ := partial.TotalAmount.Add(rest.TotalAmount)
+	assert.Equal(suite.T(), invoice.TotalAmount.String(), total.String())
+
+	_, err = suite.invoiceService.IssueCreditNote(context.Background(), suite.repos, "o1", nil)
+	assert.Equal(suite.T(), constants.ErrorCreditExceedsInvoice, err)
+}
+
+func (suite *invoiceServiceTestSuite) TestCreditNoteExceedsInvoice() {
+	suite.issue()
+
+	_, err := suite.invoiceService.IssueCreditNote(context.Background(), suite.repos, "o1", map[string]int{"i1": 4})
+	assert.Equal(suite.T(), constants.ErrorCreditExceedsInvoice, err)
+
+	_, err = suite.invoiceService.IssueCreditNote(context.Background(), suite.repos, "o1", map[string]int{"unknown": 1})
+	assert.Equal(suite.T(), constants.ErrorCreditExceedsInvoice, err)
+}
+
+func (suite *invoiceServiceTestSuite) TestCreditNoteWithoutInvoice() {
+	_, err := suite.invoiceService.IssueCreditNote(context.Background(), suite.repos, "o2", nil)
+	assert.Equal(suite.T(), constants.ErrorNotFound, err)
+}
+
+func (suite *invoiceServiceTestSuite) TestGetOrderInvoiceNotFound() {
+	_, err := suite.invoiceService.GetOrderInvoice(context.Background(), "o2")
+	assert.Equal(suite.T(), constants.ErrorNotFound, err)
+}
+
+func (suite *invoiceServiceTestSuite) TestGetInvoice() {
+	suite.issue()
+
+	suite.mockInvoiceRepo.EXPECT().GetByNumber(gomock.Any(), "INV-000001").Return(suite.issued[0], nil).Times(1)
+
+	result, err := suite.invoiceService.GetInvoice(context.Background(), "INV-000001")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "o1", result.OrderId)
+}
+
+func (suite *invoiceServiceTestSuite) TestRenderPDF() {
+	invoice := suite.issue()
+
+	first, err := RenderPDF(invoice)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), bytes.HasPrefix(first, []byte("%PDF-")))
+
+	second, _ := RenderPDF(invoice)
+	assert.Equal(suite.T(), first, second)
+}
+
+func (suite *invoiceServiceTestSuite) TestRenderUBL() {
+	invoice := suite.issue()
+	invoice.IssuedAt = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	body, err := RenderUBL(invoice)
+	assert.NoError(suite.T(), err)
+
+	xml := string(body)
+	assert.True(suite.T(), strings.Contains(xml, `<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"`))
+	assert.Contains(suite.T(), xml, "<cbc:IssueDate>2025-03-01</cbc:IssueDate>")
+	assert.Contains(suite.T(), xml, "<cbc:InvoiceTypeCode>380</cbc:InvoiceTypeCode>")
+	assert.Contains(suite.T(), xml, `<cbc:InvoicedQuantity unitCode="C62">3</cbc:InvoicedQuantity>`)
+	assert.Contains(suite.T(), xml, `<cbc:PayableAmount currencyID="INR">365.85</cbc:PayableAmount>`)
+
+	creditNote, err := suite.invoiceService.IssueCreditNote(context.Background(), suite.repos, "o1", map[string]int{"i2": 1})
+	assert.NoError(suite.T(), err)
+
+	body, err = RenderUBL(creditNote)
+	assert.NoError(suite.T(), err)
+
+	xml = string(body)
+	assert.Contains(suite.T(), xml, "<cbc:CreditNoteTypeCode>381</cbc:CreditNoteTypeCode>")
+	assert.Contains(suite.T(), xml, "<cac:CreditNoteLine>")
+	assert.Contains(suite.T(), xml, "<cbc:ID>INV-000001</cbc:ID>")
+}
+
+func (suite *invoiceServiceTestSuite) TestQuoteCreditNote() {
+	suite.issue()
+
+	quote, err := suite.invoiceService.QuoteCreditNote(context.Background(), suite.repos, "o1", map[string]int{"i1": 1})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), quote.InvoiceNumber)
+	assert.Equal(suite.T(), "106.20 INR", quote.TotalAmount.String())
+	assert.Len(suite.T(), suite.issued, 1)
+}
@@ -0,0 +1,134 @@
+package invoices
+
+import (
+	"bytes"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/money"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// RenderPDF lays out an invoice or credit note on A4 pages. Dates are fixed to
+// the issue date and the catalog is sorted so rendering the same document twice
+// gives the same bytes.
+func RenderPDF(invoice *dtos.Invoice) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetCreationDate(invoice.IssuedAt)
+	pdf.SetModificationDate(invoice.IssuedAt)
+	pdf.SetCatalogSort(true)
+	pdf.SetTitle(invoice.InvoiceNumber, true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	title := "INVOICE"
+	if invoice.Type == constants.InvoiceTypeCreditNote {
+		title = "CREDIT NOTE"
+	}
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, title, "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 5, tr("Number: "+invoice.InvoiceNumber), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, "Date: "+invoice.IssuedAt.Format("2006-01-02"), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, tr("Order: "+invoice.OrderId), "", 1, "L", false, 0, "")
+	if invoice.InvoiceRef != "" {
+		pdf.CellFormat(0, 5, tr("Credits invoice: "+invoice.InvoiceRef), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(5)
+
+	y := pdf.GetY()
+	pdf.MultiCell(90, 5, tr("From\n"+partyText(invoice.Seller)), "", "L", false)
+	sellerY := pdf.GetY()
+	pdf.SetXY(110, y)
+	pdf.MultiCell(90, 5, tr("Bill to\n"+partyText(invoice.Buyer)), "", "L", false)
+	if sellerY > pdf.GetY() {
+		pdf.SetY(sellerY)
+	}
+	pdf.Ln(5)
+
+	widths := []float64{10, 60, 15, 25, 25, 15, 30}
+	headers := []string{"#", "Description", "Qty", "Unit price", "Discount", "Tax %", "Net"}
+	aligns := []string{"L", "L", "R", "R", "R", "R", "R"}
+
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	for n, v := range headers {
+		pdf.CellFormat(widths[n], 7, v, "B", 0, aligns[n], true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, v := range invoice.Lines {
+		cells := []string{
+			strconv.Itoa(v.LineNumber),
+			v.Description,
+			strconv.Itoa(v.Quantity),
+			amount(v.UnitPrice),
+			amount(v.DiscountAmount),
+			v.TaxRate.String(),
+			amount(v.NetAmount),
+		}
+
+		for n, c := range cells {
+			pdf.CellFormat(widths[n], 6, tr(c), "", 0, aligns[n], false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	pdf.Ln(4)
+
+	for _, v := range invoice.Taxes {
+		totalLine(pdf, "Tax "+v.TaxRate.String()+"% on "+v.TaxableAmount.String(), v.TaxAmount.String(), false)
+	}
+
+	totalLine(pdf, "Subtotal", invoice.Subtotal.String(), false)
+	totalLine(pdf, "Discount", invoice.DiscountAmount.String(), false)
+	totalLine(pdf, "Net", invoice.NetAmount.String(), false)
+	totalLine(pdf, "Tax", invoice.TaxAmount.String(), false)
+	totalLine(pdf, "Total", invoice.TotalAmount.String(), true)
+
+	var buf bytes.Buffer
+	err := pdf.Output(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func amount(m money.Money) string {
+	return m.Amount.StringFixed(money.MinorUnits(m.Currency))
+}
+
+func totalLine(pdf *fpdf.Fpdf, label string, amount string, bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+
+	pdf.SetFont("Helvetica", style, 10)
+	pdf.CellFormat(140, 6, label, "", 0, "R", false, 0, "")
+	pdf.CellFormat(40, 6, amount, "", 1, "R", false, 0, "")
+}
+
+func partyText(party dtos.Party) string {
+	lines := []string{party.Name}
+
+	for _, v := range []string{
+		party.Address.Line1,
+		party.Address.Line2,
+		strings.TrimSpace(party.Address.City + " " + party.Address.ZipCode),
+		strings.TrimSpace(party.Address.State + " " + party.Address.Country),
+		party.Email,
+		party.Mobile,
+	} {
+		if v != "" {
+			lines = append(lines, v)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
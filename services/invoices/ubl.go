@@ -0,0 +1,221 @@
+package invoices
+
+import (
+	"encoding/xml"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/money"
+	"strconv"
+)
+
+const (
+	ublInvoiceNamespace    = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	ublCreditNoteNamespace = "urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"
+	ublCacNamespace        = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	ublCbcNamespace        = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+)
+
+// The UBL 2.1 Invoice and CreditNote documents only differ in a few element
+// names, which are set through the XMLName fields.
+type ublDocument struct {
+	XMLName              xml.Name         `xml:""`
+	Xmlns                string           `xml:"xmlns,attr"`
+	XmlnsCac             string           `xml:"xmlns:cac,attr"`
+	XmlnsCbc             string           `xml:"xmlns:cbc,attr"`
+	UBLVersionID         string           `xml:"cbc:UBLVersionID"`
+	ID                   string           `xml:"cbc:ID"`
+	IssueDate            string           `xml:"cbc:IssueDate"`
+	TypeCode             ublCode          `xml:""`
+	DocumentCurrencyCode string           `xml:"cbc:DocumentCurrencyCode"`
+	OrderReference       ublReference     `xml:"cac:OrderReference"`
+	BillingReference     *ublBillingRef   `xml:"cac:BillingReference,omitempty"`
+	AccountingSupplier   ublParty         `xml:"cac:AccountingSupplierParty"`
+	AccountingCustomer   ublParty         `xml:"cac:AccountingCustomerParty"`
+	TaxTotal             ublTaxTotal      `xml:"cac:TaxTotal"`
+	LegalMonetaryTotal   ublMonetaryTotal `xml:"cac:LegalMonetaryTotal"`
+	Lines                []ublLine        `xml:""`
+}
+
+type ublCode struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type ublReference struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type ublBillingRef struct {
+	InvoiceDocumentReference ublReference `xml:"cac:InvoiceDocumentReference"`
+}
+
+type ublParty struct {
+	Party struct {
+		PartyName struct {
+			Name string `xml:"cbc:Name"`
+		} `xml:"cac:PartyName"`
+		PostalAddress struct {
+			StreetName           string `xml:"cbc:StreetName,omitempty"`
+			AdditionalStreetName string `xml:"cbc:AdditionalStreetName,omitempty"`
+			CityName             string `xml:"cbc:CityName,omitempty"`
+			PostalZone           string `xml:"cbc:PostalZone,omitempty"`
+			CountrySubentity     string `xml:"cbc:CountrySubentity,omitempty"`
+			Country              struct {
+				IdentificationCode string `xml:"cbc:IdentificationCode"`
+			} `xml:"cac:Country"`
+		} `xml:"cac:PostalAddress"`
+		Contact struct {
+			Telephone      string `xml:"cbc:Telephone,omitempty"`
+			ElectronicMail string `xml:"cbc:ElectronicMail,omitempty"`
+		} `xml:"cac:Contact"`
+	} `xml:"cac:Party"`
+}
+
+type ublAmount struct {
+	CurrencyID string `xml:"currencyID,attr"`
+	Value      string `xml:",chardata"`
+}
+
+type ublTaxTotal struct {
+	TaxAmount   ublAmount        `xml:"cbc:TaxAmount"`
+	TaxSubtotal []ublTaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+type ublTaxSubtotal struct {
+	TaxableAmount ublAmount      `xml:"cbc:TaxableAmount"`
+	TaxAmount     ublAmount      `xml:"cbc:TaxAmount"`
+	TaxCategory   ublTaxCategory `xml:"cac:TaxCategory"`
+}
+
+type ublTaxCategory struct {
+	Percent   string `xml:"cbc:Percent"`
+	TaxScheme struct {
+		ID string `xml:"cbc:ID"`
+	} `xml:"cac:TaxScheme"`
+}
+
+type ublMonetaryTotal struct {
+	LineExtensionAmount ublAmount `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount  ublAmount `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount  ublAmount `xml:"cbc:TaxInclusiveAmount"`
+	PayableAmount       ublAmount `xml:"cbc:PayableAmount"`
+}
+
+type ublLine struct {
+	XMLName             xml.Name
+	ID                  string      `xml:"cbc:ID"`
+	Quantity            ublQuantity `xml:""`
+	LineExtensionAmount ublAmount   `xml:"cbc:LineExtensionAmount"`
+	Item                struct {
+		Name                      string `xml:"cbc:Name"`
+		SellersItemIdentification struct {
+			ID string `xml:"cbc:ID"`
+		} `xml:"cac:SellersItemIdentification"`
+		ClassifiedTaxCategory ublTaxCategory `xml:"cac:ClassifiedTaxCategory"`
+	} `xml:"cac:Item"`
+	Price struct {
+		PriceAmount ublAmount `xml:"cbc:PriceAmount"`
+	} `xml:"cac:Price"`
+}
+
+type ublQuantity struct {
+	XMLName  xml.Name
+	UnitCode string `xml:"unitCode,attr"`
+	Value    int    `xml:",chardata"`
+}
+
+// RenderUBL renders an invoice as a UBL 2.1 Invoice and a credit note as a UBL
+// 2.1 CreditNote.
+func RenderUBL(invoice *dtos.Invoice) ([]byte, error) {
+	root, namespace, typeCode, typeValue, lineName, quantityName :=
+		"Invoice", ublInvoiceNamespace, "cbc:InvoiceTypeCode", "380", "cac:InvoiceLine", "cbc:InvoicedQuantity"
+	if invoice.Type == constants.InvoiceTypeCreditNote {
+		root, namespace, typeCode, typeValue, lineName, quantityName =
+			"CreditNote", ublCreditNoteNamespace, "cbc:CreditNoteTypeCode", "381", "cac:CreditNoteLine", "cbc:CreditedQuantity"
+	}
+
+	doc := ublDocument{
+		XMLName:              xml.Name{Local: root},
+		Xmlns:                namespace,
+		XmlnsCac:             ublCacNamespace,
+		XmlnsCbc:             ublCbcNamespace,
+		UBLVersionID:         "2.1",
+		ID:                   invoice.InvoiceNumber,
+		IssueDate:            invoice.IssuedAt.Format("2006-01-02"),
+		TypeCode:             ublCode{XMLName: xml.Name{Local: typeCode}, Value: typeValue},
+		DocumentCurrencyCode: invoice.Currency,
+		OrderReference:       ublReference{ID: invoice.OrderId},
+		AccountingSupplier:   toUBLParty(invoice.Seller),
+		AccountingCustomer:   toUBLParty(invoice.Buyer),
+		TaxTotal: ublTaxTotal{
+			TaxAmount: toUBLAmount(invoice.TaxAmount),
+		},
+		LegalMonetaryTotal: ublMonetaryTotal{
+			LineExtensionAmount: toUBLAmount(invoice.NetAmount),
+			TaxExclusiveAmount:  toUBLAmount(invoice.NetAmount),
+			TaxInclusiveAmount:  toUBLAmount(invoice.TotalAmount),
+			PayableAmount:       toUBLAmount(invoice.TotalAmount),
+		},
+	}
+
+	if invoice.InvoiceRef != "" {
+		doc.BillingReference = &ublBillingRef{InvoiceDocumentReference: ublReference{ID: invoice.InvoiceRef}}
+	}
+
+	for _, v := range invoice.Taxes {
+		doc.TaxTotal.TaxSubtotal = append(doc.TaxTotal.TaxSubtotal, ublTaxSubtotal{
+			TaxableAmount: toUBLAmount(v.TaxableAmount),
+			TaxAmount:     toUBLAmount(v.TaxAmount),
+			TaxCategory:   toUBLTaxCategory(v.TaxRate.String()),
+		})
+	}
+
+	for _, v := range invoice.Lines {
+		line := ublLine{
+			XMLName:             xml.Name{Local: lineName},
+			ID:                  strconv.Itoa(v.LineNumber),
+			Quantity:            ublQuantity{XMLName: xml.Name{Local: quantityName}, UnitCode: "C62", Value: v.Quantity},
+			LineExtensionAmount: toUBLAmount(v.NetAmount),
+		}
+		line.Item.Name = v.Description
+		line.Item.SellersItemIdentification.ID = v.ArticleId
+		line.Item.ClassifiedTaxCategory = toUBLTaxCategory(v.TaxRate.String())
+		line.Price.PriceAmount = toUBLAmount(v.UnitPrice)
+
+		doc.Lines = append(doc.Lines, line)
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+func toUBLParty(party dtos.Party) ublParty {
+	var p ublParty
+
+	p.Party.PartyName.Name = party.Name
+	p.Party.PostalAddress.StreetName = party.Address.Line1
+	p.Party.PostalAddress.AdditionalStreetName = party.Address.Line2
+	p.Party.PostalAddress.CityName = party.Address.City
+	p.Party.PostalAddress.PostalZone = party.Address.ZipCode
+	p.Party.PostalAddress.CountrySubentity = party.Address.State
+	p.Party.PostalAddress.Country.IdentificationCode = party.Address.Country
+	p.Party.Contact.Telephone = party.Mobile
+	p.Party.Contact.ElectronicMail = party.Email
+
+	return p
+}
+
+func toUBLAmount(m money.Money) ublAmount {
+	return ublAmount{CurrencyID: m.Currency, Value: amount(m)}
+}
+
+func toUBLTaxCategory(percent string) ublTaxCategory {
+	category := ublTaxCategory{Percent: percent}
+	category.TaxScheme.ID = "VAT"
+
+	return category
+}
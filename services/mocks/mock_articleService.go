@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	dtos "inventory-management/dtos"
 	reflect "reflect"
 
@@ -35,87 +36,87 @@ func (m *MockArticleService) EXPECT() *MockArticleServiceMockRecorder {
 }
 
 // CreateArticle mocks base method.
-func (m *MockArticleService) CreateArticle(req *dtos.Article) error {
+func (m *MockArticleService) CreateArticle(ctx context.Context, req *dtos.Article) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateArticle", req)
+	ret := m.ctrl.Call(m, "CreateArticle", ctx, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateArticle indicates an expected call of CreateArticle.
-func (mr *MockArticleServiceMockRecorder) CreateArticle(req interface{}) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) CreateArticle(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArticle", reflect.TypeOf((*MockArticleService)(nil).CreateArticle), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArticle", reflect.TypeOf((*MockArticleService)(nil).CreateArticle), ctx, req)
 }
 
 // DeleteArticle mocks base method.
-func (m *MockArticleService) DeleteArticle(articleId string) error {
+func (m *MockArticleService) DeleteArticle(ctx context.Context, articleId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteArticle", articleId)
+	ret := m.ctrl.Call(m, "DeleteArticle", ctx, articleId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteArticle indicates an expected call of DeleteArticle.
-func (mr *MockArticleServiceMockRecorder) DeleteArticle(articleId interface{}) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) DeleteArticle(ctx, articleId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArticle", reflect.TypeOf((*MockArticleService)(nil).DeleteArticle), articleId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArticle", reflect.TypeOf((*MockArticleService)(nil).DeleteArticle), ctx, articleId)
 }
 
 // GetArticle mocks base method.
-func (m *MockArticleService) GetArticle(articleId string) (*dtos.Article, error) {
+func (m *MockArticleService) GetArticle(ctx context.Context, articleId string) (*dtos.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArticle", articleId)
+	ret := m.ctrl.Call(m, "GetArticle", ctx, articleId)
 	ret0, _ := ret[0].(*dtos.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetArticle indicates an expected call of GetArticle.
-func (mr *MockArticleServiceMockRecorder) GetArticle(articleId interface{}) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) GetArticle(ctx, articleId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticle", reflect.TypeOf((*MockArticleService)(nil).GetArticle), articleId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticle", reflect.TypeOf((*MockArticleService)(nil).GetArticle), ctx, articleId)
 }
 
 // ListArticle mocks base method.
-func (m *MockArticleService) ListArticle() ([]*dtos.Article, error) {
+func (m *MockArticleService) ListArticle(ctx context.Context) ([]*dtos.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListArticle")
+	ret := m.ctrl.Call(m, "ListArticle", ctx)
 	ret0, _ := ret[0].([]*dtos.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListArticle indicates an expected call of ListArticle.
-func (mr *MockArticleServiceMockRecorder) ListArticle() *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) ListArticle(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArticle", reflect.TypeOf((*MockArticleService)(nil).ListArticle))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArticle", reflect.TypeOf((*MockArticleService)(nil).ListArticle), ctx)
 }
 
 // UpdateArticle mocks base method.
-func (m *MockArticleService) UpdateArticle(id string, req *dtos.Article) error {
+func (m *MockArticleService) UpdateArticle(ctx context.Context, id string, req *dtos.Article) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateArticle", id, req)
+	ret := m.ctrl.Call(m, "UpdateArticle", ctx, id, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateArticle indicates an expected call of UpdateArticle.
-func (mr *MockArticleServiceMockRecorder) UpdateArticle(id, req interface{}) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) UpdateArticle(ctx, id, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticle", reflect.TypeOf((*MockArticleService)(nil).UpdateArticle), id, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticle", reflect.TypeOf((*MockArticleService)(nil).UpdateArticle), ctx, id, req)
 }
 
 // UpdateArticleStock mocks base method.
-func (m *MockArticleService) UpdateArticleStock(articleId string, req *dtos.UpdateStock) error {
+func (m *MockArticleService) UpdateArticleStock(ctx context.Context, articleId string, req *dtos.UpdateStock) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateArticleStock", articleId, req)
+	ret := m.ctrl.Call(m, "UpdateArticleStock", ctx, articleId, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateArticleStock indicates an expected call of UpdateArticleStock.
-func (mr *MockArticleServiceMockRecorder) UpdateArticleStock(articleId, req interface{}) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) UpdateArticleStock(ctx, articleId, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticleStock", reflect.TypeOf((*MockArticleService)(nil).UpdateArticleStock), articleId, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticleStock", reflect.TypeOf((*MockArticleService)(nil).UpdateArticleStock), ctx, articleId, req)
 }
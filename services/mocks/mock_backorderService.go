@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/backorders/backorderService.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	dtos "inventory-management/dtos"
+	models "inventory-management/models"
+	repository "inventory-management/repository"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBackorderService is a mock of BackorderService interface.
+type MockBackorderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackorderServiceMockRecorder
+}
+
+// MockBackorderServiceMockRecorder is the mock recorder for MockBackorderService.
+type MockBackorderServiceMockRecorder struct {
+	mock *MockBackorderService
+}
+
+// NewMockBackorderService creates a new mock instance.
+func NewMockBackorderService(ctrl *gomock.Controller) *MockBackorderService {
+	mock := &MockBackorderService{ctrl: ctrl}
+	mock.recorder = &MockBackorderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackorderService) EXPECT() *MockBackorderServiceMockRecorder {
+	return m.recorder
+}
+
+// Allocate mocks base method.
+func (m *MockBackorderService) Allocate(ctx context.Context, repos *repository.Repos, order *models.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allocate", ctx, repos, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allocate indicates an expected call of Allocate.
+func (mr *MockBackorderServiceMockRecorder) Allocate(ctx, repos, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allocate", reflect.TypeOf((*MockBackorderService)(nil).Allocate), ctx, repos, order)
+}
+
+// AllocateStock mocks base method.
+func (m *MockBackorderService) AllocateStock(ctx context.Context, repos *repository.Repos, articleId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateStock", ctx, repos, articleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AllocateStock indicates an expected call of AllocateStock.
+func (mr *MockBackorderServiceMockRecorder) AllocateStock(ctx, repos, articleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateStock", reflect.TypeOf((*MockBackorderService)(nil).AllocateStock), ctx, repos, articleId)
+}
+
+// GetArticleBackorders mocks base method.
+func (m *MockBackorderService) GetArticleBackorders(ctx context.Context, articleId string) (*dtos.BackorderReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleBackorders", ctx, articleId)
+	ret0, _ := ret[0].(*dtos.BackorderReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticleBackorders indicates an expected call of GetArticleBackorders.
+func (mr *MockBackorderServiceMockRecorder) GetArticleBackorders(ctx, articleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleBackorders", reflect.TypeOf((*MockBackorderService)(nil).GetArticleBackorders), ctx, articleId)
+}
+
+// ReceiveStock mocks base method.
+func (m *MockBackorderService) ReceiveStock(ctx context.Context, articleId string, req *dtos.ReceiveStock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveStock", ctx, articleId, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReceiveStock indicates an expected call of ReceiveStock.
+func (mr *MockBackorderServiceMockRecorder) ReceiveStock(ctx, articleId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveStock", reflect.TypeOf((*MockBackorderService)(nil).ReceiveStock), ctx, articleId, req)
+}
+
+// Release mocks base method.
+func (m *MockBackorderService) Release(ctx context.Context, repos *repository.Repos, orderId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, repos, orderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockBackorderServiceMockRecorder) Release(ctx, repos, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockBackorderService)(nil).Release), ctx, repos, orderId)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/catalog/catalogService.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	dtos "inventory-management/dtos"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCatalogService is a mock of CatalogService interface.
+type MockCatalogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogServiceMockRecorder
+}
+
+// MockCatalogServiceMockRecorder is the mock recorder for MockCatalogService.
+type MockCatalogServiceMockRecorder struct {
+	mock *MockCatalogService
+}
+
+// NewMockCatalogService creates a new mock instance.
+func NewMockCatalogService(ctrl *gomock.Controller) *MockCatalogService {
+	mock := &MockCatalogService{ctrl: ctrl}
+	mock.recorder = &MockCatalogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogService) EXPECT() *MockCatalogServiceMockRecorder {
+	return m.recorder
+}
+
+// ExportArticles mocks base method.
+func (m *MockCatalogService) ExportArticles(ctx context.Context, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportArticles", ctx, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportArticles indicates an expected call of ExportArticles.
+func (mr *MockCatalogServiceMockRecorder) ExportArticles(ctx, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportArticles", reflect.TypeOf((*MockCatalogService)(nil).ExportArticles), ctx, format, w)
+}
+
+// GetImportJob mocks base method.
+func (m *MockCatalogService) GetImportJob(ctx context.Context, jobId string) (*dtos.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", ctx, jobId)
+	ret0, _ := ret[0].(*dtos.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockCatalogServiceMockRecorder) GetImportJob(ctx, jobId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockCatalogService)(nil).GetImportJob), ctx, jobId)
+}
+
+// ImportArticles mocks base method.
+func (m *MockCatalogService) ImportArticles(ctx context.Context, req *dtos.ArticleImport, data []byte) (*dtos.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportArticles", ctx, req, data)
+	ret0, _ := ret[0].(*dtos.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportArticles indicates an expected call of ImportArticles.
+func (mr *MockCatalogServiceMockRecorder) ImportArticles(ctx, req, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportArticles", reflect.TypeOf((*MockCatalogService)(nil).ImportArticles), ctx, req, data)
+}
+
+// Running mocks base method.
+func (m *MockCatalogService) Running() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Running")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Running indicates an expected call of Running.
+func (mr *MockCatalogServiceMockRecorder) Running() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Running", reflect.TypeOf((*MockCatalogService)(nil).Running))
+}
+
+// Shutdown mocks base method.
+func (m *MockCatalogService) Shutdown(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockCatalogServiceMockRecorder) Shutdown(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockCatalogService)(nil).Shutdown), ctx)
+}
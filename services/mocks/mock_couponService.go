@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/coupons/couponService.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	dtos "inventory-management/dtos"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCouponService is a mock of CouponService interface.
+type MockCouponService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCouponServiceMockRecorder
+}
+
+// MockCouponServiceMockRecorder is the mock recorder for MockCouponService.
+type MockCouponServiceMockRecorder struct {
+	mock *MockCouponService
+}
+
+// NewMockCouponService creates a new mock instance.
+func NewMockCouponService(ctrl *gomock.Controller) *MockCouponService {
+	mock := &MockCouponService{ctrl: ctrl}
+	mock.recorder = &MockCouponServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCouponService) EXPECT() *MockCouponServiceMockRecorder {
+	return m.recorder
+}
+
+// ApplyCoupon mocks base method.
+func (m *MockCouponService) ApplyCoupon(ctx context.Context, code string, order *models.Order, items []*models.OrderItem) ([]*models.OrderDiscount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyCoupon", ctx, code, order, items)
+	ret0, _ := ret[0].([]*models.OrderDiscount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyCoupon indicates an expected call of ApplyCoupon.
+func (mr *MockCouponServiceMockRecorder) ApplyCoupon(ctx, code, order, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCoupon", reflect.TypeOf((*MockCouponService)(nil).ApplyCoupon), ctx, code, order, items)
+}
+
+// CreateCoupon mocks base method.
+func (m *MockCouponService) CreateCoupon(ctx context.Context, req *dtos.Coupon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCoupon", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCoupon indicates an expected call of CreateCoupon.
+func (mr *MockCouponServiceMockRecorder) CreateCoupon(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCoupon", reflect.TypeOf((*MockCouponService)(nil).CreateCoupon), ctx, req)
+}
+
+// DeleteCoupon mocks base method.
+func (m *MockCouponService) DeleteCoupon(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCoupon", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCoupon indicates an expected call of DeleteCoupon.
+func (mr *MockCouponServiceMockRecorder) DeleteCoupon(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCoupon", reflect.TypeOf((*MockCouponService)(nil).DeleteCoupon), ctx, code)
+}
+
+// GetCoupon mocks base method.
+func (m *MockCouponService) GetCoupon(ctx context.Context, code string) (*dtos.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoupon", ctx, code)
+	ret0, _ := ret[0].(*dtos.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoupon indicates an expected call of GetCoupon.
+func (mr *MockCouponServiceMockRecorder) GetCoupon(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoupon", reflect.TypeOf((*MockCouponService)(nil).GetCoupon), ctx, code)
+}
+
+// UpdateCoupon mocks base method.
+func (m *MockCouponService) UpdateCoupon(ctx context.Context, code string, req *dtos.Coupon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCoupon", ctx, code, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCoupon indicates an expected call of UpdateCoupon.
+func (mr *MockCouponServiceMockRecorder) UpdateCoupon(ctx, code, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCoupon", reflect.TypeOf((*MockCouponService)(nil).UpdateCoupon), ctx, code, req)
+}
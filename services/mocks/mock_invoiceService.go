@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/invoices/invoiceService.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	dtos "inventory-management/dtos"
+	models "inventory-management/models"
+	repository "inventory-management/repository"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInvoiceService is a mock of InvoiceService interface.
+type MockInvoiceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceServiceMockRecorder
+}
+
+// MockInvoiceServiceMockRecorder is the mock recorder for MockInvoiceService.
+type MockInvoiceServiceMockRecorder struct {
+	mock *MockInvoiceService
+}
+
+// NewMockInvoiceService creates a new mock instance.
+func NewMockInvoiceService(ctrl *gomock.Controller) *MockInvoiceService {
+	mock := &MockInvoiceService{ctrl: ctrl}
+	mock.recorder = &MockInvoiceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceService) EXPECT() *MockInvoiceServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCreditNotes mocks base method.
+func (m *MockInvoiceService) GetCreditNotes(ctx context.Context, orderId string) ([]*dtos.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreditNotes", ctx, orderId)
+	ret0, _ := ret[0].([]*dtos.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreditNotes indicates an expected call of GetCreditNotes.
+func (mr *MockInvoiceServiceMockRecorder) GetCreditNotes(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreditNotes", reflect.TypeOf((*MockInvoiceService)(nil).GetCreditNotes), ctx, orderId)
+}
+
+// GetInvoice mocks base method.
+func (m *MockInvoiceService) GetInvoice(ctx context.Context, invoiceNumber string) (*dtos.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, invoiceNumber)
+	ret0, _ := ret[0].(*dtos.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockInvoiceServiceMockRecorder) GetInvoice(ctx, invoiceNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoice), ctx, invoiceNumber)
+}
+
+// GetOrderInvoice mocks base method.
+func (m *MockInvoiceService) GetOrderInvoice(ctx context.Context, orderId string) (*dtos.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderInvoice", ctx, orderId)
+	ret0, _ := ret[0].(*dtos.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderInvoice indicates an expected call of GetOrderInvoice.
+func (mr *MockInvoiceServiceMockRecorder) GetOrderInvoice(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderInvoice", reflect.TypeOf((*MockInvoiceService)(nil).GetOrderInvoice), ctx, orderId)
+}
+
+// IssueCreditNote mocks base method.
+func (m *MockInvoiceService) IssueCreditNote(ctx context.Context, repos *repository.Repos, orderId string, quantities map[string]int) (*dtos.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueCreditNote", ctx, repos, orderId, quantities)
+	ret0, _ := ret[0].(*dtos.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueCreditNote indicates an expected call of IssueCreditNote.
+func (mr *MockInvoiceServiceMockRecorder) IssueCreditNote(ctx, repos, orderId, quantities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueCreditNote", reflect.TypeOf((*MockInvoiceService)(nil).IssueCreditNote), ctx, repos, orderId, quantities)
+}
+
+// IssueInvoice mocks base method.
+func (m *MockInvoiceService) IssueInvoice(ctx context.Context, repos *repository.Repos, order *models.Order) (*dtos.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueInvoice", ctx, repos, order)
+	ret0, _ := ret[0].(*dtos.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueInvoice indicates an expected call of IssueInvoice.
+func (mr *MockInvoiceServiceMockRecorder) IssueInvoice(ctx, repos, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueInvoice", reflect.TypeOf((*MockInvoiceService)(nil).IssueInvoice), ctx, repos, order)
+}
+
+// QuoteCreditNote mocks base method.
+func (m *MockInvoiceService) QuoteCreditNote(ctx context.Context, repos *repository.Repos, orderId string, quantities map[string]int) (*dtos.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteCreditNote", ctx, repos, orderId, quantities)
+	ret0, _ := ret[0].(*dtos.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteCreditNote indicates an expected call of QuoteCreditNote.
+func (mr *MockInvoiceServiceMockRecorder) QuoteCreditNote(ctx, repos, orderId, quantities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteCreditNote", reflect.TypeOf((*MockInvoiceService)(nil).QuoteCreditNote), ctx, repos, orderId, quantities)
+}
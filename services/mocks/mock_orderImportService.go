@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/orders/orderImportService.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	dtos "inventory-management/dtos"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrderImportService is a mock of OrderImportService interface.
+type MockOrderImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderImportServiceMockRecorder
+}
+
+// MockOrderImportServiceMockRecorder is the mock recorder for MockOrderImportService.
+type MockOrderImportServiceMockRecorder struct {
+	mock *MockOrderImportService
+}
+
+// NewMockOrderImportService creates a new mock instance.
+func NewMockOrderImportService(ctrl *gomock.Controller) *MockOrderImportService {
+	mock := &MockOrderImportService{ctrl: ctrl}
+	mock.recorder = &MockOrderImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderImportService) EXPECT() *MockOrderImportServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportOrders mocks base method.
+func (m *MockOrderImportService) ImportOrders(ctx context.Context, req *dtos.OrderImport, data []byte) (*dtos.OrderImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportOrders", ctx, req, data)
+	ret0, _ := ret[0].(*dtos.OrderImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportOrders indicates an expected call of ImportOrders.
+func (mr *MockOrderImportServiceMockRecorder) ImportOrders(ctx, req, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportOrders", reflect.TypeOf((*MockOrderImportService)(nil).ImportOrders), ctx, req, data)
+}
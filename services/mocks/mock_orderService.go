@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	dtos "inventory-management/dtos"
 	reflect "reflect"
 
@@ -35,58 +36,72 @@ func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
 }
 
 // CreateOrder mocks base method.
-func (m *MockOrderService) CreateOrder(req *dtos.Order) error {
+func (m *MockOrderService) CreateOrder(ctx context.Context, req *dtos.Order) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrder", req)
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateOrder indicates an expected call of CreateOrder.
-func (mr *MockOrderServiceMockRecorder) CreateOrder(req interface{}) *gomock.Call {
+func (mr *MockOrderServiceMockRecorder) CreateOrder(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderService)(nil).CreateOrder), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderService)(nil).CreateOrder), ctx, req)
 }
 
 // DeleteOrder mocks base method.
-func (m *MockOrderService) DeleteOrder(orderId string) error {
+func (m *MockOrderService) DeleteOrder(ctx context.Context, orderId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOrder", orderId)
+	ret := m.ctrl.Call(m, "DeleteOrder", ctx, orderId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteOrder indicates an expected call of DeleteOrder.
-func (mr *MockOrderServiceMockRecorder) DeleteOrder(orderId interface{}) *gomock.Call {
+func (mr *MockOrderServiceMockRecorder) DeleteOrder(ctx, orderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrder", reflect.TypeOf((*MockOrderService)(nil).DeleteOrder), orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrder", reflect.TypeOf((*MockOrderService)(nil).DeleteOrder), ctx, orderId)
 }
 
 // GetOrder mocks base method.
-func (m *MockOrderService) GetOrder(orderId string) (*dtos.Order, error) {
+func (m *MockOrderService) GetOrder(ctx context.Context, orderId string) (*dtos.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrder", orderId)
+	ret := m.ctrl.Call(m, "GetOrder", ctx, orderId)
 	ret0, _ := ret[0].(*dtos.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrder indicates an expected call of GetOrder.
-func (mr *MockOrderServiceMockRecorder) GetOrder(orderId interface{}) *gomock.Call {
+func (mr *MockOrderServiceMockRecorder) GetOrder(ctx, orderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderService)(nil).GetOrder), orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderService)(nil).GetOrder), ctx, orderId)
 }
 
 // UpdateOrder mocks base method.
-func (m *MockOrderService) UpdateOrder(id string, req *dtos.Order) error {
+func (m *MockOrderService) UpdateOrder(ctx context.Context, id string, req *dtos.Order) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrder", id, req)
+	ret := m.ctrl.Call(m, "UpdateOrder", ctx, id, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateOrder indicates an expected call of UpdateOrder.
-func (mr *MockOrderServiceMockRecorder) UpdateOrder(id, req interface{}) *gomock.Call {
+func (mr *MockOrderServiceMockRecorder) UpdateOrder(ctx, id, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*MockOrderService)(nil).UpdateOrder), id, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*MockOrderService)(nil).UpdateOrder), ctx, id, req)
+}
+
+// UpdateOrderStatus mocks base method.
+func (m *MockOrderService) UpdateOrderStatus(ctx context.Context, id string, req *dtos.UpdateOrderStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
+func (mr *MockOrderServiceMockRecorder) UpdateOrderStatus(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockOrderService)(nil).UpdateOrderStatus), ctx, id, req)
 }
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/payments/paymentService.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	dtos "inventory-management/dtos"
+	models "inventory-management/models"
+	money "inventory-management/money"
+	repository "inventory-management/repository"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentService is a mock of PaymentService interface.
+type MockPaymentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentServiceMockRecorder
+}
+
+// MockPaymentServiceMockRecorder is the mock recorder for MockPaymentService.
+type MockPaymentServiceMockRecorder struct {
+	mock *MockPaymentService
+}
+
+// NewMockPaymentService creates a new mock instance.
+func NewMockPaymentService(ctrl *gomock.Controller) *MockPaymentService {
+	mock := &MockPaymentService{ctrl: ctrl}
+	mock.recorder = &MockPaymentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentService) EXPECT() *MockPaymentServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckPaid mocks base method.
+func (m *MockPaymentService) CheckPaid(ctx context.Context, repos *repository.Repos, order *models.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPaid", ctx, repos, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckPaid indicates an expected call of CheckPaid.
+func (mr *MockPaymentServiceMockRecorder) CheckPaid(ctx, repos, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPaid", reflect.TypeOf((*MockPaymentService)(nil).CheckPaid), ctx, repos, order)
+}
+
+// GetOrderPayments mocks base method.
+func (m *MockPaymentService) GetOrderPayments(ctx context.Context, orderId string) (*dtos.OrderBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderPayments", ctx, orderId)
+	ret0, _ := ret[0].(*dtos.OrderBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderPayments indicates an expected call of GetOrderPayments.
+func (mr *MockPaymentServiceMockRecorder) GetOrderPayments(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderPayments", reflect.TypeOf((*MockPaymentService)(nil).GetOrderPayments), ctx, orderId)
+}
+
+// RecordPayment mocks base method.
+func (m *MockPaymentService) RecordPayment(ctx context.Context, orderId string, req *dtos.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPayment", ctx, orderId, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordPayment indicates an expected call of RecordPayment.
+func (mr *MockPaymentServiceMockRecorder) RecordPayment(ctx, orderId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPayment", reflect.TypeOf((*MockPaymentService)(nil).RecordPayment), ctx, orderId, req)
+}
+
+// Refund mocks base method.
+func (m *MockPaymentService) Refund(ctx context.Context, repos *repository.Repos, orderId string, amount money.Money, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, repos, orderId, amount, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentServiceMockRecorder) Refund(ctx, repos, orderId, amount, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPaymentService)(nil).Refund), ctx, repos, orderId, amount, reason)
+}
+
+// SettleRefunds mocks base method.
+func (m *MockPaymentService) SettleRefunds(ctx context.Context, orderId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleRefunds", ctx, orderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SettleRefunds indicates an expected call of SettleRefunds.
+func (mr *MockPaymentServiceMockRecorder) SettleRefunds(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleRefunds", reflect.TypeOf((*MockPaymentService)(nil).SettleRefunds), ctx, orderId)
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/pricelists/priceListService.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	dtos "inventory-management/dtos"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPriceListService is a mock of PriceListService interface.
+type MockPriceListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceListServiceMockRecorder
+}
+
+// MockPriceListServiceMockRecorder is the mock recorder for MockPriceListService.
+type MockPriceListServiceMockRecorder struct {
+	mock *MockPriceListService
+}
+
+// NewMockPriceListService creates a new mock instance.
+func NewMockPriceListService(ctrl *gomock.Controller) *MockPriceListService {
+	mock := &MockPriceListService{ctrl: ctrl}
+	mock.recorder = &MockPriceListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceListService) EXPECT() *MockPriceListServiceMockRecorder {
+	return m.recorder
+}
+
+// CreatePriceList mocks base method.
+func (m *MockPriceListService) CreatePriceList(ctx context.Context, req *dtos.PriceList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceList", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePriceList indicates an expected call of CreatePriceList.
+func (mr *MockPriceListServiceMockRecorder) CreatePriceList(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceList", reflect.TypeOf((*MockPriceListService)(nil).CreatePriceList), ctx, req)
+}
+
+// DeletePriceList mocks base method.
+func (m *MockPriceListService) DeletePriceList(ctx context.Context, priceListId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePriceList", ctx, priceListId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePriceList indicates an expected call of DeletePriceList.
+func (mr *MockPriceListServiceMockRecorder) DeletePriceList(ctx, priceListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePriceList", reflect.TypeOf((*MockPriceListService)(nil).DeletePriceList), ctx, priceListId)
+}
+
+// GetPriceList mocks base method.
+func (m *MockPriceListService) GetPriceList(ctx context.Context, priceListId string) (*dtos.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceList", ctx, priceListId)
+	ret0, _ := ret[0].(*dtos.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceList indicates an expected call of GetPriceList.
+func (mr *MockPriceListServiceMockRecorder) GetPriceList(ctx, priceListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceList", reflect.TypeOf((*MockPriceListService)(nil).GetPriceList), ctx, priceListId)
+}
+
+// UpdatePriceList mocks base method.
+func (m *MockPriceListService) UpdatePriceList(ctx context.Context, id string, req *dtos.PriceList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePriceList", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePriceList indicates an expected call of UpdatePriceList.
+func (mr *MockPriceListServiceMockRecorder) UpdatePriceList(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePriceList", reflect.TypeOf((*MockPriceListService)(nil).UpdatePriceList), ctx, id, req)
+}
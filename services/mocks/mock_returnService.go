@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/returns/returnService.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	dtos "inventory-management/dtos"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReturnService is a mock of ReturnService interface.
+type MockReturnService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnServiceMockRecorder
+}
+
+// MockReturnServiceMockRecorder is the mock recorder for MockReturnService.
+type MockReturnServiceMockRecorder struct {
+	mock *MockReturnService
+}
+
+// NewMockReturnService creates a new mock instance.
+func NewMockReturnService(ctrl *gomock.Controller) *MockReturnService {
+	mock := &MockReturnService{ctrl: ctrl}
+	mock.recorder = &MockReturnServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnService) EXPECT() *MockReturnServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateReturn mocks base method.
+func (m *MockReturnService) CreateReturn(ctx context.Context, orderId string, req *dtos.Return) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReturn", ctx, orderId, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReturn indicates an expected call of CreateReturn.
+func (mr *MockReturnServiceMockRecorder) CreateReturn(ctx, orderId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturn", reflect.TypeOf((*MockReturnService)(nil).CreateReturn), ctx, orderId, req)
+}
+
+// GetOrderReturns mocks base method.
+func (m *MockReturnService) GetOrderReturns(ctx context.Context, orderId string) ([]*dtos.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderReturns", ctx, orderId)
+	ret0, _ := ret[0].([]*dtos.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderReturns indicates an expected call of GetOrderReturns.
+func (mr *MockReturnServiceMockRecorder) GetOrderReturns(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderReturns", reflect.TypeOf((*MockReturnService)(nil).GetOrderReturns), ctx, orderId)
+}
+
+// GetReturn mocks base method.
+func (m *MockReturnService) GetReturn(ctx context.Context, returnId string) (*dtos.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturn", ctx, returnId)
+	ret0, _ := ret[0].(*dtos.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturn indicates an expected call of GetReturn.
+func (mr *MockReturnServiceMockRecorder) GetReturn(ctx, returnId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturn", reflect.TypeOf((*MockReturnService)(nil).GetReturn), ctx, returnId)
+}
+
+// UpdateReturnStatus mocks base method.
+func (m *MockReturnService) UpdateReturnStatus(ctx context.Context, returnId string, req *dtos.UpdateReturnStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReturnStatus", ctx, returnId, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReturnStatus indicates an expected call of UpdateReturnStatus.
+func (mr *MockReturnServiceMockRecorder) UpdateReturnStatus(ctx, returnId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReturnStatus", reflect.TypeOf((*MockReturnService)(nil).UpdateReturnStatus), ctx, returnId, req)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/shipments/shipmentService.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	dtos "inventory-management/dtos"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockShipmentService is a mock of ShipmentService interface.
+type MockShipmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentServiceMockRecorder
+}
+
+// MockShipmentServiceMockRecorder is the mock recorder for MockShipmentService.
+type MockShipmentServiceMockRecorder struct {
+	mock *MockShipmentService
+}
+
+// NewMockShipmentService creates a new mock instance.
+func NewMockShipmentService(ctrl *gomock.Controller) *MockShipmentService {
+	mock := &MockShipmentService{ctrl: ctrl}
+	mock.recorder = &MockShipmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentService) EXPECT() *MockShipmentServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateShipment mocks base method.
+func (m *MockShipmentService) CreateShipment(ctx context.Context, orderId string, req *dtos.Shipment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipment", ctx, orderId, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockShipmentServiceMockRecorder) CreateShipment(ctx, orderId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockShipmentService)(nil).CreateShipment), ctx, orderId, req)
+}
+
+// GetOrderShipments mocks base method.
+func (m *MockShipmentService) GetOrderShipments(ctx context.Context, orderId string) (*dtos.OrderFulfilment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderShipments", ctx, orderId)
+	ret0, _ := ret[0].(*dtos.OrderFulfilment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderShipments indicates an expected call of GetOrderShipments.
+func (mr *MockShipmentServiceMockRecorder) GetOrderShipments(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderShipments", reflect.TypeOf((*MockShipmentService)(nil).GetOrderShipments), ctx, orderId)
+}
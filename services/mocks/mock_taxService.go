@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/taxes/taxService.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	dtos "inventory-management/dtos"
+	models "inventory-management/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaxService is a mock of TaxService interface.
+type MockTaxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxServiceMockRecorder
+}
+
+// MockTaxServiceMockRecorder is the mock recorder for MockTaxService.
+type MockTaxServiceMockRecorder struct {
+	mock *MockTaxService
+}
+
+// NewMockTaxService creates a new mock instance.
+func NewMockTaxService(ctrl *gomock.Controller) *MockTaxService {
+	mock := &MockTaxService{ctrl: ctrl}
+	mock.recorder = &MockTaxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxService) EXPECT() *MockTaxServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateTaxRule mocks base method.
+func (m *MockTaxService) CreateTaxRule(ctx context.Context, req *dtos.TaxRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxRule", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaxRule indicates an expected call of CreateTaxRule.
+func (mr *MockTaxServiceMockRecorder) CreateTaxRule(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxRule", reflect.TypeOf((*MockTaxService)(nil).CreateTaxRule), ctx, req)
+}
+
+// DeleteTaxRule mocks base method.
+func (m *MockTaxService) DeleteTaxRule(ctx context.Context, taxRuleId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaxRule", ctx, taxRuleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaxRule indicates an expected call of DeleteTaxRule.
+func (mr *MockTaxServiceMockRecorder) DeleteTaxRule(ctx, taxRuleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaxRule", reflect.TypeOf((*MockTaxService)(nil).DeleteTaxRule), ctx, taxRuleId)
+}
+
+// GetTaxRule mocks base method.
+func (m *MockTaxService) GetTaxRule(ctx context.Context, taxRuleId string) (*dtos.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRule", ctx, taxRuleId)
+	ret0, _ := ret[0].(*dtos.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRule indicates an expected call of GetTaxRule.
+func (mr *MockTaxServiceMockRecorder) GetTaxRule(ctx, taxRuleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRule", reflect.TypeOf((*MockTaxService)(nil).GetTaxRule), ctx, taxRuleId)
+}
+
+// TaxOrder mocks base method.
+func (m *MockTaxService) TaxOrder(ctx context.Context, order *models.Order, items []*models.OrderItem, discounts []*models.OrderDiscount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TaxOrder", ctx, order, items, discounts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TaxOrder indicates an expected call of TaxOrder.
+func (mr *MockTaxServiceMockRecorder) TaxOrder(ctx, order, items, discounts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaxOrder", reflect.TypeOf((*MockTaxService)(nil).TaxOrder), ctx, order, items, discounts)
+}
+
+// UpdateTaxRule mocks base method.
+func (m *MockTaxService) UpdateTaxRule(ctx context.Context, id string, req *dtos.TaxRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxRule", ctx, id, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaxRule indicates an expected call of UpdateTaxRule.
+func (mr *MockTaxServiceMockRecorder) UpdateTaxRule(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxRule", reflect.TypeOf((*MockTaxService)(nil).UpdateTaxRule), ctx, id, req)
+}
@@ -0,0 +1,260 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/repository"
+	"inventory-management/tabular"
+	"inventory-management/tracing"
+	"inventory-management/validation"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// orderColumns are the fields of an order file. The order fields are taken
+// from the first row of each order; every row adds one item.
+var orderColumns = []string{"order_reference", "customer_id", "article_id", "quantity", "currency", "coupon_code",
+	"shipping_address_id", "backorder_policy", "payment_terms"}
+
+var optionalOrderColumns = map[string]bool{"currency": true, "coupon_code": true, "shipping_address_id": true,
+	"backorder_policy": true, "payment_terms": true}
+
+type OrderImportService interface {
+	ImportOrders(ctx context.Context, req *dtos.OrderImport, data []byte) (*dtos.OrderImportReport, error)
+}
+
+type orderImportService struct {
+	orderService OrderService
+	articleRepo  repository.ArticleRepo
+	userRepo     repository.UserRepo
+}
+
+func NewOrderImportService(orderService OrderService, articleRepo repository.ArticleRepo, userRepo repository.UserRepo) OrderImportService {
+	return &orderImportService{
+		orderService: orderService,
+		articleRepo:  articleRepo,
+		userRepo:     userRepo,
+	}
+}
+
+type importedOrder struct {
+	reference string
+	order     *dtos.Order
+	lines     []int
+	errors    []*dtos.ImportRowError
+}
+
+// ImportOrders creates an order for every order reference in the file. Each
+// order is created in its own transaction, so an order with a bad row is
+// reported and skipped without holding up the others.
+func (o *orderImportService) ImportOrders(ctx context.Context, req *dtos.OrderImport, data []byte) (*dtos.OrderImportReport, error) {
+	ctx, span := tracing.Start(ctx, "orderImportService.ImportOrders")
+	defer span.End()
+
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format == "" {
+		format = constants.FileFormatCSV
+	}
+
+	records, err := tabular.ReadRows(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, constants.ErrorImportFileEmpty
+	}
+
+	columns, err := tabular.MapColumns(records[0], orderColumns, optionalOrderColumns, req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dtos.OrderImportReport{
+		Created: []*dtos.ImportedOrder{},
+		Failed:  []string{},
+		Errors:  []*dtos.ImportRowError{},
+	}
+
+	var imported []*importedOrder
+	byReference := make(map[string]*importedOrder)
+
+	for n, row := range records[1:] {
+		if tabular.Blank(row) {
+			continue
+		}
+
+		line := n + 2
+		report.TotalRows++
+
+		reference := columns.Value(row, "order_reference")
+		if reference == "" {
+			report.Errors = append(report.Errors, &dtos.ImportRowError{Line: line, Field: "order_reference", Rule: "required"})
+			continue
+		}
+
+		current, exists := byReference[reference]
+		if !exists {
+			current = &importedOrder{
+				reference: reference,
+				order: &dtos.Order{
+					CustomerId:        columns.Value(row, "customer_id"),
+					Currency:          columns.Value(row, "currency"),
+					CouponCode:        columns.Value(row, "coupon_code"),
+					ShippingAddressId: columns.Value(row, "shipping_address_id"),
+					BackorderPolicy:   columns.Value(row, "backorder_policy"),
+					PaymentTerms:      columns.Value(row, "payment_terms"),
+				},
+			}
+
+			byReference[reference] = current
+			imported = append(imported, current)
+		}
+
+		current.addItem(line, row, columns)
+	}
+
+	articles := make(map[string]error)
+	for _, v := range imported {
+		if o.importOrder(ctx, v, articles) {
+			report.Created = append(report.Created, &dtos.ImportedOrder{
+				Reference: v.reference,
+				OrderId:   v.order.OrderId,
+				Lines:     v.lines,
+			})
+			continue
+		}
+
+		report.Failed = append(report.Failed, v.reference)
+		report.Errors = append(report.Errors, v.errors...)
+	}
+
+	return report, nil
+}
+
+func (i *importedOrder) addItem(line int, row []string, columns tabular.Columns) {
+	i.lines = append(i.lines, line)
+
+	if customerId := columns.Value(row, "customer_id"); customerId != i.order.CustomerId {
+		i.fail(line, "customer_id", "eq", i.order.CustomerId)
+	}
+
+	item := &dtos.OrderItems{
+		ArticleId: columns.Value(row, "article_id"),
+	}
+
+	quantity, err := strconv.Atoi(columns.Value(row, "quantity"))
+	if err != nil {
+		i.fail(line, "quantity", "integer", "")
+	}
+	item.Quantity = quantity
+
+	for _, v := range i.order.Items {
+		if item.ArticleId != "" && v.ArticleId == item.ArticleId {
+			i.fail(line, "article_id", "unique", "")
+		}
+	}
+
+	i.order.Items = append(i.order.Items, item)
+}
+
+// fail records the first failing rule of each field on a line, so a cell that
+// can't be parsed isn't reported again by validation.
+func (i *importedOrder) fail(line int, field string, rule string, param string) {
+	for _, v := range i.errors {
+		if v.Line == line && v.Field == field {
+			return
+		}
+	}
+
+	i.errors = append(i.errors, &dtos.ImportRowError{
+		Line:      line,
+		Reference: i.reference,
+		Field:     field,
+		Rule:      rule,
+		Param:     param,
+	})
+}
+
+// importOrder validates the order like a request body, checks its customer
+// and articles exist and creates it. Articles caches the lookups across
+// orders.
+func (o *orderImportService) importOrder(ctx context.Context, i *importedOrder, articles map[string]error) bool {
+	// Items are checked one by one so each error lands on the item's line,
+	// even when repeated articles stop validation of the order's items.
+	for n, v := range i.order.Items {
+		i.validate(i.lines[n], v, "")
+	}
+	i.validate(i.lines[0], i.order, "items")
+
+	if i.order.CustomerId != "" {
+		_, err := o.userRepo.Get(ctx, i.order.CustomerId)
+		if err != nil {
+			i.missing(i.lines[0], "customer_id", err)
+		}
+	}
+
+	for n, v := range i.order.Items {
+		if v.ArticleId == "" {
+			continue
+		}
+
+		err, checked := articles[v.ArticleId]
+		if !checked {
+			_, err = o.articleRepo.Get(ctx, v.ArticleId)
+			articles[v.ArticleId] = err
+		}
+
+		if err != nil {
+			i.missing(i.lines[n], "article_id", err)
+		}
+	}
+
+	if len(i.errors) > 0 {
+		return false
+	}
+
+	err := o.orderService.CreateOrder(ctx, i.order)
+	if err != nil {
+		i.errors = append(i.errors, &dtos.ImportRowError{Line: i.lines[0], Reference: i.reference, Message: err.Error()})
+		return false
+	}
+
+	return true
+}
+
+func (i *importedOrder) missing(line int, field string, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		i.fail(line, field, "exists", "")
+		return
+	}
+
+	i.errors = append(i.errors, &dtos.ImportRowError{Line: line, Reference: i.reference, Field: field, Message: err.Error()})
+}
+
+// validate records the rules v breaks on line, skipping errors on the skip
+// field and anything below it.
+func (i *importedOrder) validate(line int, v any, skip string) {
+	err := validation.Struct(v)
+	if err == nil {
+		return
+	}
+
+	fields, ok := validation.Fields(err)
+	if !ok {
+		i.errors = append(i.errors, &dtos.ImportRowError{Line: line, Reference: i.reference, Message: err.Error()})
+		return
+	}
+
+	for _, f := range fields {
+		if skip != "" && (f.Field == skip || strings.HasPrefix(f.Field, skip+"[")) {
+			continue
+		}
+
+		i.fail(line, f.Field, f.Rule, f.Param)
+	}
+}
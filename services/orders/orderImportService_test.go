@@ -0,0 +1,118 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/repository/mocks"
+	serviceMocks "inventory-management/services/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type orderImportServiceTestSuite struct {
+	suite.Suite
+	mockCtrl           *gomock.Controller
+	mockOrderService   *serviceMocks.MockOrderService
+	mockArticleRepo    *mocks.MockArticleRepo
+	mockUserRepo       *mocks.MockUserRepo
+	orderImportService OrderImportService
+}
+
+func TestOrderImportServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(orderImportServiceTestSuite))
+}
+
+func (suite *orderImportServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockOrderService = serviceMocks.NewMockOrderService(suite.mockCtrl)
+	suite.mockArticleRepo = mocks.NewMockArticleRepo(suite.mockCtrl)
+	suite.mockUserRepo = mocks.NewMockUserRepo(suite.mockCtrl)
+
+	suite.orderImportService = NewOrderImportService(suite.mockOrderService, suite.mockArticleRepo, suite.mockUserRepo)
+}
+
+func (suite *orderImportServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func (suite *orderImportServiceTestSuite) TestImportOrders() {
+	file := "PO,customer_id,article_id,quantity,payment_terms\n" +
+		"po-1,c1,a1,2,on_account\n" +
+		"po-2,c2,a1,1,\n" +
+		"po-1,c1,a2,3,\n" +
+		",,,,\n" +
+		"po-3,c1,a3,1,\n" +
+		"po-2,c2,a2,1,\n"
+
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c1").Return(&models.User{Id: "c1"}, nil).Times(2)
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c2").Return(&models.User{Id: "c2"}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1"}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a2").Return(&models.Article{ArticleId: "a2"}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a3").Return(nil, gorm.ErrRecordNotFound).Times(1)
+
+	suite.mockOrderService.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *dtos.Order) error {
+		assert.Equal(suite.T(), "c1", order.CustomerId)
+		assert.Equal(suite.T(), constants.PaymentTermsOnAccount, order.PaymentTerms)
+		assert.Equal(suite.T(), []*dtos.OrderItems{{ArticleId: "a1", Quantity: 2}, {ArticleId: "a2", Quantity: 3}}, order.Items)
+		order.OrderId = "o1"
+		return nil
+	}).Times(1)
+	suite.mockOrderService.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(errors.New("Error Insufficient Stock")).Times(1)
+
+	report, err := suite.orderImportService.ImportOrders(context.Background(), &dtos.OrderImport{
+		Mapping: map[string]string{"order_reference": "po"},
+	}, []byte(file))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5, report.TotalRows)
+	assert.Equal(suite.T(), []*dtos.ImportedOrder{{Reference: "po-1", OrderId: "o1", Lines: []int{2, 4}}}, report.Created)
+	assert.Equal(suite.T(), []string{"po-2", "po-3"}, report.Failed)
+	assert.Equal(suite.T(), []*dtos.ImportRowError{
+		{Line: 3, Reference: "po-2", Message: "Error Insufficient Stock"},
+		{Line: 6, Reference: "po-3", Field: "article_id", Rule: "exists"},
+	}, report.Errors)
+}
+
+func (suite *orderImportServiceTestSuite) TestImportOrdersRowErrors() {
+	file := "order_reference,customer_id,article_id,quantity\n" +
+		"po-1,c1,a1,two\n" +
+		"po-1,c2,a2,0\n" +
+		"po-1,c1,a1,1\n" +
+		",c1,a1,1\n" +
+		"po-2,c9,a1,1\n"
+
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c1").Return(&models.User{Id: "c1"}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c9").Return(nil, gorm.ErrRecordNotFound).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1"}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a2").Return(&models.Article{ArticleId: "a2"}, nil).Times(1)
+
+	report, err := suite.orderImportService.ImportOrders(context.Background(), &dtos.OrderImport{Format: "csv"}, []byte(file))
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), report.Created)
+	assert.Equal(suite.T(), []string{"po-1", "po-2"}, report.Failed)
+	assert.ElementsMatch(suite.T(), []*dtos.ImportRowError{
+		{Line: 5, Field: "order_reference", Rule: "required"},
+		{Line: 2, Reference: "po-1", Field: "quantity", Rule: "integer"},
+		{Line: 3, Reference: "po-1", Field: "customer_id", Rule: "eq", Param: "c1"},
+		{Line: 3, Reference: "po-1", Field: "quantity", Rule: "gt", Param: "0"},
+		{Line: 4, Reference: "po-1", Field: "article_id", Rule: "unique"},
+		{Line: 6, Reference: "po-2", Field: "customer_id", Rule: "exists"},
+	}, report.Errors)
+}
+
+func (suite *orderImportServiceTestSuite) TestImportOrdersMissingColumn() {
+	_, err := suite.orderImportService.ImportOrders(context.Background(), &dtos.OrderImport{}, []byte("order_reference,customer_id,quantity\npo-1,c1,1\n"))
+	assert.Equal(suite.T(), constants.ErrorImportColumnMissing, err)
+}
+
+func (suite *orderImportServiceTestSuite) TestImportOrdersEmptyFile() {
+	_, err := suite.orderImportService.ImportOrders(context.Background(), &dtos.OrderImport{}, []byte(""))
+	assert.Equal(suite.T(), constants.ErrorImportFileEmpty, err)
+}
@@ -1,127 +1,471 @@
 package orders
 
 import (
+	"context"
 	"inventory-management/constants"
 	"inventory-management/dtos"
+	"inventory-management/metrics"
 	"inventory-management/models"
+	"inventory-management/money"
 	"inventory-management/repository"
+	"inventory-management/services/backorders"
+	"inventory-management/services/coupons"
+	"inventory-management/services/invoices"
+	"inventory-management/services/payments"
+	"inventory-management/services/pricing"
+	"inventory-management/services/taxes"
+	"inventory-management/tracing"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type OrderService interface {
-	CreateOrder(req *dtos.Order) error
-	UpdateOrder(id string, req *dtos.Order) error
-	GetOrder(orderId string) (*dtos.Order, error)
-	DeleteOrder(orderId string) error
+	CreateOrder(ctx context.Context, req *dtos.Order) error
+	UpdateOrder(ctx context.Context, id string, req *dtos.Order) error
+	GetOrder(ctx context.Context, orderId string) (*dtos.Order, error)
+	DeleteOrder(ctx context.Context, orderId string) error
+	UpdateOrderStatus(ctx context.Context, id string, req *dtos.UpdateOrderStatus) error
+}
+
+// orderStatusTransitions lists the statuses an order may move to from each
+// status. Orders can only be edited or deleted while pending.
+var orderStatusTransitions = map[string][]string{
+	constants.OrderStatusPending:   {constants.OrderStatusConfirmed, constants.OrderStatusCancelled},
+	constants.OrderStatusConfirmed: {constants.OrderStatusCancelled},
 }
 
 type orderService struct {
-	orderRepo     repository.OrderRepo
-	orderItemRepo repository.OrderItemRepo
+	orderRepo         repository.OrderRepo
+	orderItemRepo     repository.OrderItemRepo
+	orderDiscountRepo repository.OrderDiscountRepo
+	txManager         repository.TxManager
+	pricingService    pricing.PricingService
+	couponService     coupons.CouponService
+	taxService        taxes.TaxService
+	invoiceService    invoices.InvoiceService
+	backorderService  backorders.BackorderService
+	paymentService    payments.PaymentService
 }
 
-func NewOrderService(orderRepo repository.OrderRepo, orderItemRepo repository.OrderItemRepo) OrderService {
+func NewOrderService(orderRepo repository.OrderRepo, orderItemRepo repository.OrderItemRepo, orderDiscountRepo repository.OrderDiscountRepo,
+	txManager repository.TxManager, pricingService pricing.PricingService, couponService coupons.CouponService, taxService taxes.TaxService,
+	invoiceService invoices.InvoiceService, backorderService backorders.BackorderService, paymentService payments.PaymentService) OrderService {
 	return &orderService{
-		orderRepo:     orderRepo,
-		orderItemRepo: orderItemRepo,
+		orderRepo:         orderRepo,
+		orderItemRepo:     orderItemRepo,
+		orderDiscountRepo: orderDiscountRepo,
+		txManager:         txManager,
+		pricingService:    pricingService,
+		couponService:     couponService,
+		taxService:        taxService,
+		invoiceService:    invoiceService,
+		backorderService:  backorderService,
+		paymentService:    paymentService,
 	}
 }
 
-func (o *orderService) CreateOrder(req *dtos.Order) error {
+func (o *orderService) CreateOrder(ctx context.Context, req *dtos.Order) error {
+	ctx, span := tracing.Start(ctx, "orderService.CreateOrder")
+	defer span.End()
+
 	orderModel, itemsModel := OrderDtosToModel(req)
+	orderModel.Status = constants.OrderStatusPending
+	orderModel.Version = 1
+	if orderModel.PaymentTerms == "" {
+		orderModel.PaymentTerms = constants.PaymentTermsPrepaid
+	}
 
-	err := o.orderRepo.Create(orderModel)
+	discounts, err := o.priceOrder(ctx, orderModel, itemsModel)
 	if err != nil {
 		return err
 	}
 
-	err = o.orderItemRepo.Create(itemsModel...)
+	err = o.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		err := repos.Orders.Create(ctx, orderModel)
+		if err != nil {
+			return err
+		}
+
+		err = repos.OrderItems.Create(ctx, itemsModel...)
+		if err != nil {
+			return err
+		}
+
+		return o.saveDiscounts(ctx, repos, orderModel, discounts)
+	})
 	if err != nil {
 		return err
 	}
 
+	metrics.OrderCreated(orderModel.TotalAmount)
+
 	return nil
 }
 
-func (o *orderService) UpdateOrder(id string, req *dtos.Order) error {
+func (o *orderService) UpdateOrder(ctx context.Context, id string, req *dtos.Order) error {
+	ctx, span := tracing.Start(ctx, "orderService.UpdateOrder")
+	defer span.End()
+
 	if req.OrderId == "" {
 		return constants.ErrorOrderIdEmpty
 	}
 
 	orderModel, itemsModel := OrderDtosToModel(req)
 
-	err := o.orderRepo.Update(id, orderModel)
+	discounts, err := o.priceOrder(ctx, orderModel, itemsModel)
 	if err != nil {
 		return err
 	}
 
-	orderItems, err := o.orderItemRepo.GetByOrder(id)
-	if err != nil {
-		return err
-	}
+	return o.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		current, err := pendingOrder(ctx, repos.Orders, id)
+		if err != nil {
+			return err
+		}
 
-	itemsMap := make(map[string]struct{})
-	for _, v := range itemsModel {
-		itemsMap[v.OrderItemId] = struct{}{}
-	}
+		// Terms left out keep the order's, as they decide whether it has to be
+		// paid before it ships.
+		if orderModel.PaymentTerms == "" {
+			orderModel.PaymentTerms = current.PaymentTerms
+		}
+		if orderModel.PaymentTerms == "" {
+			orderModel.PaymentTerms = constants.PaymentTermsPrepaid
+		}
 
-	var deletedItems []string
-	for _, v := range orderItems {
-		if _, exists := itemsMap[v.OrderItemId]; !exists {
-			deletedItems = append(deletedItems, v.OrderItemId)
+		err = repos.Orders.Update(ctx, id, orderModel)
+		if err != nil {
+			return err
+		}
+
+		orderItems, err := repos.OrderItems.GetByOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		itemsMap := make(map[string]struct{})
+		for _, v := range itemsModel {
+			itemsMap[v.OrderItemId] = struct{}{}
+		}
+
+		var deletedItems []string
+		for _, v := range orderItems {
+			if _, exists := itemsMap[v.OrderItemId]; !exists {
+				deletedItems = append(deletedItems, v.OrderItemId)
+			}
+		}
+
+		if len(deletedItems) > 0 {
+			err := repos.OrderItems.DeleteAll(ctx, deletedItems)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = repos.OrderItems.Upsert(ctx, itemsModel...)
+		if err != nil {
+			return err
 		}
-	}
 
-	if len(deletedItems) > 0 {
-		err := o.orderItemRepo.DeleteAll(deletedItems)
+		err = repos.OrderDiscounts.DeleteByOrder(ctx, id)
 		if err != nil {
 			return err
 		}
+
+		// A coupon that was dropped, changed or no longer discounts anything
+		// gives its use back. One that still applies stays redeemed.
+		if current.CouponCode != "" && (current.CouponCode != orderModel.CouponCode || len(discounts) == 0) {
+			err = repos.Coupons.Release(ctx, current.CouponCode, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		return o.saveDiscounts(ctx, repos, orderModel, discounts)
+	})
+}
+
+// priceOrder prices the order, applies its coupon, if any, and taxes the
+// discounted lines, returning the discount lines.
+func (o *orderService) priceOrder(ctx context.Context, order *models.Order, items []*models.OrderItem) ([]*models.OrderDiscount, error) {
+	err := o.pricingService.PriceOrder(ctx, order, items)
+	if err != nil {
+		return nil, err
+	}
+
+	var discounts []*models.OrderDiscount
+	if order.CouponCode != "" {
+		discounts, err = o.couponService.ApplyCoupon(ctx, order.CouponCode, order, items)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	err = o.orderItemRepo.Upsert(itemsModel...)
+	err = o.taxService.TaxOrder(ctx, order, items, discounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return discounts, nil
+}
+
+// saveDiscounts stores the discount lines and redeems the coupon in the same
+// transaction as the order, so a rejected redemption rolls back the order.
+func (o *orderService) saveDiscounts(ctx context.Context, repos *repository.Repos, order *models.Order, discounts []*models.OrderDiscount) error {
+	if len(discounts) == 0 {
+		return nil
+	}
+
+	err := repos.OrderDiscounts.Create(ctx, discounts...)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return repos.Coupons.Redeem(ctx, order.CouponCode, order.CustomerId, order.OrderId, order.OrderedAt)
 }
 
-func (o *orderService) GetOrder(orderId string) (*dtos.Order, error) {
-	order, err := o.orderRepo.Get(orderId)
+func (o *orderService) GetOrder(ctx context.Context, orderId string) (*dtos.Order, error) {
+	ctx, span := tracing.Start(ctx, "orderService.GetOrder")
+	defer span.End()
+
+	order, err := o.orderRepo.Get(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	orderItems, err := o.orderItemRepo.GetByOrder(ctx, orderId)
 	if err != nil {
 		return nil, err
 	}
 
-	orderItems, err := o.orderItemRepo.GetByOrder(orderId)
+	discounts, err := o.orderDiscountRepo.GetByOrder(ctx, orderId)
 	if err != nil {
 		return nil, err
 	}
 
 	result := OrderModelToDtos(order, orderItems)
+	result.Discounts = OrderDiscountModelToDtos(discounts)
 
 	return result, nil
 }
 
-func (o *orderService) DeleteOrder(orderId string) error {
-	err := o.orderRepo.Delete(orderId)
+// DeleteOrder deletes a pending order with its items and discounts and gives
+// back the use of its coupon, all in one transaction.
+func (o *orderService) DeleteOrder(ctx context.Context, orderId string) error {
+	ctx, span := tracing.Start(ctx, "orderService.DeleteOrder")
+	defer span.End()
+
+	return o.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		order, err := pendingOrder(ctx, repos.Orders, orderId)
+		if err != nil {
+			return err
+		}
+
+		if order.CouponCode != "" {
+			err = repos.Coupons.Release(ctx, order.CouponCode, orderId)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = repos.OrderDiscounts.DeleteByOrder(ctx, orderId)
+		if err != nil {
+			return err
+		}
+
+		orderItems, err := repos.OrderItems.GetByOrder(ctx, orderId)
+		if err != nil {
+			return err
+		}
+
+		var itemIds []string
+		for _, v := range orderItems {
+			itemIds = append(itemIds, v.OrderItemId)
+		}
+
+		if len(itemIds) > 0 {
+			err = repos.OrderItems.DeleteAll(ctx, itemIds)
+			if err != nil {
+				return err
+			}
+		}
+
+		return repos.Orders.Delete(ctx, orderId)
+	})
+}
+
+// UpdateOrderStatus moves the order to the requested status. Confirming an
+// order allocates its stock and issues its invoice. Cancelling a confirmed
+// order credits whatever has not been shipped and releases its stock, and
+// cancelling any order refunds what was paid for the cancelled part. All of it
+// happens in the same transaction as the status change, except for sending
+// the refunds to the payment provider, which happens once it has committed.
+func (o *orderService) UpdateOrderStatus(ctx context.Context, id string, req *dtos.UpdateOrderStatus) error {
+	ctx, span := tracing.Start(ctx, "orderService.UpdateOrderStatus")
+	defer span.End()
+
+	err := o.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		order, err := repos.Orders.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		from := orderStatus(order)
+		if !canTransition(from, req.Status) {
+			return constants.ErrorInvalidOrderStatus
+		}
+
+		err = repos.Orders.UpdateStatus(ctx, id, order.Status, req.Status)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case req.Status == constants.OrderStatusConfirmed:
+			err = o.backorderService.Allocate(ctx, repos, order)
+			if err != nil {
+				return err
+			}
+
+			_, err = o.invoiceService.IssueInvoice(ctx, repos, order)
+		case req.Status == constants.OrderStatusCancelled && from == constants.OrderStatusConfirmed:
+			credited, err := o.creditUnshipped(ctx, repos, id)
+			if err != nil {
+				return err
+			}
+
+			err = o.backorderService.Release(ctx, repos, id)
+			if err != nil {
+				return err
+			}
+
+			return o.paymentService.Refund(ctx, repos, id, credited, "order cancelled")
+		case req.Status == constants.OrderStatusCancelled:
+			err = o.paymentService.Refund(ctx, repos, id, order.TotalAmount, "order cancelled")
+		}
+
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
+	if req.Status == constants.OrderStatusCancelled {
+		// The order is cancelled either way; refunds the provider didn't take
+		// stay pending and can be sent again through the order's refunds.
+		err = o.paymentService.SettleRefunds(ctx, id)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to settle refunds", "order_id", id, "error", err)
+		}
+	}
+
 	return nil
 }
 
+// creditUnshipped credits the quantity of each order item that has not been
+// shipped and returns the credited amount. Shipped units can only be credited
+// through a return.
+func (o *orderService) creditUnshipped(ctx context.Context, repos *repository.Repos, orderId string) (money.Money, error) {
+	orderItems, err := repos.OrderItems.GetByOrder(ctx, orderId)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	shipped, err := repos.ShipmentItems.GetByOrder(ctx, orderId)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	var quantities map[string]int
+	if len(shipped) > 0 {
+		quantities = unshipped(orderItems, shipped)
+		if len(quantities) == 0 {
+			return money.Money{}, constants.ErrorOrderFullyShipped
+		}
+	}
+
+	creditNote, err := o.invoiceService.IssueCreditNote(ctx, repos, orderId, quantities)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return creditNote.TotalAmount, nil
+}
+
+func unshipped(orderItems []*models.OrderItem, shipped []*models.ShipmentItem) map[string]int {
+	remaining := make(map[string]int)
+	for _, v := range orderItems {
+		remaining[v.OrderItemId] += v.Quantity
+	}
+
+	for _, v := range shipped {
+		remaining[v.OrderItemId] -= v.Quantity
+	}
+
+	quantities := make(map[string]int)
+	for k, v := range remaining {
+		if v > 0 {
+			quantities[k] = v
+		}
+	}
+
+	return quantities
+}
+
+// pendingOrder returns the order, failing unless it is still pending.
+func pendingOrder(ctx context.Context, orderRepo repository.OrderRepo, orderId string) (*models.Order, error) {
+	order, err := orderRepo.Get(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if orderStatus(order) != constants.OrderStatusPending {
+		return nil, constants.ErrorOrderNotEditable
+	}
+
+	return order, nil
+}
+
+// orderStatus treats orders stored before statuses existed as pending.
+func orderStatus(order *models.Order) string {
+	if order.Status == "" {
+		return constants.OrderStatusPending
+	}
+
+	return order.Status
+}
+
+func canTransition(from string, to string) bool {
+	for _, v := range orderStatusTransitions[from] {
+		if v == to {
+			return true
+		}
+	}
+
+	return false
+}
+
 func OrderModelToDtos(m *models.Order, i []*models.OrderItem) *dtos.Order {
 	o := &dtos.Order{
-		OrderId:     m.OrderId,
-		CustomerId:  m.CustomerId,
-		OrderedAt:   m.OrderedAt,
-		TotalAmount: m.TotalAmount,
-		NoOfItems:   m.NoOfItems,
-		Items:       []*dtos.OrderItems{},
+		OrderId:           m.OrderId,
+		CustomerId:        m.CustomerId,
+		OrderedAt:         m.OrderedAt,
+		Currency:          m.TotalAmount.Currency,
+		Status:            m.Status,
+		CouponCode:        m.CouponCode,
+		ShippingAddressId: m.ShippingAddressId,
+		PricesIncludeTax:  m.PricesIncludeTax,
+		BackorderPolicy:   m.BackorderPolicy,
+		PaymentTerms:      m.PaymentTerms,
+		Subtotal:          m.Subtotal,
+		DiscountAmount:    m.DiscountAmount,
+		TaxAmount:         m.TaxAmount,
+		TotalAmount:       m.TotalAmount,
+		NoOfItems:         m.NoOfItems,
+		Version:           m.Version,
+		Items:             []*dtos.OrderItems{},
 	}
 
 	var items []*dtos.OrderItems
@@ -131,6 +475,13 @@ func OrderModelToDtos(m *models.Order, i []*models.OrderItem) *dtos.Order {
 			OrderId:     v.OrderId,
 			ArticleId:   v.ArticleId,
 			Quantity:    v.Quantity,
+			UnitPrice:   v.UnitPrice,
+			PriceListId: v.PriceListId,
+			Allocated:   v.AllocatedQuantity,
+			TaxClass:    v.TaxClass,
+			TaxRuleId:   v.TaxRuleId,
+			TaxRate:     v.TaxRate,
+			TaxAmount:   v.TaxAmount,
 		})
 	}
 
@@ -140,21 +491,32 @@ func OrderModelToDtos(m *models.Order, i []*models.OrderItem) *dtos.Order {
 }
 
 func OrderDtosToModel(m *dtos.Order) (*models.Order, []*models.OrderItem) {
-	orderId := m.OrderId
-	if orderId == "" {
-		orderId = uuid.NewString()
+	if m.OrderId == "" {
+		m.OrderId = uuid.NewString()
 	}
+	orderId := m.OrderId
 
 	if m.OrderedAt.IsZero() {
 		m.OrderedAt = time.Now().UTC()
 	}
 
+	currency := m.Currency
+	if currency == "" {
+		currency = m.TotalAmount.Currency
+	}
+
 	order := &models.Order{
-		OrderId:     orderId,
-		CustomerId:  m.CustomerId,
-		OrderedAt:   m.OrderedAt,
-		TotalAmount: m.TotalAmount,
-		NoOfItems:   len(m.Items),
+		OrderId:           orderId,
+		CustomerId:        m.CustomerId,
+		OrderedAt:         m.OrderedAt,
+		CouponCode:        strings.TrimSpace(m.CouponCode),
+		ShippingAddressId: m.ShippingAddressId,
+		PricesIncludeTax:  m.PricesIncludeTax,
+		BackorderPolicy:   strings.ToLower(strings.TrimSpace(m.BackorderPolicy)),
+		PaymentTerms:      strings.ToLower(strings.TrimSpace(m.PaymentTerms)),
+		TotalAmount:       money.Money{Currency: money.NormalizeCurrency(currency)},
+		NoOfItems:         len(m.Items),
+		Version:           m.Version,
 	}
 
 	var orderItems []*models.OrderItem
@@ -173,3 +535,19 @@ func OrderDtosToModel(m *dtos.Order) (*models.Order, []*models.OrderItem) {
 
 	return order, orderItems
 }
+
+func OrderDiscountModelToDtos(m []*models.OrderDiscount) []*dtos.OrderDiscounts {
+	var discounts []*dtos.OrderDiscounts
+
+	for _, v := range m {
+		discounts = append(discounts, &dtos.OrderDiscounts{
+			OrderDiscountId: v.OrderDiscountId,
+			OrderItemId:     v.OrderItemId,
+			CouponCode:      v.CouponCode,
+			Description:     v.Description,
+			Amount:          v.Amount,
+		})
+	}
+
+	return discounts
+}
@@ -1,11 +1,15 @@
 package orders
 
 import (
+	"context"
 	"errors"
 	"inventory-management/constants"
 	"inventory-management/dtos"
 	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
 	"inventory-management/repository/mocks"
+	serviceMocks "inventory-management/services/mocks"
 	"testing"
 	"time"
 
@@ -16,10 +20,20 @@ import (
 
 type orderServiceTestSuite struct {
 	suite.Suite
-	mockCtrl          *gomock.Controller
-	mockOrderRepo     *mocks.MockOrderRepo
-	mockOrderItemRepo *mocks.MockOrderItemRepo
-	orderService      OrderService
+	mockCtrl              *gomock.Controller
+	mockOrderRepo         *mocks.MockOrderRepo
+	mockOrderItemRepo     *mocks.MockOrderItemRepo
+	mockOrderDiscountRepo *mocks.MockOrderDiscountRepo
+	mockCouponRepo        *mocks.MockCouponRepo
+	mockShipmentItemRepo  *mocks.MockShipmentItemRepo
+	mockTxManager         *mocks.MockTxManager
+	mockPricingService    *serviceMocks.MockPricingService
+	mockCouponService     *serviceMocks.MockCouponService
+	mockTaxService        *serviceMocks.MockTaxService
+	mockInvoiceService    *serviceMocks.MockInvoiceService
+	mockBackorderService  *serviceMocks.MockBackorderService
+	mockPaymentService    *serviceMocks.MockPaymentService
+	orderService          OrderService
 }
 
 func TestOrderTestSuite(t *testing.T) {
@@ -31,8 +45,48 @@ func (suite *orderServiceTestSuite) SetupTest() {
 
 	suite.mockOrderRepo = mocks.NewMockOrderRepo(suite.mockCtrl)
 	suite.mockOrderItemRepo = mocks.NewMockOrderItemRepo(suite.mockCtrl)
+	suite.mockOrderDiscountRepo = mocks.NewMockOrderDiscountRepo(suite.mockCtrl)
+	suite.mockCouponRepo = mocks.NewMockCouponRepo(suite.mockCtrl)
+	suite.mockShipmentItemRepo = mocks.NewMockShipmentItemRepo(suite.mockCtrl)
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
+	suite.mockPricingService = serviceMocks.NewMockPricingService(suite.mockCtrl)
+	suite.mockCouponService = serviceMocks.NewMockCouponService(suite.mockCtrl)
+	suite.mockTaxService = serviceMocks.NewMockTaxService(suite.mockCtrl)
+	suite.mockInvoiceService = serviceMocks.NewMockInvoiceService(suite.mockCtrl)
+	suite.mockBackorderService = serviceMocks.NewMockBackorderService(suite.mockCtrl)
+	suite.mockPaymentService = serviceMocks.NewMockPaymentService(suite.mockCtrl)
+
+	repos := &repository.Repos{
+		Orders:         suite.mockOrderRepo,
+		OrderItems:     suite.mockOrderItemRepo,
+		OrderDiscounts: suite.mockOrderDiscountRepo,
+		Coupons:        suite.mockCouponRepo,
+		ShipmentItems:  suite.mockShipmentItemRepo,
+	}
+	suite.mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(repos *repository.Repos) error) error {
+			return fn(repos)
+		}).AnyTimes()
+
+	suite.orderService = NewOrderService(suite.mockOrderRepo, suite.mockOrderItemRepo, suite.mockOrderDiscountRepo,
+		suite.mockTxManager, suite.mockPricingService, suite.mockCouponService, suite.mockTaxService, suite.mockInvoiceService,
+		suite.mockBackorderService, suite.mockPaymentService)
+}
+
+func (suite *orderServiceTestSuite) expectPriceOrder(total money.Money) {
+	suite.mockPricingService.EXPECT().PriceOrder(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, order *models.Order, items []*models.OrderItem) error {
+			order.TotalAmount = total
+			return nil
+		}).Times(1)
+}
+
+func (suite *orderServiceTestSuite) expectTaxOrder() {
+	suite.mockTaxService.EXPECT().TaxOrder(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+}
 
-	suite.orderService = NewOrderService(suite.mockOrderRepo, suite.mockOrderItemRepo)
+func (suite *orderServiceTestSuite) expectPendingOrder(id string) {
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), id).Return(&models.Order{OrderId: id, Status: constants.OrderStatusPending}, nil).Times(1)
 }
 
 func (suite *orderServiceTestSuite) TestCreateOrder() {
@@ -42,7 +96,7 @@ func (suite *orderServiceTestSuite) TestCreateOrder() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -61,11 +115,14 @@ func (suite *orderServiceTestSuite) TestCreateOrder() {
 	}
 
 	orderModel := &models.Order{
-		OrderId:     "123",
-		CustomerId:  "234",
-		OrderedAt:   now,
-		TotalAmount: 200,
-		NoOfItems:   2,
+		OrderId:      "123",
+		CustomerId:   "234",
+		OrderedAt:    now,
+		Status:       constants.OrderStatusPending,
+		PaymentTerms: constants.PaymentTermsPrepaid,
+		TotalAmount:  money.MustParse("200", "INR"),
+		NoOfItems:    2,
+		Version:      1,
 	}
 
 	// itemsModel := []*models.OrderItem{
@@ -83,10 +140,12 @@ func (suite *orderServiceTestSuite) TestCreateOrder() {
 	// 	},
 	// }
 
-	suite.mockOrderRepo.EXPECT().Create(orderModel).Return(nil).Times(1)
-	suite.mockOrderItemRepo.EXPECT().Create(gomock.Any()).Return(nil).Times(1)
+	suite.expectPriceOrder(money.MustParse("200", "INR"))
+	suite.expectTaxOrder()
+	suite.mockOrderRepo.EXPECT().Create(gomock.Any(), orderModel).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
-	err := suite.orderService.CreateOrder(req)
+	err := suite.orderService.CreateOrder(context.Background(), req)
 	assert.NoError(suite.T(), err)
 }
 
@@ -97,7 +156,7 @@ func (suite *orderServiceTestSuite) TestCreateOrderRepoError() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -116,16 +175,21 @@ func (suite *orderServiceTestSuite) TestCreateOrderRepoError() {
 	}
 
 	model := &models.Order{
-		OrderId:     "123",
-		CustomerId:  "234",
-		OrderedAt:   now,
-		TotalAmount: 200,
-		NoOfItems:   2,
+		OrderId:      "123",
+		CustomerId:   "234",
+		OrderedAt:    now,
+		Status:       constants.OrderStatusPending,
+		PaymentTerms: constants.PaymentTermsPrepaid,
+		TotalAmount:  money.MustParse("200", "INR"),
+		NoOfItems:    2,
+		Version:      1,
 	}
 
-	suite.mockOrderRepo.EXPECT().Create(model).Return(errors.New("repo error")).Times(1)
+	suite.expectPriceOrder(money.MustParse("200", "INR"))
+	suite.expectTaxOrder()
+	suite.mockOrderRepo.EXPECT().Create(gomock.Any(), model).Return(errors.New("repo error")).Times(1)
 
-	err := suite.orderService.CreateOrder(req)
+	err := suite.orderService.CreateOrder(context.Background(), req)
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "repo error", err.Error())
 }
@@ -137,7 +201,8 @@ func (suite *orderServiceTestSuite) TestGetOrder() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		Currency:    "INR",
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -159,7 +224,7 @@ func (suite *orderServiceTestSuite) TestGetOrder() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 	}
 
@@ -178,10 +243,11 @@ func (suite *orderServiceTestSuite) TestGetOrder() {
 		},
 	}
 
-	suite.mockOrderRepo.EXPECT().Get("123").Return(mockOrder, nil).Times(1)
-	suite.mockOrderItemRepo.EXPECT().GetByOrder("123").Return(itemsMock, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(mockOrder, nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return(itemsMock, nil).Times(1)
+	suite.mockOrderDiscountRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return(nil, nil).Times(1)
 
-	result, err := suite.orderService.GetOrder("123")
+	result, err := suite.orderService.GetOrder(context.Background(), "123")
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expected, result)
 }
@@ -189,9 +255,9 @@ func (suite *orderServiceTestSuite) TestGetOrder() {
 func (suite *orderServiceTestSuite) TestGetOrderError() {
 	expectedError := constants.ErrorNotFound
 
-	suite.mockOrderRepo.EXPECT().Get("123").Return(nil, expectedError).Times(1)
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(nil, expectedError).Times(1)
 
-	result, err := suite.orderService.GetOrder("123")
+	result, err := suite.orderService.GetOrder(context.Background(), "123")
 
 	assert.Nil(suite.T(), result)
 	assert.Error(suite.T(), err)
@@ -205,7 +271,7 @@ func (suite *orderServiceTestSuite) TestUpdateOrder() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -224,21 +290,60 @@ func (suite *orderServiceTestSuite) TestUpdateOrder() {
 	}
 
 	model := &models.Order{
-		OrderId:     "123",
-		CustomerId:  "234",
-		OrderedAt:   now,
-		TotalAmount: 200,
-		NoOfItems:   2,
+		OrderId:      "123",
+		CustomerId:   "234",
+		OrderedAt:    now,
+		TotalAmount:  money.MustParse("200", "INR"),
+		NoOfItems:    2,
+		PaymentTerms: constants.PaymentTermsPrepaid,
 	}
 
-	suite.mockOrderRepo.EXPECT().Update("123", model).Return(nil).Times(1)
-	suite.mockOrderItemRepo.EXPECT().GetByOrder("123").Return(nil, nil).Times(1)
-	suite.mockOrderItemRepo.EXPECT().Upsert(gomock.AssignableToTypeOf([]*models.OrderItem{})).Return(nil).Times(1)
+	suite.expectPriceOrder(money.MustParse("200", "INR"))
+	suite.expectTaxOrder()
+	suite.expectPendingOrder("123")
+	suite.mockOrderRepo.EXPECT().Update(gomock.Any(), "123", model).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return(nil, nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Upsert(gomock.Any(), gomock.AssignableToTypeOf([]*models.OrderItem{})).Return(nil).Times(1)
+	suite.mockOrderDiscountRepo.EXPECT().DeleteByOrder(gomock.Any(), "123").Return(nil).Times(1)
 
-	err := suite.orderService.UpdateOrder("123", req)
+	err := suite.orderService.UpdateOrder(context.Background(), "123", req)
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *orderServiceTestSuite) TestUpdateOrderKeepsPaymentTerms() {
+	req := &dtos.Order{
+		OrderId:    "123",
+		CustomerId: "234",
+		Items:      []*dtos.OrderItems{},
+	}
+
+	suite.expectPriceOrder(money.Money{})
+	suite.expectTaxOrder()
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.Order{OrderId: "123", Status: constants.OrderStatusPending,
+		PaymentTerms: constants.PaymentTermsOnAccount}, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().Update(gomock.Any(), "123", gomock.Any()).DoAndReturn(func(_ context.Context, id string, order *models.Order) error {
+		assert.Equal(suite.T(), constants.PaymentTermsOnAccount, order.PaymentTerms)
+		return errors.New("update failed")
+	}).Times(1)
+
+	err := suite.orderService.UpdateOrder(context.Background(), "123", req)
+	assert.EqualError(suite.T(), err, "update failed")
+
+	req.PaymentTerms = constants.PaymentTermsPrepaid
+
+	suite.expectPriceOrder(money.Money{})
+	suite.expectTaxOrder()
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.Order{OrderId: "123", Status: constants.OrderStatusPending,
+		PaymentTerms: constants.PaymentTermsOnAccount}, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().Update(gomock.Any(), "123", gomock.Any()).DoAndReturn(func(_ context.Context, id string, order *models.Order) error {
+		assert.Equal(suite.T(), constants.PaymentTermsPrepaid, order.PaymentTerms)
+		return errors.New("update failed")
+	}).Times(1)
+
+	err = suite.orderService.UpdateOrder(context.Background(), "123", req)
+	assert.EqualError(suite.T(), err, "update failed")
+}
+
 func (suite *orderServiceTestSuite) TestUpdateOrderError() {
 	now := time.Now()
 
@@ -246,7 +351,7 @@ func (suite *orderServiceTestSuite) TestUpdateOrderError() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -265,30 +370,52 @@ func (suite *orderServiceTestSuite) TestUpdateOrderError() {
 	}
 
 	model := &models.Order{
-		OrderId:     "123",
-		CustomerId:  "234",
-		OrderedAt:   now,
-		TotalAmount: 200,
-		NoOfItems:   2,
+		OrderId:      "123",
+		CustomerId:   "234",
+		OrderedAt:    now,
+		TotalAmount:  money.MustParse("200", "INR"),
+		NoOfItems:    2,
+		PaymentTerms: constants.PaymentTermsPrepaid,
 	}
 
-	suite.mockOrderRepo.EXPECT().Update("123", model).Return(constants.ErrorNotFound).Times(1)
+	suite.expectPriceOrder(money.MustParse("200", "INR"))
+	suite.expectTaxOrder()
+	suite.expectPendingOrder("123")
+	suite.mockOrderRepo.EXPECT().Update(gomock.Any(), "123", model).Return(constants.ErrorNotFound).Times(1)
 
-	err := suite.orderService.UpdateOrder("123", req)
+	err := suite.orderService.UpdateOrder(context.Background(), "123", req)
 	assert.Error(suite.T(), err)
 }
 
 func (suite *orderServiceTestSuite) TestDeleteOrder() {
-	suite.mockOrderRepo.EXPECT().Delete("123").Return(nil).Times(1)
-
-	err := suite.orderService.DeleteOrder("123")
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.Order{OrderId: "123", Status: constants.OrderStatusPending,
+		CouponCode: "SAVE10"}, nil).Times(1)
+	suite.mockCouponRepo.EXPECT().Release(gomock.Any(), "SAVE10", "123").Return(nil).Times(1)
+	suite.mockOrderDiscountRepo.EXPECT().DeleteByOrder(gomock.Any(), "123").Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return([]*models.OrderItem{{OrderItemId: "i1"}, {OrderItemId: "i2"}}, nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().DeleteAll(gomock.Any(), []string{"i1", "i2"}).Return(nil).Times(1)
+	suite.mockOrderRepo.EXPECT().Delete(gomock.Any(), "123").Return(nil).Times(1)
+
+	err := suite.orderService.DeleteOrder(context.Background(), "123")
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *orderServiceTestSuite) TestDeleteOrderReleaseError() {
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.Order{OrderId: "123", Status: constants.OrderStatusPending,
+		CouponCode: "SAVE10"}, nil).Times(1)
+	suite.mockCouponRepo.EXPECT().Release(gomock.Any(), "SAVE10", "123").Return(errors.New("release failed")).Times(1)
+
+	err := suite.orderService.DeleteOrder(context.Background(), "123")
+	assert.EqualError(suite.T(), err, "release failed")
+}
+
 func (suite *orderServiceTestSuite) TestDeleteOrderError() {
-	suite.mockOrderRepo.EXPECT().Delete("123").Return(constants.ErrorNotFound).Times(1)
+	suite.expectPendingOrder("123")
+	suite.mockOrderDiscountRepo.EXPECT().DeleteByOrder(gomock.Any(), "123").Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return(nil, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().Delete(gomock.Any(), "123").Return(constants.ErrorNotFound).Times(1)
 
-	err := suite.orderService.DeleteOrder("123")
+	err := suite.orderService.DeleteOrder(context.Background(), "123")
 	assert.Error(suite.T(), err)
 }
 
@@ -299,7 +426,7 @@ func (suite *orderServiceTestSuite) TestOrderDtosToModel() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -321,7 +448,7 @@ func (suite *orderServiceTestSuite) TestOrderDtosToModel() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.Money{Currency: "INR"},
 		NoOfItems:   2,
 	}
 
@@ -336,7 +463,7 @@ func (suite *orderServiceTestSuite) TestOrderModelToDtos() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 	}
 
@@ -359,7 +486,8 @@ func (suite *orderServiceTestSuite) TestOrderModelToDtos() {
 		OrderId:     "123",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		Currency:    "INR",
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -388,7 +516,7 @@ func (suite *orderServiceTestSuite) TestOrderDtosToModel_IdMissing() {
 		OrderId:     "",
 		CustomerId:  "234",
 		OrderedAt:   now,
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -415,7 +543,7 @@ func (suite *orderServiceTestSuite) TestOrderDtosToModel_TimedMissing() {
 	input := &dtos.Order{
 		OrderId:     "123",
 		CustomerId:  "234",
-		TotalAmount: 200,
+		TotalAmount: money.MustParse("200", "INR"),
 		NoOfItems:   2,
 		Items: []*dtos.OrderItems{
 			{
@@ -446,10 +574,15 @@ func (suite *orderServiceTestSuite) TestCreateOrder_OrderRepoError() {
 	}
 
 	orderModel, _ := OrderDtosToModel(req)
+	orderModel.Status = constants.OrderStatusPending
+	orderModel.PaymentTerms = constants.PaymentTermsPrepaid
+	orderModel.Version = 1
 
-	suite.mockOrderRepo.EXPECT().Create(orderModel).Return(errors.New("create failed")).Times(1)
+	suite.expectPriceOrder(money.Money{})
+	suite.expectTaxOrder()
+	suite.mockOrderRepo.EXPECT().Create(gomock.Any(), orderModel).Return(errors.New("create failed")).Times(1)
 
-	err := suite.orderService.CreateOrder(req)
+	err := suite.orderService.CreateOrder(context.Background(), req)
 
 	assert.EqualError(suite.T(), err, "create failed")
 }
@@ -462,11 +595,16 @@ func (suite *orderServiceTestSuite) TestCreateOrder_ItemRepoError() {
 	}
 
 	orderModel, _ := OrderDtosToModel(req)
+	orderModel.Status = constants.OrderStatusPending
+	orderModel.PaymentTerms = constants.PaymentTermsPrepaid
+	orderModel.Version = 1
 
-	suite.mockOrderRepo.EXPECT().Create(orderModel).Return(nil).Times(1)
-	suite.mockOrderItemRepo.EXPECT().Create(gomock.Any()).Return(errors.New("item create error")).Times(1)
+	suite.expectPriceOrder(money.Money{})
+	suite.expectTaxOrder()
+	suite.mockOrderRepo.EXPECT().Create(gomock.Any(), orderModel).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("item create error")).Times(1)
 
-	err := suite.orderService.CreateOrder(req)
+	err := suite.orderService.CreateOrder(context.Background(), req)
 
 	assert.EqualError(suite.T(), err, "item create error")
 }
@@ -476,46 +614,55 @@ func (suite *orderServiceTestSuite) TestUpdateOrder_OrderIdEmpty() {
 		OrderId: "",
 	}
 
-	err := suite.orderService.UpdateOrder("some-id", req)
+	err := suite.orderService.UpdateOrder(context.Background(), "some-id", req)
 
 	assert.Equal(suite.T(), constants.ErrorOrderIdEmpty, err)
 }
 
 func (suite *orderServiceTestSuite) TestUpdateOrder_OrderRepoUpdateError() {
 	req := &dtos.Order{
-		OrderId:    "123",
-		CustomerId: "234",
-		Items:      []*dtos.OrderItems{},
+		PaymentTerms: constants.PaymentTermsPrepaid,
+		OrderId:      "123",
+		CustomerId:   "234",
+		Items:        []*dtos.OrderItems{},
 	}
 
 	orderModel, _ := OrderDtosToModel(req)
 
-	suite.mockOrderRepo.EXPECT().Update("123", orderModel).Return(errors.New("update failed")).Times(1)
+	suite.expectPriceOrder(money.Money{})
+	suite.expectTaxOrder()
+	suite.expectPendingOrder("123")
+	suite.mockOrderRepo.EXPECT().Update(gomock.Any(), "123", orderModel).Return(errors.New("update failed")).Times(1)
 
-	err := suite.orderService.UpdateOrder("123", req)
+	err := suite.orderService.UpdateOrder(context.Background(), "123", req)
 	assert.EqualError(suite.T(), err, "update failed")
 }
 
 func (suite *orderServiceTestSuite) TestUpdateOrder_ItemRepoUpdateError() {
 	req := &dtos.Order{
-		OrderId:    "123",
-		CustomerId: "234",
-		Items:      []*dtos.OrderItems{},
+		PaymentTerms: constants.PaymentTermsPrepaid,
+		OrderId:      "123",
+		CustomerId:   "234",
+		Items:        []*dtos.OrderItems{},
 	}
 
 	orderModel, _ := OrderDtosToModel(req)
 
-	suite.mockOrderRepo.EXPECT().Update("123", orderModel).Return(nil).Times(1)
-	suite.mockOrderItemRepo.EXPECT().GetByOrder("123").Return(nil, errors.New("item update failed")).Times(1)
+	suite.expectPriceOrder(money.Money{})
+	suite.expectTaxOrder()
+	suite.expectPendingOrder("123")
+	suite.mockOrderRepo.EXPECT().Update(gomock.Any(), "123", orderModel).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return(nil, errors.New("item update failed")).Times(1)
 
-	err := suite.orderService.UpdateOrder("123", req)
+	err := suite.orderService.UpdateOrder(context.Background(), "123", req)
 
 	assert.EqualError(suite.T(), err, "item update failed")
 }
 
 func (suite *orderServiceTestSuite) TestUpdateOrder_DeleteAllError() {
 	req := &dtos.Order{
-		OrderId: "123",
+		PaymentTerms: constants.PaymentTermsPrepaid,
+		OrderId:      "123",
 		Items: []*dtos.OrderItems{
 			{
 				OrderItemId: "new-item",
@@ -527,21 +674,25 @@ func (suite *orderServiceTestSuite) TestUpdateOrder_DeleteAllError() {
 	}
 
 	orderModel, _ := OrderDtosToModel(req)
-	suite.mockOrderRepo.EXPECT().Update("123", orderModel).Return(nil).Times(1)
+	suite.expectPriceOrder(money.Money{})
+	suite.expectTaxOrder()
+	suite.expectPendingOrder("123")
+	suite.mockOrderRepo.EXPECT().Update(gomock.Any(), "123", orderModel).Return(nil).Times(1)
 
 	existing := []*models.OrderItem{
 		{OrderItemId: "old-item"},
 	}
-	suite.mockOrderItemRepo.EXPECT().GetByOrder("123").Return(existing, nil).Times(1)
-	suite.mockOrderItemRepo.EXPECT().DeleteAll([]string{"old-item"}).Return(errors.New("delete failed")).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return(existing, nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().DeleteAll(gomock.Any(), []string{"old-item"}).Return(errors.New("delete failed")).Times(1)
 
-	err := suite.orderService.UpdateOrder("123", req)
+	err := suite.orderService.UpdateOrder(context.Background(), "123", req)
 	assert.EqualError(suite.T(), err, "delete failed")
 }
 
 func (suite *orderServiceTestSuite) TestUpdateOrder_UpsertError() {
 	req := &dtos.Order{
-		OrderId: "123",
+		PaymentTerms: constants.PaymentTermsPrepaid,
+		OrderId:      "123",
 		Items: []*dtos.OrderItems{
 			{
 				OrderItemId: "item-1",
@@ -554,21 +705,363 @@ func (suite *orderServiceTestSuite) TestUpdateOrder_UpsertError() {
 
 	orderModel, _ := OrderDtosToModel(req)
 
-	suite.mockOrderRepo.EXPECT().Update("123", orderModel).Return(nil).Times(1)
-	suite.mockOrderItemRepo.EXPECT().GetByOrder("123").Return([]*models.OrderItem{}, nil).Times(1)
-	suite.mockOrderItemRepo.EXPECT().Upsert(gomock.Any()).Return(errors.New("upsert failed")).Times(1)
+	suite.expectPriceOrder(money.Money{})
+	suite.expectTaxOrder()
+	suite.expectPendingOrder("123")
+	suite.mockOrderRepo.EXPECT().Update(gomock.Any(), "123", orderModel).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return([]*models.OrderItem{}, nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(errors.New("upsert failed")).Times(1)
 
-	err := suite.orderService.UpdateOrder("123", req)
+	err := suite.orderService.UpdateOrder(context.Background(), "123", req)
 
 	assert.EqualError(suite.T(), err, "upsert failed")
 }
 
 func (suite *orderServiceTestSuite) TestGetOrder_OrderItemRepoError() {
-	suite.mockOrderRepo.EXPECT().Get("123").Return(&models.Order{}, nil).Times(1)
-	suite.mockOrderItemRepo.EXPECT().GetByOrder("123").Return(nil, errors.New("items error")).Times(1)
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.Order{}, nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return(nil, errors.New("items error")).Times(1)
 
-	result, err := suite.orderService.GetOrder("123")
+	result, err := suite.orderService.GetOrder(context.Background(), "123")
 
 	assert.Nil(suite.T(), result)
 	assert.EqualError(suite.T(), err, "items error")
 }
+
+func (suite *orderServiceTestSuite) TestCreateOrder_PricingError() {
+	req := &dtos.Order{
+		OrderId:    "123",
+		CustomerId: "234",
+		Currency:   "XYZ",
+		Items:      []*dtos.OrderItems{{ArticleId: "1", Quantity: 1}},
+	}
+
+	suite.mockPricingService.EXPECT().PriceOrder(gomock.Any(), gomock.Any(), gomock.Any()).Return(constants.ErrorExchangeRateNotFound).Times(1)
+
+	err := suite.orderService.CreateOrder(context.Background(), req)
+	assert.Equal(suite.T(), constants.ErrorExchangeRateNotFound, err)
+}
+
+func (suite *orderServiceTestSuite) TestCreateOrderWithCoupon() {
+	req := &dtos.Order{
+		OrderId:    "123",
+		CustomerId: "234",
+		CouponCode: " SAVE10 ",
+		Items:      []*dtos.OrderItems{{ArticleId: "1", Quantity: 1}},
+	}
+
+	discounts := []*models.OrderDiscount{
+		{OrderDiscountId: "d1", OrderId: "123", CouponCode: "SAVE10", Amount: money.MustParse("10", "INR")},
+	}
+
+	suite.expectPriceOrder(money.MustParse("100", "INR"))
+	suite.expectTaxOrder()
+	suite.mockCouponService.EXPECT().ApplyCoupon(gomock.Any(), "SAVE10", gomock.Any(), gomock.Any()).Return(discounts, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockOrderDiscountRepo.EXPECT().Create(gomock.Any(), discounts[0]).Return(nil).Times(1)
+	suite.mockCouponRepo.EXPECT().Redeem(gomock.Any(), "SAVE10", "234", "123", gomock.Any()).Return(nil).Times(1)
+
+	err := suite.orderService.CreateOrder(context.Background(), req)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *orderServiceTestSuite) TestCreateOrderCouponUsageLimit() {
+	req := &dtos.Order{
+		OrderId:    "123",
+		CustomerId: "234",
+		CouponCode: "SAVE10",
+		Items:      []*dtos.OrderItems{{ArticleId: "1", Quantity: 1}},
+	}
+
+	discounts := []*models.OrderDiscount{
+		{OrderDiscountId: "d1", OrderId: "123", CouponCode: "SAVE10", Amount: money.MustParse("10", "INR")},
+	}
+
+	suite.expectPriceOrder(money.MustParse("100", "INR"))
+	suite.expectTaxOrder()
+	suite.mockCouponService.EXPECT().ApplyCoupon(gomock.Any(), "SAVE10", gomock.Any(), gomock.Any()).Return(discounts, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockOrderDiscountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockCouponRepo.EXPECT().Redeem(gomock.Any(), "SAVE10", "234", "123", gomock.Any()).Return(constants.ErrorCouponUsageLimit).Times(1)
+
+	err := suite.orderService.CreateOrder(context.Background(), req)
+	assert.Equal(suite.T(), constants.ErrorCouponUsageLimit, err)
+}
+
+func (suite *orderServiceTestSuite) TestCreateOrderInvalidCoupon() {
+	req := &dtos.Order{
+		OrderId:    "123",
+		CustomerId: "234",
+		CouponCode: "NOPE",
+		Items:      []*dtos.OrderItems{{ArticleId: "1", Quantity: 1}},
+	}
+
+	suite.expectPriceOrder(money.MustParse("100", "INR"))
+	suite.mockCouponService.EXPECT().ApplyCoupon(gomock.Any(), "NOPE", gomock.Any(), gomock.Any()).Return(nil, constants.ErrorCouponInvalid).Times(1)
+
+	err := suite.orderService.CreateOrder(context.Background(), req)
+	assert.Equal(suite.T(), constants.ErrorCouponInvalid, err)
+}
+
+func (suite *orderServiceTestSuite) TestCreateOrderTaxesDiscountedLines() {
+	req := &dtos.Order{
+		OrderId:           "123",
+		CustomerId:        "234",
+		CouponCode:        "SAVE10",
+		ShippingAddressId: "a1",
+		Items:             []*dtos.OrderItems{{ArticleId: "1", Quantity: 1}},
+	}
+
+	discounts := []*models.OrderDiscount{
+		{OrderDiscountId: "d1", OrderId: "123", CouponCode: "SAVE10", Amount: money.MustParse("10", "INR")},
+	}
+
+	suite.expectPriceOrder(money.MustParse("100", "INR"))
+	suite.mockCouponService.EXPECT().ApplyCoupon(gomock.Any(), "SAVE10", gomock.Any(), gomock.Any()).Return(discounts, nil).Times(1)
+	suite.mockTaxService.EXPECT().TaxOrder(gomock.Any(), gomock.Any(), gomock.Any(), discounts).DoAndReturn(
+		func(_ context.Context, order *models.Order, items []*models.OrderItem, discounts []*models.OrderDiscount) error {
+			assert.Equal(suite.T(), "a1", order.ShippingAddressId)
+			order.TaxAmount = money.MustParse("16.20", "INR")
+			return nil
+		}).Times(1)
+	suite.mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *models.Order) error {
+		assert.Equal(suite.T(), "16.20 INR", order.TaxAmount.String())
+		return nil
+	}).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockOrderDiscountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockCouponRepo.EXPECT().Redeem(gomock.Any(), "SAVE10", "234", "123", gomock.Any()).Return(nil).Times(1)
+
+	err := suite.orderService.CreateOrder(context.Background(), req)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *orderServiceTestSuite) TestUpdateOrderChangesCoupon() {
+	req := &dtos.Order{
+		OrderId:    "123",
+		CustomerId: "234",
+		CouponCode: "SAVE20",
+		Items:      []*dtos.OrderItems{{OrderItemId: "i1", ArticleId: "1", Quantity: 1}},
+	}
+
+	discounts := []*models.OrderDiscount{
+		{OrderDiscountId: "d1", OrderId: "123", CouponCode: "SAVE20", Amount: money.MustParse("20", "INR")},
+	}
+
+	suite.expectPriceOrder(money.MustParse("100", "INR"))
+	suite.mockCouponService.EXPECT().ApplyCoupon(gomock.Any(), "SAVE20", gomock.Any(), gomock.Any()).Return(discounts, nil).Times(1)
+	suite.expectTaxOrder()
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.Order{OrderId: "123", Status: constants.OrderStatusPending,
+		CouponCode: "SAVE10"}, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().Update(gomock.Any(), "123", gomock.Any()).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return([]*models.OrderItem{{OrderItemId: "i1"}}, nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	gomock.InOrder(
+		suite.mockOrderDiscountRepo.EXPECT().DeleteByOrder(gomock.Any(), "123").Return(nil).Times(1),
+		suite.mockCouponRepo.EXPECT().Release(gomock.Any(), "SAVE10", "123").Return(nil).Times(1),
+		suite.mockOrderDiscountRepo.EXPECT().Create(gomock.Any(), discounts[0]).Return(nil).Times(1),
+		suite.mockCouponRepo.EXPECT().Redeem(gomock.Any(), "SAVE20", "234", "123", gomock.Any()).Return(nil).Times(1),
+	)
+
+	err := suite.orderService.UpdateOrder(context.Background(), "123", req)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *orderServiceTestSuite) TestUpdateOrderDropsCoupon() {
+	req := &dtos.Order{
+		OrderId:    "123",
+		CustomerId: "234",
+		Items:      []*dtos.OrderItems{{OrderItemId: "i1", ArticleId: "1", Quantity: 1}},
+	}
+
+	suite.expectPriceOrder(money.MustParse("100", "INR"))
+	suite.expectTaxOrder()
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.Order{OrderId: "123", Status: constants.OrderStatusPending,
+		CouponCode: "SAVE10"}, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().Update(gomock.Any(), "123", gomock.Any()).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return([]*models.OrderItem{{OrderItemId: "i1"}}, nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockOrderDiscountRepo.EXPECT().DeleteByOrder(gomock.Any(), "123").Return(nil).Times(1)
+	suite.mockCouponRepo.EXPECT().Release(gomock.Any(), "SAVE10", "123").Return(errors.New("release failed")).Times(1)
+
+	err := suite.orderService.UpdateOrder(context.Background(), "123", req)
+	assert.EqualError(suite.T(), err, "release failed")
+}
+
+func (suite *orderServiceTestSuite) TestUpdateOrderKeepsCoupon() {
+	req := &dtos.Order{
+		OrderId:    "123",
+		CustomerId: "234",
+		CouponCode: "SAVE10",
+		Items:      []*dtos.OrderItems{{OrderItemId: "i1", ArticleId: "1", Quantity: 2}},
+	}
+
+	discounts := []*models.OrderDiscount{
+		{OrderDiscountId: "d1", OrderId: "123", CouponCode: "SAVE10", Amount: money.MustParse("20", "INR")},
+	}
+
+	suite.expectPriceOrder(money.MustParse("200", "INR"))
+	suite.mockCouponService.EXPECT().ApplyCoupon(gomock.Any(), "SAVE10", gomock.Any(), gomock.Any()).Return(discounts, nil).Times(1)
+	suite.expectTaxOrder()
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.Order{OrderId: "123", Status: constants.OrderStatusPending,
+		CouponCode: "SAVE10"}, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().Update(gomock.Any(), "123", gomock.Any()).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return([]*models.OrderItem{{OrderItemId: "i1"}}, nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	suite.mockOrderDiscountRepo.EXPECT().DeleteByOrder(gomock.Any(), "123").Return(nil).Times(1)
+	suite.mockOrderDiscountRepo.EXPECT().Create(gomock.Any(), discounts[0]).Return(nil).Times(1)
+	suite.mockCouponRepo.EXPECT().Redeem(gomock.Any(), "SAVE10", "234", "123", gomock.Any()).Return(nil).Times(1)
+
+	err := suite.orderService.UpdateOrder(context.Background(), "123", req)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *orderServiceTestSuite) TestCreateOrderTaxError() {
+	req := &dtos.Order{
+		OrderId:    "123",
+		CustomerId: "234",
+		Items:      []*dtos.OrderItems{{ArticleId: "1", Quantity: 1}},
+	}
+
+	suite.expectPriceOrder(money.MustParse("100", "INR"))
+	suite.mockTaxService.EXPECT().TaxOrder(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(constants.ErrorShippingAddressEmpty).Times(1)
+
+	err := suite.orderService.CreateOrder(context.Background(), req)
+	assert.Equal(suite.T(), constants.ErrorShippingAddressEmpty, err)
+}
+
+func (suite *orderServiceTestSuite) TestConfirmOrderIssuesInvoice() {
+	order := &models.Order{OrderId: "123", CustomerId: "234", Status: constants.OrderStatusPending}
+
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(order, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), "123", constants.OrderStatusPending, constants.OrderStatusConfirmed).Return(nil).Times(1)
+	suite.mockBackorderService.EXPECT().Allocate(gomock.Any(), gomock.Any(), order).Return(nil).Times(1)
+	suite.mockInvoiceService.EXPECT().IssueInvoice(gomock.Any(), gomock.Any(), order).Return(&dtos.Invoice{InvoiceNumber: "INV-000001"}, nil).Times(1)
+
+	err := suite.orderService.UpdateOrderStatus(context.Background(), "123", &dtos.UpdateOrderStatus{Status: constants.OrderStatusConfirmed})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *orderServiceTestSuite) TestConfirmOrderInvoiceError() {
+	order := &models.Order{OrderId: "123", CustomerId: "234", Status: constants.OrderStatusPending}
+
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(order, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), "123", constants.OrderStatusPending, constants.OrderStatusConfirmed).Return(nil).Times(1)
+	suite.mockBackorderService.EXPECT().Allocate(gomock.Any(), gomock.Any(), order).Return(nil).Times(1)
+	suite.mockInvoiceService.EXPECT().IssueInvoice(gomock.Any(), gomock.Any(), order).Return(nil, constants.ErrorSellerNotConfigured).Times(1)
+
+	err := suite.orderService.UpdateOrderStatus(context.Background(), "123", &dtos.UpdateOrderStatus{Status: constants.OrderStatusConfirmed})
+	assert.Equal(suite.T(), constants.ErrorSellerNotConfigured, err)
+}
+
+func (suite *orderServiceTestSuite) TestConfirmOrderInsufficientStock() {
+	order := &models.Order{OrderId: "123", CustomerId: "234", Status: constants.OrderStatusPending}
+
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(order, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), "123", constants.OrderStatusPending, constants.OrderStatusConfirmed).Return(nil).Times(1)
+	suite.mockBackorderService.EXPECT().Allocate(gomock.Any(), gomock.Any(), order).Return(constants.ErrorInsufficientStock).Times(1)
+
+	err := suite.orderService.UpdateOrderStatus(context.Background(), "123", &dtos.UpdateOrderStatus{Status: constants.OrderStatusConfirmed})
+	assert.Equal(suite.T(), constants.ErrorInsufficientStock, err)
+}
+
+func (suite *orderServiceTestSuite) TestCancelConfirmedOrderIssuesCreditNote() {
+	order := &models.Order{OrderId: "123", CustomerId: "234", Status: constants.OrderStatusConfirmed}
+
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(order, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), "123", constants.OrderStatusConfirmed, constants.OrderStatusCancelled).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return([]*models.OrderItem{{OrderItemId: "i1", Quantity: 2}}, nil).Times(1)
+	suite.mockShipmentItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return(nil, nil).Times(1)
+	suite.mockInvoiceService.EXPECT().IssueCreditNote(gomock.Any(), gomock.Any(), "123", nil).
+		Return(&dtos.Invoice{InvoiceNumber: "CN-000001", TotalAmount: money.MustParse("200", "INR")}, nil).Times(1)
+	suite.mockBackorderService.EXPECT().Release(gomock.Any(), gomock.Any(), "123").Return(nil).Times(1)
+	suite.mockPaymentService.EXPECT().Refund(gomock.Any(), gomock.Any(), "123", money.MustParse("200", "INR"), "order cancelled").Return(nil).Times(1)
+	suite.mockPaymentService.EXPECT().SettleRefunds(gomock.Any(), "123").Return(nil).Times(1)
+
+	err := suite.orderService.UpdateOrderStatus(context.Background(), "123", &dtos.UpdateOrderStatus{Status: constants.OrderStatusCancelled})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *orderServiceTestSuite) TestCancelPartiallyShippedOrder() {
+	order := &models.Order{OrderId: "123", CustomerId: "234", Status: constants.OrderStatusConfirmed}
+
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(order, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), "123", constants.OrderStatusConfirmed, constants.OrderStatusCancelled).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return([]*models.OrderItem{
+		{OrderItemId: "i1", Quantity: 3},
+		{OrderItemId: "i2", Quantity: 1},
+	}, nil).Times(1)
+	suite.mockShipmentItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return([]*models.ShipmentItem{
+		{ShipmentItemId: "si1", OrderItemId: "i1", Quantity: 2},
+		{ShipmentItemId: "si2", OrderItemId: "i2", Quantity: 1},
+	}, nil).Times(1)
+	suite.mockInvoiceService.EXPECT().IssueCreditNote(gomock.Any(), gomock.Any(), "123", map[string]int{"i1": 1}).
+		Return(&dtos.Invoice{InvoiceNumber: "CN-000001", TotalAmount: money.MustParse("100", "INR")}, nil).Times(1)
+	suite.mockBackorderService.EXPECT().Release(gomock.Any(), gomock.Any(), "123").Return(nil).Times(1)
+	suite.mockPaymentService.EXPECT().Refund(gomock.Any(), gomock.Any(), "123", money.MustParse("100", "INR"), "order cancelled").Return(nil).Times(1)
+	suite.mockPaymentService.EXPECT().SettleRefunds(gomock.Any(), "123").Return(nil).Times(1)
+
+	err := suite.orderService.UpdateOrderStatus(context.Background(), "123", &dtos.UpdateOrderStatus{Status: constants.OrderStatusCancelled})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *orderServiceTestSuite) TestCancelFullyShippedOrder() {
+	order := &models.Order{OrderId: "123", CustomerId: "234", Status: constants.OrderStatusConfirmed}
+
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(order, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), "123", constants.OrderStatusConfirmed, constants.OrderStatusCancelled).Return(nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return([]*models.OrderItem{{OrderItemId: "i1", Quantity: 2}}, nil).Times(1)
+	suite.mockShipmentItemRepo.EXPECT().GetByOrder(gomock.Any(), "123").Return([]*models.ShipmentItem{
+		{ShipmentItemId: "si1", OrderItemId: "i1", Quantity: 2},
+	}, nil).Times(1)
+
+	err := suite.orderService.UpdateOrderStatus(context.Background(), "123", &dtos.UpdateOrderStatus{Status: constants.OrderStatusCancelled})
+	assert.Equal(suite.T(), constants.ErrorOrderFullyShipped, err)
+}
+
+func (suite *orderServiceTestSuite) TestCancelPendingOrder() {
+	order := &models.Order{OrderId: "123", CustomerId: "234", TotalAmount: money.MustParse("300", "INR")}
+
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(order, nil).Times(1)
+	suite.mockOrderRepo.EXPECT().UpdateStatus(gomock.Any(), "123", "", constants.OrderStatusCancelled).Return(nil).Times(1)
+	gomock.InOrder(
+		suite.mockPaymentService.EXPECT().Refund(gomock.Any(), gomock.Any(), "123", money.MustParse("300", "INR"), "order cancelled").Return(nil),
+		suite.mockPaymentService.EXPECT().SettleRefunds(gomock.Any(), "123").Return(errors.New("provider unavailable")),
+	)
+
+	// The refund stays pending and the cancellation stands.
+	err := suite.orderService.UpdateOrderStatus(context.Background(), "123", &dtos.UpdateOrderStatus{Status: constants.OrderStatusCancelled})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *orderServiceTestSuite) TestUpdateOrderStatusInvalidTransition() {
+	order := &models.Order{OrderId: "123", CustomerId: "234", Status: constants.OrderStatusCancelled}
+
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(order, nil).Times(1)
+
+	err := suite.orderService.UpdateOrderStatus(context.Background(), "123", &dtos.UpdateOrderStatus{Status: constants.OrderStatusConfirmed})
+	assert.Equal(suite.T(), constants.ErrorInvalidOrderStatus, err)
+}
+
+func (suite *orderServiceTestSuite) TestUpdateOrderNotPending() {
+	req := &dtos.Order{
+		OrderId:    "123",
+		CustomerId: "234",
+		Items:      []*dtos.OrderItems{{ArticleId: "1", Quantity: 1}},
+	}
+
+	suite.expectPriceOrder(money.MustParse("100", "INR"))
+	suite.expectTaxOrder()
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.Order{OrderId: "123", Status: constants.OrderStatusConfirmed}, nil).Times(1)
+
+	err := suite.orderService.UpdateOrder(context.Background(), "123", req)
+	assert.Equal(suite.T(), constants.ErrorOrderNotEditable, err)
+}
+
+func (suite *orderServiceTestSuite) TestDeleteOrderNotPending() {
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.Order{OrderId: "123", Status: constants.OrderStatusConfirmed}, nil).Times(1)
+
+	err := suite.orderService.DeleteOrder(context.Background(), "123")
+	assert.Equal(suite.T(), constants.ErrorOrderNotEditable, err)
+}
@@ -0,0 +1,86 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-management/constants"
+	"inventory-management/money"
+	"sync"
+)
+
+// FakeProvider keeps charges in memory. Charges made with a method listed in
+// Declined fail, and a charge can never be refunded for more than it was.
+// While Unavailable is set every call fails, as if the provider couldn't be
+// reached.
+type FakeProvider struct {
+	Declined    map[string]bool
+	Unavailable bool
+
+	mu         sync.Mutex
+	next       int
+	refundable map[string]money.Money
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{
+		Declined:   make(map[string]bool),
+		refundable: make(map[string]money.Money),
+	}
+}
+
+func (f *FakeProvider) Charge(ctx context.Context, reference string, orderId string, method string, amount money.Money) (*ProviderResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Unavailable {
+		return nil, errors.New("provider unavailable")
+	}
+
+	f.next++
+	ref := fmt.Sprintf("fake_ch_%d", f.next)
+
+	if f.Declined[method] {
+		return &ProviderResult{ExternalRef: ref, Status: constants.PaymentStatusFailed}, nil
+	}
+
+	f.refundable[ref] = amount
+
+	return &ProviderResult{ExternalRef: ref, Status: constants.PaymentStatusSucceeded}, nil
+}
+
+func (f *FakeProvider) Refund(ctx context.Context, reference string, externalRef string, amount money.Money) (*ProviderResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Unavailable {
+		return nil, errors.New("provider unavailable")
+	}
+
+	refundable, ok := f.refundable[externalRef]
+	if !ok {
+		return nil, errors.New("unknown charge " + externalRef)
+	}
+
+	left, err := refundable.Sub(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if left.IsNegative() {
+		return nil, errors.New("refund exceeds charge " + externalRef)
+	}
+
+	f.refundable[externalRef] = left
+	f.next++
+
+	return &ProviderResult{ExternalRef: fmt.Sprintf("fake_re_%d", f.next), Status: constants.PaymentStatusSucceeded}, nil
+}
+
+// Refundable is what is left to refund on a charge.
+func (f *FakeProvider) Refundable(externalRef string) money.Money {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.refundable[externalRef]
+}
@@ -0,0 +1,98 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"inventory-management/constants"
+	"inventory-management/money"
+	"net/http"
+	"strings"
+)
+
+// HTTPProvider charges and refunds through a payment gateway's JSON API. Both
+// are POSTed, to /charges and /refunds under the endpoint, with the reference
+// as the Idempotency-Key, and answered with the gateway's id for the charge or
+// refund and a status of succeeded or failed.
+type HTTPProvider struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+func NewHTTPProvider(endpoint string, apiKey string, client *http.Client) *HTTPProvider {
+	return &HTTPProvider{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		apiKey:   apiKey,
+		client:   client,
+	}
+}
+
+type gatewayCharge struct {
+	Reference string      `json:"reference"`
+	OrderId   string      `json:"order_id"`
+	Method    string      `json:"method"`
+	Amount    money.Money `json:"amount"`
+}
+
+type gatewayRefund struct {
+	Reference string      `json:"reference"`
+	Charge    string      `json:"charge"`
+	Amount    money.Money `json:"amount"`
+}
+
+type gatewayResult struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (h *HTTPProvider) Charge(ctx context.Context, reference string, orderId string, method string, amount money.Money) (*ProviderResult, error) {
+	return h.post(ctx, "/charges", reference, &gatewayCharge{Reference: reference, OrderId: orderId, Method: method, Amount: amount})
+}
+
+func (h *HTTPProvider) Refund(ctx context.Context, reference string, externalRef string, amount money.Money) (*ProviderResult, error) {
+	return h.post(ctx, "/refunds", reference, &gatewayRefund{Reference: reference, Charge: externalRef, Amount: amount})
+}
+
+func (h *HTTPProvider) post(ctx context.Context, path string, reference string, body any) (*ProviderResult, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", reference)
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("payment gateway answered %s to %s", resp.Status, path)
+	}
+
+	var result gatewayResult
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	switch result.Status {
+	case constants.PaymentStatusSucceeded, constants.PaymentStatusFailed:
+	default:
+		return nil, fmt.Errorf("payment gateway answered %s with status %q", path, result.Status)
+	}
+
+	return &ProviderResult{ExternalRef: result.Id, Status: result.Status}, nil
+}
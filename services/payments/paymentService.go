@@ -0,0 +1,380 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/tracing"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PaymentService interface {
+	RecordPayment(ctx context.Context, orderId string, req *dtos.Payment) error
+	GetOrderPayments(ctx context.Context, orderId string) (*dtos.OrderBalance, error)
+	Refund(ctx context.Context, repos *repository.Repos, orderId string, amount money.Money, reason string) error
+	SettleRefunds(ctx context.Context, orderId string) error
+	CheckPaid(ctx context.Context, repos *repository.Repos, order *models.Order) error
+}
+
+type paymentService struct {
+	orderRepo   repository.OrderRepo
+	paymentRepo repository.PaymentRepo
+	invoiceRepo repository.InvoiceRepo
+	txManager   repository.TxManager
+	provider    Provider
+}
+
+func NewPaymentService(orderRepo repository.OrderRepo, paymentRepo repository.PaymentRepo, invoiceRepo repository.InvoiceRepo,
+	txManager repository.TxManager, provider Provider) PaymentService {
+	return &paymentService{
+		orderRepo:   orderRepo,
+		paymentRepo: paymentRepo,
+		invoiceRepo: invoiceRepo,
+		txManager:   txManager,
+		provider:    provider,
+	}
+}
+
+// RecordPayment records the payment as pending, charges it with the provider
+// and then records the outcome. The order row is locked while the pending
+// payment is stored so concurrent payments cannot pay more than the balance
+// between them, but not during the charge. A payment whose charge failed to
+// get an answer stays pending and is charged again when it is posted again
+// with the same payment id. A declined charge is still recorded, as failed.
+func (p *paymentService) RecordPayment(ctx context.Context, orderId string, req *dtos.Payment) error {
+	ctx, span := tracing.Start(ctx, "paymentService.RecordPayment")
+	defer span.End()
+
+	if strings.TrimSpace(req.Method) == "" {
+		return constants.ErrorPaymentMethodEmpty
+	}
+
+	if !req.Amount.Amount.IsPositive() {
+		return constants.ErrorInvalidAmount
+	}
+
+	retry := req.PaymentId != ""
+	payment := PaymentDtosToModel(req)
+	payment.OrderId = orderId
+	payment.Type = constants.PaymentTypePayment
+	payment.Status = constants.PaymentStatusPending
+
+	err := p.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		order, err := repos.Orders.GetForUpdate(ctx, orderId)
+		if err != nil {
+			return err
+		}
+
+		if retry {
+			existing, err := repos.Payments.Get(ctx, payment.PaymentId)
+			if err == nil {
+				if existing.OrderId != orderId || existing.Type != constants.PaymentTypePayment || existing.Status != constants.PaymentStatusPending {
+					return constants.ErrorRecordExists
+				}
+
+				payment = existing
+				return nil
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		}
+
+		if payment.Amount.Currency == "" {
+			payment.Amount = money.New(payment.Amount.Amount, order.TotalAmount.Currency)
+		}
+
+		balance, err := orderBalance(ctx, repos.Payments, repos.Invoices, order)
+		if err != nil {
+			return err
+		}
+
+		left, err := balance.Balance.Sub(payment.Amount)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range balance.Payments {
+			if v.Type == constants.PaymentTypePayment && v.Status == constants.PaymentStatusPending {
+				left = money.New(left.Amount.Sub(v.Amount.Amount), left.Currency)
+			}
+		}
+
+		if left.IsNegative() {
+			return constants.ErrorPaymentExceedsBalance
+		}
+
+		return repos.Payments.Create(ctx, payment)
+	})
+	if err != nil {
+		return err
+	}
+
+	req.PaymentId = payment.PaymentId
+
+	result, err := p.provider.Charge(ctx, payment.PaymentId, orderId, payment.Method, payment.Amount)
+	if err != nil {
+		return err
+	}
+
+	// The money has moved by now, so the outcome is recorded even if the
+	// request has timed out or been cancelled.
+	err = p.paymentRepo.Settle(context.WithoutCancel(ctx), payment.PaymentId, result.ExternalRef, result.Status)
+	if err != nil {
+		return err
+	}
+
+	if result.Status == constants.PaymentStatusFailed {
+		return constants.ErrorPaymentFailed
+	}
+
+	return nil
+}
+
+func (p *paymentService) GetOrderPayments(ctx context.Context, orderId string) (*dtos.OrderBalance, error) {
+	ctx, span := tracing.Start(ctx, "paymentService.GetOrderPayments")
+	defer span.End()
+
+	order, err := p.orderRepo.Get(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return orderBalance(ctx, p.paymentRepo, p.invoiceRepo, order)
+}
+
+// Refund records amount as paid back to the customer, newest payment first,
+// but never more than was paid and not yet refunded. The refunds are recorded
+// as pending in the caller's transaction and only sent to the provider by
+// SettleRefunds once it has committed. Nothing happens for an order that was
+// not paid.
+func (p *paymentService) Refund(ctx context.Context, repos *repository.Repos, orderId string, amount money.Money, reason string) error {
+	ctx, span := tracing.Start(ctx, "paymentService.Refund")
+	defer span.End()
+
+	payments, err := repos.Payments.GetByOrder(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	refunded := make(map[string]money.Money)
+	for _, v := range payments {
+		if v.Type == constants.PaymentTypeRefund && v.Status != constants.PaymentStatusFailed {
+			refunded[v.RefundOf] = money.New(refunded[v.RefundOf].Amount.Add(v.Amount.Amount), v.Amount.Currency)
+		}
+	}
+
+	left := amount.Amount
+	for n := len(payments) - 1; n >= 0 && left.IsPositive(); n-- {
+		v := payments[n]
+		if v.Type != constants.PaymentTypePayment || v.Status != constants.PaymentStatusSucceeded {
+			continue
+		}
+
+		if v.Amount.Currency != amount.Currency {
+			return constants.ErrorCurrencyMismatch
+		}
+
+		refundable := v.Amount.Amount.Sub(refunded[v.PaymentId].Amount)
+		if !refundable.IsPositive() {
+			continue
+		}
+
+		if refundable.GreaterThan(left) {
+			refundable = left
+		}
+
+		err = repos.Payments.Create(ctx, &models.Payment{
+			PaymentId: uuid.NewString(),
+			OrderId:   orderId,
+			Type:      constants.PaymentTypeRefund,
+			Method:    v.Method,
+			Amount:    money.New(refundable, amount.Currency),
+			Status:    constants.PaymentStatusPending,
+			RefundOf:  v.PaymentId,
+			Reason:    reason,
+			CreatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		left = left.Sub(refundable)
+	}
+
+	return nil
+}
+
+// SettleRefunds sends the order's pending refunds to the provider and records
+// the outcome of each. Refunds the provider couldn't be reached for stay
+// pending and are sent again, with the same reference, the next time.
+func (p *paymentService) SettleRefunds(ctx context.Context, orderId string) error {
+	ctx, span := tracing.Start(ctx, "paymentService.SettleRefunds")
+	defer span.End()
+
+	payments, err := p.paymentRepo.GetByOrder(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	charges := make(map[string]string)
+	for _, v := range payments {
+		if v.Type == constants.PaymentTypePayment {
+			charges[v.PaymentId] = v.ExternalRef
+		}
+	}
+
+	var errs []error
+	for _, v := range payments {
+		if v.Type != constants.PaymentTypeRefund || v.Status != constants.PaymentStatusPending {
+			continue
+		}
+
+		result, err := p.provider.Refund(ctx, v.PaymentId, charges[v.RefundOf], v.Amount)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		err = p.paymentRepo.Settle(context.WithoutCancel(ctx), v.PaymentId, result.ExternalRef, result.Status)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CheckPaid fails for prepaid orders that still have a balance. Orders on
+// account are invoiced and paid later.
+func (p *paymentService) CheckPaid(ctx context.Context, repos *repository.Repos, order *models.Order) error {
+	ctx, span := tracing.Start(ctx, "paymentService.CheckPaid")
+	defer span.End()
+
+	if order.PaymentTerms == constants.PaymentTermsOnAccount {
+		return nil
+	}
+
+	balance, err := orderBalance(ctx, repos.Payments, repos.Invoices, order)
+	if err != nil {
+		return err
+	}
+
+	if balance.Balance.Amount.IsPositive() {
+		return constants.ErrorOrderNotPaid
+	}
+
+	return nil
+}
+
+// orderBalance adds up the payments and refunds of the order. An order
+// cancelled before it was invoiced owes nothing, otherwise the total is
+// reduced by the credit notes issued against it.
+func orderBalance(ctx context.Context, paymentRepo repository.PaymentRepo, invoiceRepo repository.InvoiceRepo, order *models.Order) (*dtos.OrderBalance, error) {
+	currency := order.TotalAmount.Currency
+
+	payments, err := paymentRepo.GetByOrder(ctx, order.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	invoices, err := invoiceRepo.GetByOrder(ctx, order.OrderId, constants.InvoiceTypeInvoice)
+	if err != nil {
+		return nil, err
+	}
+
+	creditNotes, err := invoiceRepo.GetByOrder(ctx, order.OrderId, constants.InvoiceTypeCreditNote)
+	if err != nil {
+		return nil, err
+	}
+
+	total := order.TotalAmount
+	if order.Status == constants.OrderStatusCancelled && len(invoices) == 0 {
+		total = money.Zero(currency)
+	}
+
+	credited := money.Zero(currency)
+	for _, v := range creditNotes {
+		credited, err = credited.Add(v.TotalAmount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	paid, refunded := money.Zero(currency), money.Zero(currency)
+	for _, v := range payments {
+		if v.Status != constants.PaymentStatusSucceeded {
+			continue
+		}
+
+		if v.Type == constants.PaymentTypeRefund {
+			refunded, err = refunded.Add(v.Amount)
+		} else {
+			paid, err = paid.Add(v.Amount)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	balance := money.New(total.Amount.Sub(credited.Amount).Sub(paid.Amount).Add(refunded.Amount), currency)
+
+	return &dtos.OrderBalance{
+		OrderId:      order.OrderId,
+		PaymentTerms: order.PaymentTerms,
+		TotalAmount:  total,
+		Credited:     credited,
+		Paid:         paid,
+		Refunded:     refunded,
+		Balance:      balance,
+		Payments:     PaymentModelToDtos(payments),
+	}, nil
+}
+
+func PaymentModelToDtos(m []*models.Payment) []*dtos.Payment {
+	payments := []*dtos.Payment{}
+
+	for _, v := range m {
+		payments = append(payments, &dtos.Payment{
+			PaymentId:   v.PaymentId,
+			OrderId:     v.OrderId,
+			Type:        v.Type,
+			Method:      v.Method,
+			Amount:      v.Amount,
+			Status:      v.Status,
+			ExternalRef: v.ExternalRef,
+			RefundOf:    v.RefundOf,
+			Reason:      v.Reason,
+			CreatedAt:   v.CreatedAt,
+		})
+	}
+
+	return payments
+}
+
+func PaymentDtosToModel(m *dtos.Payment) *models.Payment {
+	if m.PaymentId == "" {
+		m.PaymentId = uuid.NewString()
+	}
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now().UTC()
+	}
+
+	return &models.Payment{
+		PaymentId: m.PaymentId,
+		OrderId:   m.OrderId,
+		Method:    strings.ToLower(strings.TrimSpace(m.Method)),
+		Amount:    money.New(m.Amount.Amount, m.Amount.Currency),
+		Reason:    m.Reason,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -0,0 +1,424 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/repository/mocks"
+	"inventory-management/tracing"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"gorm.io/gorm"
+)
+
+type paymentServiceTestSuite struct {
+	suite.Suite
+	mockCtrl        *gomock.Controller
+	mockOrderRepo   *mocks.MockOrderRepo
+	mockPaymentRepo *mocks.MockPaymentRepo
+	mockInvoiceRepo *mocks.MockInvoiceRepo
+	mockTxManager   *mocks.MockTxManager
+	provider        *FakeProvider
+	inTransaction   bool
+	repos           *repository.Repos
+	order           *models.Order
+	payments        []*models.Payment
+	creditNotes     []*models.Invoice
+	paymentService  PaymentService
+}
+
+func TestPaymentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(paymentServiceTestSuite))
+}
+
+// checkedProvider fails the test when the provider is called inside a
+// transaction, where a slow provider would hold the order's row locks.
+type checkedProvider struct {
+	*FakeProvider
+	suite *paymentServiceTestSuite
+}
+
+func (c *checkedProvider) Charge(ctx context.Context, reference string, orderId string, method string, amount money.Money) (*ProviderResult, error) {
+	assert.False(c.suite.T(), c.suite.inTransaction, "charged inside a transaction")
+	return c.FakeProvider.Charge(ctx, reference, orderId, method, amount)
+}
+
+func (c *checkedProvider) Refund(ctx context.Context, reference string, externalRef string, amount money.Money) (*ProviderResult, error) {
+	assert.False(c.suite.T(), c.suite.inTransaction, "refunded inside a transaction")
+	return c.FakeProvider.Refund(ctx, reference, externalRef, amount)
+}
+
+// SetupTest keeps payments in memory so a test can pay, refund and check the
+// balance in turn. The order is a confirmed, invoiced, prepaid order of 365.85.
+func (suite *paymentServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockOrderRepo = mocks.NewMockOrderRepo(suite.mockCtrl)
+	suite.mockPaymentRepo = mocks.NewMockPaymentRepo(suite.mockCtrl)
+	suite.mockInvoiceRepo = mocks.NewMockInvoiceRepo(suite.mockCtrl)
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
+	suite.provider = NewFakeProvider()
+
+	suite.order = &models.Order{
+		OrderId:      "o1",
+		Status:       constants.OrderStatusConfirmed,
+		PaymentTerms: constants.PaymentTermsPrepaid,
+		TotalAmount:  money.MustParse("365.85", "INR"),
+	}
+	suite.payments = nil
+	suite.creditNotes = nil
+
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "o1").Return(suite.order, nil).AnyTimes()
+	suite.mockOrderRepo.EXPECT().GetForUpdate(gomock.Any(), "o1").Return(suite.order, nil).AnyTimes()
+	suite.mockPaymentRepo.EXPECT().GetByOrder(gomock.Any(), "o1").DoAndReturn(func(_ context.Context, orderId string) ([]*models.Payment, error) {
+		return suite.payments, nil
+	}).AnyTimes()
+	suite.mockPaymentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, payment *models.Payment) error {
+		stored := *payment
+		suite.payments = append(suite.payments, &stored)
+		return nil
+	}).AnyTimes()
+	suite.mockPaymentRepo.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, paymentId string) (*models.Payment, error) {
+		for _, v := range suite.payments {
+			if v.PaymentId == paymentId {
+				stored := *v
+				return &stored, nil
+			}
+		}
+		return nil, gorm.ErrRecordNotFound
+	}).AnyTimes()
+	suite.mockPaymentRepo.EXPECT().Settle(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, paymentId string, externalRef string, status string) error {
+			for _, v := range suite.payments {
+				if v.PaymentId == paymentId && v.Status == constants.PaymentStatusPending {
+					v.ExternalRef = externalRef
+					v.Status = status
+					return nil
+				}
+			}
+			return errors.New("error settling payment")
+		}).AnyTimes()
+	suite.mockInvoiceRepo.EXPECT().GetByOrder(gomock.Any(), "o1", constants.InvoiceTypeInvoice).Return([]*models.Invoice{
+		{InvoiceId: "inv1", OrderId: "o1", TotalAmount: suite.order.TotalAmount},
+	}, nil).AnyTimes()
+	suite.mockInvoiceRepo.EXPECT().GetByOrder(gomock.Any(), "o1", constants.InvoiceTypeCreditNote).DoAndReturn(func(_ context.Context, orderId string, invoiceType string) ([]*models.Invoice, error) {
+		return suite.creditNotes, nil
+	}).AnyTimes()
+
+	suite.repos = &repository.Repos{
+		Orders:   suite.mockOrderRepo,
+		Payments: suite.mockPaymentRepo,
+		Invoices: suite.mockInvoiceRepo,
+	}
+	suite.mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(repos *repository.Repos) error) error {
+			suite.inTransaction = true
+			defer func() { suite.inTransaction = false }()
+			return fn(suite.repos)
+		}).AnyTimes()
+
+	suite.paymentService = NewPaymentService(suite.mockOrderRepo, suite.mockPaymentRepo, suite.mockInvoiceRepo, suite.mockTxManager,
+		&checkedProvider{FakeProvider: suite.provider, suite: suite})
+}
+
+func (suite *paymentServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+// refund refunds amount the way a cancellation or return does: inside a
+// transaction, with the refunds sent to the provider after it.
+func (suite *paymentServiceTestSuite) refund(amount string, reason string) error {
+	err := suite.mockTxManager.WithTransaction(context.Background(), func(repos *repository.Repos) error {
+		return suite.paymentService.Refund(context.Background(), repos, "o1", money.MustParse(amount, "INR"), reason)
+	})
+	if err != nil {
+		return err
+	}
+
+	return suite.paymentService.SettleRefunds(context.Background(), "o1")
+}
+
+func (suite *paymentServiceTestSuite) pay(method string, amount string) error {
+	return suite.paymentService.RecordPayment(context.Background(), "o1", &dtos.Payment{Method: method, Amount: money.MustParse(amount, "")})
+}
+
+func (suite *paymentServiceTestSuite) TestRecordPayment() {
+	err := suite.pay("Card", "300")
+	assert.NoError(suite.T(), err)
+
+	assert.Len(suite.T(), suite.payments, 1)
+	payment := suite.payments[0]
+	assert.Equal(suite.T(), constants.PaymentTypePayment, payment.Type)
+	assert.Equal(suite.T(), constants.PaymentStatusSucceeded, payment.Status)
+	assert.Equal(suite.T(), "card", payment.Method)
+	assert.Equal(suite.T(), "300.00 INR", payment.Amount.String())
+	assert.NotEmpty(suite.T(), payment.ExternalRef)
+
+	balance, err := suite.paymentService.GetOrderPayments(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "300.00 INR", balance.Paid.String())
+	assert.Equal(suite.T(), "65.85 INR", balance.Balance.String())
+	assert.Len(suite.T(), balance.Payments, 1)
+}
+
+func (suite *paymentServiceTestSuite) TestRecordPaymentExceedsBalance() {
+	assert.NoError(suite.T(), suite.pay("card", "300"))
+
+	err := suite.pay("card", "65.86")
+	assert.Equal(suite.T(), constants.ErrorPaymentExceedsBalance, err)
+	assert.Len(suite.T(), suite.payments, 1)
+}
+
+func (suite *paymentServiceTestSuite) TestRecordPaymentCurrencyMismatch() {
+	err := suite.paymentService.RecordPayment(context.Background(), "o1", &dtos.Payment{Method: "card", Amount: money.MustParse("10", "USD")})
+	assert.Equal(suite.T(), constants.ErrorCurrencyMismatch, err)
+}
+
+func (suite *paymentServiceTestSuite) TestRecordPaymentDeclined() {
+	suite.provider.Declined["card"] = true
+
+	err := suite.pay("card", "100")
+	assert.Equal(suite.T(), constants.ErrorPaymentFailed, err)
+
+	assert.Len(suite.T(), suite.payments, 1)
+	assert.Equal(suite.T(), constants.PaymentStatusFailed, suite.payments[0].Status)
+
+	balance, err := suite.paymentService.GetOrderPayments(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "365.85 INR", balance.Balance.String())
+}
+
+func (suite *paymentServiceTestSuite) TestRecordPaymentProviderUnavailable() {
+	suite.provider.Unavailable = true
+
+	req := &dtos.Payment{Method: "card", Amount: money.MustParse("300", "")}
+	err := suite.paymentService.RecordPayment(context.Background(), "o1", req)
+	assert.EqualError(suite.T(), err, "provider unavailable")
+
+	assert.Len(suite.T(), suite.payments, 1)
+	assert.Equal(suite.T(), constants.PaymentStatusPending, suite.payments[0].Status)
+	assert.Equal(suite.T(), suite.payments[0].PaymentId, req.PaymentId)
+
+	// The pending payment still counts against the balance.
+	err = suite.pay("card", "100")
+	assert.Equal(suite.T(), constants.ErrorPaymentExceedsBalance, err)
+
+	suite.provider.Unavailable = false
+
+	err = suite.paymentService.RecordPayment(context.Background(), "o1", &dtos.Payment{PaymentId: req.PaymentId, Method: "card",
+		Amount: money.MustParse("300", "")})
+	assert.NoError(suite.T(), err)
+
+	assert.Len(suite.T(), suite.payments, 1)
+	assert.Equal(suite.T(), constants.PaymentStatusSucceeded, suite.payments[0].Status)
+
+	err = suite.paymentService.RecordPayment(context.Background(), "o1", &dtos.Payment{PaymentId: req.PaymentId, Method: "card",
+		Amount: money.MustParse("300", "")})
+	assert.Equal(suite.T(), constants.ErrorRecordExists, err)
+}
+
+func (suite *paymentServiceTestSuite) TestRecordPaymentInvalid() {
+	err := suite.pay("", "100")
+	assert.Equal(suite.T(), constants.ErrorPaymentMethodEmpty, err)
+
+	err = suite.pay("card", "0")
+	assert.Equal(suite.T(), constants.ErrorInvalidAmount, err)
+}
+
+func (suite *paymentServiceTestSuite) TestRefundNewestPaymentFirst() {
+	assert.NoError(suite.T(), suite.pay("card", "300"))
+	assert.NoError(suite.T(), suite.pay("bank_transfer", "65.85"))
+
+	suite.creditNotes = []*models.Invoice{{InvoiceId: "cn1", OrderId: "o1", TotalAmount: money.MustParse("100", "INR")}}
+	err := suite.refund("100", "return r1")
+	assert.NoError(suite.T(), err)
+
+	assert.Len(suite.T(), suite.payments, 4)
+	assert.Equal(suite.T(), suite.payments[1].PaymentId, suite.payments[2].RefundOf)
+	assert.Equal(suite.T(), "65.85 INR", suite.payments[2].Amount.String())
+	assert.Equal(suite.T(), suite.payments[0].PaymentId, suite.payments[3].RefundOf)
+	assert.Equal(suite.T(), "34.15 INR", suite.payments[3].Amount.String())
+	assert.Equal(suite.T(), "return r1", suite.payments[3].Reason)
+	assert.Equal(suite.T(), "265.85 INR", suite.provider.Refundable(suite.payments[0].ExternalRef).String())
+
+	balance, err := suite.paymentService.GetOrderPayments(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "100.00 INR", balance.Credited.String())
+	assert.Equal(suite.T(), "100.00 INR", balance.Refunded.String())
+	assert.Equal(suite.T(), "0.00 INR", balance.Balance.String())
+}
+
+func (suite *paymentServiceTestSuite) TestRefundCappedAtPaid() {
+	assert.NoError(suite.T(), suite.pay("card", "100"))
+
+	err := suite.refund("365.85", "order cancelled")
+	assert.NoError(suite.T(), err)
+
+	err = suite.refund("365.85", "order cancelled")
+	assert.NoError(suite.T(), err)
+
+	assert.Len(suite.T(), suite.payments, 2)
+	assert.Equal(suite.T(), "100.00 INR", suite.payments[1].Amount.String())
+}
+
+func (suite *paymentServiceTestSuite) TestSettleRefundsRetriesPending() {
+	assert.NoError(suite.T(), suite.pay("card", "300"))
+
+	suite.provider.Unavailable = true
+	err := suite.refund("100", "order cancelled")
+	assert.EqualError(suite.T(), err, "provider unavailable")
+
+	assert.Len(suite.T(), suite.payments, 2)
+	assert.Equal(suite.T(), constants.PaymentStatusPending, suite.payments[1].Status)
+
+	// A pending refund isn't refunded a second time.
+	err = suite.refund("300", "order cancelled")
+	assert.Error(suite.T(), err)
+	assert.Len(suite.T(), suite.payments, 3)
+	assert.Equal(suite.T(), "200.00 INR", suite.payments[2].Amount.String())
+
+	suite.provider.Unavailable = false
+	err = suite.paymentService.SettleRefunds(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), constants.PaymentStatusSucceeded, suite.payments[1].Status)
+	assert.Equal(suite.T(), constants.PaymentStatusSucceeded, suite.payments[2].Status)
+	assert.Equal(suite.T(), "0.00 INR", suite.provider.Refundable(suite.payments[0].ExternalRef).String())
+}
+
+func (suite *paymentServiceTestSuite) TestRefundUnpaidOrder() {
+	err := suite.refund("365.85", "order cancelled")
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), suite.payments)
+}
+
+func (suite *paymentServiceTestSuite) TestCheckPaid() {
+	err := suite.paymentService.CheckPaid(context.Background(), suite.repos, suite.order)
+	assert.Equal(suite.T(), constants.ErrorOrderNotPaid, err)
+
+	assert.NoError(suite.T(), suite.pay("card", "365.85"))
+
+	err = suite.paymentService.CheckPaid(context.Background(), suite.repos, suite.order)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *paymentServiceTestSuite) TestCheckPaidOnAccount() {
+	suite.order.PaymentTerms = constants.PaymentTermsOnAccount
+
+	err := suite.paymentService.CheckPaid(context.Background(), suite.repos, suite.order)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *paymentServiceTestSuite) TestBalanceCancelledBeforeInvoice() {
+	order := &models.Order{OrderId: "o2", Status: constants.OrderStatusCancelled, TotalAmount: money.MustParse("50", "INR")}
+	suite.mockPaymentRepo.EXPECT().GetByOrder(gomock.Any(), "o2").Return(nil, nil).Times(1)
+	suite.mockInvoiceRepo.EXPECT().GetByOrder(gomock.Any(), "o2", gomock.Any()).Return(nil, nil).Times(2)
+
+	balance, err := orderBalance(context.Background(), suite.mockPaymentRepo, suite.mockInvoiceRepo, order)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "0.00 INR", balance.Balance.String())
+}
+
+// TestNewProvider builds the configured provider. The in memory fake can't be
+// configured, as it would lose every charge on restart and fail their refunds.
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(ProviderManual, "", "")
+	assert.NoError(t, err)
+
+	result, err := provider.Charge(context.Background(), "p1", "o1", "cash", money.MustParse("10", "INR"))
+	assert.NoError(t, err)
+	assert.Equal(t, &ProviderResult{ExternalRef: "manual_p1", Status: constants.PaymentStatusSucceeded}, result)
+
+	result, err = provider.Refund(context.Background(), "r1", result.ExternalRef, money.MustParse("10", "INR"))
+	assert.NoError(t, err)
+	assert.Equal(t, constants.PaymentStatusSucceeded, result.Status)
+
+	provider, err = NewProvider(ProviderHTTP, "https://gateway.example", "key")
+	assert.NoError(t, err)
+	assert.IsType(t, &HTTPProvider{}, provider)
+
+	for _, name := range []string{"", "fake", ProviderHTTP} {
+		_, err := NewProvider(name, "", "")
+		assert.ErrorIs(t, err, constants.ErrorUnknownPaymentProvider)
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	var requests []*http.Request
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+
+		switch {
+		case r.URL.Path == "/charges" && body["method"] == "card":
+			w.Write([]byte(`{"id": "ch_1", "status": "succeeded"}`))
+		case r.URL.Path == "/charges":
+			w.Write([]byte(`{"id": "ch_2", "status": "failed"}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewHTTPProvider(server.URL+"/", "secret", server.Client())
+
+	result, err := provider.Charge(context.Background(), "p1", "o1", "card", money.MustParse("10", "INR"))
+	assert.NoError(t, err)
+	assert.Equal(t, &ProviderResult{ExternalRef: "ch_1", Status: constants.PaymentStatusSucceeded}, result)
+	assert.Equal(t, "p1", requests[0].Header.Get("Idempotency-Key"))
+	assert.Equal(t, "Bearer secret", requests[0].Header.Get("Authorization"))
+	assert.Equal(t, "o1", bodies[0]["order_id"])
+	assert.Equal(t, map[string]any{"amount": "10.00", "currency": "INR"}, bodies[0]["amount"])
+
+	result, err = provider.Charge(context.Background(), "p2", "o1", "upi", money.MustParse("10", "INR"))
+	assert.NoError(t, err)
+	assert.Equal(t, constants.PaymentStatusFailed, result.Status)
+
+	_, err = provider.Refund(context.Background(), "r1", "ch_1", money.MustParse("10", "INR"))
+	assert.ErrorContains(t, err, "502")
+	assert.Equal(t, "ch_1", bodies[2]["charge"])
+}
+
+// TestHTTPProviderPropagatesTrace checks the configured gateway client passes
+// the trace context on, so the gateway's spans join the payment's trace.
+func TestHTTPProviderPropagatesTrace(t *testing.T) {
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Write([]byte(`{"id": "ch_1", "status": "succeeded"}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider(ProviderHTTP, server.URL, "")
+	assert.NoError(t, err)
+
+	ctx, span := tracing.Start(context.Background(), "paymentService.RecordPayment")
+	_, err = provider.Charge(ctx, "p1", "o1", "card", money.MustParse("10", "INR"))
+	span.End()
+	assert.NoError(t, err)
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+}
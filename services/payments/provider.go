@@ -0,0 +1,51 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"inventory-management/constants"
+	"inventory-management/money"
+	"inventory-management/tracing"
+	"net/http"
+	"time"
+)
+
+var (
+	ProviderManual = "manual"
+	ProviderHTTP   = "http"
+)
+
+// Provider moves money through an external payment provider. A declined charge
+// is not an error: it comes back with the failed status so it can be recorded.
+// reference is unique to each charge or refund and the same when it is
+// retried, so a provider can tell a retry from a second payment.
+type Provider interface {
+	Charge(ctx context.Context, reference string, orderId string, method string, amount money.Money) (*ProviderResult, error)
+	Refund(ctx context.Context, reference string, externalRef string, amount money.Money) (*ProviderResult, error)
+}
+
+type ProviderResult struct {
+	ExternalRef string
+	Status      string
+}
+
+// NewProvider returns the provider configured by name. The manual provider
+// needs nothing else; the HTTP gateway is reached at endpoint with apiKey, and
+// its requests are traced and carry the trace context.
+// FakeProvider keeps charges in memory, so refunds of charges made before a
+// restart would fail; it is only for tests and can't be configured.
+func NewProvider(name string, endpoint string, apiKey string) (Provider, error) {
+	switch name {
+	case ProviderManual:
+		return NewManualProvider(), nil
+	case ProviderHTTP:
+		if endpoint == "" {
+			return nil, fmt.Errorf("%w: %q needs an endpoint", constants.ErrorUnknownPaymentProvider, name)
+		}
+
+		client := &http.Client{Timeout: 30 * time.Second, Transport: &tracing.Transport{Base: http.DefaultTransport}}
+		return NewHTTPProvider(endpoint, apiKey, client), nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", constants.ErrorUnknownPaymentProvider, name)
+}
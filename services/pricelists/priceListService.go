@@ -0,0 +1,181 @@
+package pricelists
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/tracing"
+
+	"github.com/google/uuid"
+)
+
+type PriceListService interface {
+	CreatePriceList(ctx context.Context, req *dtos.PriceList) error
+	UpdatePriceList(ctx context.Context, id string, req *dtos.PriceList) error
+	GetPriceList(ctx context.Context, priceListId string) (*dtos.PriceList, error)
+	DeletePriceList(ctx context.Context, priceListId string) error
+}
+
+type priceListService struct {
+	priceListRepo     repository.PriceListRepo
+	priceListItemRepo repository.PriceListItemRepo
+}
+
+func NewPriceListService(priceListRepo repository.PriceListRepo, priceListItemRepo repository.PriceListItemRepo) PriceListService {
+	return &priceListService{
+		priceListRepo:     priceListRepo,
+		priceListItemRepo: priceListItemRepo,
+	}
+}
+
+func (p *priceListService) CreatePriceList(ctx context.Context, req *dtos.PriceList) error {
+	ctx, span := tracing.Start(ctx, "priceListService.CreatePriceList")
+	defer span.End()
+
+	priceListModel, itemsModel := PriceListDtosToModel(req)
+	if !money.ValidCurrency(priceListModel.Currency) {
+		return constants.ErrorInvalidCurrency
+	}
+
+	err := p.priceListRepo.Create(ctx, priceListModel)
+	if err != nil {
+		return err
+	}
+
+	if len(itemsModel) > 0 {
+		err = p.priceListItemRepo.Upsert(ctx, itemsModel...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *priceListService) UpdatePriceList(ctx context.Context, id string, req *dtos.PriceList) error {
+	ctx, span := tracing.Start(ctx, "priceListService.UpdatePriceList")
+	defer span.End()
+
+	req.PriceListId = id
+
+	priceListModel, itemsModel := PriceListDtosToModel(req)
+	if !money.ValidCurrency(priceListModel.Currency) {
+		return constants.ErrorInvalidCurrency
+	}
+
+	err := p.priceListRepo.Update(ctx, id, priceListModel)
+	if err != nil {
+		return err
+	}
+
+	err = p.priceListItemRepo.DeleteByPriceList(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(itemsModel) > 0 {
+		err = p.priceListItemRepo.Upsert(ctx, itemsModel...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *priceListService) GetPriceList(ctx context.Context, priceListId string) (*dtos.PriceList, error) {
+	ctx, span := tracing.Start(ctx, "priceListService.GetPriceList")
+	defer span.End()
+
+	priceList, err := p.priceListRepo.Get(ctx, priceListId)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := p.priceListItemRepo.GetByPriceList(ctx, priceListId)
+	if err != nil {
+		return nil, err
+	}
+
+	return PriceListModelToDtos(priceList, items), nil
+}
+
+func (p *priceListService) DeletePriceList(ctx context.Context, priceListId string) error {
+	ctx, span := tracing.Start(ctx, "priceListService.DeletePriceList")
+	defer span.End()
+
+	err := p.priceListRepo.Delete(ctx, priceListId)
+	if err != nil {
+		return err
+	}
+
+	err = p.priceListItemRepo.DeleteByPriceList(ctx, priceListId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func PriceListModelToDtos(m *models.PriceList, i []*models.PriceListItem) *dtos.PriceList {
+	p := &dtos.PriceList{
+		PriceListId:   m.PriceListId,
+		Name:          m.Name,
+		Currency:      m.Currency,
+		CustomerId:    m.CustomerId,
+		CustomerGroup: m.CustomerGroup,
+		ValidFrom:     m.ValidFrom,
+		ValidTo:       m.ValidTo,
+		Items:         []*dtos.PriceListItems{},
+	}
+
+	for _, v := range i {
+		p.Items = append(p.Items, &dtos.PriceListItems{
+			PriceListItemId: v.PriceListItemId,
+			ArticleId:       v.ArticleId,
+			MinQuantity:     v.MinQuantity,
+			UnitPrice:       v.UnitPrice,
+			DiscountPercent: v.DiscountPercent,
+		})
+	}
+
+	return p
+}
+
+func PriceListDtosToModel(m *dtos.PriceList) (*models.PriceList, []*models.PriceListItem) {
+	priceListId := m.PriceListId
+	if priceListId == "" {
+		priceListId = uuid.NewString()
+	}
+
+	priceList := &models.PriceList{
+		PriceListId:   priceListId,
+		Name:          m.Name,
+		Currency:      money.NormalizeCurrency(m.Currency),
+		CustomerId:    m.CustomerId,
+		CustomerGroup: m.CustomerGroup,
+		ValidFrom:     m.ValidFrom,
+		ValidTo:       m.ValidTo,
+	}
+
+	var items []*models.PriceListItem
+	for _, v := range m.Items {
+		if v.PriceListItemId == "" {
+			v.PriceListItemId = uuid.NewString()
+		}
+
+		items = append(items, &models.PriceListItem{
+			PriceListItemId: v.PriceListItemId,
+			PriceListId:     priceListId,
+			ArticleId:       v.ArticleId,
+			MinQuantity:     v.MinQuantity,
+			UnitPrice:       v.UnitPrice,
+			DiscountPercent: v.DiscountPercent,
+		})
+	}
+
+	return priceList, items
+}
@@ -0,0 +1,147 @@
+package pricelists
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/repository/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type priceListServiceTestSuite struct {
+	suite.Suite
+	mockCtrl              *gomock.Controller
+	mockPriceListRepo     *mocks.MockPriceListRepo
+	mockPriceListItemRepo *mocks.MockPriceListItemRepo
+	priceListService      PriceListService
+}
+
+func TestPriceListTestSuite(t *testing.T) {
+	suite.Run(t, new(priceListServiceTestSuite))
+}
+
+func (suite *priceListServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockPriceListRepo = mocks.NewMockPriceListRepo(suite.mockCtrl)
+	suite.mockPriceListItemRepo = mocks.NewMockPriceListItemRepo(suite.mockCtrl)
+
+	suite.priceListService = NewPriceListService(suite.mockPriceListRepo, suite.mockPriceListItemRepo)
+}
+
+func (suite *priceListServiceTestSuite) newRequest() *dtos.PriceList {
+	return &dtos.PriceList{
+		PriceListId:   "pl1",
+		Name:          "Wholesale",
+		Currency:      "inr",
+		CustomerGroup: "wholesale",
+		Items: []*dtos.PriceListItems{
+			{
+				PriceListItemId: "1",
+				ArticleId:       "a1",
+				MinQuantity:     10,
+				DiscountPercent: decimal.NewNullDecimal(decimal.NewFromInt(5)),
+			},
+		},
+	}
+}
+
+func (suite *priceListServiceTestSuite) TestCreatePriceList() {
+	priceListModel := &models.PriceList{
+		PriceListId:   "pl1",
+		Name:          "Wholesale",
+		Currency:      "INR",
+		CustomerGroup: "wholesale",
+	}
+
+	itemModel := &models.PriceListItem{
+		PriceListItemId: "1",
+		PriceListId:     "pl1",
+		ArticleId:       "a1",
+		MinQuantity:     10,
+		DiscountPercent: decimal.NewNullDecimal(decimal.NewFromInt(5)),
+	}
+
+	suite.mockPriceListRepo.EXPECT().Create(gomock.Any(), priceListModel).Return(nil).Times(1)
+	suite.mockPriceListItemRepo.EXPECT().Upsert(gomock.Any(), itemModel).Return(nil).Times(1)
+
+	err := suite.priceListService.CreatePriceList(context.Background(), suite.newRequest())
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *priceListServiceTestSuite) TestCreatePriceListInvalidCurrency() {
+	req := suite.newRequest()
+	req.Currency = "rupee"
+
+	err := suite.priceListService.CreatePriceList(context.Background(), req)
+	assert.Equal(suite.T(), constants.ErrorInvalidCurrency, err)
+}
+
+func (suite *priceListServiceTestSuite) TestCreatePriceListRepoError() {
+	suite.mockPriceListRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("repo error")).Times(1)
+
+	err := suite.priceListService.CreatePriceList(context.Background(), suite.newRequest())
+	assert.EqualError(suite.T(), err, "repo error")
+}
+
+func (suite *priceListServiceTestSuite) TestUpdatePriceList() {
+	suite.mockPriceListRepo.EXPECT().Update(gomock.Any(), "pl1", gomock.Any()).Return(nil).Times(1)
+	suite.mockPriceListItemRepo.EXPECT().DeleteByPriceList(gomock.Any(), "pl1").Return(nil).Times(1)
+	suite.mockPriceListItemRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	err := suite.priceListService.UpdatePriceList(context.Background(), "pl1", suite.newRequest())
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *priceListServiceTestSuite) TestUpdatePriceListError() {
+	suite.mockPriceListRepo.EXPECT().Update(gomock.Any(), "pl1", gomock.Any()).Return(errors.New("error updating price list")).Times(1)
+
+	err := suite.priceListService.UpdatePriceList(context.Background(), "pl1", suite.newRequest())
+	assert.EqualError(suite.T(), err, "error updating price list")
+}
+
+func (suite *priceListServiceTestSuite) TestGetPriceList() {
+	priceList := &models.PriceList{PriceListId: "pl1", Name: "Wholesale", Currency: "INR", CustomerGroup: "wholesale"}
+	items := []*models.PriceListItem{
+		{PriceListItemId: "1", PriceListId: "pl1", ArticleId: "a1", MinQuantity: 10, DiscountPercent: decimal.NewNullDecimal(decimal.NewFromInt(5))},
+	}
+
+	suite.mockPriceListRepo.EXPECT().Get(gomock.Any(), "pl1").Return(priceList, nil).Times(1)
+	suite.mockPriceListItemRepo.EXPECT().GetByPriceList(gomock.Any(), "pl1").Return(items, nil).Times(1)
+
+	result, err := suite.priceListService.GetPriceList(context.Background(), "pl1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Wholesale", result.Name)
+	assert.Len(suite.T(), result.Items, 1)
+	assert.Equal(suite.T(), 10, result.Items[0].MinQuantity)
+}
+
+func (suite *priceListServiceTestSuite) TestGetPriceListError() {
+	suite.mockPriceListRepo.EXPECT().Get(gomock.Any(), "pl1").Return(nil, constants.ErrorNotFound).Times(1)
+
+	result, err := suite.priceListService.GetPriceList(context.Background(), "pl1")
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), constants.ErrorNotFound, err)
+}
+
+func (suite *priceListServiceTestSuite) TestDeletePriceList() {
+	suite.mockPriceListRepo.EXPECT().Delete(gomock.Any(), "pl1").Return(nil).Times(1)
+	suite.mockPriceListItemRepo.EXPECT().DeleteByPriceList(gomock.Any(), "pl1").Return(nil).Times(1)
+
+	err := suite.priceListService.DeletePriceList(context.Background(), "pl1")
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *priceListServiceTestSuite) TestDeletePriceListError() {
+	suite.mockPriceListRepo.EXPECT().Delete(gomock.Any(), "pl1").Return(constants.ErrorNotFound).Times(1)
+
+	err := suite.priceListService.DeletePriceList(context.Background(), "pl1")
+	assert.Error(suite.T(), err)
+}
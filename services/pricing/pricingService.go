@@ -0,0 +1,173 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/tracing"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type PricingService interface {
+	PriceOrder(ctx context.Context, order *models.Order, items []*models.OrderItem) error
+}
+
+type pricingService struct {
+	articleRepo       repository.ArticleRepo
+	articlePriceRepo  repository.ArticlePriceRepo
+	priceListRepo     repository.PriceListRepo
+	priceListItemRepo repository.PriceListItemRepo
+	userRepo          repository.UserRepo
+	rates             *money.Rates
+}
+
+func NewPricingService(articleRepo repository.ArticleRepo, articlePriceRepo repository.ArticlePriceRepo, priceListRepo repository.PriceListRepo,
+	priceListItemRepo repository.PriceListItemRepo, userRepo repository.UserRepo, rates *money.Rates) PricingService {
+	return &pricingService{
+		articleRepo:       articleRepo,
+		articlePriceRepo:  articlePriceRepo,
+		priceListRepo:     priceListRepo,
+		priceListItemRepo: priceListItemRepo,
+		userRepo:          userRepo,
+		rates:             rates,
+	}
+}
+
+// PriceOrder sets the unit price of every item in the order currency, falling
+// back to the base currency, and recalculates the order total from them. Each
+// item gets the lowest of its standard price and any quantity break from a
+// price list that applies to the customer when the order was placed. Any
+// previous discount is cleared so the total equals the subtotal.
+func (p *pricingService) PriceOrder(ctx context.Context, order *models.Order, items []*models.OrderItem) error {
+	ctx, span := tracing.Start(ctx, "pricingService.PriceOrder")
+	defer span.End()
+
+	currency := order.TotalAmount.Currency
+	if currency == "" {
+		currency = p.rates.Base
+	}
+
+	if !money.ValidCurrency(currency) {
+		return constants.ErrorInvalidCurrency
+	}
+
+	tiers, err := p.customerTiers(ctx, order, currency, items)
+	if err != nil {
+		return err
+	}
+
+	total := money.Zero(currency)
+	for _, v := range items {
+		unitPrice, err := p.unitPrice(ctx, v.ArticleId, currency)
+		if err != nil {
+			return err
+		}
+
+		v.PriceListId = ""
+		for _, tier := range tiers[v.ArticleId] {
+			if tier.MinQuantity > v.Quantity {
+				continue
+			}
+
+			candidate := tierPrice(tier, unitPrice)
+			if candidate.Amount.LessThan(unitPrice.Amount) {
+				unitPrice = candidate
+				v.PriceListId = tier.PriceListId
+			}
+		}
+
+		v.UnitPrice = unitPrice
+
+		total, err = total.Add(unitPrice.Mul(int64(v.Quantity)))
+		if err != nil {
+			return err
+		}
+	}
+
+	order.Subtotal = total
+	order.DiscountAmount = money.Zero(currency)
+	order.TotalAmount = total
+
+	return nil
+}
+
+// unitPrice prefers an explicit price list entry for the currency and only
+// converts the article's base price when there is none.
+func (p *pricingService) unitPrice(ctx context.Context, articleId string, currency string) (money.Money, error) {
+	price, err := p.articlePriceRepo.Get(ctx, articleId, currency)
+	if err == nil {
+		return price.Money(), nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return money.Money{}, err
+	}
+
+	article, err := p.articleRepo.Get(ctx, articleId)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return p.rates.Convert(article.Price, currency)
+}
+
+func (p *pricingService) customerTiers(ctx context.Context, order *models.Order, currency string, items []*models.OrderItem) (map[string][]*models.PriceListItem, error) {
+	if order.CustomerId == "" || len(items) == 0 {
+		return nil, nil
+	}
+
+	var customerGroup string
+	user, err := p.userRepo.Get(ctx, order.CustomerId)
+	if err == nil {
+		customerGroup = user.CustomerGroup
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	priceLists, err := p.priceListRepo.GetApplicable(ctx, order.CustomerId, customerGroup, currency, order.OrderedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(priceLists) == 0 {
+		return nil, nil
+	}
+
+	var priceListIds []string
+	for _, v := range priceLists {
+		priceListIds = append(priceListIds, v.PriceListId)
+	}
+
+	var articleIds []string
+	for _, v := range items {
+		articleIds = append(articleIds, v.ArticleId)
+	}
+
+	priceListItems, err := p.priceListItemRepo.GetByPriceLists(ctx, priceListIds, articleIds)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers := make(map[string][]*models.PriceListItem)
+	for _, v := range priceListItems {
+		tiers[v.ArticleId] = append(tiers[v.ArticleId], v)
+	}
+
+	return tiers, nil
+}
+
+func tierPrice(tier *models.PriceListItem, standard money.Money) money.Money {
+	if tier.UnitPrice.Valid {
+		return money.New(tier.UnitPrice.Decimal, standard.Currency)
+	}
+
+	hundred := decimal.NewFromInt(100)
+	factor := hundred.Sub(tier.DiscountPercent.Decimal).Div(hundred)
+
+	return money.New(standard.Amount.Mul(factor), standard.Currency)
+}
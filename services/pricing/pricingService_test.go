@@ -0,0 +1,186 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type pricingServiceTestSuite struct {
+	suite.Suite
+	mockCtrl              *gomock.Controller
+	mockArticleRepo       *mocks.MockArticleRepo
+	mockArticlePriceRepo  *mocks.MockArticlePriceRepo
+	mockPriceListRepo     *mocks.MockPriceListRepo
+	mockPriceListItemRepo *mocks.MockPriceListItemRepo
+	mockUserRepo          *mocks.MockUserRepo
+	pricingService        PricingService
+}
+
+func TestPricingTestSuite(t *testing.T) {
+	suite.Run(t, new(pricingServiceTestSuite))
+}
+
+func (suite *pricingServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockArticleRepo = mocks.NewMockArticleRepo(suite.mockCtrl)
+	suite.mockArticlePriceRepo = mocks.NewMockArticlePriceRepo(suite.mockCtrl)
+	suite.mockPriceListRepo = mocks.NewMockPriceListRepo(suite.mockCtrl)
+	suite.mockPriceListItemRepo = mocks.NewMockPriceListItemRepo(suite.mockCtrl)
+	suite.mockUserRepo = mocks.NewMockUserRepo(suite.mockCtrl)
+
+	rates := money.NewRates("INR", map[string]decimal.Decimal{
+		"USD": decimal.RequireFromString("0.012"),
+	})
+
+	suite.pricingService = NewPricingService(suite.mockArticleRepo, suite.mockArticlePriceRepo, suite.mockPriceListRepo,
+		suite.mockPriceListItemRepo, suite.mockUserRepo, rates)
+}
+
+func (suite *pricingServiceTestSuite) TestPriceOrderBaseCurrency() {
+	order := &models.Order{OrderId: "123"}
+	items := []*models.OrderItem{
+		{OrderItemId: "1", ArticleId: "a1", Quantity: 3},
+		{OrderItemId: "2", ArticleId: "a2", Quantity: 1},
+	}
+
+	suite.mockArticlePriceRepo.EXPECT().Get(gomock.Any(), "a1", "INR").Return(nil, gorm.ErrRecordNotFound).Times(1)
+	suite.mockArticlePriceRepo.EXPECT().Get(gomock.Any(), "a2", "INR").Return(nil, gorm.ErrRecordNotFound).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Price: money.MustParse("0.10", "INR")}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a2").Return(&models.Article{ArticleId: "a2", Price: money.MustParse("0.20", "INR")}, nil).Times(1)
+
+	err := suite.pricingService.PriceOrder(context.Background(), order, items)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "0.50 INR", order.TotalAmount.String())
+	assert.Equal(suite.T(), "0.10 INR", items[0].UnitPrice.String())
+}
+
+func (suite *pricingServiceTestSuite) TestPriceOrderUsesPriceList() {
+	order := &models.Order{OrderId: "123", TotalAmount: money.Money{Currency: "USD"}}
+	items := []*models.OrderItem{
+		{OrderItemId: "1", ArticleId: "a1", Quantity: 2},
+	}
+
+	price := &models.ArticlePrice{ArticleId: "a1", Currency: "USD", Amount: decimal.RequireFromString("1.99")}
+	suite.mockArticlePriceRepo.EXPECT().Get(gomock.Any(), "a1", "USD").Return(price, nil).Times(1)
+
+	err := suite.pricingService.PriceOrder(context.Background(), order, items)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "3.98 USD", order.TotalAmount.String())
+}
+
+func (suite *pricingServiceTestSuite) TestPriceOrderConvertsBasePrice() {
+	order := &models.Order{OrderId: "123", TotalAmount: money.Money{Currency: "USD"}}
+	items := []*models.OrderItem{
+		{OrderItemId: "1", ArticleId: "a1", Quantity: 2},
+	}
+
+	suite.mockArticlePriceRepo.EXPECT().Get(gomock.Any(), "a1", "USD").Return(nil, gorm.ErrRecordNotFound).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Price: money.MustParse("1000", "INR")}, nil).Times(1)
+
+	err := suite.pricingService.PriceOrder(context.Background(), order, items)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "12.00 USD", items[0].UnitPrice.String())
+	assert.Equal(suite.T(), "24.00 USD", order.TotalAmount.String())
+}
+
+func (suite *pricingServiceTestSuite) TestPriceOrderMissingRate() {
+	order := &models.Order{OrderId: "123", TotalAmount: money.Money{Currency: "EUR"}}
+	items := []*models.OrderItem{
+		{OrderItemId: "1", ArticleId: "a1", Quantity: 1},
+	}
+
+	suite.mockArticlePriceRepo.EXPECT().Get(gomock.Any(), "a1", "EUR").Return(nil, gorm.ErrRecordNotFound).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Price: money.MustParse("1000", "INR")}, nil).Times(1)
+
+	err := suite.pricingService.PriceOrder(context.Background(), order, items)
+	assert.Equal(suite.T(), constants.ErrorExchangeRateNotFound, err)
+}
+
+func (suite *pricingServiceTestSuite) TestPriceOrderRepoError() {
+	order := &models.Order{OrderId: "123"}
+	items := []*models.OrderItem{
+		{OrderItemId: "1", ArticleId: "a1", Quantity: 1},
+	}
+
+	suite.mockArticlePriceRepo.EXPECT().Get(gomock.Any(), "a1", "INR").Return(nil, errors.New("db down")).Times(1)
+
+	err := suite.pricingService.PriceOrder(context.Background(), order, items)
+	assert.EqualError(suite.T(), err, "db down")
+}
+
+func (suite *pricingServiceTestSuite) TestPriceOrderInvalidCurrency() {
+	order := &models.Order{OrderId: "123", TotalAmount: money.Money{Currency: "DOLLAR"}}
+
+	err := suite.pricingService.PriceOrder(context.Background(), order, nil)
+	assert.Equal(suite.T(), constants.ErrorInvalidCurrency, err)
+}
+
+func (suite *pricingServiceTestSuite) TestPriceOrderQuantityBreaks() {
+	orderedAt := time.Now()
+	order := &models.Order{OrderId: "123", CustomerId: "c1", OrderedAt: orderedAt}
+	items := []*models.OrderItem{
+		{OrderItemId: "1", ArticleId: "a1", Quantity: 12},
+		{OrderItemId: "2", ArticleId: "a2", Quantity: 2},
+	}
+
+	priceLists := []*models.PriceList{
+		{PriceListId: "pl-group", CustomerGroup: "wholesale", Currency: "INR"},
+		{PriceListId: "pl-customer", CustomerId: "c1", Currency: "INR"},
+	}
+
+	tiers := []*models.PriceListItem{
+		{PriceListId: "pl-group", ArticleId: "a1", MinQuantity: 10, DiscountPercent: decimal.NewNullDecimal(decimal.NewFromInt(5))},
+		{PriceListId: "pl-customer", ArticleId: "a1", MinQuantity: 20, UnitPrice: decimal.NewNullDecimal(decimal.NewFromInt(50))},
+		{PriceListId: "pl-customer", ArticleId: "a2", MinQuantity: 1, UnitPrice: decimal.NewNullDecimal(decimal.NewFromInt(250))},
+	}
+
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c1").Return(&models.User{Id: "c1", CustomerGroup: "wholesale"}, nil).Times(1)
+	suite.mockPriceListRepo.EXPECT().GetApplicable(gomock.Any(), "c1", "wholesale", "INR", orderedAt).Return(priceLists, nil).Times(1)
+	suite.mockPriceListItemRepo.EXPECT().GetByPriceLists(gomock.Any(), []string{"pl-group", "pl-customer"}, []string{"a1", "a2"}).Return(tiers, nil).Times(1)
+	suite.mockArticlePriceRepo.EXPECT().Get(gomock.Any(), gomock.Any(), "INR").Return(nil, gorm.ErrRecordNotFound).Times(2)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Price: money.MustParse("100", "INR")}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a2").Return(&models.Article{ArticleId: "a2", Price: money.MustParse("200", "INR")}, nil).Times(1)
+
+	err := suite.pricingService.PriceOrder(context.Background(), order, items)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "95.00 INR", items[0].UnitPrice.String())
+	assert.Equal(suite.T(), "pl-group", items[0].PriceListId)
+	assert.Equal(suite.T(), "200.00 INR", items[1].UnitPrice.String())
+	assert.Equal(suite.T(), "", items[1].PriceListId)
+	assert.Equal(suite.T(), "1540.00 INR", order.TotalAmount.String())
+}
+
+func (suite *pricingServiceTestSuite) TestPriceOrderUnknownCustomer() {
+	orderedAt := time.Now()
+	order := &models.Order{OrderId: "123", CustomerId: "c1", OrderedAt: orderedAt}
+	items := []*models.OrderItem{
+		{OrderItemId: "1", ArticleId: "a1", Quantity: 1},
+	}
+
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c1").Return(nil, gorm.ErrRecordNotFound).Times(1)
+	suite.mockPriceListRepo.EXPECT().GetApplicable(gomock.Any(), "c1", "", "INR", orderedAt).Return(nil, nil).Times(1)
+	suite.mockArticlePriceRepo.EXPECT().Get(gomock.Any(), "a1", "INR").Return(nil, gorm.ErrRecordNotFound).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1", Price: money.MustParse("100", "INR")}, nil).Times(1)
+
+	err := suite.pricingService.PriceOrder(context.Background(), order, items)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "100.00 INR", order.TotalAmount.String())
+}
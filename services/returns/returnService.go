@@ -0,0 +1,362 @@
+package returns
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/repository"
+	"inventory-management/services/backorders"
+	"inventory-management/services/invoices"
+	"inventory-management/services/payments"
+	"inventory-management/tracing"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReturnService interface {
+	CreateReturn(ctx context.Context, orderId string, req *dtos.Return) error
+	GetReturn(ctx context.Context, returnId string) (*dtos.Return, error)
+	GetOrderReturns(ctx context.Context, orderId string) ([]*dtos.Return, error)
+	UpdateReturnStatus(ctx context.Context, returnId string, req *dtos.UpdateReturnStatus) error
+}
+
+// returnStatusTransitions lists the statuses a return may move to from each
+// status. Refunded and rejected returns are final.
+var returnStatusTransitions = map[string][]string{
+	constants.ReturnStatusRequested: {constants.ReturnStatusApproved, constants.ReturnStatusRejected},
+	constants.ReturnStatusApproved:  {constants.ReturnStatusReceived, constants.ReturnStatusRejected},
+	constants.ReturnStatusReceived:  {constants.ReturnStatusInspected},
+	constants.ReturnStatusInspected: {constants.ReturnStatusRefunded},
+}
+
+type returnService struct {
+	returnRepo       repository.ReturnRepo
+	returnItemRepo   repository.ReturnItemRepo
+	txManager        repository.TxManager
+	invoiceService   invoices.InvoiceService
+	paymentService   payments.PaymentService
+	backorderService backorders.BackorderService
+}
+
+func NewReturnService(returnRepo repository.ReturnRepo, returnItemRepo repository.ReturnItemRepo, txManager repository.TxManager,
+	invoiceService invoices.InvoiceService, paymentService payments.PaymentService, backorderService backorders.BackorderService) ReturnService {
+	return &returnService{
+		returnRepo:       returnRepo,
+		returnItemRepo:   returnItemRepo,
+		txManager:        txManager,
+		invoiceService:   invoiceService,
+		paymentService:   paymentService,
+		backorderService: backorderService,
+	}
+}
+
+// CreateReturn requests a return of shipped order items. The order row is
+// locked while the returned quantities are checked so concurrent requests
+// cannot return more than was shipped between them. The refund amount is
+// quoted from the invoiced line prices.
+func (r *returnService) CreateReturn(ctx context.Context, orderId string, req *dtos.Return) error {
+	ctx, span := tracing.Start(ctx, "returnService.CreateReturn")
+	defer span.End()
+
+	if len(req.Items) == 0 {
+		return constants.ErrorReturnItemsEmpty
+	}
+
+	ret, items := ReturnDtosToModel(req)
+	ret.OrderId = orderId
+	ret.Status = constants.ReturnStatusRequested
+	ret.RequestedAt = time.Now().UTC()
+
+	return r.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		_, err := repos.Orders.GetForUpdate(ctx, orderId)
+		if err != nil {
+			return err
+		}
+
+		shipped, err := repos.ShipmentItems.GetByOrder(ctx, orderId)
+		if err != nil {
+			return err
+		}
+
+		returned, err := repos.ReturnItems.GetReturnedByOrder(ctx, orderId)
+		if err != nil {
+			return err
+		}
+
+		remaining := make(map[string]int)
+		articles := make(map[string]string)
+		for _, v := range shipped {
+			remaining[v.OrderItemId] += v.Quantity
+			articles[v.OrderItemId] = v.ArticleId
+		}
+
+		for _, v := range returned {
+			remaining[v.OrderItemId] -= v.Quantity
+		}
+
+		quantities := make(map[string]int)
+		for _, v := range items {
+			if v.Quantity <= 0 {
+				return constants.ErrorInvalidQuantity
+			}
+
+			if v.Quantity > remaining[v.OrderItemId] {
+				return constants.ErrorReturnExceedsShipped
+			}
+
+			remaining[v.OrderItemId] -= v.Quantity
+			quantities[v.OrderItemId] += v.Quantity
+			v.ArticleId = articles[v.OrderItemId]
+		}
+
+		quote, err := r.invoiceService.QuoteCreditNote(ctx, repos, orderId, quantities)
+		if err != nil {
+			return err
+		}
+
+		ret.RefundAmount = quote.TotalAmount
+
+		err = repos.Returns.Create(ctx, ret)
+		if err != nil {
+			return err
+		}
+
+		return repos.ReturnItems.Create(ctx, items...)
+	})
+}
+
+func (r *returnService) GetReturn(ctx context.Context, returnId string) (*dtos.Return, error) {
+	ctx, span := tracing.Start(ctx, "returnService.GetReturn")
+	defer span.End()
+
+	ret, err := r.returnRepo.Get(ctx, returnId)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := r.returnItemRepo.GetByReturn(ctx, returnId)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReturnModelToDtos(ret, items), nil
+}
+
+func (r *returnService) GetOrderReturns(ctx context.Context, orderId string) ([]*dtos.Return, error) {
+	ctx, span := tracing.Start(ctx, "returnService.GetOrderReturns")
+	defer span.End()
+
+	rets, err := r.returnRepo.GetByOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*dtos.Return{}
+	for _, v := range rets {
+		items, err := r.returnItemRepo.GetByReturn(ctx, v.ReturnId)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, ReturnModelToDtos(v, items))
+	}
+
+	return result, nil
+}
+
+// UpdateReturnStatus moves the return to the requested status. Inspecting a
+// return puts the units back into sellable or damaged stock and refunding it
+// issues a credit note and pays its amount back, all in the same transaction
+// as the status change. The refund is sent to the payment provider once the
+// transaction has committed.
+func (r *returnService) UpdateReturnStatus(ctx context.Context, returnId string, req *dtos.UpdateReturnStatus) error {
+	ctx, span := tracing.Start(ctx, "returnService.UpdateReturnStatus")
+	defer span.End()
+
+	var orderId string
+	err := r.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		ret, err := repos.Returns.Get(ctx, returnId)
+		if err != nil {
+			return err
+		}
+
+		if !canTransition(ret.Status, req.Status) {
+			return constants.ErrorInvalidReturnStatus
+		}
+
+		err = repos.Returns.UpdateStatus(ctx, returnId, ret.Status, req.Status)
+		if err != nil {
+			return err
+		}
+
+		switch req.Status {
+		case constants.ReturnStatusInspected:
+			return r.inspect(ctx, repos, returnId, req.Items)
+		case constants.ReturnStatusRefunded:
+			orderId = ret.OrderId
+			return r.refund(ctx, repos, ret)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if orderId != "" {
+		// The return is refunded either way; refunds the provider didn't take
+		// stay pending and can be sent again through the order's refunds.
+		err = r.paymentService.SettleRefunds(ctx, orderId)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to settle refunds", "order_id", orderId, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// inspect records the outcome for every returned item. Each item's restocked
+// and damaged quantities have to add up to the quantity returned. Restocked
+// units go to the article's open backorders first.
+func (r *returnService) inspect(ctx context.Context, repos *repository.Repos, returnId string, outcomes []*dtos.ReturnItems) error {
+	items, err := repos.ReturnItems.GetByReturn(ctx, returnId)
+	if err != nil {
+		return err
+	}
+
+	inspected := make(map[string]*dtos.ReturnItems)
+	for _, v := range outcomes {
+		inspected[v.ReturnItemId] = v
+	}
+
+	if len(inspected) != len(items) || len(outcomes) != len(items) {
+		return constants.ErrorInspectionMismatch
+	}
+
+	var restocked []string
+	for _, v := range items {
+		outcome, ok := inspected[v.ReturnItemId]
+		if !ok || outcome.RestockedQuantity < 0 || outcome.DamagedQuantity < 0 ||
+			outcome.RestockedQuantity+outcome.DamagedQuantity != v.Quantity {
+			return constants.ErrorInspectionMismatch
+		}
+
+		err = repos.ReturnItems.UpdateInspection(ctx, v.ReturnItemId, outcome.RestockedQuantity, outcome.DamagedQuantity)
+		if err != nil {
+			return err
+		}
+
+		err = repos.Articles.AdjustStock(ctx, v.ArticleId, int64(outcome.RestockedQuantity), int64(outcome.DamagedQuantity))
+		if err != nil {
+			return err
+		}
+
+		if outcome.RestockedQuantity > 0 && !slices.Contains(restocked, v.ArticleId) {
+			restocked = append(restocked, v.ArticleId)
+		}
+	}
+
+	for _, v := range restocked {
+		err = r.backorderService.AllocateStock(ctx, repos, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *returnService) refund(ctx context.Context, repos *repository.Repos, ret *models.Return) error {
+	items, err := repos.ReturnItems.GetByReturn(ctx, ret.ReturnId)
+	if err != nil {
+		return err
+	}
+
+	quantities := make(map[string]int)
+	for _, v := range items {
+		quantities[v.OrderItemId] += v.Quantity
+	}
+
+	creditNote, err := r.invoiceService.IssueCreditNote(ctx, repos, ret.OrderId, quantities)
+	if err != nil {
+		return err
+	}
+
+	ret.RefundAmount = creditNote.TotalAmount
+	ret.CreditNoteNumber = creditNote.InvoiceNumber
+
+	err = repos.Returns.UpdateRefund(ctx, ret.ReturnId, ret)
+	if err != nil {
+		return err
+	}
+
+	return r.paymentService.Refund(ctx, repos, ret.OrderId, creditNote.TotalAmount, "return "+ret.ReturnId)
+}
+
+func canTransition(from string, to string) bool {
+	for _, v := range returnStatusTransitions[from] {
+		if v == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ReturnModelToDtos(m *models.Return, i []*models.ReturnItem) *dtos.Return {
+	r := &dtos.Return{
+		ReturnId:         m.ReturnId,
+		OrderId:          m.OrderId,
+		Status:           m.Status,
+		Reason:           m.Reason,
+		RequestedAt:      m.RequestedAt,
+		RefundAmount:     m.RefundAmount,
+		CreditNoteNumber: m.CreditNoteNumber,
+		Items:            []*dtos.ReturnItems{},
+	}
+
+	for _, v := range i {
+		r.Items = append(r.Items, &dtos.ReturnItems{
+			ReturnItemId:      v.ReturnItemId,
+			OrderItemId:       v.OrderItemId,
+			ArticleId:         v.ArticleId,
+			Quantity:          v.Quantity,
+			RestockedQuantity: v.RestockedQuantity,
+			DamagedQuantity:   v.DamagedQuantity,
+		})
+	}
+
+	return r
+}
+
+func ReturnDtosToModel(m *dtos.Return) (*models.Return, []*models.ReturnItem) {
+	if m.ReturnId == "" {
+		m.ReturnId = uuid.NewString()
+	}
+
+	ret := &models.Return{
+		ReturnId: m.ReturnId,
+		OrderId:  m.OrderId,
+		Reason:   m.Reason,
+	}
+
+	var items []*models.ReturnItem
+	for _, v := range m.Items {
+		if v.ReturnItemId == "" {
+			v.ReturnItemId = uuid.NewString()
+		}
+
+		items = append(items, &models.ReturnItem{
+			ReturnItemId: v.ReturnItemId,
+			ReturnId:     m.ReturnId,
+			OrderItemId:  v.OrderItemId,
+			Quantity:     v.Quantity,
+		})
+	}
+
+	return ret, items
+}
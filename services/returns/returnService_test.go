@@ -0,0 +1,278 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/repository/mocks"
+	serviceMocks "inventory-management/services/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type returnServiceTestSuite struct {
+	suite.Suite
+	mockCtrl             *gomock.Controller
+	mockOrderRepo        *mocks.MockOrderRepo
+	mockShipmentItemRepo *mocks.MockShipmentItemRepo
+	mockReturnRepo       *mocks.MockReturnRepo
+	mockReturnItemRepo   *mocks.MockReturnItemRepo
+	mockArticleRepo      *mocks.MockArticleRepo
+	mockTxManager        *mocks.MockTxManager
+	mockInvoiceService   *serviceMocks.MockInvoiceService
+	mockPaymentService   *serviceMocks.MockPaymentService
+	mockBackorderService *serviceMocks.MockBackorderService
+	returnService        ReturnService
+}
+
+func TestReturnServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(returnServiceTestSuite))
+}
+
+func (suite *returnServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockOrderRepo = mocks.NewMockOrderRepo(suite.mockCtrl)
+	suite.mockShipmentItemRepo = mocks.NewMockShipmentItemRepo(suite.mockCtrl)
+	suite.mockReturnRepo = mocks.NewMockReturnRepo(suite.mockCtrl)
+	suite.mockReturnItemRepo = mocks.NewMockReturnItemRepo(suite.mockCtrl)
+	suite.mockArticleRepo = mocks.NewMockArticleRepo(suite.mockCtrl)
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
+	suite.mockInvoiceService = serviceMocks.NewMockInvoiceService(suite.mockCtrl)
+	suite.mockPaymentService = serviceMocks.NewMockPaymentService(suite.mockCtrl)
+	suite.mockBackorderService = serviceMocks.NewMockBackorderService(suite.mockCtrl)
+
+	repos := &repository.Repos{
+		Orders:        suite.mockOrderRepo,
+		ShipmentItems: suite.mockShipmentItemRepo,
+		Returns:       suite.mockReturnRepo,
+		ReturnItems:   suite.mockReturnItemRepo,
+		Articles:      suite.mockArticleRepo,
+	}
+	suite.mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(repos *repository.Repos) error) error {
+			return fn(repos)
+		}).AnyTimes()
+
+	suite.returnService = NewReturnService(suite.mockReturnRepo, suite.mockReturnItemRepo, suite.mockTxManager, suite.mockInvoiceService,
+		suite.mockPaymentService, suite.mockBackorderService)
+}
+
+func (suite *returnServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+// expectShippedOrder sets up an order that shipped 3 x a1 over two shipments
+// and 1 x a2, of which one a1 has already been returned.
+func (suite *returnServiceTestSuite) expectShippedOrder(shipped bool) {
+	var shipmentItems []*models.ShipmentItem
+	if shipped {
+		shipmentItems = []*models.ShipmentItem{
+			{ShipmentItemId: "si1", ShipmentId: "s1", OrderId: "o1", OrderItemId: "i1", ArticleId: "a1", Quantity: 2},
+			{ShipmentItemId: "si2", ShipmentId: "s2", OrderId: "o1", OrderItemId: "i1", ArticleId: "a1", Quantity: 1},
+			{ShipmentItemId: "si3", ShipmentId: "s2", OrderId: "o1", OrderItemId: "i2", ArticleId: "a2", Quantity: 1},
+		}
+	}
+
+	suite.mockOrderRepo.EXPECT().GetForUpdate(gomock.Any(), "o1").Return(&models.Order{OrderId: "o1", Status: constants.OrderStatusConfirmed}, nil).Times(1)
+	suite.mockShipmentItemRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return(shipmentItems, nil).Times(1)
+	suite.mockReturnItemRepo.EXPECT().GetReturnedByOrder(gomock.Any(), "o1").Return([]*models.ReturnItem{
+		{ReturnItemId: "ri0", ReturnId: "r0", OrderItemId: "i1", Quantity: 1},
+	}, nil).Times(1)
+}
+
+func (suite *returnServiceTestSuite) TestCreateReturn() {
+	suite.expectShippedOrder(true)
+
+	suite.mockInvoiceService.EXPECT().QuoteCreditNote(gomock.Any(), gomock.Any(), "o1", map[string]int{"i1": 2, "i2": 1}).
+		Return(&dtos.Invoice{TotalAmount: money.MustParse("236", "INR")}, nil).Times(1)
+	suite.mockReturnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, ret *models.Return) error {
+		assert.Equal(suite.T(), "o1", ret.OrderId)
+		assert.Equal(suite.T(), constants.ReturnStatusRequested, ret.Status)
+		assert.Equal(suite.T(), "236.00 INR", ret.RefundAmount.String())
+		return nil
+	}).Times(1)
+	suite.mockReturnItemRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, items ...*models.ReturnItem) error {
+		assert.Len(suite.T(), items, 2)
+		assert.Equal(suite.T(), "a1", items[0].ArticleId)
+		assert.Equal(suite.T(), "a2", items[1].ArticleId)
+		return nil
+	}).Times(1)
+
+	req := &dtos.Return{
+		Reason: "wrong size",
+		Items: []*dtos.ReturnItems{
+			{OrderItemId: "i1", Quantity: 2},
+			{OrderItemId: "i2", Quantity: 1},
+		},
+	}
+
+	err := suite.returnService.CreateReturn(context.Background(), "o1", req)
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), req.ReturnId)
+}
+
+func (suite *returnServiceTestSuite) TestCreateReturnExceedsShipped() {
+	suite.expectShippedOrder(true)
+
+	err := suite.returnService.CreateReturn(context.Background(), "o1", &dtos.Return{Items: []*dtos.ReturnItems{{OrderItemId: "i1", Quantity: 3}}})
+	assert.Equal(suite.T(), constants.ErrorReturnExceedsShipped, err)
+}
+
+func (suite *returnServiceTestSuite) TestCreateReturnSameItemTwice() {
+	suite.expectShippedOrder(true)
+
+	req := &dtos.Return{Items: []*dtos.ReturnItems{{OrderItemId: "i1", Quantity: 1}, {OrderItemId: "i1", Quantity: 2}}}
+
+	err := suite.returnService.CreateReturn(context.Background(), "o1", req)
+	assert.Equal(suite.T(), constants.ErrorReturnExceedsShipped, err)
+}
+
+func (suite *returnServiceTestSuite) TestCreateReturnNotShipped() {
+	suite.expectShippedOrder(false)
+
+	err := suite.returnService.CreateReturn(context.Background(), "o1", &dtos.Return{Items: []*dtos.ReturnItems{{OrderItemId: "i2", Quantity: 1}}})
+	assert.Equal(suite.T(), constants.ErrorReturnExceedsShipped, err)
+}
+
+func (suite *returnServiceTestSuite) TestCreateReturnInvalidQuantity() {
+	suite.expectShippedOrder(true)
+
+	err := suite.returnService.CreateReturn(context.Background(), "o1", &dtos.Return{Items: []*dtos.ReturnItems{{OrderItemId: "i2", Quantity: 0}}})
+	assert.Equal(suite.T(), constants.ErrorInvalidQuantity, err)
+}
+
+func (suite *returnServiceTestSuite) TestCreateReturnNoItems() {
+	err := suite.returnService.CreateReturn(context.Background(), "o1", &dtos.Return{})
+	assert.Equal(suite.T(), constants.ErrorReturnItemsEmpty, err)
+}
+
+func (suite *returnServiceTestSuite) TestGetReturn() {
+	suite.mockReturnRepo.EXPECT().Get(gomock.Any(), "r1").Return(&models.Return{ReturnId: "r1", OrderId: "o1", Status: "approved"}, nil).Times(1)
+	suite.mockReturnItemRepo.EXPECT().GetByReturn(gomock.Any(), "r1").Return([]*models.ReturnItem{
+		{ReturnItemId: "ri1", ReturnId: "r1", OrderItemId: "i1", ArticleId: "a1", Quantity: 2},
+	}, nil).Times(1)
+
+	result, err := suite.returnService.GetReturn(context.Background(), "r1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "approved", result.Status)
+	assert.Len(suite.T(), result.Items, 1)
+}
+
+func (suite *returnServiceTestSuite) TestUpdateReturnStatus() {
+	suite.mockReturnRepo.EXPECT().Get(gomock.Any(), "r1").Return(&models.Return{ReturnId: "r1", Status: constants.ReturnStatusRequested}, nil).Times(1)
+	suite.mockReturnRepo.EXPECT().UpdateStatus(gomock.Any(), "r1", constants.ReturnStatusRequested, constants.ReturnStatusApproved).Return(nil).Times(1)
+
+	err := suite.returnService.UpdateReturnStatus(context.Background(), "r1", &dtos.UpdateReturnStatus{Status: constants.ReturnStatusApproved})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *returnServiceTestSuite) TestUpdateReturnStatusInvalidTransition() {
+	suite.mockReturnRepo.EXPECT().Get(gomock.Any(), "r1").Return(&models.Return{ReturnId: "r1", Status: constants.ReturnStatusRequested}, nil).Times(1)
+
+	err := suite.returnService.UpdateReturnStatus(context.Background(), "r1", &dtos.UpdateReturnStatus{Status: constants.ReturnStatusRefunded})
+	assert.Equal(suite.T(), constants.ErrorInvalidReturnStatus, err)
+}
+
+func (suite *returnServiceTestSuite) expectInspection() {
+	suite.mockReturnRepo.EXPECT().Get(gomock.Any(), "r1").Return(&models.Return{ReturnId: "r1", Status: constants.ReturnStatusReceived}, nil).Times(1)
+	suite.mockReturnRepo.EXPECT().UpdateStatus(gomock.Any(), "r1", constants.ReturnStatusReceived, constants.ReturnStatusInspected).Return(nil).Times(1)
+	suite.mockReturnItemRepo.EXPECT().GetByReturn(gomock.Any(), "r1").Return([]*models.ReturnItem{
+		{ReturnItemId: "ri1", ReturnId: "r1", OrderItemId: "i1", ArticleId: "a1", Quantity: 2},
+		{ReturnItemId: "ri2", ReturnId: "r1", OrderItemId: "i2", ArticleId: "a2", Quantity: 1},
+	}, nil).Times(1)
+}
+
+func (suite *returnServiceTestSuite) TestInspectReturn() {
+	suite.expectInspection()
+
+	suite.mockReturnItemRepo.EXPECT().UpdateInspection(gomock.Any(), "ri1", 1, 1).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().AdjustStock(gomock.Any(), "a1", int64(1), int64(1)).Return(nil).Times(1)
+	suite.mockReturnItemRepo.EXPECT().UpdateInspection(gomock.Any(), "ri2", 1, 0).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().AdjustStock(gomock.Any(), "a2", int64(1), int64(0)).Return(nil).Times(1)
+	suite.mockBackorderService.EXPECT().AllocateStock(gomock.Any(), gomock.Any(), "a1").Return(nil).Times(1)
+	suite.mockBackorderService.EXPECT().AllocateStock(gomock.Any(), gomock.Any(), "a2").Return(nil).Times(1)
+
+	err := suite.returnService.UpdateReturnStatus(context.Background(), "r1", &dtos.UpdateReturnStatus{
+		Status: constants.ReturnStatusInspected,
+		Items: []*dtos.ReturnItems{
+			{ReturnItemId: "ri1", RestockedQuantity: 1, DamagedQuantity: 1},
+			{ReturnItemId: "ri2", RestockedQuantity: 1},
+		},
+	})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *returnServiceTestSuite) TestInspectReturnAllocatesRestockedOnly() {
+	suite.expectInspection()
+
+	suite.mockReturnItemRepo.EXPECT().UpdateInspection(gomock.Any(), "ri1", 2, 0).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().AdjustStock(gomock.Any(), "a1", int64(2), int64(0)).Return(nil).Times(1)
+	suite.mockReturnItemRepo.EXPECT().UpdateInspection(gomock.Any(), "ri2", 0, 1).Return(nil).Times(1)
+	suite.mockArticleRepo.EXPECT().AdjustStock(gomock.Any(), "a2", int64(0), int64(1)).Return(nil).Times(1)
+	suite.mockBackorderService.EXPECT().AllocateStock(gomock.Any(), gomock.Any(), "a1").Return(errors.New("allocation failed")).Times(1)
+
+	err := suite.returnService.UpdateReturnStatus(context.Background(), "r1", &dtos.UpdateReturnStatus{
+		Status: constants.ReturnStatusInspected,
+		Items: []*dtos.ReturnItems{
+			{ReturnItemId: "ri1", RestockedQuantity: 2},
+			{ReturnItemId: "ri2", DamagedQuantity: 1},
+		},
+	})
+	assert.EqualError(suite.T(), err, "allocation failed")
+}
+
+func (suite *returnServiceTestSuite) TestInspectReturnMismatch() {
+	suite.expectInspection()
+
+	suite.mockReturnItemRepo.EXPECT().UpdateInspection(gomock.Any(), "ri1", 2, 0).Return(nil).AnyTimes()
+	suite.mockArticleRepo.EXPECT().AdjustStock(gomock.Any(), "a1", int64(2), int64(0)).Return(nil).AnyTimes()
+
+	err := suite.returnService.UpdateReturnStatus(context.Background(), "r1", &dtos.UpdateReturnStatus{
+		Status: constants.ReturnStatusInspected,
+		Items: []*dtos.ReturnItems{
+			{ReturnItemId: "ri1", RestockedQuantity: 2},
+			{ReturnItemId: "ri2", RestockedQuantity: 1, DamagedQuantity: 1},
+		},
+	})
+	assert.Equal(suite.T(), constants.ErrorInspectionMismatch, err)
+}
+
+func (suite *returnServiceTestSuite) TestInspectReturnMissingItem() {
+	suite.expectInspection()
+
+	err := suite.returnService.UpdateReturnStatus(context.Background(), "r1", &dtos.UpdateReturnStatus{
+		Status: constants.ReturnStatusInspected,
+		Items:  []*dtos.ReturnItems{{ReturnItemId: "ri1", RestockedQuantity: 2}},
+	})
+	assert.Equal(suite.T(), constants.ErrorInspectionMismatch, err)
+}
+
+func (suite *returnServiceTestSuite) TestRefundReturn() {
+	ret := &models.Return{ReturnId: "r1", OrderId: "o1", Status: constants.ReturnStatusInspected}
+
+	suite.mockReturnRepo.EXPECT().Get(gomock.Any(), "r1").Return(ret, nil).Times(1)
+	suite.mockReturnRepo.EXPECT().UpdateStatus(gomock.Any(), "r1", constants.ReturnStatusInspected, constants.ReturnStatusRefunded).Return(nil).Times(1)
+	suite.mockReturnItemRepo.EXPECT().GetByReturn(gomock.Any(), "r1").Return([]*models.ReturnItem{
+		{ReturnItemId: "ri1", ReturnId: "r1", OrderItemId: "i1", ArticleId: "a1", Quantity: 2},
+	}, nil).Times(1)
+	suite.mockInvoiceService.EXPECT().IssueCreditNote(gomock.Any(), gomock.Any(), "o1", map[string]int{"i1": 2}).
+		Return(&dtos.Invoice{InvoiceNumber: "CN-000001", TotalAmount: money.MustParse("236", "INR")}, nil).Times(1)
+	suite.mockReturnRepo.EXPECT().UpdateRefund(gomock.Any(), "r1", gomock.Any()).DoAndReturn(func(_ context.Context, returnId string, ret *models.Return) error {
+		assert.Equal(suite.T(), "CN-000001", ret.CreditNoteNumber)
+		assert.Equal(suite.T(), "236.00 INR", ret.RefundAmount.String())
+		return nil
+	}).Times(1)
+	suite.mockPaymentService.EXPECT().Refund(gomock.Any(), gomock.Any(), "o1", money.MustParse("236", "INR"), "return r1").Return(nil).Times(1)
+	suite.mockPaymentService.EXPECT().SettleRefunds(gomock.Any(), "o1").Return(nil).Times(1)
+
+	err := suite.returnService.UpdateReturnStatus(context.Background(), "r1", &dtos.UpdateReturnStatus{Status: constants.ReturnStatusRefunded})
+	assert.NoError(suite.T(), err)
+}
@@ -0,0 +1,260 @@
+package shipments
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/repository"
+	"inventory-management/services/payments"
+	"inventory-management/tracing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShipmentService interface {
+	CreateShipment(ctx context.Context, orderId string, req *dtos.Shipment) error
+	GetOrderShipments(ctx context.Context, orderId string) (*dtos.OrderFulfilment, error)
+}
+
+type shipmentService struct {
+	orderRepo        repository.OrderRepo
+	orderItemRepo    repository.OrderItemRepo
+	shipmentRepo     repository.ShipmentRepo
+	shipmentItemRepo repository.ShipmentItemRepo
+	txManager        repository.TxManager
+	paymentService   payments.PaymentService
+}
+
+func NewShipmentService(orderRepo repository.OrderRepo, orderItemRepo repository.OrderItemRepo, shipmentRepo repository.ShipmentRepo,
+	shipmentItemRepo repository.ShipmentItemRepo, txManager repository.TxManager, paymentService payments.PaymentService) ShipmentService {
+	return &shipmentService{
+		orderRepo:        orderRepo,
+		orderItemRepo:    orderItemRepo,
+		shipmentRepo:     shipmentRepo,
+		shipmentItemRepo: shipmentItemRepo,
+		txManager:        txManager,
+		paymentService:   paymentService,
+	}
+}
+
+// CreateShipment ships part of a confirmed order. Only units allocated to the
+// order can be shipped; they were taken out of stock when allocated. Prepaid
+// orders have to be paid in full first. The order row is locked while the
+// unshipped quantities are checked so concurrent shipments cannot ship the same
+// units twice.
+func (s *shipmentService) CreateShipment(ctx context.Context, orderId string, req *dtos.Shipment) error {
+	ctx, span := tracing.Start(ctx, "shipmentService.CreateShipment")
+	defer span.End()
+
+	if len(req.Items) == 0 {
+		return constants.ErrorShipmentItemsEmpty
+	}
+
+	shipment, items := ShipmentDtosToModel(req)
+	shipment.OrderId = orderId
+
+	return s.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		order, err := repos.Orders.GetForUpdate(ctx, orderId)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != constants.OrderStatusConfirmed {
+			return constants.ErrorOrderNotConfirmed
+		}
+
+		err = s.paymentService.CheckPaid(ctx, repos, order)
+		if err != nil {
+			return err
+		}
+
+		orderItems, err := repos.OrderItems.GetByOrder(ctx, orderId)
+		if err != nil {
+			return err
+		}
+
+		shipped, err := repos.ShipmentItems.GetByOrder(ctx, orderId)
+		if err != nil {
+			return err
+		}
+
+		remaining := make(map[string]int)
+		articles := make(map[string]string)
+		for _, v := range orderItems {
+			remaining[v.OrderItemId] += v.AllocatedQuantity
+			articles[v.OrderItemId] = v.ArticleId
+		}
+
+		for _, v := range shipped {
+			remaining[v.OrderItemId] -= v.Quantity
+		}
+
+		for _, v := range items {
+			if v.Quantity <= 0 {
+				return constants.ErrorInvalidQuantity
+			}
+
+			if v.Quantity > remaining[v.OrderItemId] {
+				return constants.ErrorShipmentExceedsAllocated
+			}
+
+			remaining[v.OrderItemId] -= v.Quantity
+			v.OrderId = orderId
+			v.ArticleId = articles[v.OrderItemId]
+		}
+
+		err = repos.Shipments.Create(ctx, shipment)
+		if err != nil {
+			return err
+		}
+
+		return repos.ShipmentItems.Create(ctx, items...)
+	})
+}
+
+func (s *shipmentService) GetOrderShipments(ctx context.Context, orderId string) (*dtos.OrderFulfilment, error) {
+	ctx, span := tracing.Start(ctx, "shipmentService.GetOrderShipments")
+	defer span.End()
+
+	order, err := s.orderRepo.Get(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	orderItems, err := s.orderItemRepo.GetByOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	shipments, err := s.shipmentRepo.GetByOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	shipmentItems, err := s.shipmentItemRepo.GetByOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	status, items := Fulfilment(order, orderItems, shipmentItems)
+
+	result := &dtos.OrderFulfilment{
+		OrderId:          orderId,
+		FulfilmentStatus: status,
+		Items:            items,
+		Shipments:        ShipmentModelToDtos(shipments, shipmentItems),
+	}
+
+	return result, nil
+}
+
+// Fulfilment compares the shipped quantity of each order item with the ordered
+// quantity. Whatever a confirmed order could not allocate is backordered.
+func Fulfilment(order *models.Order, orderItems []*models.OrderItem, shipmentItems []*models.ShipmentItem) (string, []*dtos.FulfilmentItems) {
+	shipped := make(map[string]int)
+	for _, v := range shipmentItems {
+		shipped[v.OrderItemId] += v.Quantity
+	}
+
+	ordered, shippedTotal := 0, 0
+	items := []*dtos.FulfilmentItems{}
+	for _, v := range orderItems {
+		item := &dtos.FulfilmentItems{
+			OrderItemId: v.OrderItemId,
+			ArticleId:   v.ArticleId,
+			Ordered:     v.Quantity,
+			Allocated:   v.AllocatedQuantity,
+			Shipped:     shipped[v.OrderItemId],
+		}
+
+		if order.Status == constants.OrderStatusConfirmed {
+			item.Backordered = v.Quantity - v.AllocatedQuantity
+		}
+
+		items = append(items, item)
+
+		ordered += v.Quantity
+		shippedTotal += shipped[v.OrderItemId]
+	}
+
+	switch {
+	case shippedTotal == 0:
+		return constants.FulfilmentStatusUnfulfilled, items
+	case shippedTotal >= ordered:
+		return constants.FulfilmentStatusFulfilled, items
+	default:
+		return constants.FulfilmentStatusPartial, items
+	}
+}
+
+func ShipmentModelToDtos(m []*models.Shipment, i []*models.ShipmentItem) []*dtos.Shipment {
+	shipments := []*dtos.Shipment{}
+	byId := make(map[string]*dtos.Shipment)
+
+	for _, v := range m {
+		shipment := &dtos.Shipment{
+			ShipmentId:     v.ShipmentId,
+			OrderId:        v.OrderId,
+			Carrier:        v.Carrier,
+			TrackingNumber: v.TrackingNumber,
+			ShippedAt:      v.ShippedAt,
+			Items:          []*dtos.ShipmentItems{},
+		}
+
+		shipments = append(shipments, shipment)
+		byId[v.ShipmentId] = shipment
+	}
+
+	for _, v := range i {
+		shipment, ok := byId[v.ShipmentId]
+		if !ok {
+			continue
+		}
+
+		shipment.Items = append(shipment.Items, &dtos.ShipmentItems{
+			ShipmentItemId: v.ShipmentItemId,
+			OrderItemId:    v.OrderItemId,
+			ArticleId:      v.ArticleId,
+			Quantity:       v.Quantity,
+		})
+	}
+
+	return shipments
+}
+
+func ShipmentDtosToModel(m *dtos.Shipment) (*models.Shipment, []*models.ShipmentItem) {
+	if m.ShipmentId == "" {
+		m.ShipmentId = uuid.NewString()
+	}
+
+	if m.ShippedAt.IsZero() {
+		m.ShippedAt = time.Now().UTC()
+	}
+
+	shipment := &models.Shipment{
+		ShipmentId:     m.ShipmentId,
+		OrderId:        m.OrderId,
+		Carrier:        m.Carrier,
+		TrackingNumber: m.TrackingNumber,
+		ShippedAt:      m.ShippedAt,
+	}
+
+	var items []*models.ShipmentItem
+	for _, v := range m.Items {
+		if v.ShipmentItemId == "" {
+			v.ShipmentItemId = uuid.NewString()
+		}
+
+		items = append(items, &models.ShipmentItem{
+			ShipmentItemId: v.ShipmentItemId,
+			ShipmentId:     m.ShipmentId,
+			OrderId:        m.OrderId,
+			OrderItemId:    v.OrderItemId,
+			Quantity:       v.Quantity,
+		})
+	}
+
+	return shipment, items
+}
@@ -0,0 +1,184 @@
+package shipments
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/repository"
+	"inventory-management/repository/mocks"
+	serviceMocks "inventory-management/services/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type shipmentServiceTestSuite struct {
+	suite.Suite
+	mockCtrl             *gomock.Controller
+	mockOrderRepo        *mocks.MockOrderRepo
+	mockOrderItemRepo    *mocks.MockOrderItemRepo
+	mockShipmentRepo     *mocks.MockShipmentRepo
+	mockShipmentItemRepo *mocks.MockShipmentItemRepo
+	mockTxManager        *mocks.MockTxManager
+	mockPaymentService   *serviceMocks.MockPaymentService
+	shipmentService      ShipmentService
+}
+
+func TestShipmentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(shipmentServiceTestSuite))
+}
+
+func (suite *shipmentServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockOrderRepo = mocks.NewMockOrderRepo(suite.mockCtrl)
+	suite.mockOrderItemRepo = mocks.NewMockOrderItemRepo(suite.mockCtrl)
+	suite.mockShipmentRepo = mocks.NewMockShipmentRepo(suite.mockCtrl)
+	suite.mockShipmentItemRepo = mocks.NewMockShipmentItemRepo(suite.mockCtrl)
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
+	suite.mockPaymentService = serviceMocks.NewMockPaymentService(suite.mockCtrl)
+
+	repos := &repository.Repos{
+		Orders:        suite.mockOrderRepo,
+		OrderItems:    suite.mockOrderItemRepo,
+		Shipments:     suite.mockShipmentRepo,
+		ShipmentItems: suite.mockShipmentItemRepo,
+	}
+	suite.mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(repos *repository.Repos) error) error {
+			return fn(repos)
+		}).AnyTimes()
+
+	suite.shipmentService = NewShipmentService(suite.mockOrderRepo, suite.mockOrderItemRepo, suite.mockShipmentRepo,
+		suite.mockShipmentItemRepo, suite.mockTxManager, suite.mockPaymentService)
+}
+
+func (suite *shipmentServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+func orderItems() []*models.OrderItem {
+	return []*models.OrderItem{
+		{OrderItemId: "i1", OrderId: "o1", ArticleId: "a1", Quantity: 3, AllocatedQuantity: 3},
+		{OrderItemId: "i2", OrderId: "o1", ArticleId: "a2", Quantity: 2, AllocatedQuantity: 1},
+	}
+}
+
+// expectOrder sets up an order of 3 x a1 and 2 x a2, of which all a1 and one
+// a2 are allocated and 2 x a1 already shipped. The order is paid.
+func (suite *shipmentServiceTestSuite) expectOrder(status string) {
+	suite.mockOrderRepo.EXPECT().GetForUpdate(gomock.Any(), "o1").Return(&models.Order{OrderId: "o1", Status: status}, nil).Times(1)
+	suite.mockPaymentService.EXPECT().CheckPaid(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return(orderItems(), nil).AnyTimes()
+	suite.mockShipmentItemRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return([]*models.ShipmentItem{
+		{ShipmentItemId: "si1", ShipmentId: "s1", OrderId: "o1", OrderItemId: "i1", ArticleId: "a1", Quantity: 2},
+	}, nil).AnyTimes()
+}
+
+func (suite *shipmentServiceTestSuite) TestCreateShipment() {
+	suite.expectOrder(constants.OrderStatusConfirmed)
+
+	suite.mockShipmentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, shipment *models.Shipment) error {
+		assert.Equal(suite.T(), "o1", shipment.OrderId)
+		assert.Equal(suite.T(), "UPS", shipment.Carrier)
+		assert.False(suite.T(), shipment.ShippedAt.IsZero())
+		return nil
+	}).Times(1)
+	suite.mockShipmentItemRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, items ...*models.ShipmentItem) error {
+		assert.Len(suite.T(), items, 2)
+		assert.Equal(suite.T(), "o1", items[0].OrderId)
+		assert.Equal(suite.T(), "a1", items[0].ArticleId)
+		return nil
+	}).Times(1)
+
+	req := &dtos.Shipment{
+		Carrier:        "UPS",
+		TrackingNumber: "1Z999",
+		Items: []*dtos.ShipmentItems{
+			{OrderItemId: "i1", Quantity: 1},
+			{OrderItemId: "i2", Quantity: 1},
+		},
+	}
+
+	err := suite.shipmentService.CreateShipment(context.Background(), "o1", req)
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), req.ShipmentId)
+}
+
+func (suite *shipmentServiceTestSuite) TestCreateShipmentExceedsOrder() {
+	suite.expectOrder(constants.OrderStatusConfirmed)
+
+	err := suite.shipmentService.CreateShipment(context.Background(), "o1", &dtos.Shipment{Items: []*dtos.ShipmentItems{{OrderItemId: "i1", Quantity: 2}}})
+	assert.Equal(suite.T(), constants.ErrorShipmentExceedsAllocated, err)
+}
+
+func (suite *shipmentServiceTestSuite) TestCreateShipmentBackordered() {
+	suite.expectOrder(constants.OrderStatusConfirmed)
+
+	err := suite.shipmentService.CreateShipment(context.Background(), "o1", &dtos.Shipment{Items: []*dtos.ShipmentItems{{OrderItemId: "i2", Quantity: 2}}})
+	assert.Equal(suite.T(), constants.ErrorShipmentExceedsAllocated, err)
+}
+
+func (suite *shipmentServiceTestSuite) TestCreateShipmentNotConfirmed() {
+	suite.expectOrder(constants.OrderStatusPending)
+
+	err := suite.shipmentService.CreateShipment(context.Background(), "o1", &dtos.Shipment{Items: []*dtos.ShipmentItems{{OrderItemId: "i2", Quantity: 1}}})
+	assert.Equal(suite.T(), constants.ErrorOrderNotConfirmed, err)
+}
+
+func (suite *shipmentServiceTestSuite) TestCreateShipmentNotPaid() {
+	suite.mockOrderRepo.EXPECT().GetForUpdate(gomock.Any(), "o1").Return(&models.Order{OrderId: "o1", Status: constants.OrderStatusConfirmed}, nil).Times(1)
+	suite.mockPaymentService.EXPECT().CheckPaid(gomock.Any(), gomock.Any(), gomock.Any()).Return(constants.ErrorOrderNotPaid).Times(1)
+
+	err := suite.shipmentService.CreateShipment(context.Background(), "o1", &dtos.Shipment{Items: []*dtos.ShipmentItems{{OrderItemId: "i1", Quantity: 1}}})
+	assert.Equal(suite.T(), constants.ErrorOrderNotPaid, err)
+}
+
+func (suite *shipmentServiceTestSuite) TestCreateShipmentNoItems() {
+	err := suite.shipmentService.CreateShipment(context.Background(), "o1", &dtos.Shipment{})
+	assert.Equal(suite.T(), constants.ErrorShipmentItemsEmpty, err)
+}
+
+func (suite *shipmentServiceTestSuite) TestGetOrderShipments() {
+	suite.mockOrderRepo.EXPECT().Get(gomock.Any(), "o1").Return(&models.Order{OrderId: "o1", Status: constants.OrderStatusConfirmed}, nil).Times(1)
+	suite.mockOrderItemRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return(orderItems(), nil).Times(1)
+	suite.mockShipmentRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return([]*models.Shipment{
+		{ShipmentId: "s1", OrderId: "o1", Carrier: "UPS"},
+		{ShipmentId: "s2", OrderId: "o1", Carrier: "DHL"},
+	}, nil).Times(1)
+	suite.mockShipmentItemRepo.EXPECT().GetByOrder(gomock.Any(), "o1").Return([]*models.ShipmentItem{
+		{ShipmentItemId: "si1", ShipmentId: "s1", OrderId: "o1", OrderItemId: "i1", ArticleId: "a1", Quantity: 2},
+		{ShipmentItemId: "si2", ShipmentId: "s2", OrderId: "o1", OrderItemId: "i2", ArticleId: "a2", Quantity: 1},
+	}, nil).Times(1)
+
+	result, err := suite.shipmentService.GetOrderShipments(context.Background(), "o1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.FulfilmentStatusPartial, result.FulfilmentStatus)
+	assert.Len(suite.T(), result.Shipments, 2)
+	assert.Len(suite.T(), result.Shipments[1].Items, 1)
+	assert.Equal(suite.T(), 2, result.Items[0].Shipped)
+	assert.Equal(suite.T(), 0, result.Items[0].Backordered)
+	assert.Equal(suite.T(), 1, result.Items[1].Backordered)
+}
+
+func (suite *shipmentServiceTestSuite) TestFulfilment() {
+	order := &models.Order{OrderId: "o1", Status: constants.OrderStatusConfirmed}
+
+	status, items := Fulfilment(order, orderItems(), nil)
+	assert.Equal(suite.T(), constants.FulfilmentStatusUnfulfilled, status)
+	assert.Equal(suite.T(), 1, items[1].Backordered)
+
+	status, items = Fulfilment(&models.Order{OrderId: "o1", Status: constants.OrderStatusPending}, orderItems(), nil)
+	assert.Equal(suite.T(), constants.FulfilmentStatusUnfulfilled, status)
+
+	assert.Equal(suite.T(), 0, items[1].Backordered)
+
+	status, _ = Fulfilment(order, orderItems(), []*models.ShipmentItem{
+		{OrderItemId: "i1", Quantity: 3},
+		{OrderItemId: "i2", Quantity: 2},
+	})
+	assert.Equal(suite.T(), constants.FulfilmentStatusFulfilled, status)
+}
@@ -0,0 +1,331 @@
+package taxes
+
+import (
+	"context"
+	"errors"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository"
+	"inventory-management/tracing"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type TaxService interface {
+	CreateTaxRule(ctx context.Context, req *dtos.TaxRule) error
+	UpdateTaxRule(ctx context.Context, id string, req *dtos.TaxRule) error
+	GetTaxRule(ctx context.Context, taxRuleId string) (*dtos.TaxRule, error)
+	DeleteTaxRule(ctx context.Context, taxRuleId string) error
+	TaxOrder(ctx context.Context, order *models.Order, items []*models.OrderItem, discounts []*models.OrderDiscount) error
+}
+
+type taxService struct {
+	taxRuleRepo repository.TaxRuleRepo
+	addressRepo repository.AddressRepo
+	userRepo    repository.UserRepo
+	articleRepo repository.ArticleRepo
+}
+
+func NewTaxService(taxRuleRepo repository.TaxRuleRepo, addressRepo repository.AddressRepo, userRepo repository.UserRepo,
+	articleRepo repository.ArticleRepo) TaxService {
+	return &taxService{
+		taxRuleRepo: taxRuleRepo,
+		addressRepo: addressRepo,
+		userRepo:    userRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+func (t *taxService) CreateTaxRule(ctx context.Context, req *dtos.TaxRule) error {
+	ctx, span := tracing.Start(ctx, "taxService.CreateTaxRule")
+	defer span.End()
+
+	err := t.taxRuleRepo.Create(ctx, TaxRuleDtosToModel(req))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *taxService) UpdateTaxRule(ctx context.Context, id string, req *dtos.TaxRule) error {
+	ctx, span := tracing.Start(ctx, "taxService.UpdateTaxRule")
+	defer span.End()
+
+	req.TaxRuleId = id
+
+	err := t.taxRuleRepo.Update(ctx, id, TaxRuleDtosToModel(req))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *taxService) GetTaxRule(ctx context.Context, taxRuleId string) (*dtos.TaxRule, error) {
+	ctx, span := tracing.Start(ctx, "taxService.GetTaxRule")
+	defer span.End()
+
+	taxRule, err := t.taxRuleRepo.Get(ctx, taxRuleId)
+	if err != nil {
+		return nil, err
+	}
+
+	return TaxRuleModelToDtos(taxRule), nil
+}
+
+func (t *taxService) DeleteTaxRule(ctx context.Context, taxRuleId string) error {
+	ctx, span := tracing.Start(ctx, "taxService.DeleteTaxRule")
+	defer span.End()
+
+	err := t.taxRuleRepo.Delete(ctx, taxRuleId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// TaxOrder works out the tax on every item of a priced and discounted order
+// from the rules for its shipping address, which defaults to the customer's
+// address. With tax-exclusive prices the tax is added to the total, otherwise
+// it is the part of the total that is tax.
+func (t *taxService) TaxOrder(ctx context.Context, order *models.Order, items []*models.OrderItem, discounts []*models.OrderDiscount) error {
+	ctx, span := tracing.Start(ctx, "taxService.TaxOrder")
+	defer span.End()
+
+	address, err := t.destination(ctx, order)
+	if err != nil {
+		return err
+	}
+
+	rules, err := t.taxRuleRepo.GetApplicable(ctx, normalizeRegion(address.Country), order.OrderedAt)
+	if err != nil {
+		return err
+	}
+
+	taxable, err := TaxableAmounts(order, items, discounts)
+	if err != nil {
+		return err
+	}
+
+	taxAmount := money.Zero(order.Subtotal.Currency)
+	for _, v := range items {
+		taxClass, err := t.taxClass(ctx, v.ArticleId)
+		if err != nil {
+			return err
+		}
+
+		v.TaxClass = taxClass
+		v.TaxRuleId = ""
+		v.TaxRate = decimal.Zero
+
+		rule := matchRule(rules, address, taxClass)
+		if rule != nil {
+			v.TaxRuleId = rule.TaxRuleId
+			v.TaxRate = rule.Rate
+		}
+
+		v.TaxAmount = lineTax(taxable[v.OrderItemId], v.TaxRate, order.PricesIncludeTax)
+
+		taxAmount, err = taxAmount.Add(v.TaxAmount)
+		if err != nil {
+			return err
+		}
+	}
+
+	order.TaxAmount = taxAmount
+
+	if !order.PricesIncludeTax {
+		order.TotalAmount, err = order.TotalAmount.Add(taxAmount)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *taxService) destination(ctx context.Context, order *models.Order) (*models.Address, error) {
+	if order.ShippingAddressId == "" {
+		user, err := t.userRepo.Get(ctx, order.CustomerId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrorShippingAddressEmpty
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		order.ShippingAddressId = user.AddressId
+	}
+
+	if order.ShippingAddressId == "" {
+		return nil, constants.ErrorShippingAddressEmpty
+	}
+
+	return t.addressRepo.Get(ctx, order.ShippingAddressId)
+}
+
+func (t *taxService) taxClass(ctx context.Context, articleId string) (string, error) {
+	article, err := t.articleRepo.Get(ctx, articleId)
+	if err != nil {
+		return "", err
+	}
+
+	if article.TaxClass == "" {
+		return constants.TaxClassStandard, nil
+	}
+
+	return article.TaxClass, nil
+}
+
+// TaxableAmounts returns the amount each item is taxed on: its line total less
+// its own discounts and its share of the order discounts, split in proportion
+// to the line totals with any rounding difference on the last line.
+func TaxableAmounts(order *models.Order, items []*models.OrderItem, discounts []*models.OrderDiscount) (map[string]money.Money, error) {
+	currency := order.Subtotal.Currency
+	taxable := make(map[string]money.Money)
+	orderDiscount := money.Zero(currency)
+
+	var err error
+	for _, v := range items {
+		taxable[v.OrderItemId] = v.UnitPrice.Mul(int64(v.Quantity))
+	}
+
+	for _, v := range discounts {
+		if v.OrderItemId == "" {
+			orderDiscount, err = orderDiscount.Add(v.Amount)
+		} else if line, ok := taxable[v.OrderItemId]; ok {
+			taxable[v.OrderItemId], err = line.Sub(v.Amount)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if orderDiscount.IsZero() {
+		return taxable, nil
+	}
+
+	total := decimal.Zero
+	for _, v := range items {
+		total = total.Add(taxable[v.OrderItemId].Amount)
+	}
+
+	if !total.IsPositive() {
+		return taxable, nil
+	}
+
+	remaining := orderDiscount
+	for i, v := range items {
+		share := remaining
+		if i < len(items)-1 {
+			share = money.New(orderDiscount.Amount.Mul(taxable[v.OrderItemId].Amount).Div(total), currency)
+		}
+
+		remaining, err = remaining.Sub(share)
+		if err != nil {
+			return nil, err
+		}
+
+		taxable[v.OrderItemId], err = taxable[v.OrderItemId].Sub(share)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return taxable, nil
+}
+
+// matchRule picks the rule for the tax class that matches the address most
+// closely. A zip prefix is more specific than a state and a longer prefix more
+// specific than a shorter one.
+func matchRule(rules []*models.TaxRule, address *models.Address, taxClass string) *models.TaxRule {
+	state := normalizeRegion(address.State)
+	zipCode := normalizeZip(address.ZipCode)
+
+	var best *models.TaxRule
+	bestScore := -1
+	for _, v := range rules {
+		if v.TaxClass != taxClass {
+			continue
+		}
+
+		if v.State != "" && v.State != state {
+			continue
+		}
+
+		if !strings.HasPrefix(zipCode, v.ZipPrefix) {
+			continue
+		}
+
+		score := len(v.ZipPrefix) * 2
+		if v.State != "" {
+			score++
+		}
+
+		if score > bestScore {
+			best = v
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+func lineTax(taxable money.Money, rate decimal.Decimal, inclusive bool) money.Money {
+	if inclusive {
+		return money.New(taxable.Amount.Mul(rate).Div(rate.Add(decimal.NewFromInt(100))), taxable.Currency)
+	}
+
+	return money.New(taxable.Amount.Mul(rate).Div(decimal.NewFromInt(100)), taxable.Currency)
+}
+
+func normalizeRegion(region string) string {
+	return strings.ToUpper(strings.TrimSpace(region))
+}
+
+func normalizeZip(zipCode string) string {
+	return strings.ToUpper(strings.ReplaceAll(zipCode, " ", ""))
+}
+
+func TaxRuleModelToDtos(m *models.TaxRule) *dtos.TaxRule {
+	return &dtos.TaxRule{
+		TaxRuleId: m.TaxRuleId,
+		Name:      m.Name,
+		Country:   m.Country,
+		State:     m.State,
+		ZipPrefix: m.ZipPrefix,
+		TaxClass:  m.TaxClass,
+		Rate:      m.Rate,
+		ValidFrom: m.ValidFrom,
+		ValidTo:   m.ValidTo,
+	}
+}
+
+func TaxRuleDtosToModel(m *dtos.TaxRule) *models.TaxRule {
+	taxRuleId := m.TaxRuleId
+	if taxRuleId == "" {
+		taxRuleId = uuid.NewString()
+		m.TaxRuleId = taxRuleId
+	}
+
+	return &models.TaxRule{
+		TaxRuleId: taxRuleId,
+		Name:      m.Name,
+		Country:   normalizeRegion(m.Country),
+		State:     normalizeRegion(m.State),
+		ZipPrefix: normalizeZip(m.ZipPrefix),
+		TaxClass:  strings.ToLower(strings.TrimSpace(m.TaxClass)),
+		Rate:      m.Rate,
+		ValidFrom: m.ValidFrom,
+		ValidTo:   m.ValidTo,
+	}
+}
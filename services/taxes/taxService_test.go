@@ -0,0 +1,219 @@
+package taxes
+
+import (
+	"context"
+	"inventory-management/constants"
+	"inventory-management/dtos"
+	"inventory-management/models"
+	"inventory-management/money"
+	"inventory-management/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type taxServiceTestSuite struct {
+	suite.Suite
+	mockCtrl        *gomock.Controller
+	mockTaxRuleRepo *mocks.MockTaxRuleRepo
+	mockAddressRepo *mocks.MockAddressRepo
+	mockUserRepo    *mocks.MockUserRepo
+	mockArticleRepo *mocks.MockArticleRepo
+	taxService      TaxService
+}
+
+func TestTaxServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(taxServiceTestSuite))
+}
+
+func (suite *taxServiceTestSuite) SetupTest() {
+	suite.mockCtrl = gomock.NewController(suite.T())
+
+	suite.mockTaxRuleRepo = mocks.NewMockTaxRuleRepo(suite.mockCtrl)
+	suite.mockAddressRepo = mocks.NewMockAddressRepo(suite.mockCtrl)
+	suite.mockUserRepo = mocks.NewMockUserRepo(suite.mockCtrl)
+	suite.mockArticleRepo = mocks.NewMockArticleRepo(suite.mockCtrl)
+
+	suite.taxService = NewTaxService(suite.mockTaxRuleRepo, suite.mockAddressRepo, suite.mockUserRepo, suite.mockArticleRepo)
+}
+
+func (suite *taxServiceTestSuite) TearDownTest() {
+	suite.mockCtrl.Finish()
+}
+
+// pricedOrder is 2 x 100 of a1 and 1 x 300 of a2 shipped to Bengaluru.
+func (suite *taxServiceTestSuite) pricedOrder() (*models.Order, []*models.OrderItem) {
+	order := &models.Order{
+		OrderId:           "o1",
+		CustomerId:        "c1",
+		OrderedAt:         time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		ShippingAddressId: "addr1",
+		Subtotal:          money.MustParse("500", "INR"),
+		DiscountAmount:    money.Zero("INR"),
+		TotalAmount:       money.MustParse("500", "INR"),
+	}
+
+	items := []*models.OrderItem{
+		{OrderItemId: "i1", OrderId: "o1", ArticleId: "a1", Quantity: 2, UnitPrice: money.MustParse("100", "INR")},
+		{OrderItemId: "i2", OrderId: "o1", ArticleId: "a2", Quantity: 1, UnitPrice: money.MustParse("300", "INR")},
+	}
+
+	return order, items
+}
+
+func (suite *taxServiceTestSuite) expectDestination(order *models.Order) {
+	suite.mockAddressRepo.EXPECT().Get(gomock.Any(), "addr1").Return(&models.Address{
+		AddressId: "addr1",
+		State:     "ka",
+		Country:   "in",
+		ZipCode:   "560 001",
+	}, nil).Times(1)
+
+	suite.mockTaxRuleRepo.EXPECT().GetApplicable(gomock.Any(), "IN", order.OrderedAt).Return([]*models.TaxRule{
+		{TaxRuleId: "in-std", Country: "IN", TaxClass: "standard", Rate: decimal.NewFromInt(18)},
+		{TaxRuleId: "in-ka-std", Country: "IN", State: "KA", TaxClass: "standard", Rate: decimal.NewFromInt(12)},
+		{TaxRuleId: "in-560-std", Country: "IN", ZipPrefix: "560", TaxClass: "standard", Rate: decimal.NewFromInt(10)},
+		{TaxRuleId: "in-food", Country: "IN", TaxClass: "food", Rate: decimal.NewFromInt(5)},
+		{TaxRuleId: "in-mh-food", Country: "IN", State: "MH", TaxClass: "food", Rate: decimal.NewFromInt(0)},
+	}, nil).Times(1)
+
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1"}, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a2").Return(&models.Article{ArticleId: "a2", TaxClass: "food"}, nil).Times(1)
+}
+
+func (suite *taxServiceTestSuite) TestTaxOrderExclusive() {
+	order, items := suite.pricedOrder()
+	suite.expectDestination(order)
+
+	err := suite.taxService.TaxOrder(context.Background(), order, items, nil)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), "in-560-std", items[0].TaxRuleId)
+	assert.Equal(suite.T(), constants.TaxClassStandard, items[0].TaxClass)
+	assert.Equal(suite.T(), "20.00 INR", items[0].TaxAmount.String())
+	assert.Equal(suite.T(), "in-food", items[1].TaxRuleId)
+	assert.Equal(suite.T(), "15.00 INR", items[1].TaxAmount.String())
+	assert.Equal(suite.T(), "35.00 INR", order.TaxAmount.String())
+	assert.Equal(suite.T(), "535.00 INR", order.TotalAmount.String())
+}
+
+func (suite *taxServiceTestSuite) TestTaxOrderInclusive() {
+	order, items := suite.pricedOrder()
+	order.PricesIncludeTax = true
+	suite.expectDestination(order)
+
+	err := suite.taxService.TaxOrder(context.Background(), order, items, nil)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), "18.18 INR", items[0].TaxAmount.String())
+	assert.Equal(suite.T(), "14.29 INR", items[1].TaxAmount.String())
+	assert.Equal(suite.T(), "32.47 INR", order.TaxAmount.String())
+	assert.Equal(suite.T(), "500.00 INR", order.TotalAmount.String())
+}
+
+func (suite *taxServiceTestSuite) TestTaxOrderAfterDiscounts() {
+	order, items := suite.pricedOrder()
+	order.DiscountAmount = money.MustParse("70", "INR")
+	order.TotalAmount = money.MustParse("430", "INR")
+	suite.expectDestination(order)
+
+	discounts := []*models.OrderDiscount{
+		{OrderDiscountId: "d1", OrderItemId: "i1", Amount: money.MustParse("20", "INR")},
+		{OrderDiscountId: "d2", Amount: money.MustParse("50", "INR")},
+	}
+
+	err := suite.taxService.TaxOrder(context.Background(), order, items, discounts)
+	assert.NoError(suite.T(), err)
+
+	// i1 is taxed on 200 - 20 - 18.75 and i2 on 300 - 31.25
+	assert.Equal(suite.T(), "16.13 INR", items[0].TaxAmount.String())
+	assert.Equal(suite.T(), "13.44 INR", items[1].TaxAmount.String())
+	assert.Equal(suite.T(), "459.57 INR", order.TotalAmount.String())
+}
+
+func (suite *taxServiceTestSuite) TestTaxOrderNoMatchingRule() {
+	order, items := suite.pricedOrder()
+	items = items[:1]
+
+	suite.mockAddressRepo.EXPECT().Get(gomock.Any(), "addr1").Return(&models.Address{AddressId: "addr1", Country: "US"}, nil).Times(1)
+	suite.mockTaxRuleRepo.EXPECT().GetApplicable(gomock.Any(), "US", order.OrderedAt).Return(nil, nil).Times(1)
+	suite.mockArticleRepo.EXPECT().Get(gomock.Any(), "a1").Return(&models.Article{ArticleId: "a1"}, nil).Times(1)
+
+	err := suite.taxService.TaxOrder(context.Background(), order, items, nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "", items[0].TaxRuleId)
+	assert.True(suite.T(), order.TaxAmount.IsZero())
+	assert.Equal(suite.T(), "500.00 INR", order.TotalAmount.String())
+}
+
+func (suite *taxServiceTestSuite) TestTaxOrderDefaultsToCustomerAddress() {
+	order, items := suite.pricedOrder()
+	order.ShippingAddressId = ""
+
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c1").Return(&models.User{Id: "c1", AddressId: "addr1"}, nil).Times(1)
+	suite.expectDestination(order)
+
+	err := suite.taxService.TaxOrder(context.Background(), order, items, nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "addr1", order.ShippingAddressId)
+}
+
+func (suite *taxServiceTestSuite) TestTaxOrderNoAddress() {
+	order, items := suite.pricedOrder()
+	order.ShippingAddressId = ""
+
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "c1").Return(nil, gorm.ErrRecordNotFound).Times(1)
+
+	err := suite.taxService.TaxOrder(context.Background(), order, items, nil)
+	assert.Equal(suite.T(), constants.ErrorShippingAddressEmpty, err)
+}
+
+func (suite *taxServiceTestSuite) TestCreateTaxRule() {
+	req := &dtos.TaxRule{
+		Name:      "Karnataka GST",
+		Country:   " in ",
+		State:     "ka",
+		ZipPrefix: "560 0",
+		TaxClass:  "Standard",
+		Rate:      decimal.NewFromInt(18),
+	}
+
+	suite.mockTaxRuleRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.TaxRule) error {
+		assert.Equal(suite.T(), "IN", m.Country)
+		assert.Equal(suite.T(), "KA", m.State)
+		assert.Equal(suite.T(), "5600", m.ZipPrefix)
+		assert.Equal(suite.T(), "standard", m.TaxClass)
+		return nil
+	}).Times(1)
+
+	err := suite.taxService.CreateTaxRule(context.Background(), req)
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), req.TaxRuleId)
+}
+
+func (suite *taxServiceTestSuite) TestUpdateTaxRuleError() {
+	suite.mockTaxRuleRepo.EXPECT().Update(gomock.Any(), "t1", gomock.Any()).Return(constants.ErrorNotFound).Times(1)
+
+	err := suite.taxService.UpdateTaxRule(context.Background(), "t1", &dtos.TaxRule{Name: "GST"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *taxServiceTestSuite) TestGetTaxRule() {
+	suite.mockTaxRuleRepo.EXPECT().Get(gomock.Any(), "t1").Return(&models.TaxRule{TaxRuleId: "t1", Name: "GST"}, nil).Times(1)
+
+	result, err := suite.taxService.GetTaxRule(context.Background(), "t1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "GST", result.Name)
+}
+
+func (suite *taxServiceTestSuite) TestDeleteTaxRule() {
+	suite.mockTaxRuleRepo.EXPECT().Delete(gomock.Any(), "t1").Return(nil).Times(1)
+
+	err := suite.taxService.DeleteTaxRule(context.Background(), "t1")
+	assert.NoError(suite.T(), err)
+}
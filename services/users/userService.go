@@ -1,71 +1,91 @@
 package users
 
 import (
+	"context"
 	"inventory-management/dtos"
 	"inventory-management/models"
 	"inventory-management/repository"
+	"inventory-management/tracing"
+	"strings"
 
 	"github.com/google/uuid"
 )
 
 type UserService interface {
-	CreateUser(req *dtos.User) error
-	UpdateUser(id string, req *dtos.User) error
-	GetUser(userId string) (*dtos.User, error)
-	DeleteUser(userId string) error
+	CreateUser(ctx context.Context, req *dtos.User) error
+	UpdateUser(ctx context.Context, id string, req *dtos.User) error
+	GetUser(ctx context.Context, userId string) (*dtos.User, error)
+	DeleteUser(ctx context.Context, userId string) error
 }
 
 type userService struct {
 	userRepo    repository.UserRepo
 	addressRepo repository.AddressRepo
+	txManager   repository.TxManager
 }
 
-func NewUserService(userRepo repository.UserRepo, addressRepo repository.AddressRepo) UserService {
+func NewUserService(userRepo repository.UserRepo, addressRepo repository.AddressRepo, txManager repository.TxManager) UserService {
 	return &userService{
 		userRepo:    userRepo,
 		addressRepo: addressRepo,
+		txManager:   txManager,
 	}
 }
 
-func (o *userService) CreateUser(req *dtos.User) error {
-	userModel, addressModel := UserDtosToModel(req)
-
-	err := o.userRepo.Upsert(userModel)
-	if err != nil {
-		return err
-	}
-
-	err = o.addressRepo.Upsert(addressModel)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
+// CreateUser creates the user and their address in one transaction. An
+// address id that is already taken fails with ErrorRecordExists.
+func (o *userService) CreateUser(ctx context.Context, req *dtos.User) error {
+	ctx, span := tracing.Start(ctx, "userService.CreateUser")
+	defer span.End()
 
-func (o *userService) UpdateUser(id string, req *dtos.User) error {
 	userModel, addressModel := UserDtosToModel(req)
+	userModel.Version = 1
 
-	err := o.userRepo.Upsert(userModel)
-	if err != nil {
-		return err
-	}
+	return o.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		err := repos.Users.Create(ctx, userModel)
+		if err != nil {
+			return err
+		}
 
-	err = o.addressRepo.Upsert(addressModel)
-	if err != nil {
-		return err
-	}
+		return repos.Addresses.Create(ctx, addressModel)
+	})
+}
 
-	return nil
+// UpdateUser overwrites the user as long as it is still at req.Version. The
+// address written is always the user's own, whatever address id was sent.
+func (o *userService) UpdateUser(ctx context.Context, id string, req *dtos.User) error {
+	ctx, span := tracing.Start(ctx, "userService.UpdateUser")
+	defer span.End()
+
+	return o.txManager.WithTransaction(ctx, func(repos *repository.Repos) error {
+		current, err := repos.Users.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		req.Id = id
+		req.Address.AddressId = current.AddressId
+		userModel, addressModel := UserDtosToModel(req)
+
+		err = repos.Users.Update(ctx, id, userModel)
+		if err != nil {
+			return err
+		}
+
+		return repos.Addresses.Update(ctx, current.AddressId, addressModel)
+	})
 }
 
-func (o *userService) GetUser(userId string) (*dtos.User, error) {
-	user, err := o.userRepo.Get(userId)
+func (o *userService) GetUser(ctx context.Context, userId string) (*dtos.User, error) {
+	ctx, span := tracing.Start(ctx, "userService.GetUser")
+	defer span.End()
+
+	user, err := o.userRepo.Get(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
 
-	address, err := o.addressRepo.Get(user.AddressId)
+	address, err := o.addressRepo.Get(ctx, user.AddressId)
 	if err != nil {
 		return nil, err
 	}
@@ -75,8 +95,11 @@ func (o *userService) GetUser(userId string) (*dtos.User, error) {
 	return result, nil
 }
 
-func (o *userService) DeleteUser(userId string) error {
-	err := o.userRepo.Delete(userId)
+func (o *userService) DeleteUser(ctx context.Context, userId string) error {
+	ctx, span := tracing.Start(ctx, "userService.DeleteUser")
+	defer span.End()
+
+	err := o.userRepo.Delete(ctx, userId)
 	if err != nil {
 		return err
 	}
@@ -99,7 +122,10 @@ func UserModelToDtos(m *models.User, a *models.Address) *dtos.User {
 			Country:   a.Country,
 			ZipCode:   a.ZipCode,
 		},
-		Role: m.Role,
+		Role:            m.Role,
+		CustomerGroup:   m.CustomerGroup,
+		BackorderPolicy: m.BackorderPolicy,
+		Version:         m.Version,
 	}
 
 	return user
@@ -116,12 +142,15 @@ func UserDtosToModel(m *dtos.User) (*models.User, *models.Address) {
 	}
 
 	userModel := &models.User{
-		Id:        m.Id,
-		Name:      m.Name,
-		Email:     m.Email,
-		Mobile:    m.Mobile,
-		AddressId: addressId,
-		Role:      m.Role,
+		Id:              m.Id,
+		Name:            m.Name,
+		Email:           m.Email,
+		Mobile:          m.Mobile,
+		AddressId:       addressId,
+		Role:            m.Role,
+		CustomerGroup:   m.CustomerGroup,
+		BackorderPolicy: strings.ToLower(strings.TrimSpace(m.BackorderPolicy)),
+		Version:         m.Version,
 	}
 
 	addressModel := &models.Address{
@@ -1,10 +1,12 @@
 package users
 
 import (
+	"context"
 	"errors"
 	"inventory-management/constants"
 	"inventory-management/dtos"
 	"inventory-management/models"
+	"inventory-management/repository"
 	"inventory-management/repository/mocks"
 	"testing"
 
@@ -18,6 +20,7 @@ type userServiceTestSuite struct {
 	mockCtrl        *gomock.Controller
 	mockUserRepo    *mocks.MockUserRepo
 	mockAddressRepo *mocks.MockAddressRepo
+	mockTxManager   *mocks.MockTxManager
 	userService     UserService
 }
 
@@ -30,8 +33,18 @@ func (suite *userServiceTestSuite) SetupTest() {
 
 	suite.mockUserRepo = mocks.NewMockUserRepo(suite.mockCtrl)
 	suite.mockAddressRepo = mocks.NewMockAddressRepo(suite.mockCtrl)
+	suite.mockTxManager = mocks.NewMockTxManager(suite.mockCtrl)
 
-	suite.userService = NewUserService(suite.mockUserRepo, suite.mockAddressRepo)
+	repos := &repository.Repos{
+		Users:     suite.mockUserRepo,
+		Addresses: suite.mockAddressRepo,
+	}
+	suite.mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(repos *repository.Repos) error) error {
+			return fn(repos)
+		}).AnyTimes()
+
+	suite.userService = NewUserService(suite.mockUserRepo, suite.mockAddressRepo, suite.mockTxManager)
 }
 
 func (suite *userServiceTestSuite) TestCreateUser() {
@@ -59,6 +72,7 @@ func (suite *userServiceTestSuite) TestCreateUser() {
 		Mobile:    "12345",
 		AddressId: "5",
 		Role:      constants.RoleCustomer,
+		Version:   1,
 	}
 
 	addressModel := &models.Address{
@@ -71,10 +85,10 @@ func (suite *userServiceTestSuite) TestCreateUser() {
 		ZipCode:   "600001",
 	}
 
-	suite.mockUserRepo.EXPECT().Upsert(userModel).Return(nil).Times(1)
-	suite.mockAddressRepo.EXPECT().Upsert(addressModel).Return(nil).Times(1)
+	suite.mockUserRepo.EXPECT().Create(gomock.Any(), userModel).Return(nil).Times(1)
+	suite.mockAddressRepo.EXPECT().Create(gomock.Any(), addressModel).Return(nil).Times(1)
 
-	err := suite.userService.CreateUser(req)
+	err := suite.userService.CreateUser(context.Background(), req)
 	assert.NoError(suite.T(), err)
 }
 
@@ -103,11 +117,12 @@ func (suite *userServiceTestSuite) TestCreateUserRepoError() {
 		Mobile:    "12345",
 		AddressId: "5",
 		Role:      constants.RoleCustomer,
+		Version:   1,
 	}
 
-	suite.mockUserRepo.EXPECT().Upsert(userModel).Return(errors.New("repo error")).Times(1)
+	suite.mockUserRepo.EXPECT().Create(gomock.Any(), userModel).Return(errors.New("repo error")).Times(1)
 
-	err := suite.userService.CreateUser(req)
+	err := suite.userService.CreateUser(context.Background(), req)
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "repo error", err.Error())
 }
@@ -137,6 +152,7 @@ func (suite *userServiceTestSuite) TestCreateUserAddressError() {
 		Mobile:    "12345",
 		AddressId: "5",
 		Role:      constants.RoleCustomer,
+		Version:   1,
 	}
 
 	addressModel := &models.Address{
@@ -149,10 +165,10 @@ func (suite *userServiceTestSuite) TestCreateUserAddressError() {
 		ZipCode:   "600001",
 	}
 
-	suite.mockUserRepo.EXPECT().Upsert(userModel).Return(nil).Times(1)
-	suite.mockAddressRepo.EXPECT().Upsert(addressModel).Return(errors.New("address repo error")).Times(1)
+	suite.mockUserRepo.EXPECT().Create(gomock.Any(), userModel).Return(nil).Times(1)
+	suite.mockAddressRepo.EXPECT().Create(gomock.Any(), addressModel).Return(errors.New("address repo error")).Times(1)
 
-	err := suite.userService.CreateUser(req)
+	err := suite.userService.CreateUser(context.Background(), req)
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "address repo error", err.Error())
 }
@@ -194,10 +210,10 @@ func (suite *userServiceTestSuite) TestGetUser() {
 		ZipCode:   "600001",
 	}
 
-	suite.mockUserRepo.EXPECT().Get("123").Return(userModel, nil).Times(1)
-	suite.mockAddressRepo.EXPECT().Get("5").Return(addressModel, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "123").Return(userModel, nil).Times(1)
+	suite.mockAddressRepo.EXPECT().Get(gomock.Any(), "5").Return(addressModel, nil).Times(1)
 
-	result, err := suite.userService.GetUser("123")
+	result, err := suite.userService.GetUser(context.Background(), "123")
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expected, result)
 }
@@ -205,9 +221,9 @@ func (suite *userServiceTestSuite) TestGetUser() {
 func (suite *userServiceTestSuite) TestGetUserError() {
 	expectedError := constants.ErrorNotFound
 
-	suite.mockUserRepo.EXPECT().Get("123").Return(nil, expectedError).Times(1)
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "123").Return(nil, expectedError).Times(1)
 
-	result, err := suite.userService.GetUser("123")
+	result, err := suite.userService.GetUser(context.Background(), "123")
 
 	assert.Nil(suite.T(), result)
 	assert.Error(suite.T(), err)
@@ -226,10 +242,10 @@ func (suite *userServiceTestSuite) TestGetUserAddressError() {
 		Role:      constants.RoleCustomer,
 	}
 
-	suite.mockUserRepo.EXPECT().Get("123").Return(userModel, nil).Times(1)
-	suite.mockAddressRepo.EXPECT().Get("5").Return(nil, expectedError).Times(1)
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "123").Return(userModel, nil).Times(1)
+	suite.mockAddressRepo.EXPECT().Get(gomock.Any(), "5").Return(nil, expectedError).Times(1)
 
-	result, err := suite.userService.GetUser("123")
+	result, err := suite.userService.GetUser(context.Background(), "123")
 
 	assert.Nil(suite.T(), result)
 	assert.Error(suite.T(), err)
@@ -273,10 +289,11 @@ func (suite *userServiceTestSuite) TestUpdateUser() {
 		ZipCode:   "600001",
 	}
 
-	suite.mockUserRepo.EXPECT().Upsert(userModel).Return(nil).Times(1)
-	suite.mockAddressRepo.EXPECT().Upsert(addressModel).Return(nil).Times(1)
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.User{Id: "123", AddressId: "5"}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any(), "123", userModel).Return(nil).Times(1)
+	suite.mockAddressRepo.EXPECT().Update(gomock.Any(), "5", addressModel).Return(nil).Times(1)
 
-	err := suite.userService.UpdateUser("123", req)
+	err := suite.userService.UpdateUser(context.Background(), "123", req)
 	assert.NoError(suite.T(), err)
 }
 
@@ -307,9 +324,10 @@ func (suite *userServiceTestSuite) TestUpdateUserError() {
 		Role:      constants.RoleCustomer,
 	}
 
-	suite.mockUserRepo.EXPECT().Upsert(userModel).Return(constants.ErrorNotFound).Times(1)
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.User{Id: "123", AddressId: "5"}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any(), "123", userModel).Return(constants.ErrorNotFound).Times(1)
 
-	err := suite.userService.UpdateUser("123", req)
+	err := suite.userService.UpdateUser(context.Background(), "123", req)
 	assert.Error(suite.T(), err)
 }
 
@@ -350,24 +368,64 @@ func (suite *userServiceTestSuite) TestUpdateUserAddressError() {
 		ZipCode:   "600001",
 	}
 
-	suite.mockUserRepo.EXPECT().Upsert(userModel).Return(nil).Times(1)
-	suite.mockAddressRepo.EXPECT().Upsert(addressModel).Return(errors.New("address update error")).Times(1)
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.User{Id: "123", AddressId: "5"}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any(), "123", userModel).Return(nil).Times(1)
+	suite.mockAddressRepo.EXPECT().Update(gomock.Any(), "5", addressModel).Return(errors.New("address update error")).Times(1)
 
-	err := suite.userService.UpdateUser("123", req)
+	err := suite.userService.UpdateUser(context.Background(), "123", req)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *userServiceTestSuite) TestUpdateUserWritesOwnAddress() {
+	req := &dtos.User{
+		Name:    "John",
+		Address: dtos.Address{AddressId: "someone-elses", Line1: "12", Country: "in"},
+		Role:    constants.RoleCustomer,
+	}
+
+	suite.mockUserRepo.EXPECT().Get(gomock.Any(), "123").Return(&models.User{Id: "123", AddressId: "5"}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any(), "123", gomock.Any()).DoAndReturn(func(_ context.Context, id string, user *models.User) error {
+		assert.Equal(suite.T(), "5", user.AddressId)
+		return nil
+	}).Times(1)
+	suite.mockAddressRepo.EXPECT().Update(gomock.Any(), "5", gomock.Any()).DoAndReturn(func(_ context.Context, id string, address *models.Address) error {
+		assert.Equal(suite.T(), "5", address.AddressId)
+		return nil
+	}).Times(1)
+
+	err := suite.userService.UpdateUser(context.Background(), "123", req)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *userServiceTestSuite) TestCreateUserWritesInTransaction() {
+	txUserRepo := mocks.NewMockUserRepo(suite.mockCtrl)
+	txAddressRepo := mocks.NewMockAddressRepo(suite.mockCtrl)
+	txManager := mocks.NewMockTxManager(suite.mockCtrl)
+	txManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(repos *repository.Repos) error) error {
+			return fn(&repository.Repos{Users: txUserRepo, Addresses: txAddressRepo})
+		}).Times(1)
+
+	service := NewUserService(suite.mockUserRepo, suite.mockAddressRepo, txManager)
+
+	txUserRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	txAddressRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(constants.ErrorRecordExists).Times(1)
+
+	err := service.CreateUser(context.Background(), &dtos.User{Name: "John", Address: dtos.Address{AddressId: "5", Country: "in"}})
+	assert.Equal(suite.T(), constants.ErrorRecordExists, err)
+}
+
 func (suite *userServiceTestSuite) TestDeleteUser() {
-	suite.mockUserRepo.EXPECT().Delete("123").Return(nil).Times(1)
+	suite.mockUserRepo.EXPECT().Delete(gomock.Any(), "123").Return(nil).Times(1)
 
-	err := suite.userService.DeleteUser("123")
+	err := suite.userService.DeleteUser(context.Background(), "123")
 	assert.NoError(suite.T(), err)
 }
 
 func (suite *userServiceTestSuite) TestDeleteUserError() {
-	suite.mockUserRepo.EXPECT().Delete("123").Return(constants.ErrorNotFound).Times(1)
+	suite.mockUserRepo.EXPECT().Delete(gomock.Any(), "123").Return(constants.ErrorNotFound).Times(1)
 
-	err := suite.userService.DeleteUser("123")
+	err := suite.userService.DeleteUser(context.Background(), "123")
 	assert.Error(suite.T(), err)
 }
 
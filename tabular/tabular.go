@@ -0,0 +1,178 @@
+package tabular
+
+import (
+	"bytes"
+	"encoding/csv"
+	"inventory-management/constants"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ContentTypes maps the supported file formats onto their media types.
+var ContentTypes = map[string]string{
+	constants.FileFormatCSV:  "text/csv",
+	constants.FileFormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ReadRows reads every row of a CSV file or of the first sheet of an XLSX
+// workbook.
+func ReadRows(format string, data []byte) ([][]string, error) {
+	switch format {
+	case constants.FileFormatCSV:
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return reader.ReadAll()
+	case constants.FileFormatXLSX:
+		file, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		return file.GetRows(file.GetSheetName(0))
+	}
+
+	return nil, constants.ErrorUnsupportedFileFormat
+}
+
+// Blank reports whether every cell of the row is empty.
+func Blank(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Columns maps field names to the position of their column in a row.
+type Columns map[string]int
+
+// MapColumns finds the column of every field from the header row. Fields are
+// read from the column named after them unless mapping names another header,
+// and headers are matched case-insensitively. Only optional fields that are
+// not mapped may be missing from the file.
+func MapColumns(header []string, fields []string, optional map[string]bool, mapping map[string]string) (Columns, error) {
+	known := make(map[string]struct{})
+	for _, v := range fields {
+		known[v] = struct{}{}
+	}
+
+	for k := range mapping {
+		if _, exists := known[k]; !exists {
+			return nil, constants.ErrorInvalidColumnMapping
+		}
+	}
+
+	positions := make(map[string]int)
+	for n, v := range header {
+		positions[strings.ToLower(strings.TrimSpace(v))] = n
+	}
+
+	columns := make(Columns)
+	for _, field := range fields {
+		name, mapped := mapping[field]
+		if !mapped {
+			name = field
+		}
+
+		n, exists := positions[strings.ToLower(strings.TrimSpace(name))]
+		if !exists {
+			if mapped || !optional[field] {
+				return nil, constants.ErrorImportColumnMissing
+			}
+			continue
+		}
+
+		columns[field] = n
+	}
+
+	return columns, nil
+}
+
+// Value is the trimmed cell of the field in row, or empty when the field has
+// no column or the row is short.
+func (c Columns) Value(row []string, field string) string {
+	n, ok := c[field]
+	if !ok || n >= len(row) {
+		return ""
+	}
+
+	return strings.TrimSpace(row[n])
+}
+
+// Writer writes rows to a file. Nothing may be written after Close.
+type Writer interface {
+	Write(row []string) error
+	Close() error
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case constants.FileFormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case constants.FileFormatXLSX:
+		file := excelize.NewFile()
+		stream, err := file.NewStreamWriter(file.GetSheetName(0))
+		if err != nil {
+			return nil, err
+		}
+
+		return &xlsxWriter{file: file, stream: stream, out: w}, nil
+	}
+
+	return nil, constants.ErrorUnsupportedFileFormat
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvWriter) Write(row []string) error {
+	return c.writer.Write(row)
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// xlsxWriter streams rows into the sheet, but the workbook itself can only be
+// written out once it is complete, on Close.
+type xlsxWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	out    io.Writer
+	rows   int
+}
+
+func (x *xlsxWriter) Write(row []string) error {
+	x.rows++
+
+	cell, err := excelize.CoordinatesToCellName(1, x.rows)
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(row))
+	for n, v := range row {
+		values[n] = v
+	}
+
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	err := x.stream.Flush()
+	if err != nil {
+		return err
+	}
+
+	return x.file.Write(x.out)
+}
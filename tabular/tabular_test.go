@@ -0,0 +1,58 @@
+package tabular
+
+import (
+	"bytes"
+	"inventory-management/constants"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadRowsCSV(t *testing.T) {
+	rows, err := ReadRows(constants.FileFormatCSV, []byte("\xef\xbb\xbfa, b\n1,\"2, 3\"\n4\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "b"}, {"1", "2, 3"}, {"4"}}, rows)
+}
+
+func TestWriteAndReadXLSX(t *testing.T) {
+	var buf bytes.Buffer
+
+	writer, err := NewWriter(constants.FileFormatXLSX, &buf)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Write([]string{"a", "b"}))
+	assert.NoError(t, writer.Write([]string{"1", "2"}))
+	assert.NoError(t, writer.Close())
+
+	rows, err := ReadRows(constants.FileFormatXLSX, buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "b"}, {"1", "2"}}, rows)
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	_, err := ReadRows("ods", nil)
+	assert.Equal(t, constants.ErrorUnsupportedFileFormat, err)
+
+	_, err = NewWriter("ods", &bytes.Buffer{})
+	assert.Equal(t, constants.ErrorUnsupportedFileFormat, err)
+}
+
+func TestMapColumns(t *testing.T) {
+	fields := []string{"id", "name", "note"}
+	optional := map[string]bool{"note": true}
+
+	columns, err := MapColumns([]string{"Name", " SKU "}, fields, optional, map[string]string{"id": "sku"})
+	assert.NoError(t, err)
+	assert.Equal(t, Columns{"id": 1, "name": 0}, columns)
+	assert.Equal(t, "x1", columns.Value([]string{"box", " x1 "}, "id"))
+	assert.Equal(t, "", columns.Value([]string{"box"}, "id"))
+	assert.Equal(t, "", columns.Value([]string{"box", "x1"}, "note"))
+
+	_, err = MapColumns([]string{"id"}, fields, optional, nil)
+	assert.Equal(t, constants.ErrorImportColumnMissing, err)
+
+	_, err = MapColumns([]string{"id", "name"}, fields, optional, map[string]string{"note": "remarks"})
+	assert.Equal(t, constants.ErrorImportColumnMissing, err)
+
+	_, err = MapColumns([]string{"id", "name"}, fields, optional, map[string]string{"sku": "id"})
+	assert.Equal(t, constants.ErrorInvalidColumnMapping, err)
+}
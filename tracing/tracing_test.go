@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// record sends the spans started during the test to a recorder.
+func record(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	return recorder
+}
+
+func TestSetupFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.json")
+
+	stop, err := Setup(context.Background(), "inventory-test", ExporterFile, path)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	})
+
+	_, span := Start(context.Background(), "orderService.CreateOrder")
+	span.End()
+	assert.NoError(t, stop(context.Background()))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"Name":"orderService.CreateOrder"`)
+	assert.Contains(t, string(content), `"Value":"inventory-test"`)
+
+	_, err = Setup(context.Background(), "inventory-test", "jaeger", "")
+	assert.Error(t, err)
+}
+
+func TestGormPlugin(t *testing.T) {
+	recorder := record(t)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Use(NewGormPlugin()))
+
+	ctx, parent := Start(context.Background(), "articleService.GetArticle")
+	err = db.WithContext(ctx).Exec("CREATE TABLE articles (article_id TEXT)").Error
+	assert.NoError(t, err)
+	err = db.WithContext(ctx).Exec("SELECT * FROM missing WHERE id = ?", "secret").Error
+	assert.Error(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+
+	failed := spans[1]
+	assert.Equal(t, "db.raw", failed.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), failed.Parent().SpanID())
+	assert.Contains(t, failed.Attributes(), attribute.String("db.query.text", "SELECT * FROM missing WHERE id = ?"))
+	assert.Contains(t, failed.Attributes(), attribute.String("db.system.name", "sqlite"))
+	assert.Equal(t, "Error", failed.Status().Code.String())
+}
+
+func TestTransport(t *testing.T) {
+	recorder := record(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	ctx, parent := Start(context.Background(), "paymentService.RecordPayment")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/charges", nil)
+	client := &http.Client{Transport: &Transport{Base: http.DefaultTransport}}
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "HTTP POST", spans[0].Name())
+	assert.Contains(t, traceparent, parent.SpanContext().TraceID().String())
+	assert.Contains(t, traceparent, spans[0].SpanContext().SpanID().String())
+	assert.Empty(t, req.Header.Get("traceparent"))
+}
@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"errors"
+	"inventory-management/dtos"
+	"inventory-management/money"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	// Fields are reported by their JSON names, and money and decimals are
+	// validated by their amount so the usual numeric rules apply to them.
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	validate.RegisterCustomTypeFunc(func(v reflect.Value) any {
+		return v.Interface().(money.Money).Amount.InexactFloat64()
+	}, money.Money{})
+
+	validate.RegisterCustomTypeFunc(func(v reflect.Value) any {
+		return v.Interface().(decimal.Decimal).InexactFloat64()
+	}, decimal.Decimal{})
+}
+
+// Struct checks v against its binding tags, the same rules gin applies when
+// binding a request body.
+func Struct(v any) error {
+	return binding.Validator.ValidateStruct(v)
+}
+
+// Fields lists the failing fields of a validation error by their JSON path. It
+// returns false when err did not come from validation.
+func Fields(err error) ([]*dtos.FieldError, bool) {
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return nil, false
+	}
+
+	var result []*dtos.FieldError
+	for _, v := range fieldErrors {
+		// The namespace starts with the struct name, which the client never sees.
+		_, field, _ := strings.Cut(v.Namespace(), ".")
+		result = append(result, &dtos.FieldError{
+			Field: field,
+			Rule:  v.Tag(),
+			Param: v.Param(),
+		})
+	}
+
+	return result, true
+}